@@ -4,14 +4,15 @@ CREATE TABLE `stock` (
 `SKU` VARCHAR(64) PRIMARY KEY,
 `NAME` VARCHAR(64),
 `QUANTITY` INTEGER,
-`BUY_PRICE` REAL,
-`SELL_PRICE` REAL
+`BUY_PRICE` INTEGER,
+`SELL_PRICE` INTEGER,
+`RESERVED_QUANTITY` INTEGER NOT NULL DEFAULT 0 /* quantity held by draft sales */
 );
-INSERT INTO stock VALUES('SSI-D00791015-LL-BWH','Zalekia Plain Casual Blouse (L,Broken White)',154,61999.999999999999998,65000.0);
-INSERT INTO stock VALUES('SSI-D00864612-LL-NAV','Deklia Plain Casual Blouse (L,Navy)',85,55000.0,60000.0);
-INSERT INTO stock VALUES('SSI-D01037807-X3-BWH','Dellaya Plain Loose Big Blouse (XXXL,Broken White)',74,85000.0,90000.0);
-INSERT INTO stock VALUES('SSI-D01220307-XL-SAL','Devibav Plain Trump Blouse (XL,Salem)',182,75000.0,85000.0);
-INSERT INTO stock VALUES('SSI-D01322234-LL-WHI','Thafqya Plain Raglan Blouse (L,White)',105,60999.999999999999999,65000.0);
+INSERT INTO stock VALUES('SSI-D00791015-LL-BWH','Zalekia Plain Casual Blouse (L,Broken White)',154,62000,65000,0);
+INSERT INTO stock VALUES('SSI-D00864612-LL-NAV','Deklia Plain Casual Blouse (L,Navy)',85,55000,60000,0);
+INSERT INTO stock VALUES('SSI-D01037807-X3-BWH','Dellaya Plain Loose Big Blouse (XXXL,Broken White)',74,85000,90000,10);
+INSERT INTO stock VALUES('SSI-D01220307-XL-SAL','Devibav Plain Trump Blouse (XL,Salem)',182,75000,85000,0);
+INSERT INTO stock VALUES('SSI-D01322234-LL-WHI','Thafqya Plain Raglan Blouse (L,White)',105,61000,65000,0);
 CREATE TABLE `sales` (
 `INVOICE_ID` VARCHAR(64) PRIMARY KEY,
 `SALE_DATE` DATETIME,
@@ -28,20 +29,20 @@ CREATE TABLE `sales_items` (
 `INVOICE_ID` VARCHAR(64),
 `SKU` VARCHAR(64),
 `QUANTITY` INTEGER,
-`BUY_PRICE` REAL NULL,
-`SELL_PRICE` REAL NULL,
+`BUY_PRICE` INTEGER NULL,
+`SELL_PRICE` INTEGER NULL,
 UNIQUE(`INVOICE_ID`,`SKU`) ON CONFLICT ROLLBACK,
 FOREIGN KEY(`INVOICE_ID`) REFERENCES sales(`INVOICE_ID`),
 FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
 );
-INSERT INTO sales_items VALUES(1,'INV01','SSI-D00864612-LL-NAV',5,68000.000000000000001,70000.0);
-INSERT INTO sales_items VALUES(2,'INV01','SSI-D00791015-LL-BWH',2,50000.0,60000.0);
-INSERT INTO sales_items VALUES(3,'INV02','SSI-D01220307-XL-SAL',17,NULL,93000.000000000000001);
-INSERT INTO sales_items VALUES(4,'INV03','SSI-D01037807-X3-BWH',21,75000.0,80000.0);
-INSERT INTO sales_items VALUES(5,'INV04','SSI-D01322234-LL-WHI',19,73000.000000000000001,78800.000000000000001);
-INSERT INTO sales_items VALUES(6,'INV04','SSI-D00791015-LL-BWH',7,51999.999999999999998,60999.999999999999999);
-INSERT INTO sales_items VALUES(7,'INV05','SSI-D01037807-X3-BWH',10,NULL,80000.0);
-INSERT INTO sales_items VALUES(8,'INV06','SSI-D00864612-LL-NAV',11,NULL,83000.000000000000001);
+INSERT INTO sales_items VALUES(1,'INV01','SSI-D00864612-LL-NAV',5,68000,70000);
+INSERT INTO sales_items VALUES(2,'INV01','SSI-D00791015-LL-BWH',2,50000,60000);
+INSERT INTO sales_items VALUES(3,'INV02','SSI-D01220307-XL-SAL',17,NULL,93000);
+INSERT INTO sales_items VALUES(4,'INV03','SSI-D01037807-X3-BWH',21,75000,80000);
+INSERT INTO sales_items VALUES(5,'INV04','SSI-D01322234-LL-WHI',19,73000,78800);
+INSERT INTO sales_items VALUES(6,'INV04','SSI-D00791015-LL-BWH',7,52000,61000);
+INSERT INTO sales_items VALUES(7,'INV05','SSI-D01037807-X3-BWH',10,NULL,80000);
+INSERT INTO sales_items VALUES(8,'INV06','SSI-D00864612-LL-NAV',11,NULL,83000);
 CREATE TABLE `purchase` (
 `PURCHASE_ID` VARCHAR(64),
 `PURCHASE_DATE` DATETIME,
@@ -58,20 +59,143 @@ CREATE TABLE `purchase_items` (
 `PURCHASE_ID` VARCHAR(64),
 `SKU` VARCHAR(64), 
 `QUANTITY` INTEGER,
-`BUY_PRICE` REAL,
+`BUY_PRICE` INTEGER,
 `NOTE` TEXT NULL,
+`RECEIVED_QUANTITY` INTEGER DEFAULT 0,
 UNIQUE(`PURCHASE_ID`,`SKU`) ON CONFLICT ROLLBACK,
 FOREIGN KEY(`PURCHASE_ID`) REFERENCES purchase(`PURCHASE_ID`),
 FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
 );
-INSERT INTO purchase_items VALUES(1,'PO01','SSI-D00791015-LL-BWH',50,55999.999999999999999,'New Model');
-INSERT INTO purchase_items VALUES(2,'PO02','SSI-D00791015-LL-BWH',40,55000.0,'Color: Blue');
-INSERT INTO purchase_items VALUES(3,'PO02','SSI-D00864612-LL-NAV',20,63000.000000000000001,'Dari Pabrik ABC');
-INSERT INTO purchase_items VALUES(4,'PO03','SSI-D01037807-X3-BWH',18,64000.0,NULL);
-INSERT INTO purchase_items VALUES(5,'PO03','SSI-D01220307-XL-SAL',30,65000.0,'Order lagi');
-INSERT INTO purchase_items VALUES(6,'PO04','SSI-D01322234-LL-WHI',45,58000.000000000000001,'Model baru');
-INSERT INTO purchase_items VALUES(7,'PO05','SSI-D00864612-LL-NAV',24,69000.0,NULL);
+INSERT INTO purchase_items VALUES(1,'PO01','SSI-D00791015-LL-BWH',50,56000,'New Model',0);
+INSERT INTO purchase_items VALUES(2,'PO02','SSI-D00791015-LL-BWH',40,55000,'Color: Blue',40);
+INSERT INTO purchase_items VALUES(3,'PO02','SSI-D00864612-LL-NAV',20,63000,'Dari Pabrik ABC',20);
+INSERT INTO purchase_items VALUES(4,'PO03','SSI-D01037807-X3-BWH',18,64000,NULL,18);
+INSERT INTO purchase_items VALUES(5,'PO03','SSI-D01220307-XL-SAL',30,65000,'Order lagi',30);
+INSERT INTO purchase_items VALUES(6,'PO04','SSI-D01322234-LL-WHI',45,58000,'Model baru',45);
+INSERT INTO purchase_items VALUES(7,'PO05','SSI-D00864612-LL-NAV',24,69000,NULL,0);
+CREATE TABLE `purchase_receipts` (
+`RECEIPT_ID` VARCHAR(64) PRIMARY KEY,
+`PURCHASE_ID` VARCHAR(64),
+`RECEIPT_DATE` DATETIME,
+`NOTE` TEXT NULL,
+FOREIGN KEY(`PURCHASE_ID`) REFERENCES purchase(`PURCHASE_ID`)
+);
+CREATE TABLE `purchase_receipt_items` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`RECEIPT_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+UNIQUE(`RECEIPT_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`RECEIPT_ID`) REFERENCES purchase_receipts(`RECEIPT_ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+CREATE TABLE `stock_movements` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER, /* signed, negative quantity decreases stock */
+`UNIT_COST` INTEGER, /* buy price of the item at the time of the movement */
+`REASON` VARCHAR(64),
+`REFERENCE` VARCHAR(64),
+`NOTE` TEXT NULL,
+`MOVEMENT_DATE` DATETIME,
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+INSERT INTO stock_movements VALUES(1,'SSI-D00791015-LL-BWH',123,62000,'initial stock','SSI-D00791015-LL-BWH','','2017-12-01 00:00:00.000');
+INSERT INTO stock_movements VALUES(2,'SSI-D00864612-LL-NAV',70,55000,'initial stock','SSI-D00864612-LL-NAV','','2017-12-01 00:00:00.000');
+INSERT INTO stock_movements VALUES(3,'SSI-D01037807-X3-BWH',77,85000,'initial stock','SSI-D01037807-X3-BWH','','2017-12-01 00:00:00.000');
+INSERT INTO stock_movements VALUES(4,'SSI-D01220307-XL-SAL',152,75000,'initial stock','SSI-D01220307-XL-SAL','','2017-12-01 00:00:00.000');
+INSERT INTO stock_movements VALUES(5,'SSI-D01322234-LL-WHI',79,61000,'initial stock','SSI-D01322234-LL-WHI','','2017-12-01 00:00:00.000');
+INSERT INTO stock_movements VALUES(6,'SSI-D00791015-LL-BWH',40,62000,'purchase','PO02','','2017-12-06 10:12:56.123');
+INSERT INTO stock_movements VALUES(7,'SSI-D00864612-LL-NAV',20,55000,'purchase','PO02','','2017-12-06 10:12:56.123');
+INSERT INTO stock_movements VALUES(8,'SSI-D01037807-X3-BWH',18,85000,'purchase','PO03','','2017-12-07 14:26:10.250');
+INSERT INTO stock_movements VALUES(9,'SSI-D01220307-XL-SAL',30,75000,'purchase','PO03','','2017-12-07 14:26:10.250');
+INSERT INTO stock_movements VALUES(10,'SSI-D01322234-LL-WHI',45,61000,'purchase','PO04','','2017-12-08 17:32:09.623');
+INSERT INTO stock_movements VALUES(11,'SSI-D00864612-LL-NAV',-5,55000,'sale','INV01','','2017-12-16 16:34:12.532');
+INSERT INTO stock_movements VALUES(12,'SSI-D00791015-LL-BWH',-2,62000,'sale','INV01','','2017-12-16 16:34:12.532');
+INSERT INTO stock_movements VALUES(13,'SSI-D01037807-X3-BWH',-21,85000,'sale','INV03','','2017-12-18 18:24:23.122');
+INSERT INTO stock_movements VALUES(14,'SSI-D01322234-LL-WHI',-19,61000,'sale','INV04','','2017-12-19 21:43:17.235');
+INSERT INTO stock_movements VALUES(15,'SSI-D00791015-LL-BWH',-7,62000,'sale','INV04','','2017-12-19 21:43:17.235');
+CREATE TABLE `cost_layers` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`REMAINING_QUANTITY` INTEGER,
+`UNIT_COST` INTEGER,
+`REFERENCE` VARCHAR(64),
+`LAYER_DATE` DATETIME,
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+INSERT INTO cost_layers VALUES(1,'SSI-D00791015-LL-BWH',123,114,62000,'SSI-D00791015-LL-BWH','2017-12-01 00:00:00.000');
+INSERT INTO cost_layers VALUES(2,'SSI-D00864612-LL-NAV',70,65,55000,'SSI-D00864612-LL-NAV','2017-12-01 00:00:00.000');
+INSERT INTO cost_layers VALUES(3,'SSI-D01037807-X3-BWH',77,56,85000,'SSI-D01037807-X3-BWH','2017-12-01 00:00:00.000');
+INSERT INTO cost_layers VALUES(4,'SSI-D01220307-XL-SAL',152,152,75000,'SSI-D01220307-XL-SAL','2017-12-01 00:00:00.000');
+INSERT INTO cost_layers VALUES(5,'SSI-D01322234-LL-WHI',79,60,61000,'SSI-D01322234-LL-WHI','2017-12-01 00:00:00.000');
+INSERT INTO cost_layers VALUES(6,'SSI-D00791015-LL-BWH',40,40,55000,'PO02','2017-12-06 10:12:56.123');
+INSERT INTO cost_layers VALUES(7,'SSI-D00864612-LL-NAV',20,20,63000,'PO02','2017-12-06 10:12:56.123');
+INSERT INTO cost_layers VALUES(8,'SSI-D01037807-X3-BWH',18,18,64000,'PO03','2017-12-07 14:26:10.250');
+INSERT INTO cost_layers VALUES(9,'SSI-D01220307-XL-SAL',30,30,65000,'PO03','2017-12-07 14:26:10.250');
+INSERT INTO cost_layers VALUES(10,'SSI-D01322234-LL-WHI',45,45,58000,'PO04','2017-12-08 17:32:09.623');
+CREATE TABLE `sale_status_transitions` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`INVOICE_ID` VARCHAR(64),
+`FROM_STATUS` VARCHAR(3),
+`TO_STATUS` VARCHAR(3),
+`CHANGED_BY` VARCHAR(64),
+`CHANGED_AT` DATETIME,
+FOREIGN KEY(`INVOICE_ID`) REFERENCES sales(`INVOICE_ID`)
+);
+CREATE TABLE `sales_returns` (
+`RETURN_ID` VARCHAR(64) PRIMARY KEY,
+`INVOICE_ID` VARCHAR(64),
+`RETURN_DATE` DATETIME,
+`NOTE` TEXT NULL,
+FOREIGN KEY(`INVOICE_ID`) REFERENCES sales(`INVOICE_ID`)
+);
+CREATE TABLE `sales_return_items` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`RETURN_ID` VARCHAR(64),
+`SALE_ITEM_ID` INTEGER, /* returned line of sales_items */
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`BUY_PRICE` INTEGER, /* cost of the item when it was sold */
+`SELL_PRICE` INTEGER, /* price of the item when it was sold (refunded amount per item) */
+UNIQUE(`RETURN_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`RETURN_ID`) REFERENCES sales_returns(`RETURN_ID`),
+FOREIGN KEY(`SALE_ITEM_ID`) REFERENCES sales_items(`ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+CREATE TABLE `stock_counts` (
+`COUNT_ID` VARCHAR(64) PRIMARY KEY,
+`COUNT_DATE` DATETIME,
+`STATUS` VARCHAR(3), /* O = Open, P = Posted */
+`NOTE` TEXT NULL,
+`POSTED_DATE` DATETIME NULL
+);
+CREATE TABLE `stock_count_items` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`COUNT_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`COUNTED_QUANTITY` INTEGER NULL, /* null until the item is counted */
+`SYSTEM_QUANTITY` INTEGER, /* stock quantity when the count was posted */
+`BUY_PRICE` INTEGER, /* buy price when the count was posted */
+UNIQUE(`COUNT_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`COUNT_ID`) REFERENCES stock_counts(`COUNT_ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+CREATE TABLE `schema_migrations` (`VERSION` INTEGER PRIMARY KEY, `NAME` VARCHAR(64), `APPLIED_AT` DATETIME);
+INSERT INTO schema_migrations VALUES(0,'initial_schema','2017-12-20 00:00:00');
+INSERT INTO schema_migrations VALUES(1,'money_to_integer','2017-12-20 00:00:00');
+INSERT INTO schema_migrations VALUES(2,'sale_status_transitions','2017-12-20 00:00:00');
+INSERT INTO schema_migrations VALUES(3,'sales_returns','2017-12-20 00:00:00');
+INSERT INTO schema_migrations VALUES(4,'stock_counts','2017-12-20 00:00:00');
+INSERT INTO schema_migrations VALUES(5,'stock_reservations','2017-12-20 00:00:00');
+INSERT INTO schema_migrations VALUES(6,'item_indexes','2017-12-20 00:00:00');
+CREATE INDEX `sales_items_invoice_id` ON `sales_items`(`INVOICE_ID`);
+CREATE INDEX `sales_status_sale_date` ON `sales`(`STATUS`, `SALE_DATE`);
+CREATE INDEX `purchase_items_purchase_id` ON `purchase_items`(`PURCHASE_ID`);
 DELETE FROM sqlite_sequence;
 INSERT INTO sqlite_sequence VALUES('purchase_items',7);
 INSERT INTO sqlite_sequence VALUES('sales_items',8);
+INSERT INTO sqlite_sequence VALUES('stock_movements',15);
+INSERT INTO sqlite_sequence VALUES('cost_layers',10);
 COMMIT;
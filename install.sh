@@ -18,8 +18,10 @@ if [[ $GODEP == "" ]]; then
     exit 0
 fi
 
-#restore database
-sqlite3 /tmp/ijah.db < ijahDump.sql
+#restore database (pending schema migrations are applied when the http server starts)
+if [ ! -f /tmp/ijah.db ]; then
+    sqlite3 /tmp/ijah.db < ijahDump.sql
+fi
 
 #run http server
 cd repository/inventory/server/http/main
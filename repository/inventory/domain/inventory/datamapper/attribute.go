@@ -0,0 +1,194 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//Attribute is a struct of datamapper for attribute domain model (attribute definitions, see ItemAttributes for the values)
+type Attribute struct {
+	txScope
+}
+
+//NewAttribute creates a new Attribute datamapper and returns a pointer to it
+func NewAttribute(dbSession *sql.DB, dialect Dialect) *Attribute {
+	return &Attribute{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (a *Attribute) FindByID(id string) (model.Model, *errors.Error) {
+	stmt, err := a.conn().Prepare("SELECT NAME, TYPE FROM attributes WHERE NAME = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	attributes, errs := a.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(attributes) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return attributes[0], nil
+}
+
+//FindAll is a function for finding all records
+func (a *Attribute) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := a.conn().Query("SELECT NAME, TYPE FROM attributes ORDER BY NAME ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return a.loadRows(rows)
+}
+
+//loadRows is a function for composing attribute models from the given rows (NAME, TYPE), the rows are closed afterwards
+func (a *Attribute) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var name, attributeType sql.NullString
+
+	var returnedRow []model.Model
+	for rows.Next() {
+		err := rows.Scan(&name, &attributeType)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		attributeModel := &model.Attribute{
+			Name: name.String,
+			Type: attributeType.String,
+		}
+		attributeModel.SetLoadedFromStorage(true)
+
+		returnedRow = append(returnedRow, attributeModel)
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return returnedRow, nil
+}
+
+//Insert is a function for inserting a record
+func (a *Attribute) Insert(attributeModel model.Model) *errors.Error {
+	return a.inTx(func(tx *sql.Tx) *errors.Error {
+		return a.InsertWithTx(attributeModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (a *Attribute) InsertWithTx(attributeModel model.Model, tx *sql.Tx) *errors.Error {
+	attributeModelObj, ok := attributeModel.(*model.Attribute)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Attribute"), 0)
+	}
+	foundModel, _ := a.WithTx(tx).FindByID(attributeModel.GetID())
+	if foundModel != nil {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", attributeModel.GetID()), 0)
+	}
+	stmt, err := a.on(tx).Prepare("INSERT INTO attributes(NAME, TYPE) values(?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(attributeModelObj.Name, attributeModelObj.Type)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Update is a function for updating record
+func (a *Attribute) Update(attributeModel model.Model) *errors.Error {
+	return a.inTx(func(tx *sql.Tx) *errors.Error {
+		return a.UpdateWithTx(attributeModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+func (a *Attribute) UpdateWithTx(attributeModel model.Model, tx *sql.Tx) *errors.Error {
+	attributeModelObj, ok := attributeModel.(*model.Attribute)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Attribute"), 0)
+	}
+	_, errs := a.WithTx(tx).FindByID(attributeModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", attributeModel.GetID()), 0)
+	}
+	stmt, err := a.on(tx).Prepare("UPDATE attributes SET TYPE=? WHERE NAME=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(attributeModelObj.Type, attributeModelObj.Name)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Delete is a function for deleting record
+//Note: values of the attribute are left as they are, they should be removed first
+func (a *Attribute) Delete(attributeModel model.Model) *errors.Error {
+	attributeModelObj, ok := attributeModel.(*model.Attribute)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Attribute"), 0)
+	}
+	_, errs := a.FindByID(attributeModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", attributeModel.GetID()), 0)
+	}
+	return a.inTx(func(tx *sql.Tx) *errors.Error {
+		stmt, err := a.on(tx).Prepare("DELETE FROM attributes WHERE NAME=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer stmt.Close()
+		_, err = stmt.Exec(attributeModelObj.Name)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return nil
+	})
+}
+
+//Save is a function for persisting a model object to db
+func (a *Attribute) Save(attributeModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == attributeModel.GetLoadedFromStorage() {
+		//update operation
+		err = a.Update(attributeModel)
+	} else {
+		//insert operation
+		err = a.Insert(attributeModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (a *Attribute) WithTx(tx *sql.Tx) DataMapper {
+	return &Attribute{
+		txScope: a.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (a *Attribute) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (a *Attribute) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,195 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//Category is a struct of datamapper for category domain model
+type Category struct {
+	txScope
+}
+
+//NewCategory creates a new Category datamapper and returns a pointer to it
+func NewCategory(dbSession *sql.DB, dialect Dialect) *Category {
+	return &Category{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (c *Category) FindByID(id string) (model.Model, *errors.Error) {
+	stmt, err := c.conn().Prepare("SELECT CATEGORY_ID, NAME, PARENT_ID FROM categories WHERE CATEGORY_ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	categories, errs := c.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(categories) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return categories[0], nil
+}
+
+//FindAll is a function for finding all records
+func (c *Category) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := c.conn().Query("SELECT CATEGORY_ID, NAME, PARENT_ID FROM categories ORDER BY CATEGORY_ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return c.loadRows(rows)
+}
+
+//loadRows is a function for composing category models from the given rows (CATEGORY_ID, NAME, PARENT_ID), the rows are closed afterwards
+func (c *Category) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var categoryID, name, parentID sql.NullString
+
+	var returnedRow []model.Model
+	for rows.Next() {
+		err := rows.Scan(&categoryID, &name, &parentID)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		categoryModel := &model.Category{
+			CategoryID: categoryID.String,
+			Name:       name.String,
+			ParentID:   parentID.String,
+		}
+		categoryModel.SetLoadedFromStorage(true)
+
+		returnedRow = append(returnedRow, categoryModel)
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return returnedRow, nil
+}
+
+//Insert is a function for inserting a record
+func (c *Category) Insert(categoryModel model.Model) *errors.Error {
+	return c.inTx(func(tx *sql.Tx) *errors.Error {
+		return c.InsertWithTx(categoryModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (c *Category) InsertWithTx(categoryModel model.Model, tx *sql.Tx) *errors.Error {
+	categoryModelObj, ok := categoryModel.(*model.Category)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Category"), 0)
+	}
+	foundModel, _ := c.WithTx(tx).FindByID(categoryModel.GetID())
+	if foundModel != nil {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", categoryModel.GetID()), 0)
+	}
+	stmt, err := c.on(tx).Prepare("INSERT INTO categories(CATEGORY_ID, NAME, PARENT_ID) values(?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(categoryModelObj.CategoryID, categoryModelObj.Name, nullText(categoryModelObj.ParentID))
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Update is a function for updating record
+func (c *Category) Update(categoryModel model.Model) *errors.Error {
+	return c.inTx(func(tx *sql.Tx) *errors.Error {
+		return c.UpdateWithTx(categoryModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+func (c *Category) UpdateWithTx(categoryModel model.Model, tx *sql.Tx) *errors.Error {
+	categoryModelObj, ok := categoryModel.(*model.Category)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Category"), 0)
+	}
+	_, errs := c.WithTx(tx).FindByID(categoryModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", categoryModel.GetID()), 0)
+	}
+	stmt, err := c.on(tx).Prepare("UPDATE categories SET NAME=?, PARENT_ID=? WHERE CATEGORY_ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(categoryModelObj.Name, nullText(categoryModelObj.ParentID), categoryModelObj.CategoryID)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Delete is a function for deleting record
+//Note: subcategories and items of the category are left as they are, they should be moved first
+func (c *Category) Delete(categoryModel model.Model) *errors.Error {
+	categoryModelObj, ok := categoryModel.(*model.Category)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Category"), 0)
+	}
+	_, errs := c.FindByID(categoryModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", categoryModel.GetID()), 0)
+	}
+	return c.inTx(func(tx *sql.Tx) *errors.Error {
+		stmt, err := c.on(tx).Prepare("DELETE FROM categories WHERE CATEGORY_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer stmt.Close()
+		_, err = stmt.Exec(categoryModelObj.CategoryID)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return nil
+	})
+}
+
+//Save is a function for persisting a model object to db
+func (c *Category) Save(categoryModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == categoryModel.GetLoadedFromStorage() {
+		//update operation
+		err = c.Update(categoryModel)
+	} else {
+		//insert operation
+		err = c.Insert(categoryModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (c *Category) WithTx(tx *sql.Tx) DataMapper {
+	return &Category{
+		txScope: c.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (c *Category) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (c *Category) Shutdown() {
+	//Note: perform any cleanup here
+}
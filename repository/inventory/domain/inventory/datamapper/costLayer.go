@@ -0,0 +1,202 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//CostLayer is a struct of datamapper for cost layer domain model
+type CostLayer struct {
+	txScope
+}
+
+//NewCostLayer creates a new CostLayer datamapper and returns a pointer to it
+func NewCostLayer(dbSession *sql.DB, dialect Dialect) *CostLayer {
+	return &CostLayer{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (cl *CostLayer) FindByID(id string) (model.Model, *errors.Error) {
+	layerID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	stmt, err := cl.conn().Prepare("SELECT ID, SKU, QUANTITY, REMAINING_QUANTITY, UNIT_COST, REFERENCE, DATETIME(LAYER_DATE) FROM cost_layers WHERE ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(layerID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	layers, errs := cl.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(layers) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return layers[0], nil
+}
+
+//FindAll is a function for finding all records
+func (cl *CostLayer) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := cl.conn().Query("SELECT ID, SKU, QUANTITY, REMAINING_QUANTITY, UNIT_COST, REFERENCE, DATETIME(LAYER_DATE) FROM cost_layers ORDER BY LAYER_DATE ASC, ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return cl.loadRows(rows)
+}
+
+//FindOpenBySku is a function for finding layers of a sku which still have remaining quantity (oldest layer first)
+func (cl *CostLayer) FindOpenBySku(sku string) ([]model.Model, *errors.Error) {
+	stmt, err := cl.conn().Prepare("SELECT ID, SKU, QUANTITY, REMAINING_QUANTITY, UNIT_COST, REFERENCE, DATETIME(LAYER_DATE) FROM cost_layers WHERE SKU = ? AND REMAINING_QUANTITY > 0 ORDER BY LAYER_DATE ASC, ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(sku)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return cl.loadRows(rows)
+}
+
+//loadRows is a function for composing layer models from the given rows
+func (cl *CostLayer) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var layerID int64
+	var sku, reference, date sql.NullString
+	var quantity, remainingQuantity sql.NullInt64
+	var unitCost sql.NullInt64
+
+	var returnedRow []model.Model
+	for rows.Next() {
+		err := rows.Scan(&layerID, &sku, &quantity, &remainingQuantity, &unitCost, &reference, &date)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		dateTimeValue, err := time.Parse(timeFormat, date.String)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		layerModel := &model.CostLayer{
+			Sku:               sku.String,
+			Quantity:          quantity.Int64,
+			RemainingQuantity: remainingQuantity.Int64,
+			UnitCost:          model.Money(unitCost.Int64),
+			Reference:         reference.String,
+			Date:              dateTimeValue,
+		}
+		layerModel.SetID(layerID)
+		layerModel.SetLoadedFromStorage(true)
+
+		returnedRow = append(returnedRow, layerModel)
+	}
+	return returnedRow, nil
+}
+
+//Insert is a function for inserting a record
+func (cl *CostLayer) Insert(layerModel model.Model) *errors.Error {
+	return cl.inTx(func(tx *sql.Tx) *errors.Error {
+		return cl.InsertWithTx(layerModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (cl *CostLayer) InsertWithTx(layerModel model.Model, tx *sql.Tx) *errors.Error {
+	layerModelObj, ok := layerModel.(*model.CostLayer)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.CostLayer"), 0)
+	}
+	if true == layerModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot insert, cost layer with id: %v already exists", layerModel.GetID()), 0)
+	}
+
+	dateString := layerModelObj.Date.Format(timeFormat)
+	insertedID, err := cl.dialect.InsertReturningID(tx, "INSERT INTO cost_layers(SKU, QUANTITY, REMAINING_QUANTITY, UNIT_COST, REFERENCE, LAYER_DATE) values(?,?,?,?,?,?)", layerModelObj.Sku, layerModelObj.Quantity, layerModelObj.RemainingQuantity, layerModelObj.UnitCost, layerModelObj.Reference, dateString)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	layerModelObj.SetID(insertedID)
+	return nil
+}
+
+//Update is a function for updating record
+func (cl *CostLayer) Update(layerModel model.Model) *errors.Error {
+	return cl.inTx(func(tx *sql.Tx) *errors.Error {
+		return cl.UpdateWithTx(layerModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+//Note: only remaining quantity and unit cost of a layer can be changed
+func (cl *CostLayer) UpdateWithTx(layerModel model.Model, tx *sql.Tx) *errors.Error {
+	layerModelObj, ok := layerModel.(*model.CostLayer)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.CostLayer"), 0)
+	}
+	if false == layerModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot update, cost layer with id: %v doesn't exist", layerModel.GetID()), 0)
+	}
+
+	stmt, err := cl.on(tx).Prepare("UPDATE cost_layers SET REMAINING_QUANTITY=?, UNIT_COST=? WHERE ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(layerModelObj.RemainingQuantity, layerModelObj.UnitCost, layerModelObj.GetID())
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Delete is a function for deleting record
+//Note: layers are kept for auditing cost of goods sold, consume them instead of deleting
+func (cl *CostLayer) Delete(layerModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, cost layer %v must be consumed instead", layerModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to db
+func (cl *CostLayer) Save(layerModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == layerModel.GetLoadedFromStorage() {
+		//update operation
+		err = cl.Update(layerModel)
+	} else {
+		//insert operation
+		err = cl.Insert(layerModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (cl *CostLayer) WithTx(tx *sql.Tx) DataMapper {
+	return &CostLayer{
+		txScope: cl.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (cl *CostLayer) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (cl *CostLayer) Shutdown() {
+	//Note: perform any cleanup here
+}
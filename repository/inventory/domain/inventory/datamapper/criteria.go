@@ -0,0 +1,412 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//operators of a criteria filter
+const (
+	OperatorEqual          = "eq"
+	OperatorNotEqual       = "ne"
+	OperatorLess           = "lt"
+	OperatorLessOrEqual    = "lte"
+	OperatorGreater        = "gt"
+	OperatorGreaterOrEqual = "gte"
+	OperatorContains       = "contains" //case insensitive substring match, text fields only
+)
+
+//DefaultCriteriaLimit is the page size of a criteria without limit
+const DefaultCriteriaLimit = 50
+
+//MaxCriteriaLimit is the largest page size of a criteria (larger limits are reduced to it)
+const MaxCriteriaLimit = 500
+
+//Criteria is a definition of a list query: the records matching every filter, in order of the sorts, one page (of at most Limit records) at a time
+//the first page is found with an empty Cursor, the next pages with the cursor returned along with the previous page (see Page)
+type Criteria struct {
+	Filters []Filter
+	Sorts   []Sort
+	Limit   int    //page size (DefaultCriteriaLimit when zero)
+	Cursor  string //NextCursor of the previous page, empty for the first page
+}
+
+//Filter is a condition of a criteria
+//Value must be of the type of the field: string for text fields, int64 (or int, model.Money) for integer fields and time.Time for time fields
+type Filter struct {
+	Field    string
+	Operator string
+	Value    interface{}
+}
+
+//Sort is a sort order of a criteria
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+//Page is a page of the records found by a criteria
+type Page struct {
+	Items      []model.Model
+	Limit      int
+	NextCursor string //cursor of the next page, empty on the last page
+}
+
+//kinds of criteria field values
+const (
+	fieldText = iota
+	fieldInteger
+	fieldTime
+)
+
+//criteriaField is a definition of a field a datamapper can filter and sort records by
+type criteriaField struct {
+	column string                               //column (sql expression) of the field
+	kind   int                                  //kind of the values of the field
+	value  func(record model.Model) interface{} //value of the field of a model (string, int64 or time.Time), used by the memory datamappers and the cursors
+}
+
+//resolvedCriteria is a criteria validated against the fields of a datamapper
+//the sorts always end with the key field (unique), so the order of the records is total and the cursor tells exactly where a page ends
+type resolvedCriteria struct {
+	fields  map[string]criteriaField
+	filters []Filter
+	sorts   []Sort
+	limit   int
+	after   []interface{} //values of the sort fields of the last record of the previous page, nil for the first page
+}
+
+//resolve is a function for validating a criteria against the fields of a datamapper
+func (c Criteria) resolve(fields map[string]criteriaField, keyField string) (*resolvedCriteria, *errors.Error) {
+	resolved := &resolvedCriteria{
+		fields: fields,
+		limit:  c.Limit,
+	}
+	if c.Limit < 0 {
+		return nil, errors.Wrap(fmt.Errorf("Invalid limit %v", c.Limit), 0)
+	}
+	if c.Limit == 0 {
+		resolved.limit = DefaultCriteriaLimit
+	}
+	if resolved.limit > MaxCriteriaLimit {
+		resolved.limit = MaxCriteriaLimit
+	}
+
+	for _, val := range c.Filters {
+		field, exists := fields[val.Field]
+		if false == exists {
+			return nil, errors.Wrap(fmt.Errorf("Unknown filter field %v", val.Field), 0)
+		}
+		switch val.Operator {
+		case OperatorEqual, OperatorNotEqual, OperatorLess, OperatorLessOrEqual, OperatorGreater, OperatorGreaterOrEqual:
+		case OperatorContains:
+			if field.kind != fieldText {
+				return nil, errors.Wrap(fmt.Errorf("Operator %v only applies to text fields, %v isn't one", val.Operator, val.Field), 0)
+			}
+		default:
+			return nil, errors.Wrap(fmt.Errorf("Unknown filter operator %v", val.Operator), 0)
+		}
+		value, ok := criteriaValue(field.kind, val.Value)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Invalid value %v of filter field %v", val.Value, val.Field), 0)
+		}
+		resolved.filters = append(resolved.filters, Filter{Field: val.Field, Operator: val.Operator, Value: value})
+	}
+
+	for _, val := range c.Sorts {
+		if _, exists := fields[val.Field]; false == exists {
+			return nil, errors.Wrap(fmt.Errorf("Unknown sort field %v", val.Field), 0)
+		}
+		resolved.sorts = append(resolved.sorts, val)
+		if val.Field == keyField {
+			break
+		}
+	}
+	if len(resolved.sorts) == 0 || resolved.sorts[len(resolved.sorts)-1].Field != keyField {
+		resolved.sorts = append(resolved.sorts, Sort{Field: keyField})
+	}
+
+	if c.Cursor != "" {
+		after, errs := resolved.decodeCursor(c.Cursor)
+		if errs != nil {
+			return nil, errs
+		}
+		resolved.after = after
+	}
+	return resolved, nil
+}
+
+//criteriaValue is a function for converting a value to the type of the values of the given kind
+func criteriaValue(kind int, value interface{}) (interface{}, bool) {
+	switch kind {
+	case fieldText:
+		stringValue, ok := value.(string)
+		return stringValue, ok
+	case fieldInteger:
+		switch integerValue := value.(type) {
+		case int64:
+			return integerValue, true
+		case int:
+			return int64(integerValue), true
+		case model.Money:
+			return int64(integerValue), true
+		}
+	case fieldTime:
+		timeValue, ok := value.(time.Time)
+		return timeValue, ok
+	}
+	return nil, false
+}
+
+//sortSignature is a function for composing the text form of the sorts (e.g. "-quantity,sku"), a cursor only applies to the sorts it was made for
+func (rc *resolvedCriteria) sortSignature() string {
+	signature := make([]string, 0, len(rc.sorts))
+	for _, val := range rc.sorts {
+		if true == val.Descending {
+			signature = append(signature, "-"+val.Field)
+		} else {
+			signature = append(signature, val.Field)
+		}
+	}
+	return strings.Join(signature, ",")
+}
+
+//criteriaCursor is the content of a cursor (before encoding)
+type criteriaCursor struct {
+	Sorts  string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+//encodeCursor is a function for composing the cursor of the page following the given record
+func (rc *resolvedCriteria) encodeCursor(record model.Model) string {
+	cursor := criteriaCursor{Sorts: rc.sortSignature()}
+	for _, val := range rc.sorts {
+		switch value := rc.fields[val.Field].value(record).(type) {
+		case string:
+			cursor.Values = append(cursor.Values, value)
+		case int64:
+			cursor.Values = append(cursor.Values, strconv.FormatInt(value, 10))
+		case time.Time:
+			cursor.Values = append(cursor.Values, value.Format(timeFormat))
+		}
+	}
+	cursorJSON, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(cursorJSON)
+}
+
+//decodeCursor is a function for obtaining the values of the sort fields held by a cursor
+func (rc *resolvedCriteria) decodeCursor(encoded string) ([]interface{}, *errors.Error) {
+	invalidErr := errors.Wrap(fmt.Errorf("Invalid cursor %v", encoded), 0)
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalidErr
+	}
+	var cursor criteriaCursor
+	err = json.Unmarshal(cursorJSON, &cursor)
+	if err != nil || len(cursor.Values) != len(rc.sorts) {
+		return nil, invalidErr
+	}
+	if cursor.Sorts != rc.sortSignature() {
+		return nil, errors.Wrap(fmt.Errorf("Cursor was made for sort %v, not for %v", cursor.Sorts, rc.sortSignature()), 0)
+	}
+
+	after := make([]interface{}, 0, len(rc.sorts))
+	for x, val := range rc.sorts {
+		switch rc.fields[val.Field].kind {
+		case fieldText:
+			after = append(after, cursor.Values[x])
+		case fieldInteger:
+			value, err := strconv.ParseInt(cursor.Values[x], 10, 64)
+			if err != nil {
+				return nil, invalidErr
+			}
+			after = append(after, value)
+		case fieldTime:
+			value, err := time.Parse(timeFormat, cursor.Values[x])
+			if err != nil {
+				return nil, invalidErr
+			}
+			after = append(after, value)
+		}
+	}
+	return after, nil
+}
+
+//page is a function for composing the page of the found records, records are expected to be found with a limit of rc.limit+1
+//(a record past the limit tells there's a next page)
+func (rc *resolvedCriteria) page(records []model.Model) *Page {
+	pageObj := &Page{
+		Items: records,
+		Limit: rc.limit,
+	}
+	if len(records) > rc.limit {
+		pageObj.Items = records[:rc.limit]
+		pageObj.NextCursor = rc.encodeCursor(records[rc.limit-1])
+	}
+	if pageObj.Items == nil {
+		pageObj.Items = make([]model.Model, 0)
+	}
+	return pageObj
+}
+
+//sqlArg is a function for converting a value to a sql query argument (times are passed the way they're stored, see timeFormat)
+func sqlArg(value interface{}) interface{} {
+	if timeValue, ok := value.(time.Time); true == ok {
+		return timeValue.Format(timeFormat)
+	}
+	return value
+}
+
+//sqlOperators are the sql comparison operators of the filter operators
+var sqlOperators = map[string]string{
+	OperatorEqual:          "=",
+	OperatorNotEqual:       "<>",
+	OperatorLess:           "<",
+	OperatorLessOrEqual:    "<=",
+	OperatorGreater:        ">",
+	OperatorGreaterOrEqual: ">=",
+}
+
+//sqlWhere is a function for composing the where clause (empty when there's no condition) of the filters and the cursor, along with its arguments
+func (rc *resolvedCriteria) sqlWhere() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, val := range rc.filters {
+		column := rc.fields[val.Field].column
+		if val.Operator == OperatorContains {
+			//escape the wildcards of LIKE, so the value matches literally
+			pattern := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.ToLower(val.Value.(string)))
+			conditions = append(conditions, "LOWER("+column+") LIKE ? ESCAPE '\\'")
+			args = append(args, "%"+pattern+"%")
+			continue
+		}
+		conditions = append(conditions, column+" "+sqlOperators[val.Operator]+" ?")
+		args = append(args, sqlArg(val.Value))
+	}
+
+	//records after the cursor: (s1 > v1) OR (s1 = v1 AND s2 > v2) OR ... (< for descending sorts)
+	if rc.after != nil {
+		var alternatives []string
+		for x, val := range rc.sorts {
+			var terms []string
+			for y := 0; y < x; y++ {
+				terms = append(terms, rc.fields[rc.sorts[y].Field].column+" = ?")
+				args = append(args, sqlArg(rc.after[y]))
+			}
+			operator := " > ?"
+			if true == val.Descending {
+				operator = " < ?"
+			}
+			terms = append(terms, rc.fields[val.Field].column+operator)
+			args = append(args, sqlArg(rc.after[x]))
+			alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+//sqlOrder is a function for composing the order by clause of the sorts
+func (rc *resolvedCriteria) sqlOrder() string {
+	order := make([]string, 0, len(rc.sorts))
+	for _, val := range rc.sorts {
+		if true == val.Descending {
+			order = append(order, rc.fields[val.Field].column+" DESC")
+		} else {
+			order = append(order, rc.fields[val.Field].column+" ASC")
+		}
+	}
+	return " ORDER BY " + strings.Join(order, ", ")
+}
+
+//compareValues is a function for comparing 2 values of a field (-1 when x is less than y, 0 when they're equal, 1 otherwise)
+func compareValues(x, y interface{}) int {
+	switch xValue := x.(type) {
+	case string:
+		return strings.Compare(xValue, y.(string))
+	case int64:
+		yValue := y.(int64)
+		if xValue < yValue {
+			return -1
+		}
+		if xValue > yValue {
+			return 1
+		}
+	case time.Time:
+		//compared the way they're stored by the sql datamappers (see timeFormat)
+		return strings.Compare(xValue.Format(timeFormat), y.(time.Time).Format(timeFormat))
+	}
+	return 0
+}
+
+//match is a function for telling whether a record matches the filters and comes after the cursor (used by the memory datamappers)
+func (rc *resolvedCriteria) match(record model.Model) bool {
+	for _, val := range rc.filters {
+		value := rc.fields[val.Field].value(record)
+		if val.Operator == OperatorContains {
+			if false == strings.Contains(strings.ToLower(value.(string)), strings.ToLower(val.Value.(string))) {
+				return false
+			}
+			continue
+		}
+		comparison := compareValues(value, val.Value)
+		matched := false
+		switch val.Operator {
+		case OperatorEqual:
+			matched = comparison == 0
+		case OperatorNotEqual:
+			matched = comparison != 0
+		case OperatorLess:
+			matched = comparison < 0
+		case OperatorLessOrEqual:
+			matched = comparison <= 0
+		case OperatorGreater:
+			matched = comparison > 0
+		case OperatorGreaterOrEqual:
+			matched = comparison >= 0
+		}
+		if false == matched {
+			return false
+		}
+	}
+	if rc.after != nil {
+		return rc.compare(record, rc.after) > 0
+	}
+	return true
+}
+
+//compare is a function for comparing a record with the values of the sort fields in order of the sorts (see compareValues)
+func (rc *resolvedCriteria) compare(record model.Model, values []interface{}) int {
+	for x, val := range rc.sorts {
+		comparison := compareValues(rc.fields[val.Field].value(record), values[x])
+		if true == val.Descending {
+			comparison = -comparison
+		}
+		if comparison != 0 {
+			return comparison
+		}
+	}
+	return 0
+}
+
+//less is a function for ordering records in order of the sorts (used by the memory datamappers)
+func (rc *resolvedCriteria) less(x, y model.Model) bool {
+	yValues := make([]interface{}, 0, len(rc.sorts))
+	for _, val := range rc.sorts {
+		yValues = append(yValues, rc.fields[val.Field].value(y))
+	}
+	return rc.compare(x, yValues) < 0
+}
@@ -0,0 +1,203 @@
+package datamapper_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//criteriaMapper is a datamapper capable of finding records by criteria (both the sql and the memory datamappers)
+type criteriaMapper interface {
+	datamapper.DataMapper
+	datamapper.CriteriaFinder
+}
+
+//findAllPages is a function for finding every page of a criteria, it returns the ids of the found records page by page
+func findAllPages(t *testing.T, mapper criteriaMapper, criteria datamapper.Criteria) [][]string {
+	var pages [][]string
+	for {
+		page, err := mapper.FindByCriteria(criteria)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		ids := make([]string, 0, len(page.Items))
+		for _, val := range page.Items {
+			ids = append(ids, val.GetID())
+		}
+		pages = append(pages, ids)
+		if page.NextCursor == "" || len(pages) > 10 {
+			return pages
+		}
+		criteria.Cursor = page.NextCursor
+	}
+}
+
+func TestStockFindByCriteria(t *testing.T) {
+	forEachDialect(t, func(t *testing.T, dialect datamapper.Dialect, db *sql.DB) {
+		testStockFindByCriteria(t, datamapper.NewStock(db, dialect))
+	})
+	t.Run(datamapper.DriverMemory, func(t *testing.T) {
+		testStockFindByCriteria(t, datamapper.NewMemoryStock(datamapper.NewMemoryStore()))
+	})
+}
+
+func testStockFindByCriteria(t *testing.T, mapper criteriaMapper) {
+	stocks := []*model.Stock{
+		{Sku: "SKU-1", Name: "Blouse Merah", Quantity: 10, BuyPrice: 50000, SellPrice: 60000},
+		{Sku: "SKU-2", Name: "blouse putih", Quantity: 5, BuyPrice: 50000, SellPrice: 60000},
+		{Sku: "SKU-3", Name: "Kemeja", Quantity: 20, BuyPrice: 70000, SellPrice: 80000},
+		{Sku: "SKU-4", Name: "Blouse Hitam", Quantity: 20, BuyPrice: 50000, SellPrice: 60000, Reserved: 15},
+		{Sku: "SKU-5", Name: "Rok 100%", Quantity: 0, BuyPrice: 40000, SellPrice: 45000},
+	}
+	for _, val := range stocks {
+		err := mapper.Insert(val)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+
+	pages := findAllPages(t, mapper, datamapper.Criteria{
+		Filters: []datamapper.Filter{
+			{Field: "name", Operator: datamapper.OperatorContains, Value: "blouse"},
+			{Field: "quantity", Operator: datamapper.OperatorGreaterOrEqual, Value: 10},
+		},
+		Sorts: []datamapper.Sort{{Field: "quantity", Descending: true}},
+	})
+	t.Run("filtered items must be found in order", func(t *testing.T) {
+		if len(pages) != 1 || len(pages[0]) != 2 || pages[0][0] != "SKU-4" || pages[0][1] != "SKU-1" {
+			t.Errorf("expected [[SKU-4 SKU-1]] but got %v", pages)
+		}
+	})
+
+	//ties of the sort field are ordered by sku, so every item is found exactly once
+	pages = findAllPages(t, mapper, datamapper.Criteria{
+		Sorts: []datamapper.Sort{{Field: "quantity", Descending: true}},
+		Limit: 2,
+	})
+	t.Run("every page must be found", func(t *testing.T) {
+		if len(pages) != 3 || len(pages[2]) != 1 {
+			t.Fatalf("expected 3 pages but got %v", pages)
+		}
+		expected := []string{"SKU-3", "SKU-4", "SKU-1", "SKU-2", "SKU-5"}
+		for x, val := range append(append(pages[0], pages[1]...), pages[2]...) {
+			if val != expected[x] {
+				t.Errorf("expected %v but got %v", expected, pages)
+				break
+			}
+		}
+	})
+
+	pages = findAllPages(t, mapper, datamapper.Criteria{
+		Filters: []datamapper.Filter{{Field: "available", Operator: datamapper.OperatorLess, Value: 10}},
+		Sorts:   []datamapper.Sort{{Field: "name"}},
+	})
+	t.Run("items must be filtered by computed fields", func(t *testing.T) {
+		if len(pages) != 1 || len(pages[0]) != 3 || pages[0][0] != "SKU-4" || pages[0][1] != "SKU-5" || pages[0][2] != "SKU-2" {
+			t.Errorf("expected [[SKU-4 SKU-5 SKU-2]] but got %v", pages)
+		}
+	})
+
+	pages = findAllPages(t, mapper, datamapper.Criteria{
+		Filters: []datamapper.Filter{{Field: "name", Operator: datamapper.OperatorContains, Value: "0%"}},
+	})
+	t.Run("wildcards must match literally", func(t *testing.T) {
+		if len(pages) != 1 || len(pages[0]) != 1 || pages[0][0] != "SKU-5" {
+			t.Errorf("expected [[SKU-5]] but got %v", pages)
+		}
+	})
+
+	firstPage, _ := mapper.FindByCriteria(datamapper.Criteria{Limit: 1})
+	invalidCriteria := map[string]datamapper.Criteria{
+		"unknown field":     {Filters: []datamapper.Filter{{Field: "weight", Operator: datamapper.OperatorEqual, Value: "red"}}},
+		"unknown operator":  {Filters: []datamapper.Filter{{Field: "quantity", Operator: "like", Value: 10}}},
+		"contains integer":  {Filters: []datamapper.Filter{{Field: "quantity", Operator: datamapper.OperatorContains, Value: "1"}}},
+		"mismatched value":  {Filters: []datamapper.Filter{{Field: "quantity", Operator: datamapper.OperatorEqual, Value: "10"}}},
+		"unknown sort":      {Sorts: []datamapper.Sort{{Field: "weight"}}},
+		"negative limit":    {Limit: -1},
+		"malformed cursor":  {Cursor: "not a cursor"},
+		"cursor other sort": {Sorts: []datamapper.Sort{{Field: "name"}}, Cursor: firstPage.NextCursor},
+	}
+	for name, val := range invalidCriteria {
+		_, err := mapper.FindByCriteria(val)
+		t.Run(name+" must be rejected", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestSaleFindByCriteria(t *testing.T) {
+	forEachDialect(t, func(t *testing.T, dialect datamapper.Dialect, db *sql.DB) {
+		insertTestStock(t, dialect, db, "dummySku")
+		insertTestStock(t, dialect, db, "otherSku")
+		testSaleFindByCriteria(t, datamapper.NewSale(db, dialect))
+	})
+	t.Run(datamapper.DriverMemory, func(t *testing.T) {
+		testSaleFindByCriteria(t, datamapper.NewMemorySale(datamapper.NewMemoryStore()))
+	})
+}
+
+func testSaleFindByCriteria(t *testing.T, mapper criteriaMapper) {
+	sales := []*model.Sales{
+		{InvoiceID: "INV-1", Date: time.Date(2018, 1, 5, 8, 0, 0, 0, time.UTC), Status: "S"},
+		{InvoiceID: "INV-2", Date: time.Date(2018, 2, 5, 8, 0, 0, 0, time.UTC), Status: "S"},
+		{InvoiceID: "INV-3", Date: time.Date(2018, 1, 20, 8, 0, 0, 0, time.UTC), Status: "D"},
+		{InvoiceID: "INV-4", Date: time.Date(2018, 1, 31, 17, 0, 0, 0, time.UTC), Status: "S"},
+		{InvoiceID: "INV-5", Date: time.Date(2018, 1, 31, 17, 0, 0, 0, time.UTC), Status: "S"},
+	}
+	for _, val := range sales {
+		val.Items = map[string]*model.SaleItem{
+			"dummySku": {Sku: "dummySku", Quantity: 2, BuyPrice: 50000, SellPrice: 60000},
+			"otherSku": {Sku: "otherSku", Quantity: 1, BuyPrice: 50000, SellPrice: 65000},
+		}
+		err := mapper.Insert(val)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+
+	criteria := datamapper.Criteria{
+		Filters: []datamapper.Filter{
+			{Field: "status", Operator: datamapper.OperatorEqual, Value: "S"},
+			{Field: "date", Operator: datamapper.OperatorGreaterOrEqual, Value: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Field: "date", Operator: datamapper.OperatorLess, Value: time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Sorts: []datamapper.Sort{{Field: "date", Descending: true}},
+		Limit: 2,
+	}
+	page, err := mapper.FindByCriteria(criteria)
+	t.Run("first page must be found along with the items", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(page.Items) != 2 || page.Items[0].GetID() != "INV-4" || page.Items[1].GetID() != "INV-5" || page.NextCursor == "" {
+			t.Fatalf("expected INV-4 and INV-5 along with a cursor but got %+v", page)
+		}
+		items := page.Items[1].(*model.Sales).Items
+		if len(items) != 2 || items["otherSku"].SellPrice != 65000 || items["otherSku"].GetID() == 0 {
+			t.Errorf("expected both items of INV-5 but got %+v", items)
+		}
+	})
+
+	criteria.Cursor = page.NextCursor
+	page, err = mapper.FindByCriteria(criteria)
+	t.Run("last page must be found after the cursor", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(page.Items) != 1 || page.Items[0].GetID() != "INV-1" || page.NextCursor != "" {
+			t.Errorf("expected INV-1 without cursor but got %+v", page)
+		}
+	})
+
+	pages := findAllPages(t, mapper, datamapper.Criteria{Limit: 4})
+	t.Run("sales must be sorted by invoice id by default", func(t *testing.T) {
+		if len(pages) != 2 || len(pages[0]) != 4 || pages[0][0] != "INV-1" || pages[0][3] != "INV-4" || len(pages[1]) != 1 || pages[1][0] != "INV-5" {
+			t.Errorf("expected [[INV-1 INV-2 INV-3 INV-4] [INV-5]] but got %v", pages)
+		}
+	})
+}
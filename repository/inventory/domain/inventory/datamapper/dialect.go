@@ -0,0 +1,144 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+//Names of the database/sql drivers of the supported dialects (used as the database.driver config)
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+)
+
+//Dialect is an interface for the syntax differences between the databases the datamappers can run on
+//queries of the datamappers are written in the sqlite flavour (? placeholders, DATETIME() for reading dates) and translated by the dialect
+type Dialect interface {
+	//DriverName returns the name of the database/sql driver of the dialect
+	DriverName() string
+	//Translate returns the query converted from the sqlite flavour into the syntax of the database
+	Translate(query string) string
+	//InsertReturningID runs an insert statement (using passed transaction handler) and returns the id the database generated for the new record
+	InsertReturningID(tx *sql.Tx, query string, args ...interface{}) (int64, error)
+}
+
+//sqliteDialect is the dialect of sqlite databases, queries are run as they are
+type sqliteDialect struct{}
+
+//DriverName returns the name of the database/sql driver of the dialect
+func (d sqliteDialect) DriverName() string {
+	return DriverSQLite
+}
+
+//Translate returns the query unchanged, the datamapper queries are already written for sqlite
+func (d sqliteDialect) Translate(query string) string {
+	return query
+}
+
+//InsertReturningID runs an insert statement and returns the id taken from sql.Result.LastInsertId
+func (d sqliteDialect) InsertReturningID(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	result, err := stmt.Exec(args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+//postgresDialect is the dialect of PostgreSQL databases
+type postgresDialect struct{}
+
+//dateTimeRegxp is the regex for finding sqlite DATETIME() calls on a column
+var dateTimeRegxp = regexp.MustCompile(`DATETIME\(([\w.]+)\)`)
+
+//DriverName returns the name of the database/sql driver of the dialect
+func (d postgresDialect) DriverName() string {
+	return DriverPostgres
+}
+
+//Translate returns the query with ? placeholders numbered ($1, $2, ...) and DATETIME(column) formatting the column as timeFormat text
+func (d postgresDialect) Translate(query string) string {
+	query = dateTimeRegxp.ReplaceAllString(query, "TO_CHAR($1, 'YYYY-MM-DD HH24:MI:SS')")
+
+	var translated strings.Builder
+	placeholder := 0
+	quoted := false
+	for _, val := range query {
+		if val == '\'' {
+			quoted = !quoted
+		}
+		if val == '?' && false == quoted {
+			placeholder++
+			translated.WriteString("$" + strconv.Itoa(placeholder))
+			continue
+		}
+		translated.WriteRune(val)
+	}
+	return translated.String()
+}
+
+//InsertReturningID runs an insert statement with a RETURNING clause (the lib/pq driver doesn't support LastInsertId)
+//Note: the generated id column of every table with generated ids is named ID
+func (d postgresDialect) InsertReturningID(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	stmt, err := tx.Prepare(d.Translate(query) + " RETURNING ID")
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	var insertedID int64
+	err = stmt.QueryRow(args...).Scan(&insertedID)
+	if err != nil {
+		return 0, err
+	}
+	return insertedID, nil
+}
+
+//Supported dialects
+var (
+	SQLite   Dialect = sqliteDialect{}
+	Postgres Dialect = postgresDialect{}
+)
+
+//DialectOf is a function for obtaining the dialect of the given database/sql driver name
+func DialectOf(driverName string) (Dialect, *errors.Error) {
+	switch driverName {
+	case DriverSQLite:
+		return SQLite, nil
+	case DriverPostgres:
+		return Postgres, nil
+	}
+	return nil, errors.Wrap(fmt.Errorf("Unsupported database driver %v", driverName), 0)
+}
+
+//dialectConn is a querier running queries on the underlying handler after translating them into the dialect
+type dialectConn struct {
+	conn    querier
+	dialect Dialect
+}
+
+//Prepare is a function for creating a prepared statement of the translated query
+func (dc dialectConn) Prepare(query string) (*sql.Stmt, error) {
+	return dc.conn.Prepare(dc.dialect.Translate(query))
+}
+
+//Query is a function for running the translated query and returning its rows
+func (dc dialectConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return dc.conn.Query(dc.dialect.Translate(query), args...)
+}
+
+//QueryRow is a function for running the translated query and returning its first row
+func (dc dialectConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return dc.conn.QueryRow(dc.dialect.Translate(query), args...)
+}
@@ -0,0 +1,74 @@
+//datamapper_test provides unit tests for the sql dialects and the datamappers running on them
+package datamapper_test
+
+import (
+	"testing"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+)
+
+func TestDialectOf(t *testing.T) {
+	testCases := []struct {
+		driverName string
+		expected   datamapper.Dialect
+		failed     bool
+	}{
+		{datamapper.DriverSQLite, datamapper.SQLite, false},
+		{datamapper.DriverPostgres, datamapper.Postgres, false},
+		{"mysql", nil, true},
+	}
+	for _, val := range testCases {
+		t.Run(val.driverName, func(t *testing.T) {
+			dialect, err := datamapper.DialectOf(val.driverName)
+			if true == val.failed {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil || dialect != val.expected {
+				t.Errorf("expected %v dialect but got %v and %v", val.driverName, dialect, err)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		sqlite   string
+		postgres string
+	}{
+		{
+			"placeholders must be numbered",
+			"UPDATE stock SET NAME=?, QUANTITY=? WHERE SKU=?",
+			"UPDATE stock SET NAME=?, QUANTITY=? WHERE SKU=?",
+			"UPDATE stock SET NAME=$1, QUANTITY=$2 WHERE SKU=$3",
+		},
+		{
+			"dates must be formatted as text",
+			"SELECT INVOICE_ID, DATETIME(SALE_DATE) FROM sales WHERE DATETIME(SALE_DATE) >= ?",
+			"SELECT INVOICE_ID, DATETIME(SALE_DATE) FROM sales WHERE DATETIME(SALE_DATE) >= ?",
+			"SELECT INVOICE_ID, TO_CHAR(SALE_DATE, 'YYYY-MM-DD HH24:MI:SS') FROM sales WHERE TO_CHAR(SALE_DATE, 'YYYY-MM-DD HH24:MI:SS') >= $1",
+		},
+		{
+			"question marks inside string literals must be kept",
+			"SELECT SKU FROM stock WHERE NAME = 'what?' AND SKU = ?",
+			"SELECT SKU FROM stock WHERE NAME = 'what?' AND SKU = ?",
+			"SELECT SKU FROM stock WHERE NAME = 'what?' AND SKU = $1",
+		},
+	}
+	for _, val := range testCases {
+		t.Run(val.name, func(t *testing.T) {
+			translated := datamapper.SQLite.Translate(val.query)
+			if translated != val.sqlite {
+				t.Errorf("expected %v but got %v", val.sqlite, translated)
+			}
+			translated = datamapper.Postgres.Translate(val.query)
+			if translated != val.postgres {
+				t.Errorf("expected %v but got %v", val.postgres, translated)
+			}
+		})
+	}
+}
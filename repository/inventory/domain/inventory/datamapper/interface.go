@@ -2,6 +2,9 @@
 package datamapper
 
 import (
+	"database/sql"
+	"time"
+
 	"ijah-inventory/repository/inventory/domain/inventory/model"
 
 	"github.com/go-errors/errors"
@@ -16,3 +19,88 @@ type DataMapper interface {
 	Delete(model.Model) *errors.Error
 	Save(model.Model) *errors.Error
 }
+
+//TxUpdater is an interface for data mapper capable of updating a record using a passed transaction handler
+type TxUpdater interface {
+	UpdateWithTx(model.Model, *sql.Tx) *errors.Error
+}
+
+//TxInserter is an interface for data mapper capable of inserting a record using a passed transaction handler
+type TxInserter interface {
+	InsertWithTx(model.Model, *sql.Tx) *errors.Error
+}
+
+//Tx is an interface for a transaction data mappers can be bound to (either a *sql.Tx or a *MemoryTx)
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+//TxBinder is an interface for data mapper capable of joining a transaction
+//every operation of the returned data mapper (including finds) runs inside the passed transaction handler
+type TxBinder interface {
+	WithTx(*sql.Tx) DataMapper
+}
+
+//MemoryTxBinder is an interface for in-memory data mapper capable of joining a memory store transaction
+//every operation of the returned data mapper (including finds) runs inside the passed transaction
+type MemoryTxBinder interface {
+	WithMemoryTx(*MemoryTx) DataMapper
+}
+
+//CostLayerFinder is an interface for data mapper capable of finding the open cost layers of a sku
+type CostLayerFinder interface {
+	FindOpenBySku(sku string) ([]model.Model, *errors.Error)
+}
+
+//SaleFinder is an interface for data mapper capable of finding the done sales of a period
+type SaleFinder interface {
+	FindByDoneStatusAndDateRange(startDate, endDate time.Time) ([]model.Model, *errors.Error)
+}
+
+//PurchaseReceiptFinder is an interface for data mapper capable of finding the receipts (deliveries) of a purchase
+type PurchaseReceiptFinder interface {
+	FindByPurchaseID(purchaseID string) ([]model.Model, *errors.Error)
+}
+
+//StockMovementFinder is an interface for data mapper capable of finding the stock movements of a sku during a period
+type StockMovementFinder interface {
+	FindBySkuAndDateRange(sku string, startDate, endDate time.Time) ([]model.Model, *errors.Error)
+}
+
+//SaleStatusTransitionFinder is an interface for data mapper capable of finding the status transitions of a sale
+type SaleStatusTransitionFinder interface {
+	FindByInvoiceID(invoiceID string) ([]model.Model, *errors.Error)
+}
+
+//SalesReturnFinder is an interface for data mapper capable of finding the returns of a sale or of a period
+type SalesReturnFinder interface {
+	FindByInvoiceID(invoiceID string) ([]model.Model, *errors.Error)
+	FindByDateRange(startDate, endDate time.Time) ([]model.Model, *errors.Error)
+}
+
+//VariantFinder is an interface for data mapper capable of finding the stock items which are variants of a product
+type VariantFinder interface {
+	FindByProductID(productID string) ([]model.Model, *errors.Error)
+}
+
+//AttributeValueFinder is an interface for data mapper capable of finding the attribute values of the stock items having a value of an attribute
+type AttributeValueFinder interface {
+	FindByAttribute(name string) ([]model.Model, *errors.Error)
+}
+
+//CriteriaFinder is an interface for data mapper capable of finding a page of the records matching a criteria (see Criteria)
+type CriteriaFinder interface {
+	FindByCriteria(criteria Criteria) (*Page, *errors.Error)
+}
+
+//StockSearcher is an interface for data mapper capable of full text searching stock items by sku and name
+type StockSearcher interface {
+	Search(query string, limit int) ([]SearchHit, *errors.Error)
+}
+
+//StockAlertFinder is an interface for data mapper capable of finding the open (unresolved) low stock alerts
+type StockAlertFinder interface {
+	FindOpen() ([]model.Model, *errors.Error)
+	FindOpenBySku(sku string) (model.Model, *errors.Error)
+}
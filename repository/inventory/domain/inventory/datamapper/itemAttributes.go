@@ -0,0 +1,217 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//ItemAttributes is a struct of datamapper for item attributes domain model (attribute values of stock items)
+//every value is a row of stock_attributes, an item without values has no record
+type ItemAttributes struct {
+	txScope
+}
+
+//NewItemAttributes creates a new ItemAttributes datamapper and returns a pointer to it
+func NewItemAttributes(dbSession *sql.DB, dialect Dialect) *ItemAttributes {
+	return &ItemAttributes{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (ia *ItemAttributes) FindByID(id string) (model.Model, *errors.Error) {
+	stmt, err := ia.conn().Prepare("SELECT SKU, NAME, VALUE FROM stock_attributes WHERE SKU = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	records, errs := ia.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(records) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return records[0], nil
+}
+
+//FindAll is a function for finding all records
+func (ia *ItemAttributes) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := ia.conn().Query("SELECT SKU, NAME, VALUE FROM stock_attributes ORDER BY SKU ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return ia.loadRows(rows)
+}
+
+//FindByAttribute is a function for finding the records of the items having a value of the given attribute (along with their other values)
+func (ia *ItemAttributes) FindByAttribute(name string) ([]model.Model, *errors.Error) {
+	stmt, err := ia.conn().Prepare("SELECT SKU, NAME, VALUE FROM stock_attributes WHERE SKU IN (SELECT SKU FROM stock_attributes WHERE NAME = ?) ORDER BY SKU ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(name)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return ia.loadRows(rows)
+}
+
+//loadRows is a function for composing item attributes models from the given rows (SKU, NAME, VALUE ordered by SKU), the rows are closed afterwards
+func (ia *ItemAttributes) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var sku, name, value sql.NullString
+
+	var returnedRow []model.Model
+	var attributesModel *model.ItemAttributes
+	for rows.Next() {
+		err := rows.Scan(&sku, &name, &value)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		if attributesModel == nil || attributesModel.Sku != sku.String {
+			//first value of the item
+			attributesModel = &model.ItemAttributes{
+				Sku:    sku.String,
+				Values: make(map[string]string),
+			}
+			attributesModel.SetLoadedFromStorage(true)
+			returnedRow = append(returnedRow, attributesModel)
+		}
+		attributesModel.Values[name.String] = value.String
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return returnedRow, nil
+}
+
+//insertValues is a function for inserting the values of an item (using passed transaction handler)
+func (ia *ItemAttributes) insertValues(attributesModelObj *model.ItemAttributes, tx *sql.Tx) *errors.Error {
+	stmt, err := ia.on(tx).Prepare("INSERT INTO stock_attributes(SKU, NAME, VALUE) values(?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	for name, value := range attributesModelObj.Values {
+		_, err = stmt.Exec(attributesModelObj.Sku, name, value)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+	}
+	return nil
+}
+
+//deleteValues is a function for deleting every value of an item (using passed transaction handler)
+func (ia *ItemAttributes) deleteValues(sku string, tx *sql.Tx) *errors.Error {
+	stmt, err := ia.on(tx).Prepare("DELETE FROM stock_attributes WHERE SKU=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(sku)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Insert is a function for inserting a record
+func (ia *ItemAttributes) Insert(attributesModel model.Model) *errors.Error {
+	return ia.inTx(func(tx *sql.Tx) *errors.Error {
+		return ia.InsertWithTx(attributesModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (ia *ItemAttributes) InsertWithTx(attributesModel model.Model, tx *sql.Tx) *errors.Error {
+	attributesModelObj, ok := attributesModel.(*model.ItemAttributes)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.ItemAttributes"), 0)
+	}
+	foundModel, _ := ia.WithTx(tx).FindByID(attributesModel.GetID())
+	if foundModel != nil {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", attributesModel.GetID()), 0)
+	}
+	return ia.insertValues(attributesModelObj, tx)
+}
+
+//Update is a function for updating record
+func (ia *ItemAttributes) Update(attributesModel model.Model) *errors.Error {
+	return ia.inTx(func(tx *sql.Tx) *errors.Error {
+		return ia.UpdateWithTx(attributesModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+//the stored values are replaced by the values of the model, so the record is removed when the model has no value
+func (ia *ItemAttributes) UpdateWithTx(attributesModel model.Model, tx *sql.Tx) *errors.Error {
+	attributesModelObj, ok := attributesModel.(*model.ItemAttributes)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.ItemAttributes"), 0)
+	}
+	_, errs := ia.WithTx(tx).FindByID(attributesModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", attributesModel.GetID()), 0)
+	}
+	errs = ia.deleteValues(attributesModelObj.Sku, tx)
+	if errs != nil {
+		return errs
+	}
+	return ia.insertValues(attributesModelObj, tx)
+}
+
+//Delete is a function for deleting record
+func (ia *ItemAttributes) Delete(attributesModel model.Model) *errors.Error {
+	_, errs := ia.FindByID(attributesModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", attributesModel.GetID()), 0)
+	}
+	return ia.inTx(func(tx *sql.Tx) *errors.Error {
+		return ia.deleteValues(attributesModel.GetID(), tx)
+	})
+}
+
+//Save is a function for persisting a model object to db
+func (ia *ItemAttributes) Save(attributesModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == attributesModel.GetLoadedFromStorage() {
+		//update operation
+		err = ia.Update(attributesModel)
+	} else {
+		//insert operation
+		err = ia.Insert(attributesModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (ia *ItemAttributes) WithTx(tx *sql.Tx) DataMapper {
+	return &ItemAttributes{
+		txScope: ia.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (ia *ItemAttributes) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (ia *ItemAttributes) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,99 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryAttributeTable is the memory store table of attribute definitions (ordered by name)
+var memoryAttributeTable = memoryTable{
+	name: "attributes",
+	clone: func(record model.Model) model.Model {
+		attributeObj := *record.(*model.Attribute)
+		attributeObj.SetLoadedFromStorage(true)
+		return &attributeObj
+	},
+	less: func(x, y model.Model) bool {
+		return x.GetID() < y.GetID()
+	},
+}
+
+//MemoryAttribute is a struct of in-memory datamapper for attribute domain model
+type MemoryAttribute struct {
+	memoryScope
+}
+
+//NewMemoryAttribute creates a new MemoryAttribute datamapper on the given store and returns a pointer to it
+func NewMemoryAttribute(store *MemoryStore) *MemoryAttribute {
+	return &MemoryAttribute{
+		memoryScope: memoryScope{store: store, table: memoryAttributeTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (a *MemoryAttribute) FindByID(id string) (model.Model, *errors.Error) {
+	return a.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (a *MemoryAttribute) FindAll() ([]model.Model, *errors.Error) {
+	return a.findWhere(nil), nil
+}
+
+//Insert is a function for inserting a record
+func (a *MemoryAttribute) Insert(attributeModel model.Model) *errors.Error {
+	if _, ok := attributeModel.(*model.Attribute); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Attribute"), 0)
+	}
+	return a.inTx(func(tx *MemoryTx) *errors.Error {
+		return a.insert(tx, attributeModel)
+	})
+}
+
+//Update is a function for updating record
+func (a *MemoryAttribute) Update(attributeModel model.Model) *errors.Error {
+	if _, ok := attributeModel.(*model.Attribute); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Attribute"), 0)
+	}
+	return a.inTx(func(tx *MemoryTx) *errors.Error {
+		if a.stored(tx, attributeModel.GetID()) == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", attributeModel.GetID()), 0)
+		}
+		tx.put(a.table.name, attributeModel.GetID(), a.table.clone(attributeModel))
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (a *MemoryAttribute) Delete(attributeModel model.Model) *errors.Error {
+	return a.delete(attributeModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (a *MemoryAttribute) Save(attributeModel model.Model) *errors.Error {
+	if true == attributeModel.GetLoadedFromStorage() {
+		return a.Update(attributeModel)
+	}
+	return a.Insert(attributeModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (a *MemoryAttribute) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryAttribute{
+		memoryScope: a.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (a *MemoryAttribute) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (a *MemoryAttribute) Shutdown() {
+	//Note: perform any cleanup here
+}
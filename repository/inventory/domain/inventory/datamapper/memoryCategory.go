@@ -0,0 +1,99 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryCategoryTable is the memory store table of categories (ordered by category id)
+var memoryCategoryTable = memoryTable{
+	name: "categories",
+	clone: func(record model.Model) model.Model {
+		categoryObj := *record.(*model.Category)
+		categoryObj.SetLoadedFromStorage(true)
+		return &categoryObj
+	},
+	less: func(x, y model.Model) bool {
+		return x.GetID() < y.GetID()
+	},
+}
+
+//MemoryCategory is a struct of in-memory datamapper for category domain model
+type MemoryCategory struct {
+	memoryScope
+}
+
+//NewMemoryCategory creates a new MemoryCategory datamapper on the given store and returns a pointer to it
+func NewMemoryCategory(store *MemoryStore) *MemoryCategory {
+	return &MemoryCategory{
+		memoryScope: memoryScope{store: store, table: memoryCategoryTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (c *MemoryCategory) FindByID(id string) (model.Model, *errors.Error) {
+	return c.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (c *MemoryCategory) FindAll() ([]model.Model, *errors.Error) {
+	return c.findWhere(nil), nil
+}
+
+//Insert is a function for inserting a record
+func (c *MemoryCategory) Insert(categoryModel model.Model) *errors.Error {
+	if _, ok := categoryModel.(*model.Category); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Category"), 0)
+	}
+	return c.inTx(func(tx *MemoryTx) *errors.Error {
+		return c.insert(tx, categoryModel)
+	})
+}
+
+//Update is a function for updating record
+func (c *MemoryCategory) Update(categoryModel model.Model) *errors.Error {
+	if _, ok := categoryModel.(*model.Category); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Category"), 0)
+	}
+	return c.inTx(func(tx *MemoryTx) *errors.Error {
+		if c.stored(tx, categoryModel.GetID()) == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", categoryModel.GetID()), 0)
+		}
+		tx.put(c.table.name, categoryModel.GetID(), c.table.clone(categoryModel))
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (c *MemoryCategory) Delete(categoryModel model.Model) *errors.Error {
+	return c.delete(categoryModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (c *MemoryCategory) Save(categoryModel model.Model) *errors.Error {
+	if true == categoryModel.GetLoadedFromStorage() {
+		return c.Update(categoryModel)
+	}
+	return c.Insert(categoryModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (c *MemoryCategory) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryCategory{
+		memoryScope: c.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (c *MemoryCategory) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (c *MemoryCategory) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,137 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryCostLayerSequence is the sequence generating ids of cost layers
+const memoryCostLayerSequence = "cost_layers"
+
+//memoryCostLayerTable is the memory store table of cost layers (ordered by layer date, then by id)
+var memoryCostLayerTable = memoryTable{
+	name: "cost_layers",
+	clone: func(record model.Model) model.Model {
+		layerObj := *record.(*model.CostLayer)
+		layerObj.Date = memoryTime(layerObj.Date)
+		layerObj.SetLoadedFromStorage(true)
+		return &layerObj
+	},
+	less: func(x, y model.Model) bool {
+		xDate, yDate := x.(*model.CostLayer).Date, y.(*model.CostLayer).Date
+		if false == xDate.Equal(yDate) {
+			return xDate.Before(yDate)
+		}
+		return memoryIDLess(x, y)
+	},
+	sequence: memoryCostLayerSequence,
+	ids: func(record model.Model) []int64 {
+		id, _ := strconv.ParseInt(record.GetID(), 10, 64)
+		return []int64{id}
+	},
+}
+
+//MemoryCostLayer is a struct of in-memory datamapper for cost layer domain model
+type MemoryCostLayer struct {
+	memoryScope
+}
+
+//NewMemoryCostLayer creates a new MemoryCostLayer datamapper on the given store and returns a pointer to it
+func NewMemoryCostLayer(store *MemoryStore) *MemoryCostLayer {
+	return &MemoryCostLayer{
+		memoryScope: memoryScope{store: store, table: memoryCostLayerTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (cl *MemoryCostLayer) FindByID(id string) (model.Model, *errors.Error) {
+	return cl.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (cl *MemoryCostLayer) FindAll() ([]model.Model, *errors.Error) {
+	return cl.findWhere(nil), nil
+}
+
+//FindOpenBySku is a function for finding layers of a sku which still have remaining quantity (oldest layer first)
+func (cl *MemoryCostLayer) FindOpenBySku(sku string) ([]model.Model, *errors.Error) {
+	return cl.findWhere(func(record model.Model) bool {
+		layerObj := record.(*model.CostLayer)
+		return layerObj.Sku == sku && layerObj.RemainingQuantity > 0
+	}), nil
+}
+
+//Insert is a function for inserting a record
+func (cl *MemoryCostLayer) Insert(layerModel model.Model) *errors.Error {
+	layerModelObj, ok := layerModel.(*model.CostLayer)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.CostLayer"), 0)
+	}
+	if true == layerModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot insert, cost layer with id: %v already exists", layerModel.GetID()), 0)
+	}
+	return cl.inTx(func(tx *MemoryTx) *errors.Error {
+		layerModelObj.SetID(tx.nextID(memoryCostLayerSequence))
+		tx.put(cl.table.name, layerModelObj.GetID(), cl.table.clone(layerModelObj))
+		return nil
+	})
+}
+
+//Update is a function for updating record
+//Note: only remaining quantity and unit cost of a layer can be changed
+func (cl *MemoryCostLayer) Update(layerModel model.Model) *errors.Error {
+	layerModelObj, ok := layerModel.(*model.CostLayer)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.CostLayer"), 0)
+	}
+	if false == layerModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot update, cost layer with id: %v doesn't exist", layerModel.GetID()), 0)
+	}
+	return cl.inTx(func(tx *MemoryTx) *errors.Error {
+		storedModel := cl.stored(tx, layerModel.GetID())
+		if storedModel == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, cost layer with id: %v doesn't exist", layerModel.GetID()), 0)
+		}
+		layerObj := cl.table.clone(storedModel).(*model.CostLayer)
+		layerObj.RemainingQuantity = layerModelObj.RemainingQuantity
+		layerObj.UnitCost = layerModelObj.UnitCost
+		tx.put(cl.table.name, layerObj.GetID(), layerObj)
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+//Note: layers are kept for auditing cost of goods sold, consume them instead of deleting
+func (cl *MemoryCostLayer) Delete(layerModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, cost layer %v must be consumed instead", layerModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to the store
+func (cl *MemoryCostLayer) Save(layerModel model.Model) *errors.Error {
+	if true == layerModel.GetLoadedFromStorage() {
+		return cl.Update(layerModel)
+	}
+	return cl.Insert(layerModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (cl *MemoryCostLayer) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryCostLayer{
+		memoryScope: cl.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (cl *MemoryCostLayer) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (cl *MemoryCostLayer) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,123 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryItemAttributesTable is the memory store table of attribute values of stock items (ordered by sku)
+var memoryItemAttributesTable = memoryTable{
+	name: "stock_attributes",
+	clone: func(record model.Model) model.Model {
+		attributesObj := *record.(*model.ItemAttributes)
+		attributesObj.Values = make(map[string]string, len(attributesObj.Values))
+		for key, val := range record.(*model.ItemAttributes).Values {
+			attributesObj.Values[key] = val
+		}
+		attributesObj.SetLoadedFromStorage(true)
+		return &attributesObj
+	},
+	less: func(x, y model.Model) bool {
+		return x.GetID() < y.GetID()
+	},
+}
+
+//MemoryItemAttributes is a struct of in-memory datamapper for item attributes domain model
+//Note: same as ItemAttributes, an item without values has no record
+type MemoryItemAttributes struct {
+	memoryScope
+}
+
+//NewMemoryItemAttributes creates a new MemoryItemAttributes datamapper on the given store and returns a pointer to it
+func NewMemoryItemAttributes(store *MemoryStore) *MemoryItemAttributes {
+	return &MemoryItemAttributes{
+		memoryScope: memoryScope{store: store, table: memoryItemAttributesTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (ia *MemoryItemAttributes) FindByID(id string) (model.Model, *errors.Error) {
+	return ia.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (ia *MemoryItemAttributes) FindAll() ([]model.Model, *errors.Error) {
+	return ia.findWhere(nil), nil
+}
+
+//FindByAttribute is a function for finding the records of the items having a value of the given attribute (along with their other values)
+func (ia *MemoryItemAttributes) FindByAttribute(name string) ([]model.Model, *errors.Error) {
+	return ia.findWhere(func(record model.Model) bool {
+		_, exists := record.(*model.ItemAttributes).Values[name]
+		return exists
+	}), nil
+}
+
+//Insert is a function for inserting a record
+func (ia *MemoryItemAttributes) Insert(attributesModel model.Model) *errors.Error {
+	attributesModelObj, ok := attributesModel.(*model.ItemAttributes)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.ItemAttributes"), 0)
+	}
+	return ia.inTx(func(tx *MemoryTx) *errors.Error {
+		if len(attributesModelObj.Values) == 0 {
+			//nothing is stored for an item without values
+			return nil
+		}
+		return ia.insert(tx, attributesModel)
+	})
+}
+
+//Update is a function for updating record
+//the stored values are replaced by the values of the model, so the record is removed when the model has no value
+func (ia *MemoryItemAttributes) Update(attributesModel model.Model) *errors.Error {
+	attributesModelObj, ok := attributesModel.(*model.ItemAttributes)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.ItemAttributes"), 0)
+	}
+	return ia.inTx(func(tx *MemoryTx) *errors.Error {
+		if ia.stored(tx, attributesModel.GetID()) == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", attributesModel.GetID()), 0)
+		}
+		if len(attributesModelObj.Values) == 0 {
+			tx.remove(ia.table.name, attributesModel.GetID())
+			return nil
+		}
+		tx.put(ia.table.name, attributesModel.GetID(), ia.table.clone(attributesModel))
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (ia *MemoryItemAttributes) Delete(attributesModel model.Model) *errors.Error {
+	return ia.delete(attributesModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (ia *MemoryItemAttributes) Save(attributesModel model.Model) *errors.Error {
+	if true == attributesModel.GetLoadedFromStorage() {
+		return ia.Update(attributesModel)
+	}
+	return ia.Insert(attributesModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (ia *MemoryItemAttributes) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryItemAttributes{
+		memoryScope: ia.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (ia *MemoryItemAttributes) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (ia *MemoryItemAttributes) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,99 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryProductTable is the memory store table of products (ordered by product id)
+var memoryProductTable = memoryTable{
+	name: "products",
+	clone: func(record model.Model) model.Model {
+		productObj := *record.(*model.Product)
+		productObj.SetLoadedFromStorage(true)
+		return &productObj
+	},
+	less: func(x, y model.Model) bool {
+		return x.GetID() < y.GetID()
+	},
+}
+
+//MemoryProduct is a struct of in-memory datamapper for product domain model
+type MemoryProduct struct {
+	memoryScope
+}
+
+//NewMemoryProduct creates a new MemoryProduct datamapper on the given store and returns a pointer to it
+func NewMemoryProduct(store *MemoryStore) *MemoryProduct {
+	return &MemoryProduct{
+		memoryScope: memoryScope{store: store, table: memoryProductTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (p *MemoryProduct) FindByID(id string) (model.Model, *errors.Error) {
+	return p.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (p *MemoryProduct) FindAll() ([]model.Model, *errors.Error) {
+	return p.findWhere(nil), nil
+}
+
+//Insert is a function for inserting a record
+func (p *MemoryProduct) Insert(productModel model.Model) *errors.Error {
+	if _, ok := productModel.(*model.Product); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Product"), 0)
+	}
+	return p.inTx(func(tx *MemoryTx) *errors.Error {
+		return p.insert(tx, productModel)
+	})
+}
+
+//Update is a function for updating record
+func (p *MemoryProduct) Update(productModel model.Model) *errors.Error {
+	if _, ok := productModel.(*model.Product); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Product"), 0)
+	}
+	return p.inTx(func(tx *MemoryTx) *errors.Error {
+		if p.stored(tx, productModel.GetID()) == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", productModel.GetID()), 0)
+		}
+		tx.put(p.table.name, productModel.GetID(), p.table.clone(productModel))
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (p *MemoryProduct) Delete(productModel model.Model) *errors.Error {
+	return p.delete(productModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (p *MemoryProduct) Save(productModel model.Model) *errors.Error {
+	if true == productModel.GetLoadedFromStorage() {
+		return p.Update(productModel)
+	}
+	return p.Insert(productModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (p *MemoryProduct) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryProduct{
+		memoryScope: p.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (p *MemoryProduct) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (p *MemoryProduct) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,139 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryPurchaseItemSequence is the sequence generating ids of purchase items
+const memoryPurchaseItemSequence = "purchase_items"
+
+//memoryPurchaseTable is the memory store table of purchases (ordered by purchase id)
+var memoryPurchaseTable = memoryTable{
+	name: "purchase",
+	clone: func(record model.Model) model.Model {
+		purchaseObj := *record.(*model.Purchase)
+		purchaseObj.Date = memoryTime(purchaseObj.Date)
+		purchaseObj.Items = make(map[string]*model.PurchaseItem, len(purchaseObj.Items))
+		for key, val := range record.(*model.Purchase).Items {
+			itemObj := *val
+			itemObj.SetLoadedFromStorage(true)
+			purchaseObj.Items[key] = &itemObj
+		}
+		purchaseObj.SetLoadedFromStorage(true)
+		return &purchaseObj
+	},
+	less: func(x, y model.Model) bool {
+		return x.(*model.Purchase).PurchaseID < y.(*model.Purchase).PurchaseID
+	},
+	sequence: memoryPurchaseItemSequence,
+	ids: func(record model.Model) []int64 {
+		var ids []int64
+		for _, val := range record.(*model.Purchase).Items {
+			ids = append(ids, val.GetID())
+		}
+		return ids
+	},
+}
+
+//MemoryPurchase is a struct of in-memory datamapper for purchase domain model
+type MemoryPurchase struct {
+	memoryScope
+}
+
+//NewMemoryPurchase creates a new MemoryPurchase datamapper on the given store and returns a pointer to it
+func NewMemoryPurchase(store *MemoryStore) *MemoryPurchase {
+	return &MemoryPurchase{
+		memoryScope: memoryScope{store: store, table: memoryPurchaseTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (p *MemoryPurchase) FindByID(id string) (model.Model, *errors.Error) {
+	return p.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (p *MemoryPurchase) FindAll() ([]model.Model, *errors.Error) {
+	return p.findWhere(nil), nil
+}
+
+//Insert is a function for inserting a record
+func (p *MemoryPurchase) Insert(purchaseModel model.Model) *errors.Error {
+	if _, ok := purchaseModel.(*model.Purchase); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Purchase"), 0)
+	}
+	return p.inTx(func(tx *MemoryTx) *errors.Error {
+		if p.stored(tx, purchaseModel.GetID()) != nil {
+			return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", purchaseModel.GetID()), 0)
+		}
+		purchaseObj := p.table.clone(purchaseModel).(*model.Purchase)
+		for _, val := range purchaseObj.Items {
+			val.SetID(tx.nextID(memoryPurchaseItemSequence))
+		}
+		tx.put(p.table.name, purchaseObj.PurchaseID, purchaseObj)
+		return nil
+	})
+}
+
+//Update is a function for updating record
+//new items (not loaded from storage) are added, items missing from the model are kept
+func (p *MemoryPurchase) Update(purchaseModel model.Model) *errors.Error {
+	purchaseModelObj, ok := purchaseModel.(*model.Purchase)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Purchase"), 0)
+	}
+	return p.inTx(func(tx *MemoryTx) *errors.Error {
+		storedModel := p.stored(tx, purchaseModel.GetID())
+		if storedModel == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", purchaseModel.GetID()), 0)
+		}
+		purchaseObj := p.table.clone(purchaseModel).(*model.Purchase)
+		for key, val := range storedModel.(*model.Purchase).Items {
+			if _, exists := purchaseObj.Items[key]; false == exists {
+				purchaseObj.Items[key] = val
+			}
+		}
+		for key, val := range purchaseModelObj.Items {
+			if false == val.GetLoadedFromStorage() {
+				purchaseObj.Items[key].SetID(tx.nextID(memoryPurchaseItemSequence))
+			}
+		}
+		tx.put(p.table.name, purchaseObj.PurchaseID, purchaseObj)
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (p *MemoryPurchase) Delete(purchaseModel model.Model) *errors.Error {
+	return p.delete(purchaseModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (p *MemoryPurchase) Save(purchaseModel model.Model) *errors.Error {
+	if true == purchaseModel.GetLoadedFromStorage() {
+		return p.Update(purchaseModel)
+	}
+	return p.Insert(purchaseModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (p *MemoryPurchase) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryPurchase{
+		memoryScope: p.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (p *MemoryPurchase) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (p *MemoryPurchase) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,128 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryPurchaseReceiptItemSequence is the sequence generating ids of purchase receipt items
+const memoryPurchaseReceiptItemSequence = "purchase_receipt_items"
+
+//memoryPurchaseReceiptTable is the memory store table of purchase receipts (ordered by receipt date)
+var memoryPurchaseReceiptTable = memoryTable{
+	name: "purchase_receipts",
+	clone: func(record model.Model) model.Model {
+		receiptObj := *record.(*model.PurchaseReceipt)
+		receiptObj.Date = memoryTime(receiptObj.Date)
+		receiptObj.Items = make(map[string]*model.PurchaseReceiptItem, len(receiptObj.Items))
+		for key, val := range record.(*model.PurchaseReceipt).Items {
+			itemObj := *val
+			itemObj.SetLoadedFromStorage(true)
+			receiptObj.Items[key] = &itemObj
+		}
+		receiptObj.SetLoadedFromStorage(true)
+		return &receiptObj
+	},
+	less: func(x, y model.Model) bool {
+		xDate, yDate := x.(*model.PurchaseReceipt).Date, y.(*model.PurchaseReceipt).Date
+		if false == xDate.Equal(yDate) {
+			return xDate.Before(yDate)
+		}
+		return x.GetID() < y.GetID()
+	},
+	sequence: memoryPurchaseReceiptItemSequence,
+	ids: func(record model.Model) []int64 {
+		var ids []int64
+		for _, val := range record.(*model.PurchaseReceipt).Items {
+			ids = append(ids, val.GetID())
+		}
+		return ids
+	},
+}
+
+//MemoryPurchaseReceipt is a struct of in-memory datamapper for purchase receipt domain model
+//Note: receipts are immutable once inserted (same as PurchaseReceipt)
+type MemoryPurchaseReceipt struct {
+	memoryScope
+}
+
+//NewMemoryPurchaseReceipt creates a new MemoryPurchaseReceipt datamapper on the given store and returns a pointer to it
+func NewMemoryPurchaseReceipt(store *MemoryStore) *MemoryPurchaseReceipt {
+	return &MemoryPurchaseReceipt{
+		memoryScope: memoryScope{store: store, table: memoryPurchaseReceiptTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (pr *MemoryPurchaseReceipt) FindByID(id string) (model.Model, *errors.Error) {
+	return pr.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (pr *MemoryPurchaseReceipt) FindAll() ([]model.Model, *errors.Error) {
+	return pr.findWhere(nil), nil
+}
+
+//FindByPurchaseID is a function for finding all receipts of a purchase (ordered by receipt date)
+func (pr *MemoryPurchaseReceipt) FindByPurchaseID(purchaseID string) ([]model.Model, *errors.Error) {
+	return pr.findWhere(func(record model.Model) bool {
+		return record.(*model.PurchaseReceipt).PurchaseID == purchaseID
+	}), nil
+}
+
+//Insert is a function for inserting a record
+func (pr *MemoryPurchaseReceipt) Insert(receiptModel model.Model) *errors.Error {
+	if _, ok := receiptModel.(*model.PurchaseReceipt); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.PurchaseReceipt"), 0)
+	}
+	return pr.inTx(func(tx *MemoryTx) *errors.Error {
+		if pr.stored(tx, receiptModel.GetID()) != nil {
+			return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", receiptModel.GetID()), 0)
+		}
+		receiptObj := pr.table.clone(receiptModel).(*model.PurchaseReceipt)
+		for _, val := range receiptObj.Items {
+			val.SetID(tx.nextID(memoryPurchaseReceiptItemSequence))
+		}
+		tx.put(pr.table.name, receiptObj.ReceiptID, receiptObj)
+		return nil
+	})
+}
+
+//Update is a function for updating record
+func (pr *MemoryPurchaseReceipt) Update(receiptModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot update, purchase receipt %v is immutable", receiptModel.GetID()), 0)
+}
+
+//Delete is a function for deleting record
+func (pr *MemoryPurchaseReceipt) Delete(receiptModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, purchase receipt %v is immutable", receiptModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to the store
+func (pr *MemoryPurchaseReceipt) Save(receiptModel model.Model) *errors.Error {
+	if true == receiptModel.GetLoadedFromStorage() {
+		return pr.Update(receiptModel)
+	}
+	return pr.Insert(receiptModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (pr *MemoryPurchaseReceipt) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryPurchaseReceipt{
+		memoryScope: pr.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (pr *MemoryPurchaseReceipt) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (pr *MemoryPurchaseReceipt) Shutdown() {
+	//Note: perform any cleanup here
+}
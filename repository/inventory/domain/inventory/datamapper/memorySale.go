@@ -0,0 +1,157 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memorySaleItemSequence is the sequence generating ids of sale items
+const memorySaleItemSequence = "sales_items"
+
+//memorySaleTable is the memory store table of sales (ordered by invoice id)
+var memorySaleTable = memoryTable{
+	name: "sales",
+	clone: func(record model.Model) model.Model {
+		salesObj := *record.(*model.Sales)
+		salesObj.Date = memoryTime(salesObj.Date)
+		salesObj.Items = make(map[string]*model.SaleItem, len(salesObj.Items))
+		for key, val := range record.(*model.Sales).Items {
+			itemObj := *val
+			itemObj.SetLoadedFromStorage(true)
+			salesObj.Items[key] = &itemObj
+		}
+		salesObj.SetLoadedFromStorage(true)
+		return &salesObj
+	},
+	less: func(x, y model.Model) bool {
+		return x.(*model.Sales).InvoiceID < y.(*model.Sales).InvoiceID
+	},
+	sequence: memorySaleItemSequence,
+	ids: func(record model.Model) []int64 {
+		var ids []int64
+		for _, val := range record.(*model.Sales).Items {
+			ids = append(ids, val.GetID())
+		}
+		return ids
+	},
+}
+
+//MemorySale is a struct of in-memory datamapper for sales domain model
+type MemorySale struct {
+	memoryScope
+}
+
+//NewMemorySale creates a new MemorySale datamapper on the given store and returns a pointer to it
+func NewMemorySale(store *MemoryStore) *MemorySale {
+	return &MemorySale{
+		memoryScope: memoryScope{store: store, table: memorySaleTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (s *MemorySale) FindByID(id string) (model.Model, *errors.Error) {
+	return s.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (s *MemorySale) FindAll() ([]model.Model, *errors.Error) {
+	return s.findWhere(nil), nil
+}
+
+//FindByDoneStatusAndDateRange is a function for finding success/done sale record based on date range
+//Note: dates are compared the same way as the sql datamapper does (sale time against start and end day)
+func (s *MemorySale) FindByDoneStatusAndDateRange(startDate, endDate time.Time) ([]model.Model, *errors.Error) {
+	startDateString := startDate.Format(dateFormat)
+	endDateString := endDate.Format(dateFormat)
+	return s.findWhere(func(record model.Model) bool {
+		salesObj := record.(*model.Sales)
+		dateString := salesObj.Date.Format(timeFormat)
+		return salesObj.Status == "S" && dateString >= startDateString && dateString <= endDateString
+	}), nil
+}
+
+//FindByCriteria is a function for finding a page of the records matching a criteria (see saleFields for the fields)
+func (s *MemorySale) FindByCriteria(criteria Criteria) (*Page, *errors.Error) {
+	return s.findByCriteria(criteria, saleFields, saleKeyField)
+}
+
+//Insert is a function for inserting a record
+func (s *MemorySale) Insert(salesModel model.Model) *errors.Error {
+	if _, ok := salesModel.(*model.Sales); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Sales"), 0)
+	}
+	return s.inTx(func(tx *MemoryTx) *errors.Error {
+		if s.stored(tx, salesModel.GetID()) != nil {
+			return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", salesModel.GetID()), 0)
+		}
+		salesObj := s.table.clone(salesModel).(*model.Sales)
+		for _, val := range salesObj.Items {
+			val.SetID(tx.nextID(memorySaleItemSequence))
+		}
+		tx.put(s.table.name, salesObj.InvoiceID, salesObj)
+		return nil
+	})
+}
+
+//Update is a function for updating record
+//new items (not loaded from storage) are added, items missing from the model are kept
+func (s *MemorySale) Update(salesModel model.Model) *errors.Error {
+	salesModelObj, ok := salesModel.(*model.Sales)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Sales"), 0)
+	}
+	return s.inTx(func(tx *MemoryTx) *errors.Error {
+		storedModel := s.stored(tx, salesModel.GetID())
+		if storedModel == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", salesModel.GetID()), 0)
+		}
+		salesObj := s.table.clone(salesModel).(*model.Sales)
+		for key, val := range storedModel.(*model.Sales).Items {
+			if _, exists := salesObj.Items[key]; false == exists {
+				salesObj.Items[key] = val
+			}
+		}
+		for key, val := range salesModelObj.Items {
+			if false == val.GetLoadedFromStorage() {
+				salesObj.Items[key].SetID(tx.nextID(memorySaleItemSequence))
+			}
+		}
+		tx.put(s.table.name, salesObj.InvoiceID, salesObj)
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (s *MemorySale) Delete(salesModel model.Model) *errors.Error {
+	return s.delete(salesModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (s *MemorySale) Save(salesModel model.Model) *errors.Error {
+	if true == salesModel.GetLoadedFromStorage() {
+		return s.Update(salesModel)
+	}
+	return s.Insert(salesModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (s *MemorySale) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemorySale{
+		memoryScope: s.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (s *MemorySale) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (s *MemorySale) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,120 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memorySaleStatusTransitionSequence is the sequence generating ids of sale status transitions
+const memorySaleStatusTransitionSequence = "sale_status_transitions"
+
+//memorySaleStatusTransitionTable is the memory store table of sale status transitions (ordered by transition date, then by id)
+var memorySaleStatusTransitionTable = memoryTable{
+	name: "sale_status_transitions",
+	clone: func(record model.Model) model.Model {
+		transitionObj := *record.(*model.SaleStatusTransition)
+		transitionObj.Date = memoryTime(transitionObj.Date)
+		transitionObj.SetLoadedFromStorage(true)
+		return &transitionObj
+	},
+	less: func(x, y model.Model) bool {
+		xDate, yDate := x.(*model.SaleStatusTransition).Date, y.(*model.SaleStatusTransition).Date
+		if false == xDate.Equal(yDate) {
+			return xDate.Before(yDate)
+		}
+		return memoryIDLess(x, y)
+	},
+	sequence: memorySaleStatusTransitionSequence,
+	ids: func(record model.Model) []int64 {
+		id, _ := strconv.ParseInt(record.GetID(), 10, 64)
+		return []int64{id}
+	},
+}
+
+//MemorySaleStatusTransition is a struct of in-memory datamapper for sale status transition domain model
+//Note: transitions are append only (same as SaleStatusTransition)
+type MemorySaleStatusTransition struct {
+	memoryScope
+}
+
+//NewMemorySaleStatusTransition creates a new MemorySaleStatusTransition datamapper on the given store and returns a pointer to it
+func NewMemorySaleStatusTransition(store *MemoryStore) *MemorySaleStatusTransition {
+	return &MemorySaleStatusTransition{
+		memoryScope: memoryScope{store: store, table: memorySaleStatusTransitionTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sst *MemorySaleStatusTransition) FindByID(id string) (model.Model, *errors.Error) {
+	return sst.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (sst *MemorySaleStatusTransition) FindAll() ([]model.Model, *errors.Error) {
+	return sst.findWhere(nil), nil
+}
+
+//FindByInvoiceID is a function for finding the transitions of a sale (oldest transition first)
+func (sst *MemorySaleStatusTransition) FindByInvoiceID(invoiceID string) ([]model.Model, *errors.Error) {
+	return sst.findWhere(func(record model.Model) bool {
+		return record.(*model.SaleStatusTransition).InvoiceID == invoiceID
+	}), nil
+}
+
+//Insert is a function for inserting a record
+func (sst *MemorySaleStatusTransition) Insert(transitionModel model.Model) *errors.Error {
+	transitionModelObj, ok := transitionModel.(*model.SaleStatusTransition)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.SaleStatusTransition"), 0)
+	}
+	if true == transitionModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot insert, sale status transition with id: %v already exists", transitionModel.GetID()), 0)
+	}
+	return sst.inTx(func(tx *MemoryTx) *errors.Error {
+		transitionModelObj.SetID(tx.nextID(memorySaleStatusTransitionSequence))
+		tx.put(sst.table.name, transitionModelObj.GetID(), sst.table.clone(transitionModelObj))
+		return nil
+	})
+}
+
+//Update is a function for updating record
+//Note: sale status transitions are append only
+func (sst *MemorySaleStatusTransition) Update(transitionModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot update, sale status transition %v is append only", transitionModel.GetID()), 0)
+}
+
+//Delete is a function for deleting record
+//Note: sale status transitions are append only
+func (sst *MemorySaleStatusTransition) Delete(transitionModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, sale status transition %v is append only", transitionModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to the store
+func (sst *MemorySaleStatusTransition) Save(transitionModel model.Model) *errors.Error {
+	if true == transitionModel.GetLoadedFromStorage() {
+		return sst.Update(transitionModel)
+	}
+	return sst.Insert(transitionModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (sst *MemorySaleStatusTransition) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemorySaleStatusTransition{
+		memoryScope: sst.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sst *MemorySaleStatusTransition) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sst *MemorySaleStatusTransition) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,139 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memorySalesReturnItemSequence is the sequence generating ids of sales return items
+const memorySalesReturnItemSequence = "sales_return_items"
+
+//memorySalesReturnTable is the memory store table of sales returns (ordered by return date)
+var memorySalesReturnTable = memoryTable{
+	name: "sales_returns",
+	clone: func(record model.Model) model.Model {
+		returnObj := *record.(*model.SalesReturn)
+		returnObj.Date = memoryTime(returnObj.Date)
+		returnObj.Items = make(map[string]*model.SalesReturnItem, len(returnObj.Items))
+		for key, val := range record.(*model.SalesReturn).Items {
+			itemObj := *val
+			itemObj.SetLoadedFromStorage(true)
+			returnObj.Items[key] = &itemObj
+		}
+		returnObj.SetLoadedFromStorage(true)
+		return &returnObj
+	},
+	less: func(x, y model.Model) bool {
+		xDate, yDate := x.(*model.SalesReturn).Date, y.(*model.SalesReturn).Date
+		if false == xDate.Equal(yDate) {
+			return xDate.Before(yDate)
+		}
+		return x.GetID() < y.GetID()
+	},
+	sequence: memorySalesReturnItemSequence,
+	ids: func(record model.Model) []int64 {
+		var ids []int64
+		for _, val := range record.(*model.SalesReturn).Items {
+			ids = append(ids, val.GetID())
+		}
+		return ids
+	},
+}
+
+//MemorySalesReturn is a struct of in-memory datamapper for sales return domain model
+//Note: returns are immutable once inserted (same as SalesReturn)
+type MemorySalesReturn struct {
+	memoryScope
+}
+
+//NewMemorySalesReturn creates a new MemorySalesReturn datamapper on the given store and returns a pointer to it
+func NewMemorySalesReturn(store *MemoryStore) *MemorySalesReturn {
+	return &MemorySalesReturn{
+		memoryScope: memoryScope{store: store, table: memorySalesReturnTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sr *MemorySalesReturn) FindByID(id string) (model.Model, *errors.Error) {
+	return sr.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (sr *MemorySalesReturn) FindAll() ([]model.Model, *errors.Error) {
+	return sr.findWhere(nil), nil
+}
+
+//FindByInvoiceID is a function for finding all returns of a sale (ordered by return date)
+func (sr *MemorySalesReturn) FindByInvoiceID(invoiceID string) ([]model.Model, *errors.Error) {
+	return sr.findWhere(func(record model.Model) bool {
+		return record.(*model.SalesReturn).InvoiceID == invoiceID
+	}), nil
+}
+
+//FindByDateRange is a function for finding all returns made from startDate (inclusive) until endDate (exclusive)
+func (sr *MemorySalesReturn) FindByDateRange(startDate, endDate time.Time) ([]model.Model, *errors.Error) {
+	startDateString := startDate.Format(timeFormat)
+	endDateString := endDate.Format(timeFormat)
+	return sr.findWhere(func(record model.Model) bool {
+		dateString := record.(*model.SalesReturn).Date.Format(timeFormat)
+		return dateString >= startDateString && dateString < endDateString
+	}), nil
+}
+
+//Insert is a function for inserting a record
+func (sr *MemorySalesReturn) Insert(returnModel model.Model) *errors.Error {
+	if _, ok := returnModel.(*model.SalesReturn); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.SalesReturn"), 0)
+	}
+	return sr.inTx(func(tx *MemoryTx) *errors.Error {
+		if sr.stored(tx, returnModel.GetID()) != nil {
+			return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", returnModel.GetID()), 0)
+		}
+		returnObj := sr.table.clone(returnModel).(*model.SalesReturn)
+		for _, val := range returnObj.Items {
+			val.SetID(tx.nextID(memorySalesReturnItemSequence))
+		}
+		tx.put(sr.table.name, returnObj.ReturnID, returnObj)
+		return nil
+	})
+}
+
+//Update is a function for updating record
+func (sr *MemorySalesReturn) Update(returnModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot update, sales return %v is immutable", returnModel.GetID()), 0)
+}
+
+//Delete is a function for deleting record
+func (sr *MemorySalesReturn) Delete(returnModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, sales return %v is immutable", returnModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to the store
+func (sr *MemorySalesReturn) Save(returnModel model.Model) *errors.Error {
+	if true == returnModel.GetLoadedFromStorage() {
+		return sr.Update(returnModel)
+	}
+	return sr.Insert(returnModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (sr *MemorySalesReturn) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemorySalesReturn{
+		memoryScope: sr.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sr *MemorySalesReturn) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sr *MemorySalesReturn) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,121 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryStockTable is the memory store table of stock (ordered by sku)
+var memoryStockTable = memoryTable{
+	name: "stock",
+	clone: func(record model.Model) model.Model {
+		stockObj := *record.(*model.Stock)
+		stockObj.SetLoadedFromStorage(true)
+		return &stockObj
+	},
+	less: func(x, y model.Model) bool {
+		return x.(*model.Stock).Sku < y.(*model.Stock).Sku
+	},
+}
+
+//MemoryStock is a struct of in-memory datamapper for stock domain model
+type MemoryStock struct {
+	memoryScope
+}
+
+//NewMemoryStock creates a new MemoryStock datamapper on the given store and returns a pointer to it
+func NewMemoryStock(store *MemoryStore) *MemoryStock {
+	return &MemoryStock{
+		memoryScope: memoryScope{store: store, table: memoryStockTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (s *MemoryStock) FindByID(id string) (model.Model, *errors.Error) {
+	return s.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (s *MemoryStock) FindAll() ([]model.Model, *errors.Error) {
+	return s.findWhere(nil), nil
+}
+
+//FindByProductID is a function for finding the variants of a product (ordered by sku)
+func (s *MemoryStock) FindByProductID(productID string) ([]model.Model, *errors.Error) {
+	return s.findWhere(func(record model.Model) bool {
+		return record.(*model.Stock).ProductID == productID
+	}), nil
+}
+
+//FindByCriteria is a function for finding a page of the records matching a criteria (see stockFields for the fields)
+func (s *MemoryStock) FindByCriteria(criteria Criteria) (*Page, *errors.Error) {
+	return s.findByCriteria(criteria, stockFields, stockKeyField)
+}
+
+//Search is a function for finding at most limit stock items whose sku or name hold words starting with every word of the query, best match first
+//Note: items are ranked the same way as the sqlite search index does (see sqliteStockSearch)
+func (s *MemoryStock) Search(query string, limit int) ([]SearchHit, *errors.Error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, emptySearchError(query)
+	}
+	return memorySearchStock(s.findWhere(nil), terms, searchLimit(limit)), nil
+}
+
+//Insert is a function for inserting a record
+func (s *MemoryStock) Insert(stockModel model.Model) *errors.Error {
+	if _, ok := stockModel.(*model.Stock); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Stock"), 0)
+	}
+	return s.inTx(func(tx *MemoryTx) *errors.Error {
+		return s.insert(tx, stockModel)
+	})
+}
+
+//Update is a function for updating record
+func (s *MemoryStock) Update(stockModel model.Model) *errors.Error {
+	if _, ok := stockModel.(*model.Stock); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Stock"), 0)
+	}
+	return s.inTx(func(tx *MemoryTx) *errors.Error {
+		if s.stored(tx, stockModel.GetID()) == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", stockModel.GetID()), 0)
+		}
+		tx.put(s.table.name, stockModel.GetID(), s.table.clone(stockModel))
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (s *MemoryStock) Delete(stockModel model.Model) *errors.Error {
+	return s.delete(stockModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (s *MemoryStock) Save(stockModel model.Model) *errors.Error {
+	if true == stockModel.GetLoadedFromStorage() {
+		return s.Update(stockModel)
+	}
+	return s.Insert(stockModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (s *MemoryStock) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryStock{
+		memoryScope: s.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (s *MemoryStock) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (s *MemoryStock) Shutdown() {
+	//Note: perform any cleanup here
+}
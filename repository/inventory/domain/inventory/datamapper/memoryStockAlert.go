@@ -0,0 +1,148 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryStockAlertSequence is the sequence generating ids of stock alerts
+const memoryStockAlertSequence = "stock_alerts"
+
+//memoryStockAlertTable is the memory store table of stock alerts (ordered by raising date, then by id)
+var memoryStockAlertTable = memoryTable{
+	name: "stock_alerts",
+	clone: func(record model.Model) model.Model {
+		alertObj := *record.(*model.StockAlert)
+		alertObj.RaisedAt = memoryTime(alertObj.RaisedAt)
+		alertObj.ResolvedAt = memoryTime(alertObj.ResolvedAt)
+		alertObj.SetLoadedFromStorage(true)
+		return &alertObj
+	},
+	less: func(x, y model.Model) bool {
+		xDate, yDate := x.(*model.StockAlert).RaisedAt, y.(*model.StockAlert).RaisedAt
+		if false == xDate.Equal(yDate) {
+			return xDate.Before(yDate)
+		}
+		return memoryIDLess(x, y)
+	},
+	sequence: memoryStockAlertSequence,
+	ids: func(record model.Model) []int64 {
+		id, _ := strconv.ParseInt(record.GetID(), 10, 64)
+		return []int64{id}
+	},
+}
+
+//MemoryStockAlert is a struct of in-memory datamapper for stock alert domain model
+type MemoryStockAlert struct {
+	memoryScope
+}
+
+//NewMemoryStockAlert creates a new MemoryStockAlert datamapper on the given store and returns a pointer to it
+func NewMemoryStockAlert(store *MemoryStore) *MemoryStockAlert {
+	return &MemoryStockAlert{
+		memoryScope: memoryScope{store: store, table: memoryStockAlertTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sa *MemoryStockAlert) FindByID(id string) (model.Model, *errors.Error) {
+	return sa.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (sa *MemoryStockAlert) FindAll() ([]model.Model, *errors.Error) {
+	return sa.findWhere(nil), nil
+}
+
+//FindOpen is a function for finding the alerts which haven't been resolved (oldest alert first)
+func (sa *MemoryStockAlert) FindOpen() ([]model.Model, *errors.Error) {
+	return sa.findWhere(func(record model.Model) bool {
+		return record.(*model.StockAlert).IsOpen()
+	}), nil
+}
+
+//FindOpenBySku is a function for finding the open alert of a sku, ErrNotFound is returned when the sku has no open alert
+func (sa *MemoryStockAlert) FindOpenBySku(sku string) (model.Model, *errors.Error) {
+	alerts := sa.findWhere(func(record model.Model) bool {
+		alertObj := record.(*model.StockAlert)
+		return alertObj.Sku == sku && alertObj.IsOpen()
+	})
+	if len(alerts) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return alerts[0], nil
+}
+
+//Insert is a function for inserting a record
+func (sa *MemoryStockAlert) Insert(alertModel model.Model) *errors.Error {
+	alertModelObj, ok := alertModel.(*model.StockAlert)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockAlert"), 0)
+	}
+	if true == alertModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot insert, stock alert with id: %v already exists", alertModel.GetID()), 0)
+	}
+	return sa.inTx(func(tx *MemoryTx) *errors.Error {
+		alertModelObj.SetID(tx.nextID(memoryStockAlertSequence))
+		tx.put(sa.table.name, alertModelObj.GetID(), sa.table.clone(alertModelObj))
+		return nil
+	})
+}
+
+//Update is a function for updating record
+//Note: the sku of an alert can't be changed
+func (sa *MemoryStockAlert) Update(alertModel model.Model) *errors.Error {
+	alertModelObj, ok := alertModel.(*model.StockAlert)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockAlert"), 0)
+	}
+	if false == alertModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot update, stock alert with id: %v doesn't exist", alertModel.GetID()), 0)
+	}
+	return sa.inTx(func(tx *MemoryTx) *errors.Error {
+		storedModel := sa.stored(tx, alertModel.GetID())
+		if storedModel == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, stock alert with id: %v doesn't exist", alertModel.GetID()), 0)
+		}
+		alertObj := sa.table.clone(alertModelObj).(*model.StockAlert)
+		alertObj.Sku = storedModel.(*model.StockAlert).Sku
+		tx.put(sa.table.name, alertObj.GetID(), alertObj)
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+//Note: alerts are kept as the history of low stock, resolve them instead of deleting
+func (sa *MemoryStockAlert) Delete(alertModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, stock alert %v must be resolved instead", alertModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to the store
+func (sa *MemoryStockAlert) Save(alertModel model.Model) *errors.Error {
+	if true == alertModel.GetLoadedFromStorage() {
+		return sa.Update(alertModel)
+	}
+	return sa.Insert(alertModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (sa *MemoryStockAlert) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryStockAlert{
+		memoryScope: sa.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sa *MemoryStockAlert) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sa *MemoryStockAlert) Shutdown() {
+	//Note: perform any cleanup here
+}
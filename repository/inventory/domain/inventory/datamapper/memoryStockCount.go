@@ -0,0 +1,157 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryStockCountItemSequence is the sequence generating ids of stock count items
+const memoryStockCountItemSequence = "stock_count_items"
+
+//memoryStockCountTable is the memory store table of stock counts (ordered by count date)
+var memoryStockCountTable = memoryTable{
+	name: "stock_counts",
+	clone: func(record model.Model) model.Model {
+		countObj := *record.(*model.StockCount)
+		countObj.Date = memoryTime(countObj.Date)
+		countObj.PostedDate = memoryTime(countObj.PostedDate)
+		countObj.Items = make(map[string]*model.StockCountItem, len(countObj.Items))
+		for key, val := range record.(*model.StockCount).Items {
+			itemObj := *val
+			//counted quantity isn't stored until the item is counted
+			if false == itemObj.Counted {
+				itemObj.CountedQuantity = 0
+			}
+			itemObj.SetLoadedFromStorage(true)
+			countObj.Items[key] = &itemObj
+		}
+		countObj.SetLoadedFromStorage(true)
+		return &countObj
+	},
+	less: func(x, y model.Model) bool {
+		xDate, yDate := x.(*model.StockCount).Date, y.(*model.StockCount).Date
+		if false == xDate.Equal(yDate) {
+			return xDate.Before(yDate)
+		}
+		return x.GetID() < y.GetID()
+	},
+	sequence: memoryStockCountItemSequence,
+	ids: func(record model.Model) []int64 {
+		var ids []int64
+		for _, val := range record.(*model.StockCount).Items {
+			ids = append(ids, val.GetID())
+		}
+		return ids
+	},
+}
+
+//MemoryStockCount is a struct of in-memory datamapper for stock count domain model
+type MemoryStockCount struct {
+	memoryScope
+}
+
+//NewMemoryStockCount creates a new MemoryStockCount datamapper on the given store and returns a pointer to it
+func NewMemoryStockCount(store *MemoryStore) *MemoryStockCount {
+	return &MemoryStockCount{
+		memoryScope: memoryScope{store: store, table: memoryStockCountTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sc *MemoryStockCount) FindByID(id string) (model.Model, *errors.Error) {
+	return sc.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (sc *MemoryStockCount) FindAll() ([]model.Model, *errors.Error) {
+	return sc.findWhere(nil), nil
+}
+
+//Insert is a function for inserting a record
+func (sc *MemoryStockCount) Insert(countModel model.Model) *errors.Error {
+	if _, ok := countModel.(*model.StockCount); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockCount"), 0)
+	}
+	return sc.inTx(func(tx *MemoryTx) *errors.Error {
+		if sc.stored(tx, countModel.GetID()) != nil {
+			return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", countModel.GetID()), 0)
+		}
+		countObj := sc.table.clone(countModel).(*model.StockCount)
+		for _, val := range countObj.Items {
+			val.SetID(tx.nextID(memoryStockCountItemSequence))
+		}
+		tx.put(sc.table.name, countObj.CountID, countObj)
+		return nil
+	})
+}
+
+//Update is a function for updating record
+//Note: same as StockCount, only status, note, posted date and the stored items (matched by item id) are changed
+func (sc *MemoryStockCount) Update(countModel model.Model) *errors.Error {
+	countModelObj, ok := countModel.(*model.StockCount)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockCount"), 0)
+	}
+	return sc.inTx(func(tx *MemoryTx) *errors.Error {
+		storedModel := sc.stored(tx, countModel.GetID())
+		if storedModel == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", countModel.GetID()), 0)
+		}
+		countObj := sc.table.clone(storedModel).(*model.StockCount)
+		countObj.Status = countModelObj.Status
+		countObj.Note = countModelObj.Note
+		countObj.PostedDate = memoryTime(countModelObj.PostedDate)
+		for _, val := range countModelObj.Items {
+			if false == val.GetLoadedFromStorage() {
+				continue
+			}
+			for _, itemObj := range countObj.Items {
+				if itemObj.GetID() == val.GetID() {
+					itemObj.Counted = val.Counted
+					itemObj.CountedQuantity = 0
+					if true == val.Counted {
+						itemObj.CountedQuantity = val.CountedQuantity
+					}
+					itemObj.SystemQuantity = val.SystemQuantity
+					itemObj.BuyPrice = val.BuyPrice
+				}
+			}
+		}
+		tx.put(sc.table.name, countObj.CountID, countObj)
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (sc *MemoryStockCount) Delete(countModel model.Model) *errors.Error {
+	return sc.delete(countModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (sc *MemoryStockCount) Save(countModel model.Model) *errors.Error {
+	if true == countModel.GetLoadedFromStorage() {
+		return sc.Update(countModel)
+	}
+	return sc.Insert(countModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (sc *MemoryStockCount) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryStockCount{
+		memoryScope: sc.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sc *MemoryStockCount) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sc *MemoryStockCount) Shutdown() {
+	//Note: perform any cleanup here
+}
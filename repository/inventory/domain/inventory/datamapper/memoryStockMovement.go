@@ -0,0 +1,125 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memoryStockMovementSequence is the sequence generating ids of stock movements
+const memoryStockMovementSequence = "stock_movements"
+
+//memoryStockMovementTable is the memory store table of stock movements (ordered by movement date, then by id)
+var memoryStockMovementTable = memoryTable{
+	name: "stock_movements",
+	clone: func(record model.Model) model.Model {
+		movementObj := *record.(*model.StockMovement)
+		movementObj.Date = memoryTime(movementObj.Date)
+		movementObj.SetLoadedFromStorage(true)
+		return &movementObj
+	},
+	less: func(x, y model.Model) bool {
+		xDate, yDate := x.(*model.StockMovement).Date, y.(*model.StockMovement).Date
+		if false == xDate.Equal(yDate) {
+			return xDate.Before(yDate)
+		}
+		return memoryIDLess(x, y)
+	},
+	sequence: memoryStockMovementSequence,
+	ids: func(record model.Model) []int64 {
+		id, _ := strconv.ParseInt(record.GetID(), 10, 64)
+		return []int64{id}
+	},
+}
+
+//MemoryStockMovement is a struct of in-memory datamapper for stock movement domain model
+//Note: stock movements are append only (same as StockMovement)
+type MemoryStockMovement struct {
+	memoryScope
+}
+
+//NewMemoryStockMovement creates a new MemoryStockMovement datamapper on the given store and returns a pointer to it
+func NewMemoryStockMovement(store *MemoryStore) *MemoryStockMovement {
+	return &MemoryStockMovement{
+		memoryScope: memoryScope{store: store, table: memoryStockMovementTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sm *MemoryStockMovement) FindByID(id string) (model.Model, *errors.Error) {
+	return sm.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (sm *MemoryStockMovement) FindAll() ([]model.Model, *errors.Error) {
+	return sm.findWhere(nil), nil
+}
+
+//FindBySkuAndDateRange is a function for finding movement records of a sku which happened on startDate (inclusive) until endDate (exclusive)
+func (sm *MemoryStockMovement) FindBySkuAndDateRange(sku string, startDate, endDate time.Time) ([]model.Model, *errors.Error) {
+	startDateString := startDate.Format(timeFormat)
+	endDateString := endDate.Format(timeFormat)
+	return sm.findWhere(func(record model.Model) bool {
+		movementObj := record.(*model.StockMovement)
+		dateString := movementObj.Date.Format(timeFormat)
+		return movementObj.Sku == sku && dateString >= startDateString && dateString < endDateString
+	}), nil
+}
+
+//Insert is a function for inserting a record
+func (sm *MemoryStockMovement) Insert(movementModel model.Model) *errors.Error {
+	movementModelObj, ok := movementModel.(*model.StockMovement)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockMovement"), 0)
+	}
+	if true == movementModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot insert, movement with id: %v already exists", movementModel.GetID()), 0)
+	}
+	return sm.inTx(func(tx *MemoryTx) *errors.Error {
+		movementModelObj.SetID(tx.nextID(memoryStockMovementSequence))
+		tx.put(sm.table.name, movementModelObj.GetID(), sm.table.clone(movementModelObj))
+		return nil
+	})
+}
+
+//Update is a function for updating record
+//Note: stock movements are append only
+func (sm *MemoryStockMovement) Update(movementModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot update, stock movement %v is append only", movementModel.GetID()), 0)
+}
+
+//Delete is a function for deleting record
+//Note: stock movements are append only
+func (sm *MemoryStockMovement) Delete(movementModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, stock movement %v is append only", movementModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to the store
+func (sm *MemoryStockMovement) Save(movementModel model.Model) *errors.Error {
+	if true == movementModel.GetLoadedFromStorage() {
+		return sm.Update(movementModel)
+	}
+	return sm.Insert(movementModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (sm *MemoryStockMovement) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemoryStockMovement{
+		memoryScope: sm.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sm *MemoryStockMovement) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sm *MemoryStockMovement) Shutdown() {
+	//Note: perform any cleanup here
+}
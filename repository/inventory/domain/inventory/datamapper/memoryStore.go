@@ -0,0 +1,345 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//DriverMemory is the database.driver config selecting the memory datamappers (see MemoryStore) instead of a database
+const DriverMemory = "memory"
+
+//MemoryStore is a thread safe in-memory storage of the inventory models (used by the memory datamappers, e.g. for tests and demos)
+//records are kept as copies, models found through the memory datamappers never share state with the store and changes are only stored when saved
+//every change runs in a transaction (see MemoryTx), transactions run one at a time and work on a snapshot of the store
+type MemoryStore struct {
+	mu        sync.RWMutex //guards tables and sequences
+	txMu      sync.Mutex   //held by the running transaction
+	tables    map[string]map[string]model.Model
+	sequences map[string]int64 //last id generated for records without natural id (e.g. cost layers and sale items)
+}
+
+//NewMemoryStore creates a new empty MemoryStore and returns a pointer to it
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tables:    make(map[string]map[string]model.Model),
+		sequences: make(map[string]int64),
+	}
+}
+
+//Begin is a function for starting a new transaction on a snapshot of the store
+//it blocks until the running transaction (if any) is committed or rolled back
+func (ms *MemoryStore) Begin() *MemoryTx {
+	ms.txMu.Lock()
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	tx := &MemoryTx{
+		store:     ms,
+		tables:    make(map[string]map[string]model.Model, len(ms.tables)),
+		sequences: make(map[string]int64, len(ms.sequences)),
+		copied:    make(map[string]bool),
+	}
+	for key, val := range ms.tables {
+		tx.tables[key] = val
+	}
+	for key, val := range ms.sequences {
+		tx.sequences[key] = val
+	}
+	return tx
+}
+
+//StartUp allows the store to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (ms *MemoryStore) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the store to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (ms *MemoryStore) Shutdown() {
+	//Note: perform any cleanup here
+}
+
+//MemoryTx is a transaction of a memory store
+//changes made inside the transaction are only visible to the transaction until it's committed, they're discarded when it's rolled back
+type MemoryTx struct {
+	store     *MemoryStore
+	tables    map[string]map[string]model.Model //snapshot of the store along with the changes of the transaction
+	sequences map[string]int64
+	copied    map[string]bool //tables changed by the transaction, the other tables are still shared with the store
+	done      bool
+}
+
+//Commit is a function for publishing the changes of the transaction to the store
+func (tx *MemoryTx) Commit() error {
+	if true == tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	tx.store.mu.Lock()
+	tx.store.tables = tx.tables
+	tx.store.sequences = tx.sequences
+	tx.store.mu.Unlock()
+	tx.store.txMu.Unlock()
+	return nil
+}
+
+//Rollback is a function for discarding the changes of the transaction
+func (tx *MemoryTx) Rollback() error {
+	if true == tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	tx.store.txMu.Unlock()
+	return nil
+}
+
+//writable is a function for obtaining the records of a table the transaction can change
+//tables of the snapshot are copied on their first change, so the store and other snapshots are left untouched
+func (tx *MemoryTx) writable(table string) map[string]model.Model {
+	if false == tx.copied[table] {
+		records := make(map[string]model.Model, len(tx.tables[table])+1)
+		for key, val := range tx.tables[table] {
+			records[key] = val
+		}
+		tx.tables[table] = records
+		tx.copied[table] = true
+	}
+	return tx.tables[table]
+}
+
+//put is a function for storing a record in a table of the transaction
+func (tx *MemoryTx) put(table, id string, record model.Model) {
+	tx.writable(table)[id] = record
+}
+
+//remove is a function for removing a record from a table of the transaction
+func (tx *MemoryTx) remove(table, id string) {
+	delete(tx.writable(table), id)
+}
+
+//nextID is a function for generating the next id of a sequence
+func (tx *MemoryTx) nextID(sequence string) int64 {
+	tx.sequences[sequence]++
+	return tx.sequences[sequence]
+}
+
+//useID is a function for moving a sequence past an id which is already used (e.g. by a loaded record)
+func (tx *MemoryTx) useID(sequence string, id int64) {
+	if id > tx.sequences[sequence] {
+		tx.sequences[sequence] = id
+	}
+}
+
+//memoryTable is a definition of a table of the memory store
+type memoryTable struct {
+	name     string
+	clone    func(record model.Model) model.Model //returns a deep copy of a record flagged as loaded from storage
+	less     func(x, y model.Model) bool          //order of found records
+	sequence string                               //sequence generating the ids of the records (or of their items), empty for tables with natural ids only
+	ids      func(record model.Model) []int64     //returns the generated ids used by a record (its own id or the ids of its items)
+}
+
+//memoryScope is a struct holding the store and table of a memory datamapper along with the transaction the datamapper is bound to (if any)
+type memoryScope struct {
+	store *MemoryStore
+	tx    *MemoryTx //transaction the datamapper is bound to (see MemoryTxBinder), nil when running on the committed records
+	table memoryTable
+}
+
+//bind is a function for returning a copy of the scope bound to the given transaction
+func (ms memoryScope) bind(tx *MemoryTx) memoryScope {
+	return memoryScope{
+		store: ms.store,
+		tx:    tx,
+		table: ms.table,
+	}
+}
+
+//view is a function for running fn on the records of the table (of the bound transaction if any, otherwise the committed records)
+//fn must not change the records
+func (ms memoryScope) view(fn func(records map[string]model.Model)) {
+	if ms.tx != nil {
+		fn(ms.tx.tables[ms.table.name])
+		return
+	}
+	ms.store.mu.RLock()
+	defer ms.store.mu.RUnlock()
+	fn(ms.store.tables[ms.table.name])
+}
+
+//inTx is a function for running fn inside the bound transaction
+//when the datamapper isn't bound, fn runs inside a new transaction which is committed when fn succeeds (rolled back otherwise)
+func (ms memoryScope) inTx(fn func(tx *MemoryTx) *errors.Error) *errors.Error {
+	if ms.tx != nil {
+		return fn(ms.tx)
+	}
+	tx := ms.store.Begin()
+	errs := fn(tx)
+	if errs != nil {
+		tx.Rollback()
+		return errs
+	}
+	err := tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//findByID is a function for finding a copy of the record with the given id
+func (ms memoryScope) findByID(id string) (model.Model, *errors.Error) {
+	var found model.Model
+	ms.view(func(records map[string]model.Model) {
+		if record, exists := records[id]; exists {
+			found = ms.table.clone(record)
+		}
+	})
+	if found == nil {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return found, nil
+}
+
+//findWhere is a function for finding copies of the records matching filter (all records when filter is nil) in order of the table
+func (ms memoryScope) findWhere(filter func(record model.Model) bool) []model.Model {
+	var found []model.Model
+	ms.view(func(records map[string]model.Model) {
+		for _, val := range records {
+			if filter == nil || true == filter(val) {
+				found = append(found, ms.table.clone(val))
+			}
+		}
+	})
+	sort.SliceStable(found, func(x, y int) bool {
+		return ms.table.less(found[x], found[y])
+	})
+	return found
+}
+
+//findByCriteria is a function for finding a page of copies of the records matching a criteria (see Criteria), fields are the fields of the table
+func (ms memoryScope) findByCriteria(criteria Criteria, fields map[string]criteriaField, keyField string) (*Page, *errors.Error) {
+	resolved, errs := criteria.resolve(fields, keyField)
+	if errs != nil {
+		return nil, errs
+	}
+	records := ms.findWhere(resolved.match)
+	sort.SliceStable(records, func(x, y int) bool {
+		return resolved.less(records[x], records[y])
+	})
+	if len(records) > resolved.limit+1 {
+		records = records[:resolved.limit+1]
+	}
+	return resolved.page(records), nil
+}
+
+//insert is a function for storing a copy of a new record (using passed transaction)
+func (ms memoryScope) insert(tx *MemoryTx, record model.Model) *errors.Error {
+	if _, exists := tx.tables[ms.table.name][record.GetID()]; exists {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", record.GetID()), 0)
+	}
+	tx.put(ms.table.name, record.GetID(), ms.table.clone(record))
+	return nil
+}
+
+//stored is a function for obtaining the stored record with the given id (using passed transaction), nil when it doesn't exist
+//the stored record must not be changed, changes are stored as a changed copy
+func (ms memoryScope) stored(tx *MemoryTx, id string) model.Model {
+	return tx.tables[ms.table.name][id]
+}
+
+//delete is a function for removing a record (along with its items)
+func (ms memoryScope) delete(id string) *errors.Error {
+	return ms.inTx(func(tx *MemoryTx) *errors.Error {
+		if ms.stored(tx, id) == nil {
+			return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", id), 0)
+		}
+		tx.remove(ms.table.name, id)
+		return nil
+	})
+}
+
+//memoryTime is a function for composing a time the way it's stored by the sql datamappers (see timeFormat)
+//so models round trip the memory store just like a database
+func memoryTime(value time.Time) time.Time {
+	storedTime, _ := time.Parse(timeFormat, value.Format(timeFormat))
+	return storedTime
+}
+
+//memoryIDLess is a function for ordering records with generated (numeric) ids by id
+func memoryIDLess(x, y model.Model) bool {
+	xID, _ := strconv.ParseInt(x.GetID(), 10, 64)
+	yID, _ := strconv.ParseInt(y.GetID(), 10, 64)
+	return xID < yID
+}
+
+//memoryTables is the list of tables of the memory store
+var memoryTables = []memoryTable{
+	memoryStockTable,
+	memoryProductTable,
+	memoryCategoryTable,
+	memoryAttributeTable,
+	memoryItemAttributesTable,
+	memorySupplierTable,
+	memoryPurchaseTable,
+	memoryPurchaseReceiptTable,
+	memorySaleTable,
+	memorySalesReturnTable,
+	memoryStockMovementTable,
+	memoryCostLayerTable,
+	memoryStockCountTable,
+	memoryStockAlertTable,
+	memorySaleStatusTransitionTable,
+}
+
+//SeedMemoryStore is a function for copying every record of a database into a memory store (e.g. to run on the data of a dump)
+//records keep their ids, ids generated by the store afterwards follow the highest copied id
+func SeedMemoryStore(store *MemoryStore, dbSession *sql.DB, dialect Dialect) *errors.Error {
+	sources := map[string]DataMapper{
+		memoryStockTable.name:                NewStock(dbSession, dialect),
+		memoryProductTable.name:              NewProduct(dbSession, dialect),
+		memoryCategoryTable.name:             NewCategory(dbSession, dialect),
+		memoryAttributeTable.name:            NewAttribute(dbSession, dialect),
+		memoryItemAttributesTable.name:       NewItemAttributes(dbSession, dialect),
+		memorySupplierTable.name:             NewSupplier(dbSession, dialect),
+		memoryPurchaseTable.name:             NewPurchase(dbSession, dialect),
+		memoryPurchaseReceiptTable.name:      NewPurchaseReceipt(dbSession, dialect),
+		memorySaleTable.name:                 NewSale(dbSession, dialect),
+		memorySalesReturnTable.name:          NewSalesReturn(dbSession, dialect),
+		memoryStockMovementTable.name:        NewStockMovement(dbSession, dialect),
+		memoryCostLayerTable.name:            NewCostLayer(dbSession, dialect),
+		memoryStockCountTable.name:           NewStockCount(dbSession, dialect),
+		memoryStockAlertTable.name:           NewStockAlert(dbSession, dialect),
+		memorySaleStatusTransitionTable.name: NewSaleStatusTransition(dbSession, dialect),
+	}
+
+	tx := store.Begin()
+	for _, table := range memoryTables {
+		records, errs := sources[table.name].FindAll()
+		if errs != nil {
+			tx.Rollback()
+			return errs
+		}
+		for _, val := range records {
+			tx.put(table.name, val.GetID(), table.clone(val))
+			if table.ids == nil {
+				continue
+			}
+			for _, id := range table.ids(val) {
+				tx.useID(table.sequence, id)
+			}
+		}
+	}
+	err := tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package datamapper_test
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+func TestMemoryStockDatamapper(t *testing.T) {
+	store := datamapper.NewMemoryStore()
+	mapper := datamapper.NewMemoryStock(store)
+	stockObj := &model.Stock{Sku: "dummySku", Name: "dummy item", Quantity: 10, BuyPrice: 50000, SellPrice: 60000}
+	err := mapper.Insert(stockObj)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	//found models are copies, changing them doesn't change the store until they're saved
+	stockObj.Quantity = 20
+	found, err := mapper.FindByID("dummySku")
+	t.Run("stored item must not share state with the inserted model", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if found.(*model.Stock).Quantity != 10 || false == found.GetLoadedFromStorage() {
+			t.Errorf("expected quantity 10 loaded from storage but got %+v", found)
+		}
+	})
+	found.(*model.Stock).Quantity = 7
+	refound, _ := mapper.FindByID("dummySku")
+	t.Run("found item must not share state with the store", func(t *testing.T) {
+		if refound.(*model.Stock).Quantity != 10 {
+			t.Errorf("expected 10 but got %v", refound.(*model.Stock).Quantity)
+		}
+	})
+	err = mapper.Save(found)
+	refound, _ = mapper.FindByID("dummySku")
+	t.Run("saved item must be updated", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if refound.(*model.Stock).Quantity != 7 {
+			t.Errorf("expected 7 but got %v", refound.(*model.Stock).Quantity)
+		}
+	})
+
+	_, err = mapper.FindByID("unknownSku")
+	t.Run("unknown item must not be found", func(t *testing.T) {
+		if err == nil || err.Err != datamapper.ErrNotFound {
+			t.Errorf("expected %v but got %v", datamapper.ErrNotFound, err)
+		}
+	})
+	err = mapper.Insert(&model.Stock{Sku: "dummySku"})
+	t.Run("duplicate item must not be inserted", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	//changes of a transaction are only visible to the transaction until it's committed
+	tx := store.Begin()
+	refound.(*model.Stock).Quantity = 100
+	err = mapper.WithMemoryTx(tx).Update(refound)
+	inTx, _ := mapper.WithMemoryTx(tx).FindByID("dummySku")
+	outside, _ := mapper.FindByID("dummySku")
+	t.Run("uncommitted update must only be visible inside the transaction", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if inTx.(*model.Stock).Quantity != 100 || outside.(*model.Stock).Quantity != 7 {
+			t.Errorf("expected 100 and 7 but got %v and %v", inTx.(*model.Stock).Quantity, outside.(*model.Stock).Quantity)
+		}
+	})
+	tx.Rollback()
+	outside, _ = mapper.FindByID("dummySku")
+	t.Run("rolled back update must be discarded", func(t *testing.T) {
+		if outside.(*model.Stock).Quantity != 7 {
+			t.Errorf("expected 7 but got %v", outside.(*model.Stock).Quantity)
+		}
+	})
+
+	tx = store.Begin()
+	err = mapper.WithMemoryTx(tx).Delete(outside)
+	errs := tx.Commit()
+	all, _ := mapper.FindAll()
+	t.Run("committed delete must be stored", func(t *testing.T) {
+		if err != nil || errs != nil {
+			t.Fatalf("expected nil but got %v and %v", err, errs)
+		}
+		if len(all) != 0 {
+			t.Errorf("expected no items but got %v", len(all))
+		}
+	})
+}
+
+func TestMemorySaleDatamapper(t *testing.T) {
+	mapper := datamapper.NewMemorySale(datamapper.NewMemoryStore())
+	sales := []*model.Sales{
+		{InvoiceID: "INV-1", Date: time.Date(2018, 1, 5, 8, 0, 0, 0, time.UTC), Status: "S"},
+		{InvoiceID: "INV-2", Date: time.Date(2018, 2, 5, 8, 0, 0, 0, time.UTC), Status: "S"},
+		{InvoiceID: "INV-3", Date: time.Date(2018, 1, 20, 8, 0, 0, 0, time.UTC), Status: "D"},
+	}
+	for _, val := range sales {
+		val.Items = map[string]*model.SaleItem{
+			"dummySku": {Sku: "dummySku", Quantity: 2, BuyPrice: 50000, SellPrice: 60000},
+		}
+		err := mapper.Insert(val)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+
+	found, err := mapper.FindByDoneStatusAndDateRange(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC))
+	t.Run("only done sales in range must be found", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(found) != 1 || found[0].GetID() != "INV-1" {
+			t.Fatalf("expected INV-1 but got %v sales", len(found))
+		}
+		item := found[0].(*model.Sales).Items["dummySku"]
+		if item.GetID() == 0 || item.SellPrice != 60000 || false == item.GetLoadedFromStorage() {
+			t.Errorf("expected stored item of INV-1 but got %+v", item)
+		}
+	})
+
+	//new items get ids, stored items keep theirs
+	saleObj := found[0].(*model.Sales)
+	storedItemID := saleObj.Items["dummySku"].GetID()
+	saleObj.Items["otherSku"] = &model.SaleItem{Sku: "otherSku", Quantity: 1, BuyPrice: 50000, SellPrice: 65000}
+	err = mapper.Save(saleObj)
+	refound, _ := mapper.FindByID("INV-1")
+	t.Run("saved sale must store its new items", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		items := refound.(*model.Sales).Items
+		if len(items) != 2 || items["dummySku"].GetID() != storedItemID || items["otherSku"].GetID() == 0 || items["otherSku"].GetID() == storedItemID {
+			t.Errorf("expected both items with distinct ids but got %+v", items)
+		}
+	})
+}
+
+func TestMemoryStoreConcurrentTransactions(t *testing.T) {
+	store := datamapper.NewMemoryStore()
+	mapper := datamapper.NewMemoryStock(store)
+	movementMapper := datamapper.NewMemoryStockMovement(store)
+	err := mapper.Insert(&model.Stock{Sku: "dummySku", Quantity: 0})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	//every transaction increments the quantity and records a movement, transactions run one at a time so no increment is lost
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tx := store.Begin()
+			found, _ := mapper.WithMemoryTx(tx).FindByID("dummySku")
+			found.(*model.Stock).Quantity++
+			mapper.WithMemoryTx(tx).Update(found)
+			movementMapper.WithMemoryTx(tx).Insert(&model.StockMovement{Sku: "dummySku", Quantity: 1, Reference: fmt.Sprintf("ref-%v", i), Date: time.Now()})
+			tx.Commit()
+		}(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mapper.FindAll()
+		}()
+	}
+	wg.Wait()
+
+	found, _ := mapper.FindByID("dummySku")
+	movements, _ := movementMapper.FindAll()
+	t.Run("every committed change must be stored", func(t *testing.T) {
+		if found.(*model.Stock).Quantity != 20 {
+			t.Errorf("expected 20 but got %v", found.(*model.Stock).Quantity)
+		}
+		ids := make(map[string]bool)
+		for _, val := range movements {
+			ids[val.GetID()] = true
+		}
+		if len(movements) != 20 || len(ids) != 20 {
+			t.Errorf("expected 20 movements with distinct ids but got %v movements with %v ids", len(movements), len(ids))
+		}
+	})
+}
+
+func TestSeedMemoryStore(t *testing.T) {
+	forEachDialect(t, func(t *testing.T, dialect datamapper.Dialect, db *sql.DB) {
+		insertTestStock(t, dialect, db, "dummySku")
+		saleObj := &model.Sales{
+			InvoiceID: "INV-1",
+			Date:      time.Date(2018, 1, 5, 8, 0, 0, 0, time.UTC),
+			Status:    "S",
+			Items: map[string]*model.SaleItem{
+				"dummySku": {Sku: "dummySku", Quantity: 2, BuyPrice: 50000, SellPrice: 60000},
+			},
+		}
+		err := datamapper.NewSale(db, dialect).Insert(saleObj)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		layerObj := &model.CostLayer{Sku: "dummySku", Quantity: 10, RemainingQuantity: 10, UnitCost: 50000, Reference: "PO-1", Date: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)}
+		err = datamapper.NewCostLayer(db, dialect).Insert(layerObj)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+
+		storedSale, _ := datamapper.NewSale(db, dialect).FindByID("INV-1")
+
+		store := datamapper.NewMemoryStore()
+		err = datamapper.SeedMemoryStore(store, db, dialect)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		stockObj, errStock := datamapper.NewMemoryStock(store).FindByID("dummySku")
+		found, errSale := datamapper.NewMemorySale(store).FindByID("INV-1")
+		t.Run("records must be copied along with their ids", func(t *testing.T) {
+			if errStock != nil || errSale != nil {
+				t.Fatalf("expected nil but got %v and %v", errStock, errSale)
+			}
+			if stockObj.(*model.Stock).Quantity != 10 {
+				t.Errorf("expected quantity 10 but got %v", stockObj.(*model.Stock).Quantity)
+			}
+			item := found.(*model.Sales).Items["dummySku"]
+			if false == found.(*model.Sales).Date.Equal(saleObj.Date) || item.GetID() == 0 || item.GetID() != storedSale.(*model.Sales).Items["dummySku"].GetID() {
+				t.Errorf("expected the copied sale but got %+v and item %+v", found, item)
+			}
+		})
+
+		newLayerObj := &model.CostLayer{Sku: "dummySku", Quantity: 1, RemainingQuantity: 1, UnitCost: 50000, Reference: "PO-2", Date: time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC)}
+		err = datamapper.NewMemoryCostLayer(store).Insert(newLayerObj)
+		layers, _ := datamapper.NewMemoryCostLayer(store).FindOpenBySku("dummySku")
+		t.Run("generated ids must follow the copied ids", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("expected nil but got %v", err)
+			}
+			if len(layers) != 2 || layers[0].GetID() != layerObj.GetID() || layers[1].GetID() != newLayerObj.GetID() || newLayerObj.GetID() == layerObj.GetID() {
+				t.Errorf("expected layers %v and %v but got %v layers", layerObj.GetID(), newLayerObj.GetID(), len(layers))
+			}
+		})
+	})
+}
@@ -0,0 +1,114 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//memorySupplierTable is the memory store table of suppliers (ordered by supplier id)
+var memorySupplierTable = memoryTable{
+	name: "suppliers",
+	clone: func(record model.Model) model.Model {
+		supplierObj := *record.(*model.Supplier)
+		supplierObj.Items = make(map[string]*model.SupplierItem, len(supplierObj.Items))
+		for key, val := range record.(*model.Supplier).Items {
+			itemObj := *val
+			itemObj.LastPurchaseDate = memoryTime(itemObj.LastPurchaseDate)
+			itemObj.SetLoadedFromStorage(true)
+			supplierObj.Items[key] = &itemObj
+		}
+		supplierObj.SetLoadedFromStorage(true)
+		return &supplierObj
+	},
+	less: func(x, y model.Model) bool {
+		return x.GetID() < y.GetID()
+	},
+}
+
+//MemorySupplier is a struct of in-memory datamapper for supplier domain model
+type MemorySupplier struct {
+	memoryScope
+}
+
+//NewMemorySupplier creates a new MemorySupplier datamapper on the given store and returns a pointer to it
+func NewMemorySupplier(store *MemoryStore) *MemorySupplier {
+	return &MemorySupplier{
+		memoryScope: memoryScope{store: store, table: memorySupplierTable},
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (s *MemorySupplier) FindByID(id string) (model.Model, *errors.Error) {
+	return s.findByID(id)
+}
+
+//FindAll is a function for finding all records
+func (s *MemorySupplier) FindAll() ([]model.Model, *errors.Error) {
+	return s.findWhere(nil), nil
+}
+
+//Insert is a function for inserting a record
+func (s *MemorySupplier) Insert(supplierModel model.Model) *errors.Error {
+	if _, ok := supplierModel.(*model.Supplier); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Supplier"), 0)
+	}
+	return s.inTx(func(tx *MemoryTx) *errors.Error {
+		return s.insert(tx, supplierModel)
+	})
+}
+
+//Update is a function for updating record
+//new items (not loaded from storage) are added, items missing from the model are kept
+func (s *MemorySupplier) Update(supplierModel model.Model) *errors.Error {
+	if _, ok := supplierModel.(*model.Supplier); false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Supplier"), 0)
+	}
+	return s.inTx(func(tx *MemoryTx) *errors.Error {
+		storedModel := s.stored(tx, supplierModel.GetID())
+		if storedModel == nil {
+			return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", supplierModel.GetID()), 0)
+		}
+		supplierObj := s.table.clone(supplierModel).(*model.Supplier)
+		for key, val := range storedModel.(*model.Supplier).Items {
+			if _, exists := supplierObj.Items[key]; false == exists {
+				supplierObj.Items[key] = val
+			}
+		}
+		tx.put(s.table.name, supplierObj.SupplierID, supplierObj)
+		return nil
+	})
+}
+
+//Delete is a function for deleting record
+func (s *MemorySupplier) Delete(supplierModel model.Model) *errors.Error {
+	return s.delete(supplierModel.GetID())
+}
+
+//Save is a function for persisting a model object to the store
+func (s *MemorySupplier) Save(supplierModel model.Model) *errors.Error {
+	if true == supplierModel.GetLoadedFromStorage() {
+		return s.Update(supplierModel)
+	}
+	return s.Insert(supplierModel)
+}
+
+//WithMemoryTx is a function for returning a copy of the datamapper bound to the given transaction
+func (s *MemorySupplier) WithMemoryTx(tx *MemoryTx) DataMapper {
+	return &MemorySupplier{
+		memoryScope: s.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (s *MemorySupplier) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (s *MemorySupplier) Shutdown() {
+	//Note: perform any cleanup here
+}
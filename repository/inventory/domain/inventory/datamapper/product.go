@@ -0,0 +1,194 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//Product is a struct of datamapper for product domain model
+type Product struct {
+	txScope
+}
+
+//NewProduct creates a new Product datamapper and returns a pointer to it
+func NewProduct(dbSession *sql.DB, dialect Dialect) *Product {
+	return &Product{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (p *Product) FindByID(id string) (model.Model, *errors.Error) {
+	stmt, err := p.conn().Prepare("SELECT PRODUCT_ID, NAME FROM products WHERE PRODUCT_ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	products, errs := p.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(products) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return products[0], nil
+}
+
+//FindAll is a function for finding all records
+func (p *Product) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := p.conn().Query("SELECT PRODUCT_ID, NAME FROM products ORDER BY PRODUCT_ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return p.loadRows(rows)
+}
+
+//loadRows is a function for composing product models from the given rows (PRODUCT_ID, NAME), the rows are closed afterwards
+func (p *Product) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var productID, name sql.NullString
+
+	var returnedRow []model.Model
+	for rows.Next() {
+		err := rows.Scan(&productID, &name)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		productModel := &model.Product{
+			ProductID: productID.String,
+			Name:      name.String,
+		}
+		productModel.SetLoadedFromStorage(true)
+
+		returnedRow = append(returnedRow, productModel)
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return returnedRow, nil
+}
+
+//Insert is a function for inserting a record
+func (p *Product) Insert(productModel model.Model) *errors.Error {
+	return p.inTx(func(tx *sql.Tx) *errors.Error {
+		return p.InsertWithTx(productModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (p *Product) InsertWithTx(productModel model.Model, tx *sql.Tx) *errors.Error {
+	productModelObj, ok := productModel.(*model.Product)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Product"), 0)
+	}
+	foundModel, _ := p.WithTx(tx).FindByID(productModel.GetID())
+	if foundModel != nil {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", productModel.GetID()), 0)
+	}
+	stmt, err := p.on(tx).Prepare("INSERT INTO products(PRODUCT_ID, NAME) values(?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(productModelObj.ProductID, productModelObj.Name)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Update is a function for updating record
+func (p *Product) Update(productModel model.Model) *errors.Error {
+	return p.inTx(func(tx *sql.Tx) *errors.Error {
+		return p.UpdateWithTx(productModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+func (p *Product) UpdateWithTx(productModel model.Model, tx *sql.Tx) *errors.Error {
+	productModelObj, ok := productModel.(*model.Product)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Product"), 0)
+	}
+	_, errs := p.WithTx(tx).FindByID(productModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", productModel.GetID()), 0)
+	}
+	stmt, err := p.on(tx).Prepare("UPDATE products SET NAME=? WHERE PRODUCT_ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(productModelObj.Name, productModelObj.ProductID)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Delete is a function for deleting record
+//Note: variants of the product are left as they are, they should be reassigned first
+func (p *Product) Delete(productModel model.Model) *errors.Error {
+	productModelObj, ok := productModel.(*model.Product)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Product"), 0)
+	}
+	_, errs := p.FindByID(productModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", productModel.GetID()), 0)
+	}
+	return p.inTx(func(tx *sql.Tx) *errors.Error {
+		stmt, err := p.on(tx).Prepare("DELETE FROM products WHERE PRODUCT_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer stmt.Close()
+		_, err = stmt.Exec(productModelObj.ProductID)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return nil
+	})
+}
+
+//Save is a function for persisting a model object to db
+func (p *Product) Save(productModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == productModel.GetLoadedFromStorage() {
+		//update operation
+		err = p.Update(productModel)
+	} else {
+		//insert operation
+		err = p.Insert(productModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (p *Product) WithTx(tx *sql.Tx) DataMapper {
+	return &Product{
+		txScope: p.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (p *Product) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (p *Product) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/go-errors/errors"
-	_ "github.com/mattn/go-sqlite3"
 
 	"ijah-inventory/repository/inventory/domain/inventory/model"
 )
@@ -18,296 +17,209 @@ const dateFormat = "2006-01-02"
 
 //Purchase is a struct of datamapper for purchase domain model
 type Purchase struct {
-	db *sql.DB
+	txScope
 }
 
 //NewPurchase creates a new Purchase datamapper and returns a pointer to it
-func NewPurchase(dbSession *sql.DB) *Purchase {
+func NewPurchase(dbSession *sql.DB, dialect Dialect) *Purchase {
 	return &Purchase{
-		db: dbSession,
+		txScope: newTxScope(dbSession, dialect),
 	}
 }
 
+//purchaseSelect is the query selecting purchases along with their items (one row per item, a purchase without items has a single row of null item columns)
+//rows of a purchase are adjacent (ordered by purchase id) so they can be streamed into models, see loadRows
+const purchaseSelect = "SELECT p.PURCHASE_ID, p.SUPPLIER_ID, DATETIME(p.PURCHASE_DATE), p.STATUS, p.NOTE, i.ID, i.SKU, i.QUANTITY, i.RECEIVED_QUANTITY, i.BUY_PRICE, i.NOTE FROM purchase p LEFT JOIN purchase_items i ON i.PURCHASE_ID = p.PURCHASE_ID"
+
+//purchaseOrder is the order of the rows of purchaseSelect
+const purchaseOrder = " ORDER BY p.PURCHASE_ID ASC, i.ID ASC"
+
 //FindByID is a function for finding a record by id
 func (p *Purchase) FindByID(id string) (model.Model, *errors.Error) {
-	stmt, err := p.db.Prepare("SELECT PURCHASE_ID, DATETIME(PURCHASE_DATE), STATUS, NOTE FROM purchase WHERE PURCHASE_ID = ?")
+	stmt, err := p.conn().Prepare(purchaseSelect + " WHERE p.PURCHASE_ID = ?" + purchaseOrder)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
 	defer stmt.Close()
 
-	var purchaseID, date, status, note sql.NullString
-
-	row := stmt.QueryRow(id)
-	err = row.Scan(&purchaseID, &date, &status, &note)
-	if err != nil {
-		var returnedErr error
-		if err == sql.ErrNoRows {
-			returnedErr = ErrNotFound
-		} else {
-			returnedErr = err
-		}
-		return nil, errors.Wrap(returnedErr, 0)
-	}
-	//how to check for no rows on returned error (on the service layer):
-	//if err.Err != sql.ErrNoRows
-
-	purchaseIDValue := purchaseID.String
-	dateValue := date.String
-	dateTimeValue, err := time.Parse(timeFormat, dateValue)
-
-	statusValue := status.String
-	noteValue := note.String
-
-	purchaseModel := &model.Purchase{
-		PurchaseID: purchaseIDValue,
-		Date:       dateTimeValue,
-		Status:     statusValue,
-		Note:       noteValue,
-	}
-	purchaseModel.SetLoadedFromStorage(true)
-
-	//load purchase items
-	itemStmt, err := p.db.Prepare("SELECT ID, SKU, QUANTITY, BUY_PRICE, NOTE FROM purchase_items WHERE PURCHASE_ID = ?")
+	rows, err := stmt.Query(id)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
-	defer itemStmt.Close()
-
-	var itemID int64
-	var sku, itemNote sql.NullString
-	var quantity sql.NullInt64
-	var buyPrice sql.NullFloat64
-
-	rows, err := itemStmt.Query(id)
-	if err != nil {
-		return nil, errors.Wrap(err, 0)
+	returnedRow, errs := p.loadRows(rows)
+	if errs != nil {
+		return nil, errs
 	}
-	defer rows.Close()
-
-	itemsRow := make(map[string]*model.PurchaseItem, 5)
-	for rows.Next() {
-		err := rows.Scan(&itemID, &sku, &quantity, &buyPrice, &itemNote)
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-		skuValue := sku.String
-		quantityValue := quantity.Int64
-		buyPriceValue := buyPrice.Float64
-		itemNoteValue := itemNote.String
-
-		purchaseItemModel := &model.PurchaseItem{
-			Sku:      skuValue,
-			Quantity: quantityValue,
-			BuyPrice: buyPriceValue,
-			Note:     itemNoteValue,
-		}
-		purchaseItemModel.SetID(itemID)
-		purchaseItemModel.SetLoadedFromStorage(true)
-
-		itemsRow[skuValue] = purchaseItemModel
+	if len(returnedRow) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
 	}
-	purchaseModel.Items = itemsRow
-
-	return purchaseModel, nil
+	return returnedRow[0], nil
 }
 
 //FindAll is a function for finding all records
 func (p *Purchase) FindAll() ([]model.Model, *errors.Error) {
-	rows, err := p.db.Query("SELECT PURCHASE_ID, DATETIME(PURCHASE_DATE), STATUS, NOTE FROM purchase ORDER BY PURCHASE_ID ASC")
+	rows, err := p.conn().Query(purchaseSelect + purchaseOrder)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
-	defer rows.Close()
+	return p.loadRows(rows)
+}
 
-	var purchaseID, date, status, note sql.NullString
+//loadRows is a function for composing purchase models (along with their items) from the given rows of purchaseSelect
+//the rows are read in a single pass and closed afterwards, so no other query runs while they're open
+func (p *Purchase) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
 
-	var itemID int64
+	var purchaseID, supplierID, date, status, note sql.NullString
+	var itemID sql.NullInt64
 	var sku, itemNote sql.NullString
-	var quantity sql.NullInt64
-	var buyPrice sql.NullFloat64
+	var quantity, receivedQuantity sql.NullInt64
+	var buyPrice sql.NullInt64
 
 	var returnedRow []model.Model
-	var firstScan = true
+	var purchaseModel *model.Purchase
 	for rows.Next() {
-		err := rows.Scan(&purchaseID, &date, &status, &note)
-		firstScan = false
-		if err != nil {
-			var returnedErr error
-			if firstScan && err == sql.ErrNoRows {
-				returnedErr = ErrNotFound
-			} else {
-				returnedErr = err
-			}
-			return nil, errors.Wrap(returnedErr, 0)
-		}
-		purchaseIDValue := purchaseID.String
-		dateValue := date.String
-		dateTimeValue, err := time.Parse(timeFormat, dateValue)
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-
-		statusValue := status.String
-		noteValue := note.String
-
-		purchaseModel := &model.Purchase{
-			PurchaseID: purchaseIDValue,
-			Date:       dateTimeValue,
-			Status:     statusValue,
-			Note:       noteValue,
-		}
-		purchaseModel.SetLoadedFromStorage(true)
-
-		//load purchase items
-		itemStmt, err := p.db.Prepare("SELECT ID, SKU, QUANTITY, BUY_PRICE, NOTE FROM purchase_items WHERE PURCHASE_ID = ?")
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-		defer itemStmt.Close()
-
-		itemRows, err := itemStmt.Query(purchaseIDValue)
+		err := rows.Scan(&purchaseID, &supplierID, &date, &status, &note, &itemID, &sku, &quantity, &receivedQuantity, &buyPrice, &itemNote)
 		if err != nil {
 			return nil, errors.Wrap(err, 0)
 		}
-		defer itemRows.Close()
-
-		itemsRow := make(map[string]*model.PurchaseItem, 5)
-		for itemRows.Next() {
-			err := itemRows.Scan(&itemID, &sku, &quantity, &buyPrice, &itemNote)
+		//first row of a purchase
+		if purchaseModel == nil || purchaseModel.PurchaseID != purchaseID.String {
+			dateTimeValue, err := time.Parse(timeFormat, date.String)
 			if err != nil {
 				return nil, errors.Wrap(err, 0)
 			}
-			skuValue := sku.String
-			quantityValue := quantity.Int64
-			buyPriceValue := buyPrice.Float64
-			itemNoteValue := itemNote.String
-
-			purchaseItemModel := &model.PurchaseItem{
-				Sku:      skuValue,
-				Quantity: quantityValue,
-				BuyPrice: buyPriceValue,
-				Note:     itemNoteValue,
+			purchaseModel = &model.Purchase{
+				PurchaseID: purchaseID.String,
+				SupplierID: supplierID.String,
+				Date:       dateTimeValue,
+				Status:     status.String,
+				Note:       note.String,
+				Items:      make(map[string]*model.PurchaseItem, 5),
 			}
-			purchaseItemModel.SetID(itemID)
-			purchaseItemModel.SetLoadedFromStorage(true)
+			purchaseModel.SetLoadedFromStorage(true)
+			returnedRow = append(returnedRow, purchaseModel)
+		}
+		//purchase without items
+		if false == itemID.Valid {
+			continue
+		}
 
-			itemsRow[skuValue] = purchaseItemModel
+		purchaseItemModel := &model.PurchaseItem{
+			Sku:              sku.String,
+			Quantity:         quantity.Int64,
+			ReceivedQuantity: receivedQuantity.Int64,
+			BuyPrice:         model.Money(buyPrice.Int64),
+			Note:             itemNote.String,
 		}
-		purchaseModel.Items = itemsRow
+		purchaseItemModel.SetID(itemID.Int64)
+		purchaseItemModel.SetLoadedFromStorage(true)
 
-		returnedRow = append(returnedRow, purchaseModel)
+		purchaseModel.Items[sku.String] = purchaseItemModel
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
 	}
 	return returnedRow, nil
 }
 
 //Insert is a function for inserting a record
 func (p *Purchase) Insert(purchaseModel model.Model) *errors.Error {
+	return p.inTx(func(tx *sql.Tx) *errors.Error {
+		return p.InsertWithTx(purchaseModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (p *Purchase) InsertWithTx(purchaseModel model.Model, tx *sql.Tx) *errors.Error {
 	purchaseModelObj, ok := purchaseModel.(*model.Purchase)
 	if false == ok {
 		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Purchase"), 0)
 	}
 
-	foundModel, _ := p.FindByID(purchaseModel.GetID())
+	foundModel, _ := p.WithTx(tx).FindByID(purchaseModel.GetID())
 	if foundModel != nil {
 		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", purchaseModel.GetID()), 0)
 	}
 
-	//start transaction
-	tx, err := p.db.Begin()
-	if err != nil {
-		return errors.Wrap(err, 0)
-	}
-	stmt, err := tx.Prepare("INSERT INTO purchase(PURCHASE_ID, PURCHASE_DATE, STATUS, NOTE) values(?,?,?,?)")
+	stmt, err := p.on(tx).Prepare("INSERT INTO purchase(PURCHASE_ID, SUPPLIER_ID, PURCHASE_DATE, STATUS, NOTE) values(?,?,?,?,?)")
 	if err != nil {
-		tx.Rollback()
 		return errors.Wrap(err, 0)
 	}
 	defer stmt.Close()
 	dateString := purchaseModelObj.Date.Format(timeFormat)
-	_, err = stmt.Exec(purchaseModelObj.PurchaseID, dateString, purchaseModelObj.Status, purchaseModelObj.Note)
+	_, err = stmt.Exec(purchaseModelObj.PurchaseID, nullText(purchaseModelObj.SupplierID), dateString, purchaseModelObj.Status, purchaseModelObj.Note)
 	if err != nil {
-		tx.Rollback()
 		return errors.Wrap(err, 0)
 	}
 
 	//insert the items
+	itemStmt, err := p.on(tx).Prepare("INSERT INTO purchase_items(PURCHASE_ID, SKU, QUANTITY, RECEIVED_QUANTITY, BUY_PRICE, NOTE) values(?,?,?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
 	for _, val := range purchaseModelObj.Items {
-		itemStmt, err := p.db.Prepare("INSERT INTO purchase_items(PURCHASE_ID, SKU, QUANTITY, BUY_PRICE, NOTE) values(?,?,?,?,?)")
-		if err != nil {
-			tx.Rollback()
-			return errors.Wrap(err, 0)
-		}
-		defer itemStmt.Close()
-		_, err = itemStmt.Exec(purchaseModelObj.PurchaseID, val.Sku, val.Quantity, val.BuyPrice, val.Note)
+		_, err = itemStmt.Exec(purchaseModelObj.PurchaseID, val.Sku, val.Quantity, val.ReceivedQuantity, val.BuyPrice, val.Note)
 		if err != nil {
-			tx.Rollback()
 			return errors.Wrap(err, 0)
 		}
 	}
-	tx.Commit()
 	return nil
 }
 
 //Update is a function for updating record
 func (p *Purchase) Update(purchaseModel model.Model) *errors.Error {
+	return p.inTx(func(tx *sql.Tx) *errors.Error {
+		return p.UpdateWithTx(purchaseModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+func (p *Purchase) UpdateWithTx(purchaseModel model.Model, tx *sql.Tx) *errors.Error {
 	purchaseModelObj, ok := purchaseModel.(*model.Purchase)
 	if false == ok {
 		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Purchase"), 0)
 	}
 
-	_, errs := p.FindByID(purchaseModel.GetID())
-	if errs != nil && errs.Err == sql.ErrNoRows {
+	_, errs := p.WithTx(tx).FindByID(purchaseModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
 		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", purchaseModel.GetID()), 0)
 	}
 
-	//start transaction
-	tx, err := p.db.Begin()
+	stmt, err := p.on(tx).Prepare("UPDATE purchase SET SUPPLIER_ID=?, PURCHASE_DATE=?, STATUS=?, NOTE=? WHERE PURCHASE_ID=?")
 	if err != nil {
 		return errors.Wrap(err, 0)
 	}
-	stmt, err := tx.Prepare("UPDATE purchase SET DATE=?, STATUS=?, NOTE=? WHERE PURCHASE_ID=?")
+	defer stmt.Close()
+	dateString := purchaseModelObj.Date.Format(timeFormat)
+	_, err = stmt.Exec(nullText(purchaseModelObj.SupplierID), dateString, purchaseModelObj.Status, purchaseModelObj.Note, purchaseModelObj.PurchaseID)
 	if err != nil {
-		tx.Rollback()
 		return errors.Wrap(err, 0)
 	}
-	dateString := purchaseModelObj.Date.Format(timeFormat)
-	_, err = stmt.Exec(dateString, purchaseModelObj.Status, purchaseModelObj.Note, purchaseModelObj.PurchaseID)
+
+	//update items, the statements are prepared once for all items
+	insertStmt, err := p.on(tx).Prepare("INSERT INTO purchase_items(PURCHASE_ID, SKU, QUANTITY, RECEIVED_QUANTITY, BUY_PRICE, NOTE) values(?,?,?,?,?,?)")
 	if err != nil {
-		tx.Rollback()
 		return errors.Wrap(err, 0)
 	}
-
-	//update items
+	defer insertStmt.Close()
+	updateStmt, err := p.on(tx).Prepare("UPDATE purchase_items SET QUANTITY=?, RECEIVED_QUANTITY=?, BUY_PRICE=?, NOTE=? WHERE ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer updateStmt.Close()
 	for _, val := range purchaseModelObj.Items {
-		var itemStmt *sql.Stmt
 		if false == val.GetLoadedFromStorage() {
-			itemStmt, err = tx.Prepare("INSERT INTO purchase_items(PURCHASE_ID, SKU, QUANTITY, BUY_PRICE, NOTE) values(?,?,?,?,?)")
-			if err != nil {
-				tx.Rollback()
-				return errors.Wrap(err, 0)
-			}
-			defer itemStmt.Close()
-			_, err = itemStmt.Exec(purchaseModelObj.PurchaseID, val.Sku, val.Quantity, val.BuyPrice, val.Note)
-			if err != nil {
-				tx.Rollback()
-				return errors.Wrap(err, 0)
-			}
+			_, err = insertStmt.Exec(purchaseModelObj.PurchaseID, val.Sku, val.Quantity, val.ReceivedQuantity, val.BuyPrice, val.Note)
 		} else {
-			itemStmt, err = tx.Prepare("UPDATE purchase_items SET QUANTITY=?, BUY_PRICE=?, NOTE=? WHERE ID=?")
-			if err != nil {
-				tx.Rollback()
-				return errors.Wrap(err, 0)
-			}
-			defer itemStmt.Close()
-			_, err = itemStmt.Exec(val.Quantity, val.BuyPrice, val.Note, val.GetID())
-			if err != nil {
-				tx.Rollback()
-				return errors.Wrap(err, 0)
-			}
+			_, err = updateStmt.Exec(val.Quantity, val.ReceivedQuantity, val.BuyPrice, val.Note, val.GetID())
+		}
+		if err != nil {
+			return errors.Wrap(err, 0)
 		}
 	}
-	tx.Commit()
 	return nil
 }
 
@@ -319,43 +231,34 @@ func (p *Purchase) Delete(purchaseModel model.Model) *errors.Error {
 	}
 
 	_, errs := p.FindByID(purchaseModel.GetID())
-	if errs.Err == sql.ErrNoRows {
+	if errs != nil && errs.Err == ErrNotFound {
 		return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", purchaseModel.GetID()), 0)
 	}
 
-	//start transaction
-	tx, err := p.db.Begin()
-	if err != nil {
-		return errors.Wrap(err, 0)
-	}
-
-	//delete items
-	itemStmt, err := tx.Prepare("DELETE FROM purchase_items WHERE PURCHASE_ID=?")
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
-	defer itemStmt.Close()
-	_, err = itemStmt.Exec(purchaseModelObj.PurchaseID)
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
+	return p.inTx(func(tx *sql.Tx) *errors.Error {
+		//delete items
+		itemStmt, err := p.on(tx).Prepare("DELETE FROM purchase_items WHERE PURCHASE_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer itemStmt.Close()
+		_, err = itemStmt.Exec(purchaseModelObj.PurchaseID)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
 
-	//delete the model
-	stmt, err := tx.Prepare("DELETE FROM purchase WHERE PURCHASE_ID=?")
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
-	defer stmt.Close()
-	_, err = stmt.Exec(purchaseModelObj.PurchaseID)
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
-	tx.Commit()
-	return nil
+		//delete the model
+		stmt, err := p.on(tx).Prepare("DELETE FROM purchase WHERE PURCHASE_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer stmt.Close()
+		_, err = stmt.Exec(purchaseModelObj.PurchaseID)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return nil
+	})
 }
 
 //Save is a function for persisting a model object to db
@@ -371,6 +274,13 @@ func (p *Purchase) Save(purchaseModel model.Model) *errors.Error {
 	return err
 }
 
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (p *Purchase) WithTx(tx *sql.Tx) DataMapper {
+	return &Purchase{
+		txScope: p.bind(tx),
+	}
+}
+
 //StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
 func (p *Purchase) StartUp() {
 	//Note: Perform any initialization or bootstrapping here
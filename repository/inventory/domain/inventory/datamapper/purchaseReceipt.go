@@ -0,0 +1,253 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//PurchaseReceipt is a struct of datamapper for purchase receipt domain model
+type PurchaseReceipt struct {
+	txScope
+}
+
+//NewPurchaseReceipt creates a new PurchaseReceipt datamapper and returns a pointer to it
+func NewPurchaseReceipt(dbSession *sql.DB, dialect Dialect) *PurchaseReceipt {
+	return &PurchaseReceipt{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (pr *PurchaseReceipt) FindByID(id string) (model.Model, *errors.Error) {
+	stmt, err := pr.conn().Prepare("SELECT RECEIPT_ID, PURCHASE_ID, DATETIME(RECEIPT_DATE), NOTE FROM purchase_receipts WHERE RECEIPT_ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	var receiptID, purchaseID, date, note sql.NullString
+
+	row := stmt.QueryRow(id)
+	err = row.Scan(&receiptID, &purchaseID, &date, &note)
+	if err != nil {
+		var returnedErr error
+		if err == sql.ErrNoRows {
+			returnedErr = ErrNotFound
+		} else {
+			returnedErr = err
+		}
+		return nil, errors.Wrap(returnedErr, 0)
+	}
+	dateTimeValue, err := time.Parse(timeFormat, date.String)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	receiptModel := &model.PurchaseReceipt{
+		ReceiptID:  receiptID.String,
+		PurchaseID: purchaseID.String,
+		Date:       dateTimeValue,
+		Note:       note.String,
+	}
+	receiptModel.SetLoadedFromStorage(true)
+
+	items, errs := pr.findItems(receiptModel.ReceiptID)
+	if errs != nil {
+		return nil, errs
+	}
+	receiptModel.Items = items
+
+	return receiptModel, nil
+}
+
+//FindAll is a function for finding all records
+func (pr *PurchaseReceipt) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := pr.conn().Query("SELECT RECEIPT_ID, PURCHASE_ID, DATETIME(RECEIPT_DATE), NOTE FROM purchase_receipts ORDER BY RECEIPT_DATE ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return pr.loadRows(rows)
+}
+
+//FindByPurchaseID is a function for finding all receipts of a purchase (ordered by receipt date)
+func (pr *PurchaseReceipt) FindByPurchaseID(purchaseID string) ([]model.Model, *errors.Error) {
+	stmt, err := pr.conn().Prepare("SELECT RECEIPT_ID, PURCHASE_ID, DATETIME(RECEIPT_DATE), NOTE FROM purchase_receipts WHERE PURCHASE_ID = ? ORDER BY RECEIPT_DATE ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	rows, err := stmt.Query(purchaseID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return pr.loadRows(rows)
+}
+
+//loadRows is a function for composing receipt models (along with their items) from the given receipt rows
+func (pr *PurchaseReceipt) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	var receiptID, purchaseID, date, note sql.NullString
+
+	var receipts []*model.PurchaseReceipt
+	for rows.Next() {
+		err := rows.Scan(&receiptID, &purchaseID, &date, &note)
+		if err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, 0)
+		}
+		dateTimeValue, err := time.Parse(timeFormat, date.String)
+		if err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, 0)
+		}
+		receiptModel := &model.PurchaseReceipt{
+			ReceiptID:  receiptID.String,
+			PurchaseID: purchaseID.String,
+			Date:       dateTimeValue,
+			Note:       note.String,
+		}
+		receiptModel.SetLoadedFromStorage(true)
+		receipts = append(receipts, receiptModel)
+	}
+	//close the receipt rows before loading the items
+	rows.Close()
+
+	var returnedRow []model.Model
+	for _, receiptModel := range receipts {
+		items, errs := pr.findItems(receiptModel.ReceiptID)
+		if errs != nil {
+			return nil, errs
+		}
+		receiptModel.Items = items
+		returnedRow = append(returnedRow, receiptModel)
+	}
+	return returnedRow, nil
+}
+
+//findItems is a function for finding the items of a receipt
+func (pr *PurchaseReceipt) findItems(receiptID string) (map[string]*model.PurchaseReceiptItem, *errors.Error) {
+	itemStmt, err := pr.conn().Prepare("SELECT ID, SKU, QUANTITY FROM purchase_receipt_items WHERE RECEIPT_ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
+
+	rows, err := itemStmt.Query(receiptID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer rows.Close()
+
+	var itemID int64
+	var sku sql.NullString
+	var quantity sql.NullInt64
+
+	itemsRow := make(map[string]*model.PurchaseReceiptItem, 5)
+	for rows.Next() {
+		err := rows.Scan(&itemID, &sku, &quantity)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		receiptItemModel := &model.PurchaseReceiptItem{
+			Sku:      sku.String,
+			Quantity: quantity.Int64,
+		}
+		receiptItemModel.SetID(itemID)
+		receiptItemModel.SetLoadedFromStorage(true)
+
+		itemsRow[receiptItemModel.Sku] = receiptItemModel
+	}
+	return itemsRow, nil
+}
+
+//Insert is a function for inserting a record
+func (pr *PurchaseReceipt) Insert(receiptModel model.Model) *errors.Error {
+	return pr.inTx(func(tx *sql.Tx) *errors.Error {
+		return pr.InsertWithTx(receiptModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (pr *PurchaseReceipt) InsertWithTx(receiptModel model.Model, tx *sql.Tx) *errors.Error {
+	receiptModelObj, ok := receiptModel.(*model.PurchaseReceipt)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.PurchaseReceipt"), 0)
+	}
+
+	foundModel, _ := pr.WithTx(tx).FindByID(receiptModel.GetID())
+	if foundModel != nil {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", receiptModel.GetID()), 0)
+	}
+
+	stmt, err := pr.on(tx).Prepare("INSERT INTO purchase_receipts(RECEIPT_ID, PURCHASE_ID, RECEIPT_DATE, NOTE) values(?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	dateString := receiptModelObj.Date.Format(timeFormat)
+	_, err = stmt.Exec(receiptModelObj.ReceiptID, receiptModelObj.PurchaseID, dateString, receiptModelObj.Note)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	//insert the items
+	itemStmt, err := pr.on(tx).Prepare("INSERT INTO purchase_receipt_items(RECEIPT_ID, SKU, QUANTITY) values(?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
+	for _, val := range receiptModelObj.Items {
+		_, err = itemStmt.Exec(receiptModelObj.ReceiptID, val.Sku, val.Quantity)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+	}
+	return nil
+}
+
+//Update is a function for updating record
+//Note: a receipt records a delivery that already happened (and already affected stock), so it can't be updated
+func (pr *PurchaseReceipt) Update(receiptModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot update, purchase receipt %v is immutable", receiptModel.GetID()), 0)
+}
+
+//Delete is a function for deleting record
+//Note: a receipt records a delivery that already happened (and already affected stock), so it can't be deleted
+func (pr *PurchaseReceipt) Delete(receiptModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, purchase receipt %v is immutable", receiptModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to db
+func (pr *PurchaseReceipt) Save(receiptModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == receiptModel.GetLoadedFromStorage() {
+		//update operation
+		err = pr.Update(receiptModel)
+	} else {
+		//insert operation
+		err = pr.Insert(receiptModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (pr *PurchaseReceipt) WithTx(tx *sql.Tx) DataMapper {
+	return &PurchaseReceipt{
+		txScope: pr.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (pr *PurchaseReceipt) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (pr *PurchaseReceipt) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -7,399 +7,274 @@ import (
 	"time"
 
 	"github.com/go-errors/errors"
-	_ "github.com/mattn/go-sqlite3"
 
 	"ijah-inventory/repository/inventory/domain/inventory/model"
 )
 
 //Sale is a struct of datamapper for purchase domain model
 type Sale struct {
-	db *sql.DB
+	txScope
 }
 
 //NewSale creates a new Purchase datamapper and returns a pointer to it
-func NewSale(dbSession *sql.DB) *Sale {
+func NewSale(dbSession *sql.DB, dialect Dialect) *Sale {
 	return &Sale{
-		db: dbSession,
+		txScope: newTxScope(dbSession, dialect),
 	}
 }
 
+//saleSelect is the query selecting sales along with their items (one row per item, a sale without items has a single row of null item columns)
+//rows of a sale must be adjacent (e.g. ordered by invoice id) so they can be streamed into models, see loadRows
+const saleSelect = "SELECT s.INVOICE_ID, DATETIME(s.SALE_DATE), s.STATUS, s.NOTE, i.ID, i.SKU, i.QUANTITY, i.BUY_PRICE, i.SELL_PRICE, i.COST FROM sales s LEFT JOIN sales_items i ON i.INVOICE_ID = s.INVOICE_ID"
+
+//saleOrder is the order of the rows of saleSelect
+const saleOrder = " ORDER BY s.INVOICE_ID ASC, i.ID ASC"
+
 //FindByID is a function for finding a record by id
 func (s *Sale) FindByID(id string) (model.Model, *errors.Error) {
-	stmt, err := s.db.Prepare("SELECT INVOICE_ID, DATETIME(SALE_DATE), STATUS, NOTE FROM sales WHERE INVOICE_ID = ?")
+	stmt, err := s.conn().Prepare(saleSelect + " WHERE s.INVOICE_ID = ?" + saleOrder)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
 	defer stmt.Close()
 
-	var invoiceID, date, status, note sql.NullString
-
-	row := stmt.QueryRow(id)
-	err = row.Scan(&invoiceID, &date, &status, &note)
-	if err != nil {
-		var returnedErr error
-		if err == sql.ErrNoRows {
-			returnedErr = ErrNotFound
-		} else {
-			returnedErr = err
-		}
-		return nil, errors.Wrap(returnedErr, 0)
-	}
-	//how to check for no rows on returned error (on the service layer):
-	//if err.Err != sql.ErrNoRows
-
-	invoiceIDValue := invoiceID.String
-	dateValue := date.String
-	dateTimeValue, err := time.Parse(timeFormat, dateValue)
-
-	statusValue := status.String
-	noteValue := note.String
-
-	salesModel := &model.Sales{
-		InvoiceID: invoiceIDValue,
-		Date:      dateTimeValue,
-		Status:    statusValue,
-		Note:      noteValue,
-	}
-	salesModel.SetLoadedFromStorage(true)
-
-	//load purchase items
-	itemStmt, err := s.db.Prepare("SELECT ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE FROM sales_items WHERE INVOICE_ID = ?")
+	rows, err := stmt.Query(id)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
-	defer itemStmt.Close()
-
-	var itemID int64
-	var sku sql.NullString
-	var quantity sql.NullInt64
-	var buyPrice, sellPrice sql.NullFloat64
-
-	rows, err := itemStmt.Query(id)
-	if err != nil {
-		return nil, errors.Wrap(err, 0)
+	returnedRow, errs := s.loadRows(rows)
+	if errs != nil {
+		return nil, errs
 	}
-	defer rows.Close()
-
-	itemsRow := make(map[string]*model.SaleItem, 5)
-	for rows.Next() {
-		err := rows.Scan(&itemID, &sku, &quantity, &buyPrice, &sellPrice)
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-		skuValue := sku.String
-		quantityValue := quantity.Int64
-		buyPriceValue := buyPrice.Float64
-		sellPriceValue := sellPrice.Float64
-
-		saleItemModel := &model.SaleItem{
-			Sku:       skuValue,
-			Quantity:  quantityValue,
-			BuyPrice:  buyPriceValue,
-			SellPrice: sellPriceValue,
-		}
-		saleItemModel.SetID(itemID)
-		saleItemModel.SetLoadedFromStorage(true)
-
-		itemsRow[skuValue] = saleItemModel
+	if len(returnedRow) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
 	}
-	salesModel.Items = itemsRow
-
-	return salesModel, nil
+	return returnedRow[0], nil
 }
 
 //FindAll is a function for finding all records
 func (s *Sale) FindAll() ([]model.Model, *errors.Error) {
-	rows, err := s.db.Query("SELECT INVOICE_ID, DATETIME(SALE_DATE), STATUS, NOTE FROM sales ORDER BY INVOICE_ID ASC")
+	rows, err := s.conn().Query(saleSelect + saleOrder)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
-	defer rows.Close()
-
-	var invoiceID, date, status, note sql.NullString
-
-	var itemID int64
-	var sku sql.NullString
-	var quantity sql.NullInt64
-	var buyPrice, sellPrice sql.NullFloat64
-
-	var returnedRow []model.Model
-	var firstScan = true
-	for rows.Next() {
-		err := rows.Scan(&invoiceID, &date, &status, &note)
-		firstScan = false
-		if err != nil {
-			var returnedErr error
-			if firstScan && err == sql.ErrNoRows {
-				returnedErr = ErrNotFound
-			} else {
-				returnedErr = err
-			}
-			return nil, errors.Wrap(returnedErr, 0)
-		}
-		invoiceIDValue := invoiceID.String
-		dateValue := date.String
-		dateTimeValue, err := time.Parse(timeFormat, dateValue)
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-
-		statusValue := status.String
-		noteValue := note.String
-
-		salesModel := &model.Sales{
-			InvoiceID: invoiceIDValue,
-			Date:      dateTimeValue,
-			Status:    statusValue,
-			Note:      noteValue,
-		}
-		salesModel.SetLoadedFromStorage(true)
-
-		//load purchase items
-		itemStmt, err := s.db.Prepare("SELECT ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE FROM sales_items WHERE INVOICE_ID = ?")
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-		defer itemStmt.Close()
-
-		itemRows, err := itemStmt.Query(invoiceIDValue)
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-		defer itemRows.Close()
-
-		itemsRow := make(map[string]*model.SaleItem, 5)
-		for itemRows.Next() {
-			err := itemRows.Scan(&itemID, &sku, &quantity, &buyPrice, &sellPrice)
-			if err != nil {
-				return nil, errors.Wrap(err, 0)
-			}
-			skuValue := sku.String
-			quantityValue := quantity.Int64
-			buyPriceValue := buyPrice.Float64
-			sellPriceValue := sellPrice.Float64
-
-			salesItemModel := &model.SaleItem{
-				Sku:       skuValue,
-				Quantity:  quantityValue,
-				BuyPrice:  buyPriceValue,
-				SellPrice: sellPriceValue,
-			}
-			salesItemModel.SetID(itemID)
-			salesItemModel.SetLoadedFromStorage(true)
-
-			itemsRow[skuValue] = salesItemModel
-		}
-		salesModel.Items = itemsRow
-
-		returnedRow = append(returnedRow, salesModel)
-	}
-	return returnedRow, nil
+	return s.loadRows(rows)
 }
 
 //FindByDoneStatusAndDateRange is a function for finding success/done sale record based on date range
-//TODO: Refactor this
 func (s *Sale) FindByDoneStatusAndDateRange(startDate, endDate time.Time) ([]model.Model, *errors.Error) {
 	startDateString := startDate.Format(dateFormat)
 	endDateString := endDate.Format(dateFormat)
 
-	stmt, err := s.db.Prepare("SELECT INVOICE_ID, DATETIME(SALE_DATE), STATUS, NOTE FROM sales WHERE STATUS='S' AND SALE_DATE BETWEEN ? AND ? ORDER BY INVOICE_ID ASC")
+	stmt, err := s.conn().Prepare(saleSelect + " WHERE s.STATUS='S' AND s.SALE_DATE BETWEEN ? AND ?" + saleOrder)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
+	defer stmt.Close()
+
 	rows, err := stmt.Query(startDateString, endDateString)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
+	return s.loadRows(rows)
+}
+
+//saleFields are the fields sales can be filtered and sorted by (see Criteria)
+//the date is compared as formatted by DATETIME (older records hold fractional seconds), the same way its values are loaded, so pages split exactly on the cursor
+var saleFields = map[string]criteriaField{
+	"invoiceId": {column: "s.INVOICE_ID", kind: fieldText, value: func(record model.Model) interface{} {
+		return record.(*model.Sales).InvoiceID
+	}},
+	"date": {column: "DATETIME(s.SALE_DATE)", kind: fieldTime, value: func(record model.Model) interface{} {
+		return record.(*model.Sales).Date
+	}},
+	"status": {column: "s.STATUS", kind: fieldText, value: func(record model.Model) interface{} {
+		return record.(*model.Sales).Status
+	}},
+}
+
+//saleKeyField is the field identifying a sale (see saleFields)
+const saleKeyField = "invoiceId"
+
+//FindByCriteria is a function for finding a page of the records matching a criteria (see saleFields for the fields)
+//the sales of the page are selected first, then loaded along with their items
+func (s *Sale) FindByCriteria(criteria Criteria) (*Page, *errors.Error) {
+	resolved, errs := criteria.resolve(saleFields, saleKeyField)
+	if errs != nil {
+		return nil, errs
+	}
+	where, args := resolved.sqlWhere()
+	order := resolved.sqlOrder()
+	stmt, err := s.conn().Prepare(saleSelect + " WHERE s.INVOICE_ID IN (SELECT s.INVOICE_ID FROM sales s" + where + order + " LIMIT ?)" + order + ", i.ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(append(args, resolved.limit+1)...)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	records, errs := s.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	return resolved.page(records), nil
+}
+
+//loadRows is a function for composing sale models (along with their items) from the given rows of saleSelect
+//the rows are read in a single pass and closed afterwards, so no other query runs while they're open
+func (s *Sale) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
 	defer rows.Close()
 
 	var invoiceID, date, status, note sql.NullString
-
-	var itemID int64
+	var itemID sql.NullInt64
 	var sku sql.NullString
 	var quantity sql.NullInt64
-	var buyPrice, sellPrice sql.NullFloat64
+	var buyPrice, sellPrice, cost sql.NullInt64
 
 	var returnedRow []model.Model
-	var firstScan = true
+	var salesModel *model.Sales
 	for rows.Next() {
-		err := rows.Scan(&invoiceID, &date, &status, &note)
-		firstScan = false
-		if err != nil {
-			var returnedErr error
-			if firstScan && err == sql.ErrNoRows {
-				returnedErr = ErrNotFound
-			} else {
-				returnedErr = err
-			}
-			return nil, errors.Wrap(returnedErr, 0)
-		}
-		invoiceIDValue := invoiceID.String
-		dateValue := date.String
-		dateTimeValue, err := time.Parse(timeFormat, dateValue)
+		err := rows.Scan(&invoiceID, &date, &status, &note, &itemID, &sku, &quantity, &buyPrice, &sellPrice, &cost)
 		if err != nil {
 			return nil, errors.Wrap(err, 0)
 		}
-
-		statusValue := status.String
-		noteValue := note.String
-
-		salesModel := &model.Sales{
-			InvoiceID: invoiceIDValue,
-			Date:      dateTimeValue,
-			Status:    statusValue,
-			Note:      noteValue,
-		}
-		salesModel.SetLoadedFromStorage(true)
-
-		//load purchase items
-		itemStmt, err := s.db.Prepare("SELECT ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE FROM sales_items WHERE INVOICE_ID = ?")
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-		defer itemStmt.Close()
-
-		itemRows, err := itemStmt.Query(invoiceIDValue)
-		if err != nil {
-			return nil, errors.Wrap(err, 0)
-		}
-		defer itemRows.Close()
-
-		itemsRow := make(map[string]*model.SaleItem, 5)
-		for itemRows.Next() {
-			err := itemRows.Scan(&itemID, &sku, &quantity, &buyPrice, &sellPrice)
+		//first row of a sale
+		if salesModel == nil || salesModel.InvoiceID != invoiceID.String {
+			dateTimeValue, err := time.Parse(timeFormat, date.String)
 			if err != nil {
 				return nil, errors.Wrap(err, 0)
 			}
-			skuValue := sku.String
-			quantityValue := quantity.Int64
-			buyPriceValue := buyPrice.Float64
-			sellPriceValue := sellPrice.Float64
-
-			salesItemModel := &model.SaleItem{
-				Sku:       skuValue,
-				Quantity:  quantityValue,
-				BuyPrice:  buyPriceValue,
-				SellPrice: sellPriceValue,
+			salesModel = &model.Sales{
+				InvoiceID: invoiceID.String,
+				Date:      dateTimeValue,
+				Status:    status.String,
+				Note:      note.String,
+				Items:     make(map[string]*model.SaleItem, 5),
 			}
-			salesItemModel.SetID(itemID)
-			salesItemModel.SetLoadedFromStorage(true)
+			salesModel.SetLoadedFromStorage(true)
+			returnedRow = append(returnedRow, salesModel)
+		}
+		//sale without items
+		if false == itemID.Valid {
+			continue
+		}
 
-			itemsRow[skuValue] = salesItemModel
+		salesItemModel := &model.SaleItem{
+			Sku:       sku.String,
+			Quantity:  quantity.Int64,
+			BuyPrice:  model.Money(buyPrice.Int64),
+			SellPrice: model.Money(sellPrice.Int64),
+			Cost:      model.Money(cost.Int64),
 		}
-		salesModel.Items = itemsRow
+		salesItemModel.SetID(itemID.Int64)
+		salesItemModel.SetLoadedFromStorage(true)
 
-		returnedRow = append(returnedRow, salesModel)
+		salesModel.Items[sku.String] = salesItemModel
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
 	}
 	return returnedRow, nil
 }
 
 //Insert is a function for inserting a record
 func (s *Sale) Insert(salesModel model.Model) *errors.Error {
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		return s.InsertWithTx(salesModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (s *Sale) InsertWithTx(salesModel model.Model, tx *sql.Tx) *errors.Error {
 	salesModelObj, ok := salesModel.(*model.Sales)
 	if false == ok {
 		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Sales"), 0)
 	}
 
-	foundModel, _ := s.FindByID(salesModel.GetID())
+	foundModel, _ := s.WithTx(tx).FindByID(salesModel.GetID())
 	if foundModel != nil {
 		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", salesModel.GetID()), 0)
 	}
 
-	//start transaction
-	tx, err := s.db.Begin()
+	stmt, err := s.on(tx).Prepare("INSERT INTO sales(INVOICE_ID, SALE_DATE, STATUS, NOTE) values(?,?,?,?)")
 	if err != nil {
 		return errors.Wrap(err, 0)
 	}
-	stmt, err := tx.Prepare("INSERT INTO sales(INVOICE_ID, SALE_DATE, STATUS, NOTE) values(?,?,?,?)")
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
 	defer stmt.Close()
 
 	dateString := salesModelObj.Date.Format(timeFormat)
 	_, err = stmt.Exec(salesModelObj.InvoiceID, dateString, salesModelObj.Status, salesModelObj.Note)
 	if err != nil {
-		tx.Rollback()
 		return errors.Wrap(err, 0)
 	}
 
 	//insert the items
+	itemStmt, err := s.on(tx).Prepare("INSERT INTO sales_items(INVOICE_ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE, COST) values(?,?,?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
 	for _, val := range salesModelObj.Items {
-		itemStmt, err := tx.Prepare("INSERT INTO sales_items(INVOICE_ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE) values(?,?,?,?,?)")
+		_, err = itemStmt.Exec(salesModelObj.InvoiceID, val.Sku, val.Quantity, val.BuyPrice, val.SellPrice, val.Cost)
 		if err != nil {
-			tx.Rollback()
-			return errors.Wrap(err, 0)
-		}
-		defer itemStmt.Close()
-		_, err = itemStmt.Exec(salesModelObj.InvoiceID, val.Sku, val.Quantity, val.BuyPrice, val.SellPrice)
-		if err != nil {
-			tx.Rollback()
 			return errors.Wrap(err, 0)
 		}
 	}
-	tx.Commit()
 	return nil
 }
 
 //Update is a function for updating record
 func (s *Sale) Update(salesModel model.Model) *errors.Error {
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		return s.UpdateWithTx(salesModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+func (s *Sale) UpdateWithTx(salesModel model.Model, tx *sql.Tx) *errors.Error {
 	salesModelObj, ok := salesModel.(*model.Sales)
 	if false == ok {
 		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Sales"), 0)
 	}
 
-	_, errs := s.FindByID(salesModel.GetID())
-	if errs != nil && errs.Err == sql.ErrNoRows {
+	_, errs := s.WithTx(tx).FindByID(salesModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
 		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", salesModel.GetID()), 0)
 	}
 
-	//start transaction
-	tx, err := s.db.Begin()
-	if err != nil {
-		return errors.Wrap(err, 0)
-	}
-	stmt, err := tx.Prepare("UPDATE sales SET SALE_DATE=?, STATUS=?, NOTE=? WHERE INVOICE_ID=?")
+	stmt, err := s.on(tx).Prepare("UPDATE sales SET SALE_DATE=?, STATUS=?, NOTE=? WHERE INVOICE_ID=?")
 	if err != nil {
-		tx.Rollback()
 		return errors.Wrap(err, 0)
 	}
 	defer stmt.Close()
 	dateString := salesModelObj.Date.Format(timeFormat)
 	_, err = stmt.Exec(dateString, salesModelObj.Status, salesModelObj.Note, salesModelObj.InvoiceID)
 	if err != nil {
-		tx.Rollback()
 		return errors.Wrap(err, 0)
 	}
 
-	//update items
+	//update items, the statements are prepared once for all items
+	insertStmt, err := s.on(tx).Prepare("INSERT INTO sales_items(INVOICE_ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE, COST) values(?,?,?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer insertStmt.Close()
+	updateStmt, err := s.on(tx).Prepare("UPDATE sales_items SET QUANTITY=?, BUY_PRICE=?, SELL_PRICE=?, COST=? WHERE ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer updateStmt.Close()
 	for _, val := range salesModelObj.Items {
-		var itemStmt *sql.Stmt
 		if false == val.GetLoadedFromStorage() {
-			itemStmt, err = tx.Prepare("INSERT INTO sales_items(INVOICE_ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE) values(?,?,?,?,?)")
-			if err != nil {
-				tx.Rollback()
-				return errors.Wrap(err, 0)
-			}
-			defer itemStmt.Close()
-			_, err = itemStmt.Exec(salesModelObj.InvoiceID, val.Sku, val.Quantity, val.BuyPrice, val.SellPrice)
-			if err != nil {
-				tx.Rollback()
-				return errors.Wrap(err, 0)
-			}
+			_, err = insertStmt.Exec(salesModelObj.InvoiceID, val.Sku, val.Quantity, val.BuyPrice, val.SellPrice, val.Cost)
 		} else {
-			itemStmt, err = tx.Prepare("UPDATE sales_items SET QUANTITY=?, BUY_PRICE=?, SELL_PRICE=? WHERE ID=?")
-			if err != nil {
-				tx.Rollback()
-				return errors.Wrap(err, 0)
-			}
-			defer itemStmt.Close()
-			_, err = itemStmt.Exec(val.Quantity, val.BuyPrice, val.SellPrice, val.GetID())
-			if err != nil {
-				tx.Rollback()
-				return errors.Wrap(err, 0)
-			}
+			_, err = updateStmt.Exec(val.Quantity, val.BuyPrice, val.SellPrice, val.Cost, val.GetID())
+		}
+		if err != nil {
+			return errors.Wrap(err, 0)
 		}
 	}
 
@@ -413,43 +288,34 @@ func (s *Sale) Delete(salesModel model.Model) *errors.Error {
 		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Sales"), 0)
 	}
 	_, errs := s.FindByID(salesModel.GetID())
-	if errs.Err == sql.ErrNoRows {
+	if errs != nil && errs.Err == ErrNotFound {
 		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", salesModel.GetID()), 0)
 	}
 
-	//start transaction
-	tx, err := s.db.Begin()
-	if err != nil {
-		return errors.Wrap(err, 0)
-	}
-
-	//delete items
-	itemStmt, err := tx.Prepare("DELETE FROM sales_items WHERE INVOICE_ID=?")
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
-	defer itemStmt.Close()
-	_, err = itemStmt.Exec(salesModelObj.InvoiceID)
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		//delete items
+		itemStmt, err := s.on(tx).Prepare("DELETE FROM sales_items WHERE INVOICE_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer itemStmt.Close()
+		_, err = itemStmt.Exec(salesModelObj.InvoiceID)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
 
-	//delete the model
-	stmt, err := tx.Prepare("DELETE FROM sales WHERE INVOICE_ID=?")
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
-	defer stmt.Close()
-	_, err = stmt.Exec(salesModelObj.InvoiceID)
-	if err != nil {
-		tx.Rollback()
-		return errors.Wrap(err, 0)
-	}
-	tx.Commit()
-	return nil
+		//delete the model
+		stmt, err := s.on(tx).Prepare("DELETE FROM sales WHERE INVOICE_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer stmt.Close()
+		_, err = stmt.Exec(salesModelObj.InvoiceID)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return nil
+	})
 }
 
 //Save is a function for persisting a model object to db
@@ -465,6 +331,13 @@ func (s *Sale) Save(salesModel model.Model) *errors.Error {
 	return err
 }
 
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (s *Sale) WithTx(tx *sql.Tx) DataMapper {
+	return &Sale{
+		txScope: s.bind(tx),
+	}
+}
+
 //StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
 func (s *Sale) StartUp() {
 	//Note: Perform any initialization or bootstrapping here
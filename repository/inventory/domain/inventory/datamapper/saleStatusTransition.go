@@ -0,0 +1,176 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//SaleStatusTransition is a struct of datamapper for sale status transition domain model
+//Note: sale status transitions are append only, they can't be updated nor deleted
+type SaleStatusTransition struct {
+	txScope
+}
+
+//NewSaleStatusTransition creates a new SaleStatusTransition datamapper and returns a pointer to it
+func NewSaleStatusTransition(dbSession *sql.DB, dialect Dialect) *SaleStatusTransition {
+	return &SaleStatusTransition{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sst *SaleStatusTransition) FindByID(id string) (model.Model, *errors.Error) {
+	transitionID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	stmt, err := sst.conn().Prepare("SELECT ID, INVOICE_ID, FROM_STATUS, TO_STATUS, CHANGED_BY, DATETIME(CHANGED_AT) FROM sale_status_transitions WHERE ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(transitionID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	transitions, errs := sst.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(transitions) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return transitions[0], nil
+}
+
+//FindAll is a function for finding all records
+func (sst *SaleStatusTransition) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := sst.conn().Query("SELECT ID, INVOICE_ID, FROM_STATUS, TO_STATUS, CHANGED_BY, DATETIME(CHANGED_AT) FROM sale_status_transitions ORDER BY CHANGED_AT ASC, ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sst.loadRows(rows)
+}
+
+//FindByInvoiceID is a function for finding all status transitions of a sale (ordered by transition time)
+func (sst *SaleStatusTransition) FindByInvoiceID(invoiceID string) ([]model.Model, *errors.Error) {
+	stmt, err := sst.conn().Prepare("SELECT ID, INVOICE_ID, FROM_STATUS, TO_STATUS, CHANGED_BY, DATETIME(CHANGED_AT) FROM sale_status_transitions WHERE INVOICE_ID = ? ORDER BY CHANGED_AT ASC, ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(invoiceID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sst.loadRows(rows)
+}
+
+//loadRows is a function for composing transition models from the given rows
+func (sst *SaleStatusTransition) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var transitionID int64
+	var invoiceID, fromStatus, toStatus, changedBy, date sql.NullString
+
+	var returnedRow []model.Model
+	for rows.Next() {
+		err := rows.Scan(&transitionID, &invoiceID, &fromStatus, &toStatus, &changedBy, &date)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		dateTimeValue, err := time.Parse(timeFormat, date.String)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		transitionModel := &model.SaleStatusTransition{
+			InvoiceID:  invoiceID.String,
+			FromStatus: fromStatus.String,
+			ToStatus:   toStatus.String,
+			ChangedBy:  changedBy.String,
+			Date:       dateTimeValue,
+		}
+		transitionModel.SetID(transitionID)
+		transitionModel.SetLoadedFromStorage(true)
+
+		returnedRow = append(returnedRow, transitionModel)
+	}
+	return returnedRow, nil
+}
+
+//Insert is a function for inserting a record
+func (sst *SaleStatusTransition) Insert(transitionModel model.Model) *errors.Error {
+	return sst.inTx(func(tx *sql.Tx) *errors.Error {
+		return sst.InsertWithTx(transitionModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (sst *SaleStatusTransition) InsertWithTx(transitionModel model.Model, tx *sql.Tx) *errors.Error {
+	transitionModelObj, ok := transitionModel.(*model.SaleStatusTransition)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.SaleStatusTransition"), 0)
+	}
+	if true == transitionModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot insert, sale status transition with id: %v already exists", transitionModel.GetID()), 0)
+	}
+
+	dateString := transitionModelObj.Date.Format(timeFormat)
+	insertedID, err := sst.dialect.InsertReturningID(tx, "INSERT INTO sale_status_transitions(INVOICE_ID, FROM_STATUS, TO_STATUS, CHANGED_BY, CHANGED_AT) values(?,?,?,?,?)", transitionModelObj.InvoiceID, transitionModelObj.FromStatus, transitionModelObj.ToStatus, transitionModelObj.ChangedBy, dateString)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	transitionModelObj.SetID(insertedID)
+	return nil
+}
+
+//Update is a function for updating record
+//Note: sale status transitions are append only
+func (sst *SaleStatusTransition) Update(transitionModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot update, sale status transition %v is append only", transitionModel.GetID()), 0)
+}
+
+//Delete is a function for deleting record
+//Note: sale status transitions are append only
+func (sst *SaleStatusTransition) Delete(transitionModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, sale status transition %v is append only", transitionModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to db
+func (sst *SaleStatusTransition) Save(transitionModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == transitionModel.GetLoadedFromStorage() {
+		//update operation
+		err = sst.Update(transitionModel)
+	} else {
+		//insert operation
+		err = sst.Insert(transitionModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (sst *SaleStatusTransition) WithTx(tx *sql.Tx) DataMapper {
+	return &SaleStatusTransition{
+		txScope: sst.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sst *SaleStatusTransition) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sst *SaleStatusTransition) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,271 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//SalesReturn is a struct of datamapper for sales return domain model
+type SalesReturn struct {
+	txScope
+}
+
+//NewSalesReturn creates a new SalesReturn datamapper and returns a pointer to it
+func NewSalesReturn(dbSession *sql.DB, dialect Dialect) *SalesReturn {
+	return &SalesReturn{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sr *SalesReturn) FindByID(id string) (model.Model, *errors.Error) {
+	stmt, err := sr.conn().Prepare("SELECT RETURN_ID, INVOICE_ID, DATETIME(RETURN_DATE), NOTE FROM sales_returns WHERE RETURN_ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	var returnID, invoiceID, date, note sql.NullString
+
+	row := stmt.QueryRow(id)
+	err = row.Scan(&returnID, &invoiceID, &date, &note)
+	if err != nil {
+		var returnedErr error
+		if err == sql.ErrNoRows {
+			returnedErr = ErrNotFound
+		} else {
+			returnedErr = err
+		}
+		return nil, errors.Wrap(returnedErr, 0)
+	}
+	dateTimeValue, err := time.Parse(timeFormat, date.String)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	returnModel := &model.SalesReturn{
+		ReturnID:  returnID.String,
+		InvoiceID: invoiceID.String,
+		Date:      dateTimeValue,
+		Note:      note.String,
+	}
+	returnModel.SetLoadedFromStorage(true)
+
+	items, errs := sr.findItems(returnModel.ReturnID)
+	if errs != nil {
+		return nil, errs
+	}
+	returnModel.Items = items
+
+	return returnModel, nil
+}
+
+//FindAll is a function for finding all records
+func (sr *SalesReturn) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := sr.conn().Query("SELECT RETURN_ID, INVOICE_ID, DATETIME(RETURN_DATE), NOTE FROM sales_returns ORDER BY RETURN_DATE ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sr.loadRows(rows)
+}
+
+//FindByInvoiceID is a function for finding all returns of a sale (ordered by return date)
+func (sr *SalesReturn) FindByInvoiceID(invoiceID string) ([]model.Model, *errors.Error) {
+	stmt, err := sr.conn().Prepare("SELECT RETURN_ID, INVOICE_ID, DATETIME(RETURN_DATE), NOTE FROM sales_returns WHERE INVOICE_ID = ? ORDER BY RETURN_DATE ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	rows, err := stmt.Query(invoiceID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sr.loadRows(rows)
+}
+
+//FindByDateRange is a function for finding returns which happened on startDate (inclusive) until endDate (exclusive)
+func (sr *SalesReturn) FindByDateRange(startDate, endDate time.Time) ([]model.Model, *errors.Error) {
+	stmt, err := sr.conn().Prepare("SELECT RETURN_ID, INVOICE_ID, DATETIME(RETURN_DATE), NOTE FROM sales_returns WHERE DATETIME(RETURN_DATE) >= ? AND DATETIME(RETURN_DATE) < ? ORDER BY RETURN_DATE ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	rows, err := stmt.Query(startDate.Format(timeFormat), endDate.Format(timeFormat))
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sr.loadRows(rows)
+}
+
+//loadRows is a function for composing return models (along with their items) from the given return rows
+func (sr *SalesReturn) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	var returnID, invoiceID, date, note sql.NullString
+
+	var salesReturns []*model.SalesReturn
+	for rows.Next() {
+		err := rows.Scan(&returnID, &invoiceID, &date, &note)
+		if err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, 0)
+		}
+		dateTimeValue, err := time.Parse(timeFormat, date.String)
+		if err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, 0)
+		}
+		returnModel := &model.SalesReturn{
+			ReturnID:  returnID.String,
+			InvoiceID: invoiceID.String,
+			Date:      dateTimeValue,
+			Note:      note.String,
+		}
+		returnModel.SetLoadedFromStorage(true)
+		salesReturns = append(salesReturns, returnModel)
+	}
+	//close the return rows before loading the items
+	rows.Close()
+
+	var returnedRow []model.Model
+	for _, returnModel := range salesReturns {
+		items, errs := sr.findItems(returnModel.ReturnID)
+		if errs != nil {
+			return nil, errs
+		}
+		returnModel.Items = items
+		returnedRow = append(returnedRow, returnModel)
+	}
+	return returnedRow, nil
+}
+
+//findItems is a function for finding the items of a return
+func (sr *SalesReturn) findItems(returnID string) (map[string]*model.SalesReturnItem, *errors.Error) {
+	itemStmt, err := sr.conn().Prepare("SELECT ID, SALE_ITEM_ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE FROM sales_return_items WHERE RETURN_ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
+
+	rows, err := itemStmt.Query(returnID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer rows.Close()
+
+	var itemID int64
+	var sku sql.NullString
+	var saleItemID, quantity sql.NullInt64
+	var buyPrice, sellPrice sql.NullInt64
+
+	itemsRow := make(map[string]*model.SalesReturnItem, 5)
+	for rows.Next() {
+		err := rows.Scan(&itemID, &saleItemID, &sku, &quantity, &buyPrice, &sellPrice)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		returnItemModel := &model.SalesReturnItem{
+			SaleItemID: saleItemID.Int64,
+			Sku:        sku.String,
+			Quantity:   quantity.Int64,
+			BuyPrice:   model.Money(buyPrice.Int64),
+			SellPrice:  model.Money(sellPrice.Int64),
+		}
+		returnItemModel.SetID(itemID)
+		returnItemModel.SetLoadedFromStorage(true)
+
+		itemsRow[returnItemModel.Sku] = returnItemModel
+	}
+	return itemsRow, nil
+}
+
+//Insert is a function for inserting a record
+func (sr *SalesReturn) Insert(returnModel model.Model) *errors.Error {
+	return sr.inTx(func(tx *sql.Tx) *errors.Error {
+		return sr.InsertWithTx(returnModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (sr *SalesReturn) InsertWithTx(returnModel model.Model, tx *sql.Tx) *errors.Error {
+	returnModelObj, ok := returnModel.(*model.SalesReturn)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.SalesReturn"), 0)
+	}
+
+	foundModel, _ := sr.WithTx(tx).FindByID(returnModel.GetID())
+	if foundModel != nil {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", returnModel.GetID()), 0)
+	}
+
+	stmt, err := sr.on(tx).Prepare("INSERT INTO sales_returns(RETURN_ID, INVOICE_ID, RETURN_DATE, NOTE) values(?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	dateString := returnModelObj.Date.Format(timeFormat)
+	_, err = stmt.Exec(returnModelObj.ReturnID, returnModelObj.InvoiceID, dateString, returnModelObj.Note)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	//insert the items
+	itemStmt, err := sr.on(tx).Prepare("INSERT INTO sales_return_items(RETURN_ID, SALE_ITEM_ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE) values(?,?,?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
+	for _, val := range returnModelObj.Items {
+		_, err = itemStmt.Exec(returnModelObj.ReturnID, val.SaleItemID, val.Sku, val.Quantity, val.BuyPrice, val.SellPrice)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+	}
+	return nil
+}
+
+//Update is a function for updating record
+//Note: a return records items that already went back to stock, so it can't be updated
+func (sr *SalesReturn) Update(returnModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot update, sales return %v is immutable", returnModel.GetID()), 0)
+}
+
+//Delete is a function for deleting record
+//Note: a return records items that already went back to stock, so it can't be deleted
+func (sr *SalesReturn) Delete(returnModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, sales return %v is immutable", returnModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to db
+func (sr *SalesReturn) Save(returnModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == returnModel.GetLoadedFromStorage() {
+		//update operation
+		err = sr.Update(returnModel)
+	} else {
+		//insert operation
+		err = sr.Insert(returnModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (sr *SalesReturn) WithTx(tx *sql.Tx) DataMapper {
+	return &SalesReturn{
+		txScope: sr.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sr *SalesReturn) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sr *SalesReturn) Shutdown() {
+	//Note: perform any cleanup here
+}
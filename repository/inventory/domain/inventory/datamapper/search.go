@@ -0,0 +1,313 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//DefaultSearchLimit is the number of results of a search without limit
+const DefaultSearchLimit = 20
+
+//MaxSearchLimit is the largest number of results of a search (larger limits are reduced to it)
+const MaxSearchLimit = 100
+
+//SearchHit is a record found by a full text search along with its rank (higher ranks match better)
+type SearchHit struct {
+	Record model.Model
+	Rank   float64
+}
+
+//searchTerms is a function for splitting text into lower case words the way the sqlite "simple" tokenizer does
+//ascii letters and digits are part of words, other ascii characters separate words, non ascii characters are part of words
+//so terms never hold full text query syntax (quotes, operators, etc.)
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(val rune) bool {
+		return val < 128 && false == (val >= 'a' && val <= 'z' || val >= '0' && val <= '9')
+	})
+}
+
+//searchLimit is a function for obtaining the number of results of a search with the given limit
+func searchLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		return MaxSearchLimit
+	}
+	return limit
+}
+
+//bm25 parameters (the usual values): k1 is the term frequency saturation, b is the document length normalization
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+//bm25 is a function for computing the okapi bm25 score of a term in a column of a record
+//hits is the number of words of the column starting with the term, length the number of words of the column
+//documentCount is the number of records, documentHits the number of records whose column holds the term, averageLength the average number of words of the column
+func bm25(hits, length, documentCount, documentHits, averageLength float64) float64 {
+	if hits == 0 {
+		return 0
+	}
+	//always positive, so a term matching most records still adds to the score
+	idf := math.Log(1 + (documentCount-documentHits+0.5)/(documentHits+0.5))
+	if averageLength == 0 {
+		averageLength = 1
+	}
+	return idf * hits * (bm25K1 + 1) / (hits + bm25K1*(1-bm25B+bm25B*length/averageLength))
+}
+
+//rankedSku is a sku found by a search index along with its rank
+type rankedSku struct {
+	sku  string
+	rank float64
+}
+
+//sortRankedSkus is a function for ordering ranked skus best match first (then by sku) and keeping at most limit
+func sortRankedSkus(ranked []rankedSku, limit int) []rankedSku {
+	sort.SliceStable(ranked, func(x, y int) bool {
+		if ranked[x].rank != ranked[y].rank {
+			return ranked[x].rank > ranked[y].rank
+		}
+		return ranked[x].sku < ranked[y].sku
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+//stockSearchIndex is an interface for the full text search index of stock items (sku and name) of a dialect
+type stockSearchIndex interface {
+	//update keeps the entry of a stock item up to date (using passed transaction handler)
+	update(conn querier, sku, name string) error
+	//remove removes the entry of a stock item (using passed transaction handler)
+	remove(conn querier, sku string) error
+	//find returns at most limit skus whose sku or name hold words starting with every term, best match first
+	find(conn querier, terms []string, limit int) ([]rankedSku, error)
+}
+
+//stockSearchIndexOf is a function for obtaining the stock search index of the given dialect
+func stockSearchIndexOf(dialect Dialect) stockSearchIndex {
+	if dialect.DriverName() == DriverPostgres {
+		return postgresStockSearch{}
+	}
+	return sqliteStockSearch{}
+}
+
+//sqliteStockSearch is the stock search index of sqlite databases, the stock_search fts4 table (see migration 007)
+//Note: fts4 is built into go-sqlite3 by default (fts5 needs the sqlite_fts5 build tag), it doesn't rank results so they're ranked by bm25 using matchinfo
+type sqliteStockSearch struct{}
+
+//execOn is a function for running a statement on the given handler
+func execOn(conn querier, query string, args ...interface{}) error {
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(args...)
+	return err
+}
+
+//update replaces the entry of the stock item
+func (ss sqliteStockSearch) update(conn querier, sku, name string) error {
+	err := ss.remove(conn, sku)
+	if err != nil {
+		return err
+	}
+	return execOn(conn, "INSERT INTO stock_search(SKU, NAME) values(?,?)", sku, name)
+}
+
+//remove removes the entry of the stock item
+func (ss sqliteStockSearch) remove(conn querier, sku string) error {
+	return execOn(conn, "DELETE FROM stock_search WHERE SKU=?", sku)
+}
+
+//find matches every term as a prefix ("raglan* white*") and ranks the matches by the sum of the bm25 scores of the terms in both columns
+func (ss sqliteStockSearch) find(conn querier, terms []string, limit int) ([]rankedSku, error) {
+	rows, err := conn.Query("SELECT SKU, matchinfo(stock_search, 'pcnalx') FROM stock_search WHERE stock_search MATCH ?", strings.Join(terms, "* ")+"*")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ranked []rankedSku
+	var sku string
+	var info []byte
+	for rows.Next() {
+		err := rows.Scan(&sku, &info)
+		if err != nil {
+			return nil, err
+		}
+		rank, err := matchinfoRank(info)
+		if err != nil {
+			return nil, err
+		}
+		ranked = append(ranked, rankedSku{sku: sku, rank: rank})
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return sortRankedSkus(ranked, limit), nil
+}
+
+//matchinfoRank is a function for computing the bm25 rank of a row from its matchinfo 'pcnalx' blob (unsigned 32 bit integers in machine byte order, little endian on the supported platforms):
+//p phrases, c columns, n rows, a average words of each column, l words of each column of the row,
+//then for each phrase and column: hits in the row, hits in all rows and rows with hits
+func matchinfoRank(info []byte) (float64, error) {
+	values := make([]float64, 0, len(info)/4)
+	for x := 0; x+4 <= len(info); x += 4 {
+		values = append(values, float64(binary.LittleEndian.Uint32(info[x:x+4])))
+	}
+	if len(values) < 3 {
+		return 0, fmt.Errorf("Invalid matchinfo of %v bytes", len(info))
+	}
+	phraseCount, columnCount, rowCount := int(values[0]), int(values[1]), values[2]
+	if len(values) != 3+2*columnCount+3*phraseCount*columnCount {
+		return 0, fmt.Errorf("Invalid matchinfo of %v bytes", len(info))
+	}
+	averages := values[3 : 3+columnCount]
+	lengths := values[3+columnCount : 3+2*columnCount]
+	hitInfo := values[3+2*columnCount:]
+
+	var rank float64
+	for phrase := 0; phrase < phraseCount; phrase++ {
+		for column := 0; column < columnCount; column++ {
+			hits := hitInfo[3*(phrase*columnCount+column)]
+			rowHits := hitInfo[3*(phrase*columnCount+column)+2]
+			rank += bm25(hits, lengths[column], rowCount, rowHits, averages[column])
+		}
+	}
+	return rank, nil
+}
+
+//postgresStockSearch is the stock search index of PostgreSQL databases, the stock_search index on the words of the sku and name of stock (see migration 007)
+//the index is kept up to date by the database
+type postgresStockSearch struct{}
+
+//stockSearchVector is the indexed text search vector of stock (must be the expression of the stock_search index)
+const stockSearchVector = "to_tsvector('simple', SKU || ' ' || COALESCE(NAME, ''))"
+
+//update does nothing, stock is indexed by the database
+func (ps postgresStockSearch) update(conn querier, sku, name string) error {
+	return nil
+}
+
+//remove does nothing, stock is indexed by the database
+func (ps postgresStockSearch) remove(conn querier, sku string) error {
+	return nil
+}
+
+//find matches every term as a prefix ('raglan:* & white:*') and ranks the matches by ts_rank
+func (ps postgresStockSearch) find(conn querier, terms []string, limit int) ([]rankedSku, error) {
+	query := strings.Join(terms, ":* & ") + ":*"
+	rows, err := conn.Query("SELECT SKU, ts_rank("+stockSearchVector+", to_tsquery('simple', ?)) AS RANK FROM stock WHERE "+stockSearchVector+" @@ to_tsquery('simple', ?) ORDER BY RANK DESC, SKU ASC LIMIT ?", query, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ranked []rankedSku
+	var sku string
+	var rank float64
+	for rows.Next() {
+		err := rows.Scan(&sku, &rank)
+		if err != nil {
+			return nil, err
+		}
+		ranked = append(ranked, rankedSku{sku: sku, rank: rank})
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return ranked, nil
+}
+
+//memorySearchStock is a function for searching stock items of the memory store the way the sqlite search index does (see sqliteStockSearch)
+func memorySearchStock(records []model.Model, terms []string, limit int) []SearchHit {
+	//words of the sku and name of every item
+	columns := make([][2][]string, len(records))
+	var totalLengths [2]float64
+	for x, val := range records {
+		stockObj := val.(*model.Stock)
+		columns[x] = [2][]string{searchTerms(stockObj.Sku), searchTerms(stockObj.Name)}
+		totalLengths[0] += float64(len(columns[x][0]))
+		totalLengths[1] += float64(len(columns[x][1]))
+	}
+	documentCount := float64(len(records))
+	//averages are whole numbers (the same as matchinfo)
+	var averages [2]float64
+	if documentCount > 0 {
+		averages = [2]float64{math.Floor(totalLengths[0] / documentCount), math.Floor(totalLengths[1] / documentCount)}
+	}
+
+	//hits[x][term][column] is the number of words of the column of item x starting with the term
+	hits := make([][][2]float64, len(records))
+	documentHits := make([][2]float64, len(terms))
+	for x := range records {
+		hits[x] = make([][2]float64, len(terms))
+		for y, term := range terms {
+			for column := 0; column < 2; column++ {
+				for _, word := range columns[x][column] {
+					if true == strings.HasPrefix(word, term) {
+						hits[x][y][column]++
+					}
+				}
+				if hits[x][y][column] > 0 {
+					documentHits[y][column]++
+				}
+			}
+		}
+	}
+
+	var ranked []rankedSku
+	for x, val := range records {
+		matched := true
+		var rank float64
+		for y := range terms {
+			if hits[x][y][0] == 0 && hits[x][y][1] == 0 {
+				matched = false
+				break
+			}
+			for column := 0; column < 2; column++ {
+				rank += bm25(hits[x][y][column], float64(len(columns[x][column])), documentCount, documentHits[y][column], averages[column])
+			}
+		}
+		if true == matched {
+			ranked = append(ranked, rankedSku{sku: val.GetID(), rank: rank})
+		}
+	}
+	return composeSearchHits(sortRankedSkus(ranked, limit), records)
+}
+
+//composeSearchHits is a function for composing the search hits of ranked skus along with their records (skus without record are left out)
+func composeSearchHits(ranked []rankedSku, records []model.Model) []SearchHit {
+	recordMap := make(map[string]model.Model, len(records))
+	for _, val := range records {
+		recordMap[val.GetID()] = val
+	}
+	searchHits := make([]SearchHit, 0, len(ranked))
+	for _, val := range ranked {
+		if record, exists := recordMap[val.sku]; true == exists {
+			searchHits = append(searchHits, SearchHit{Record: record, Rank: val.rank})
+		}
+	}
+	return searchHits
+}
+
+//emptySearchError is a function for composing the error of a search query holding no word
+func emptySearchError(query string) *errors.Error {
+	return errors.Wrap(fmt.Errorf("Search query %q holds no word", query), 0)
+}
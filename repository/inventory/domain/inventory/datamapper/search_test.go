@@ -0,0 +1,120 @@
+package datamapper_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//searchMapper is a datamapper capable of searching stock items (both the sql and the memory datamappers)
+type searchMapper interface {
+	datamapper.DataMapper
+	datamapper.StockSearcher
+}
+
+//searchSkus is a function for searching stock items, it returns the skus of the found items best match first
+func searchSkus(t *testing.T, mapper searchMapper, query string, limit int) []string {
+	searchHits, err := mapper.Search(query, limit)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	skus := make([]string, 0, len(searchHits))
+	for x, val := range searchHits {
+		if val.Rank <= 0 || x > 0 && val.Rank > searchHits[x-1].Rank {
+			t.Errorf("expected positive ranks best match first but got %+v", searchHits)
+		}
+		skus = append(skus, val.Record.GetID())
+	}
+	return skus
+}
+
+func TestStockSearch(t *testing.T) {
+	forEachDialect(t, func(t *testing.T, dialect datamapper.Dialect, db *sql.DB) {
+		testStockSearch(t, datamapper.NewStock(db, dialect))
+	})
+	t.Run(datamapper.DriverMemory, func(t *testing.T) {
+		testStockSearch(t, datamapper.NewMemoryStock(datamapper.NewMemoryStore()))
+	})
+}
+
+func testStockSearch(t *testing.T, mapper searchMapper) {
+	stocks := []*model.Stock{
+		{Sku: "SSI-D00791015", Name: "Zalekia Plain Casual Blouse (L,Broken White)", Quantity: 10, BuyPrice: 50000, SellPrice: 60000},
+		{Sku: "SSI-D00791077", Name: "Zalekia Plain Casual Blouse (M,Black)", Quantity: 5, BuyPrice: 50000, SellPrice: 60000},
+		{Sku: "SSI-D01037807", Name: "Deklia Plain Casual Blouse (XL,White White)", Quantity: 20, BuyPrice: 70000, SellPrice: 80000},
+		{Sku: "SSI-D01220307", Name: "Siunfhi Ethnic Blouse (XXL,Navy)", Quantity: 20, BuyPrice: 50000, SellPrice: 60000},
+		{Sku: "SSI-D01401050", Name: "Dellaya Plain Loose Big Blouse (M,Cream)", Quantity: 0, BuyPrice: 40000, SellPrice: 45000},
+	}
+	for _, val := range stocks {
+		err := mapper.Insert(val)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+
+	skus := searchSkus(t, mapper, "whi", 0)
+	t.Run("items must be found by prefix best match first", func(t *testing.T) {
+		if len(skus) != 2 || skus[0] != "SSI-D01037807" || skus[1] != "SSI-D00791015" {
+			t.Errorf("expected [SSI-D01037807 SSI-D00791015] but got %v", skus)
+		}
+	})
+
+	skus = searchSkus(t, mapper, "Casual, BLOUSE (m", 0)
+	t.Run("items must match every word", func(t *testing.T) {
+		if len(skus) != 1 || skus[0] != "SSI-D00791077" {
+			t.Errorf("expected [SSI-D00791077] but got %v", skus)
+		}
+	})
+
+	skus = searchSkus(t, mapper, "d0122", 0)
+	t.Run("items must be found by sku", func(t *testing.T) {
+		if len(skus) != 1 || skus[0] != "SSI-D01220307" {
+			t.Errorf("expected [SSI-D01220307] but got %v", skus)
+		}
+	})
+
+	skus = searchSkus(t, mapper, "blouse", 3)
+	t.Run("found items must be limited", func(t *testing.T) {
+		if len(skus) != 3 {
+			t.Errorf("expected 3 items but got %v", skus)
+		}
+	})
+
+	stocks[3].Name = "Siunfhi Ethnic Tunic (XXL,Navy)"
+	err := mapper.Update(stocks[3])
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = mapper.Delete(stocks[4])
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	skus = searchSkus(t, mapper, "blouse", 0)
+	t.Run("updated and deleted items must not be found by their former names", func(t *testing.T) {
+		if len(skus) != 3 {
+			t.Errorf("expected 3 items but got %v", skus)
+		}
+	})
+	skus = searchSkus(t, mapper, "tuni", 0)
+	t.Run("updated items must be found by their names", func(t *testing.T) {
+		if len(skus) != 1 || skus[0] != "SSI-D01220307" {
+			t.Errorf("expected [SSI-D01220307] but got %v", skus)
+		}
+	})
+
+	skus = searchSkus(t, mapper, "jacket", 0)
+	t.Run("nothing must be found without match", func(t *testing.T) {
+		if len(skus) != 0 {
+			t.Errorf("expected no item but got %v", skus)
+		}
+	})
+
+	_, errs := mapper.Search(" (*) ", 0)
+	t.Run("query without words must be rejected", func(t *testing.T) {
+		if errs == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
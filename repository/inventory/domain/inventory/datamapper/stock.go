@@ -4,9 +4,9 @@ package datamapper
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/go-errors/errors"
-	_ "github.com/mattn/go-sqlite3"
 
 	"ijah-inventory/repository/inventory/domain/inventory/model"
 )
@@ -18,121 +18,234 @@ var (
 
 //Stock is a struct of datamapper for stock domain model
 type Stock struct {
-	db *sql.DB
+	txScope
 }
 
 //NewStock creates a new Stock datamapper and returns a pointer to it
-func NewStock(dbSession *sql.DB) *Stock {
+func NewStock(dbSession *sql.DB, dialect Dialect) *Stock {
 	return &Stock{
-		db: dbSession,
+		txScope: newTxScope(dbSession, dialect),
 	}
 }
 
+//stockSelect is the query of stock items, the rows are composed by loadRows
+const stockSelect = "SELECT SKU, NAME, QUANTITY, BUY_PRICE, SELL_PRICE, RESERVED_QUANTITY, PRODUCT_ID, SIZE, COLOR, CATEGORY_ID, REORDER_POINT, REORDER_QUANTITY, SAFETY_STOCK FROM stock"
+
 //FindByID is a function for finding a record by id
 func (s *Stock) FindByID(id string) (model.Model, *errors.Error) {
-	stmt, err := s.db.Prepare("SELECT SKU, NAME, QUANTITY, BUY_PRICE, SELL_PRICE FROM stock WHERE SKU = ?")
-
+	stmt, err := s.conn().Prepare(stockSelect + " WHERE SKU = ?")
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
 	defer stmt.Close()
 
-	var sku, name sql.NullString
-	var quantity sql.NullInt64
-	var buyPrice, sellPrice sql.NullFloat64
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	records, errs := s.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(records) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return records[0], nil
+}
+
+//stockFields are the fields stock can be filtered and sorted by (see Criteria)
+var stockFields = map[string]criteriaField{
+	"sku": {column: "SKU", kind: fieldText, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).Sku
+	}},
+	"name": {column: "COALESCE(NAME, '')", kind: fieldText, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).Name
+	}},
+	"quantity": {column: "QUANTITY", kind: fieldInteger, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).Quantity
+	}},
+	"reserved": {column: "RESERVED_QUANTITY", kind: fieldInteger, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).Reserved
+	}},
+	"available": {column: "(QUANTITY - RESERVED_QUANTITY)", kind: fieldInteger, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).Available()
+	}},
+	"buyPrice": {column: "BUY_PRICE", kind: fieldInteger, value: func(record model.Model) interface{} {
+		return int64(record.(*model.Stock).BuyPrice)
+	}},
+	"sellPrice": {column: "SELL_PRICE", kind: fieldInteger, value: func(record model.Model) interface{} {
+		return int64(record.(*model.Stock).SellPrice)
+	}},
+	"productId": {column: "COALESCE(PRODUCT_ID, '')", kind: fieldText, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).ProductID
+	}},
+	"size": {column: "COALESCE(SIZE, '')", kind: fieldText, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).Size
+	}},
+	"color": {column: "COALESCE(COLOR, '')", kind: fieldText, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).Color
+	}},
+	"categoryId": {column: "COALESCE(CATEGORY_ID, '')", kind: fieldText, value: func(record model.Model) interface{} {
+		return record.(*model.Stock).CategoryID
+	}},
+}
+
+//stockKeyField is the field identifying a stock item (see stockFields)
+const stockKeyField = "sku"
 
-	row := stmt.QueryRow(id)
-	err = row.Scan(&sku, &name, &quantity, &buyPrice, &sellPrice)
+//FindAll is a function for finding all records
+func (s *Stock) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := s.conn().Query(stockSelect + " ORDER BY SKU ASC")
 	if err != nil {
-		var returnedErr error
-		if err == sql.ErrNoRows {
-			returnedErr = ErrNotFound
-		} else {
-			returnedErr = err
-		}
-		return nil, errors.Wrap(returnedErr, 0)
+		return nil, errors.Wrap(err, 0)
 	}
+	return s.loadRows(rows)
+}
 
-	skuValue := sku.String
-	nameValue := name.String
-	quantityValue := quantity.Int64
-	buyPriceValue := buyPrice.Float64
-	sellPriceValue := sellPrice.Float64
+//FindByProductID is a function for finding the variants of a product (ordered by sku)
+func (s *Stock) FindByProductID(productID string) ([]model.Model, *errors.Error) {
+	stmt, err := s.conn().Prepare(stockSelect + " WHERE PRODUCT_ID = ? ORDER BY SKU ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
 
-	stockModel := &model.Stock{
-		Sku:       skuValue,
-		Name:      nameValue,
-		Quantity:  quantityValue,
-		BuyPrice:  buyPriceValue,
-		SellPrice: sellPriceValue,
+	rows, err := stmt.Query(productID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
 	}
-	stockModel.SetLoadedFromStorage(true)
+	return s.loadRows(rows)
+}
 
-	return stockModel, nil
+//FindByCriteria is a function for finding a page of the records matching a criteria (see stockFields for the fields)
+func (s *Stock) FindByCriteria(criteria Criteria) (*Page, *errors.Error) {
+	resolved, errs := criteria.resolve(stockFields, stockKeyField)
+	if errs != nil {
+		return nil, errs
+	}
+	where, args := resolved.sqlWhere()
+	stmt, err := s.conn().Prepare(stockSelect + where + resolved.sqlOrder() + " LIMIT ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(append(args, resolved.limit+1)...)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	records, errs := s.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	return resolved.page(records), nil
 }
 
-//FindAll is a function for finding all records
-func (s *Stock) FindAll() ([]model.Model, *errors.Error) {
-	rows, err := s.db.Query("SELECT SKU, NAME, QUANTITY, BUY_PRICE, SELL_PRICE FROM stock ORDER BY SKU ASC")
+//Search is a function for finding at most limit stock items whose sku or name hold words starting with every word of the query, best match first
+//e.g. "raglan wh" finds "Thafqya Plain Raglan Blouse (L,White)", limit defaults to DefaultSearchLimit
+func (s *Stock) Search(query string, limit int) ([]SearchHit, *errors.Error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, emptySearchError(query)
+	}
+	ranked, err := stockSearchIndexOf(s.dialect).find(s.conn(), terms, searchLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
+	if len(ranked) == 0 {
+		return make([]SearchHit, 0), nil
+	}
+
+	//load the found items
+	placeholders := make([]string, 0, len(ranked))
+	args := make([]interface{}, 0, len(ranked))
+	for _, val := range ranked {
+		placeholders = append(placeholders, "?")
+		args = append(args, val.sku)
+	}
+	rows, err := s.conn().Query(stockSelect+" WHERE SKU IN ("+strings.Join(placeholders, ",")+")", args...)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	records, errs := s.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	return composeSearchHits(ranked, records), nil
+}
+
+//loadRows is a function for composing stock models from the given rows (see stockSelect), the rows are closed afterwards
+func (s *Stock) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
 	defer rows.Close()
 
-	var sku, name sql.NullString
-	var quantity sql.NullInt64
-	var buyPrice, sellPrice sql.NullFloat64
+	var sku, name, productID, size, color, categoryID sql.NullString
+	var quantity, reserved, reorderPoint, reorderQuantity, safetyStock sql.NullInt64
+	var buyPrice, sellPrice sql.NullInt64
 
 	var returnedRow []model.Model
-	var firstScan = true
 	for rows.Next() {
-		err := rows.Scan(&sku, &name, &quantity, &buyPrice, &sellPrice)
-		firstScan = false
+		err := rows.Scan(&sku, &name, &quantity, &buyPrice, &sellPrice, &reserved, &productID, &size, &color, &categoryID, &reorderPoint, &reorderQuantity, &safetyStock)
 		if err != nil {
-			var returnedErr error
-			if firstScan && err == sql.ErrNoRows {
-				returnedErr = ErrNotFound
-			} else {
-				returnedErr = err
-			}
-			return nil, errors.Wrap(returnedErr, 0)
+			return nil, errors.Wrap(err, 0)
 		}
-		skuValue := sku.String
-		nameValue := name.String
-		quantityValue := quantity.Int64
-		buyPriceValue := buyPrice.Float64
-		sellPriceValue := sellPrice.Float64
-
 		stockModel := &model.Stock{
-			Sku:       skuValue,
-			Name:      nameValue,
-			Quantity:  quantityValue,
-			BuyPrice:  buyPriceValue,
-			SellPrice: sellPriceValue,
+			Sku:             sku.String,
+			Name:            name.String,
+			Quantity:        quantity.Int64,
+			BuyPrice:        model.Money(buyPrice.Int64),
+			SellPrice:       model.Money(sellPrice.Int64),
+			Reserved:        reserved.Int64,
+			ProductID:       productID.String,
+			Size:            size.String,
+			Color:           color.String,
+			CategoryID:      categoryID.String,
+			ReorderPoint:    reorderPoint.Int64,
+			ReorderQuantity: reorderQuantity.Int64,
+			SafetyStock:     safetyStock.Int64,
 		}
 		stockModel.SetLoadedFromStorage(true)
 
 		returnedRow = append(returnedRow, stockModel)
 	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
 	return returnedRow, nil
 }
 
+//nullText is a function for composing the stored value of an optional text (null when empty)
+func nullText(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
 //Insert is a function for inserting a record
 func (s *Stock) Insert(stockModel model.Model) *errors.Error {
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		return s.InsertWithTx(stockModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (s *Stock) InsertWithTx(stockModel model.Model, tx *sql.Tx) *errors.Error {
 	stockModelObj, ok := stockModel.(*model.Stock)
 	if false == ok {
 		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Stock"), 0)
 	}
-	foundModel, _ := s.FindByID(stockModel.GetID())
+	foundModel, _ := s.WithTx(tx).FindByID(stockModel.GetID())
 	if foundModel != nil {
 		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", stockModel.GetID()), 0)
 	}
-	stmt, err := s.db.Prepare("INSERT INTO stock(SKU, NAME, QUANTITY, BUY_PRICE, SELL_PRICE) values(?,?,?,?,?)")
+	stmt, err := s.on(tx).Prepare("INSERT INTO stock(SKU, NAME, QUANTITY, BUY_PRICE, SELL_PRICE, RESERVED_QUANTITY, PRODUCT_ID, SIZE, COLOR, CATEGORY_ID, REORDER_POINT, REORDER_QUANTITY, SAFETY_STOCK) values(?,?,?,?,?,?,?,?,?,?,?,?,?)")
 	if err != nil {
 		return errors.Wrap(err, 0)
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(stockModelObj.Sku, stockModelObj.Name, stockModelObj.Quantity, stockModelObj.BuyPrice, stockModelObj.SellPrice)
+	_, err = stmt.Exec(stockModelObj.Sku, stockModelObj.Name, stockModelObj.Quantity, stockModelObj.BuyPrice, stockModelObj.SellPrice, stockModelObj.Reserved, nullText(stockModelObj.ProductID), nullText(stockModelObj.Size), nullText(stockModelObj.Color), nullText(stockModelObj.CategoryID), stockModelObj.ReorderPoint, stockModelObj.ReorderQuantity, stockModelObj.SafetyStock)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	err = stockSearchIndexOf(s.dialect).update(s.on(tx), stockModelObj.Sku, stockModelObj.Name)
 	if err != nil {
 		return errors.Wrap(err, 0)
 	}
@@ -141,24 +254,9 @@ func (s *Stock) Insert(stockModel model.Model) *errors.Error {
 
 //Update is a function for updating record
 func (s *Stock) Update(stockModel model.Model) *errors.Error {
-	stockModelObj, ok := stockModel.(*model.Stock)
-	if false == ok {
-		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Stock"), 0)
-	}
-	_, errs := s.FindByID(stockModel.GetID())
-	if errs != nil && errs.Err == sql.ErrNoRows {
-		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", stockModel.GetID()), 0)
-	}
-	stmt, err := s.db.Prepare("UPDATE stock SET SKU=?, NAME=?, QUANTITY=?, BUY_PRICE=?, SELL_PRICE=? WHERE SKU=?")
-	if err != nil {
-		return errors.Wrap(err, 0)
-	}
-	defer stmt.Close()
-	_, err = stmt.Exec(stockModelObj.Sku, stockModelObj.Name, stockModelObj.Quantity, stockModelObj.BuyPrice, stockModelObj.SellPrice, stockModelObj.Sku)
-	if err != nil {
-		return errors.Wrap(err, 0)
-	}
-	return nil
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		return s.UpdateWithTx(stockModel, tx)
+	})
 }
 
 //UpdateWithTx is a function for updating record (using passed transaction handler)
@@ -167,16 +265,20 @@ func (s *Stock) UpdateWithTx(stockModel model.Model, tx *sql.Tx) *errors.Error {
 	if false == ok {
 		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Stock"), 0)
 	}
-	_, errs := s.FindByID(stockModel.GetID())
+	_, errs := s.WithTx(tx).FindByID(stockModel.GetID())
 	if errs != nil && errs.Err == sql.ErrNoRows {
 		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", stockModel.GetID()), 0)
 	}
-	stmt, err := tx.Prepare("UPDATE stock SET SKU=?, NAME=?, QUANTITY=?, BUY_PRICE=?, SELL_PRICE=? WHERE SKU=?")
+	stmt, err := s.on(tx).Prepare("UPDATE stock SET SKU=?, NAME=?, QUANTITY=?, BUY_PRICE=?, SELL_PRICE=?, RESERVED_QUANTITY=?, PRODUCT_ID=?, SIZE=?, COLOR=?, CATEGORY_ID=?, REORDER_POINT=?, REORDER_QUANTITY=?, SAFETY_STOCK=? WHERE SKU=?")
 	if err != nil {
 		return errors.Wrap(err, 0)
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(stockModelObj.Sku, stockModelObj.Name, stockModelObj.Quantity, stockModelObj.BuyPrice, stockModelObj.SellPrice, stockModelObj.Sku)
+	_, err = stmt.Exec(stockModelObj.Sku, stockModelObj.Name, stockModelObj.Quantity, stockModelObj.BuyPrice, stockModelObj.SellPrice, stockModelObj.Reserved, nullText(stockModelObj.ProductID), nullText(stockModelObj.Size), nullText(stockModelObj.Color), nullText(stockModelObj.CategoryID), stockModelObj.ReorderPoint, stockModelObj.ReorderQuantity, stockModelObj.SafetyStock, stockModelObj.Sku)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	err = stockSearchIndexOf(s.dialect).update(s.on(tx), stockModelObj.Sku, stockModelObj.Name)
 	if err != nil {
 		return errors.Wrap(err, 0)
 	}
@@ -190,19 +292,25 @@ func (s *Stock) Delete(stockModel model.Model) *errors.Error {
 		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Stock"), 0)
 	}
 	_, errs := s.FindByID(stockModel.GetID())
-	if errs.Err == sql.ErrNoRows {
+	if errs != nil && errs.Err == ErrNotFound {
 		return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", stockModel.GetID()), 0)
 	}
-	stmt, err := s.db.Prepare("DELETE FROM stock WHERE SKU=?")
-	if err != nil {
-		return errors.Wrap(err, 0)
-	}
-	defer stmt.Close()
-	_, err = stmt.Exec(stockModelObj.Sku)
-	if err != nil {
-		return errors.Wrap(err, 0)
-	}
-	return nil
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		stmt, err := s.on(tx).Prepare("DELETE FROM stock WHERE SKU=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer stmt.Close()
+		_, err = stmt.Exec(stockModelObj.Sku)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		err = stockSearchIndexOf(s.dialect).remove(s.on(tx), stockModelObj.Sku)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return nil
+	})
 }
 
 //Save is a function for persisting a model object to db
@@ -218,6 +326,13 @@ func (s *Stock) Save(stockModel model.Model) *errors.Error {
 	return err
 }
 
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (s *Stock) WithTx(tx *sql.Tx) DataMapper {
+	return &Stock{
+		txScope: s.bind(tx),
+	}
+}
+
 //StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
 func (s *Stock) StartUp() {
 	//Note: Perform any initialization or bootstrapping here
@@ -0,0 +1,229 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//StockAlert is a struct of datamapper for stock alert domain model
+type StockAlert struct {
+	txScope
+}
+
+//NewStockAlert creates a new StockAlert datamapper and returns a pointer to it
+func NewStockAlert(dbSession *sql.DB, dialect Dialect) *StockAlert {
+	return &StockAlert{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//stockAlertSelect is the query of stock alerts, the rows are composed by loadRows
+const stockAlertSelect = "SELECT ID, SKU, LEVEL, QUANTITY, REORDER_POINT, REORDER_QUANTITY, SAFETY_STOCK, DATETIME(RAISED_AT), DATETIME(RESOLVED_AT) FROM stock_alerts"
+
+//FindByID is a function for finding a record by id
+func (sa *StockAlert) FindByID(id string) (model.Model, *errors.Error) {
+	alertID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	stmt, err := sa.conn().Prepare(stockAlertSelect + " WHERE ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(alertID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	alerts, errs := sa.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(alerts) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return alerts[0], nil
+}
+
+//FindAll is a function for finding all records
+func (sa *StockAlert) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := sa.conn().Query(stockAlertSelect + " ORDER BY RAISED_AT ASC, ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sa.loadRows(rows)
+}
+
+//FindOpen is a function for finding the alerts which haven't been resolved (oldest alert first)
+func (sa *StockAlert) FindOpen() ([]model.Model, *errors.Error) {
+	rows, err := sa.conn().Query(stockAlertSelect + " WHERE RESOLVED_AT IS NULL ORDER BY RAISED_AT ASC, ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sa.loadRows(rows)
+}
+
+//FindOpenBySku is a function for finding the open alert of a sku, ErrNotFound is returned when the sku has no open alert
+func (sa *StockAlert) FindOpenBySku(sku string) (model.Model, *errors.Error) {
+	stmt, err := sa.conn().Prepare(stockAlertSelect + " WHERE SKU = ? AND RESOLVED_AT IS NULL ORDER BY ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(sku)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	alerts, errs := sa.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(alerts) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return alerts[0], nil
+}
+
+//loadRows is a function for composing alert models from the given rows (see stockAlertSelect), the rows are closed afterwards
+func (sa *StockAlert) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var alertID int64
+	var sku, level, raisedAt, resolvedAt sql.NullString
+	var quantity, reorderPoint, reorderQuantity, safetyStock sql.NullInt64
+
+	var returnedRow []model.Model
+	for rows.Next() {
+		err := rows.Scan(&alertID, &sku, &level, &quantity, &reorderPoint, &reorderQuantity, &safetyStock, &raisedAt, &resolvedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		alertModel := &model.StockAlert{
+			Sku:             sku.String,
+			Level:           level.String,
+			Quantity:        quantity.Int64,
+			ReorderPoint:    reorderPoint.Int64,
+			ReorderQuantity: reorderQuantity.Int64,
+			SafetyStock:     safetyStock.Int64,
+		}
+		alertModel.RaisedAt, err = time.Parse(timeFormat, raisedAt.String)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		if true == resolvedAt.Valid {
+			alertModel.ResolvedAt, err = time.Parse(timeFormat, resolvedAt.String)
+			if err != nil {
+				return nil, errors.Wrap(err, 0)
+			}
+		}
+		alertModel.SetID(alertID)
+		alertModel.SetLoadedFromStorage(true)
+
+		returnedRow = append(returnedRow, alertModel)
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return returnedRow, nil
+}
+
+//Insert is a function for inserting a record
+func (sa *StockAlert) Insert(alertModel model.Model) *errors.Error {
+	return sa.inTx(func(tx *sql.Tx) *errors.Error {
+		return sa.InsertWithTx(alertModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (sa *StockAlert) InsertWithTx(alertModel model.Model, tx *sql.Tx) *errors.Error {
+	alertModelObj, ok := alertModel.(*model.StockAlert)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockAlert"), 0)
+	}
+	if true == alertModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot insert, stock alert with id: %v already exists", alertModel.GetID()), 0)
+	}
+
+	insertedID, err := sa.dialect.InsertReturningID(tx, "INSERT INTO stock_alerts(SKU, LEVEL, QUANTITY, REORDER_POINT, REORDER_QUANTITY, SAFETY_STOCK, RAISED_AT, RESOLVED_AT) values(?,?,?,?,?,?,?,?)", alertModelObj.Sku, alertModelObj.Level, alertModelObj.Quantity, alertModelObj.ReorderPoint, alertModelObj.ReorderQuantity, alertModelObj.SafetyStock, alertModelObj.RaisedAt.Format(timeFormat), nullTime(alertModelObj.ResolvedAt))
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	alertModelObj.SetID(insertedID)
+	return nil
+}
+
+//Update is a function for updating record
+func (sa *StockAlert) Update(alertModel model.Model) *errors.Error {
+	return sa.inTx(func(tx *sql.Tx) *errors.Error {
+		return sa.UpdateWithTx(alertModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+//Note: the sku of an alert can't be changed
+func (sa *StockAlert) UpdateWithTx(alertModel model.Model, tx *sql.Tx) *errors.Error {
+	alertModelObj, ok := alertModel.(*model.StockAlert)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockAlert"), 0)
+	}
+	if false == alertModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot update, stock alert with id: %v doesn't exist", alertModel.GetID()), 0)
+	}
+
+	stmt, err := sa.on(tx).Prepare("UPDATE stock_alerts SET LEVEL=?, QUANTITY=?, REORDER_POINT=?, REORDER_QUANTITY=?, SAFETY_STOCK=?, RAISED_AT=?, RESOLVED_AT=? WHERE ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(alertModelObj.Level, alertModelObj.Quantity, alertModelObj.ReorderPoint, alertModelObj.ReorderQuantity, alertModelObj.SafetyStock, alertModelObj.RaisedAt.Format(timeFormat), nullTime(alertModelObj.ResolvedAt), alertModelObj.GetID())
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Delete is a function for deleting record
+//Note: alerts are kept as the history of low stock, resolve them instead of deleting
+func (sa *StockAlert) Delete(alertModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, stock alert %v must be resolved instead", alertModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to db
+func (sa *StockAlert) Save(alertModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == alertModel.GetLoadedFromStorage() {
+		//update operation
+		err = sa.Update(alertModel)
+	} else {
+		//insert operation
+		err = sa.Insert(alertModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (sa *StockAlert) WithTx(tx *sql.Tx) DataMapper {
+	return &StockAlert{
+		txScope: sa.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sa *StockAlert) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sa *StockAlert) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,314 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//StockCount is a struct of datamapper for stock count domain model
+type StockCount struct {
+	txScope
+}
+
+//NewStockCount creates a new StockCount datamapper and returns a pointer to it
+func NewStockCount(dbSession *sql.DB, dialect Dialect) *StockCount {
+	return &StockCount{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sc *StockCount) FindByID(id string) (model.Model, *errors.Error) {
+	stmt, err := sc.conn().Prepare("SELECT COUNT_ID, DATETIME(COUNT_DATE), STATUS, NOTE, DATETIME(POSTED_DATE) FROM stock_counts WHERE COUNT_ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	counts, errs := sc.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(counts) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return counts[0], nil
+}
+
+//FindAll is a function for finding all records
+func (sc *StockCount) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := sc.conn().Query("SELECT COUNT_ID, DATETIME(COUNT_DATE), STATUS, NOTE, DATETIME(POSTED_DATE) FROM stock_counts ORDER BY COUNT_DATE ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sc.loadRows(rows)
+}
+
+//loadRows is a function for composing count models (along with their items) from the given count rows
+func (sc *StockCount) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	var countID, date, status, note, postedDate sql.NullString
+
+	var counts []*model.StockCount
+	for rows.Next() {
+		err := rows.Scan(&countID, &date, &status, &note, &postedDate)
+		if err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, 0)
+		}
+		dateTimeValue, err := time.Parse(timeFormat, date.String)
+		if err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, 0)
+		}
+		countModel := &model.StockCount{
+			CountID: countID.String,
+			Date:    dateTimeValue,
+			Status:  status.String,
+			Note:    note.String,
+		}
+		//posted date is only set once the count is posted
+		if true == postedDate.Valid {
+			postedDateTimeValue, err := time.Parse(timeFormat, postedDate.String)
+			if err != nil {
+				rows.Close()
+				return nil, errors.Wrap(err, 0)
+			}
+			countModel.PostedDate = postedDateTimeValue
+		}
+		countModel.SetLoadedFromStorage(true)
+		counts = append(counts, countModel)
+	}
+	//close the count rows before loading the items
+	rows.Close()
+
+	var returnedRow []model.Model
+	for _, countModel := range counts {
+		items, errs := sc.findItems(countModel.CountID)
+		if errs != nil {
+			return nil, errs
+		}
+		countModel.Items = items
+		returnedRow = append(returnedRow, countModel)
+	}
+	return returnedRow, nil
+}
+
+//findItems is a function for finding the items of a count
+func (sc *StockCount) findItems(countID string) (map[string]*model.StockCountItem, *errors.Error) {
+	itemStmt, err := sc.conn().Prepare("SELECT ID, SKU, COUNTED_QUANTITY, SYSTEM_QUANTITY, BUY_PRICE FROM stock_count_items WHERE COUNT_ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
+
+	rows, err := itemStmt.Query(countID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer rows.Close()
+
+	var itemID int64
+	var sku sql.NullString
+	var countedQuantity, systemQuantity sql.NullInt64
+	var buyPrice sql.NullInt64
+
+	itemsRow := make(map[string]*model.StockCountItem, 5)
+	for rows.Next() {
+		err := rows.Scan(&itemID, &sku, &countedQuantity, &systemQuantity, &buyPrice)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		countItemModel := &model.StockCountItem{
+			Sku:             sku.String,
+			Counted:         countedQuantity.Valid, //counted quantity is null until it's recorded
+			CountedQuantity: countedQuantity.Int64,
+			SystemQuantity:  systemQuantity.Int64,
+			BuyPrice:        model.Money(buyPrice.Int64),
+		}
+		countItemModel.SetID(itemID)
+		countItemModel.SetLoadedFromStorage(true)
+
+		itemsRow[countItemModel.Sku] = countItemModel
+	}
+	return itemsRow, nil
+}
+
+//Insert is a function for inserting a record
+func (sc *StockCount) Insert(countModel model.Model) *errors.Error {
+	return sc.inTx(func(tx *sql.Tx) *errors.Error {
+		return sc.InsertWithTx(countModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (sc *StockCount) InsertWithTx(countModel model.Model, tx *sql.Tx) *errors.Error {
+	countModelObj, ok := countModel.(*model.StockCount)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockCount"), 0)
+	}
+
+	foundModel, _ := sc.WithTx(tx).FindByID(countModel.GetID())
+	if foundModel != nil {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", countModel.GetID()), 0)
+	}
+
+	stmt, err := sc.on(tx).Prepare("INSERT INTO stock_counts(COUNT_ID, COUNT_DATE, STATUS, NOTE, POSTED_DATE) values(?,?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	dateString := countModelObj.Date.Format(timeFormat)
+	_, err = stmt.Exec(countModelObj.CountID, dateString, countModelObj.Status, countModelObj.Note, sc.postedDateValue(countModelObj))
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	//insert the items
+	itemStmt, err := sc.on(tx).Prepare("INSERT INTO stock_count_items(COUNT_ID, SKU, COUNTED_QUANTITY, SYSTEM_QUANTITY, BUY_PRICE) values(?,?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
+	for _, val := range countModelObj.Items {
+		_, err = itemStmt.Exec(countModelObj.CountID, val.Sku, sc.countedQuantityValue(val), val.SystemQuantity, val.BuyPrice)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+	}
+	return nil
+}
+
+//Update is a function for updating record
+func (sc *StockCount) Update(countModel model.Model) *errors.Error {
+	return sc.inTx(func(tx *sql.Tx) *errors.Error {
+		return sc.UpdateWithTx(countModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+func (sc *StockCount) UpdateWithTx(countModel model.Model, tx *sql.Tx) *errors.Error {
+	countModelObj, ok := countModel.(*model.StockCount)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockCount"), 0)
+	}
+
+	_, errs := sc.WithTx(tx).FindByID(countModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", countModel.GetID()), 0)
+	}
+
+	stmt, err := sc.on(tx).Prepare("UPDATE stock_counts SET STATUS=?, NOTE=?, POSTED_DATE=? WHERE COUNT_ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(countModelObj.Status, countModelObj.Note, sc.postedDateValue(countModelObj), countModelObj.CountID)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	//update items
+	itemStmt, err := sc.on(tx).Prepare("UPDATE stock_count_items SET COUNTED_QUANTITY=?, SYSTEM_QUANTITY=?, BUY_PRICE=? WHERE ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer itemStmt.Close()
+	for _, val := range countModelObj.Items {
+		_, err = itemStmt.Exec(sc.countedQuantityValue(val), val.SystemQuantity, val.BuyPrice, val.GetID())
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+	}
+	return nil
+}
+
+//postedDateValue is a function for composing the stored posted date of a count (null while the count is open)
+func (sc *StockCount) postedDateValue(countModelObj *model.StockCount) sql.NullString {
+	if true == countModelObj.PostedDate.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: countModelObj.PostedDate.Format(timeFormat), Valid: true}
+}
+
+//countedQuantityValue is a function for composing the stored counted quantity of an item (null until it's counted)
+func (sc *StockCount) countedQuantityValue(countItemObj *model.StockCountItem) sql.NullInt64 {
+	return sql.NullInt64{Int64: countItemObj.CountedQuantity, Valid: countItemObj.Counted}
+}
+
+//Delete is a function for deleting record
+func (sc *StockCount) Delete(countModel model.Model) *errors.Error {
+	countModelObj, ok := countModel.(*model.StockCount)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockCount"), 0)
+	}
+
+	_, errs := sc.FindByID(countModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", countModel.GetID()), 0)
+	}
+
+	return sc.inTx(func(tx *sql.Tx) *errors.Error {
+		//delete items
+		itemStmt, err := sc.on(tx).Prepare("DELETE FROM stock_count_items WHERE COUNT_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer itemStmt.Close()
+		_, err = itemStmt.Exec(countModelObj.CountID)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+
+		//delete the model
+		stmt, err := sc.on(tx).Prepare("DELETE FROM stock_counts WHERE COUNT_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer stmt.Close()
+		_, err = stmt.Exec(countModelObj.CountID)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return nil
+	})
+}
+
+//Save is a function for persisting a model object to db
+func (sc *StockCount) Save(countModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == countModel.GetLoadedFromStorage() {
+		//update operation
+		err = sc.Update(countModel)
+	} else {
+		//insert operation
+		err = sc.Insert(countModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (sc *StockCount) WithTx(tx *sql.Tx) DataMapper {
+	return &StockCount{
+		txScope: sc.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sc *StockCount) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sc *StockCount) Shutdown() {
+	//Note: perform any cleanup here
+}
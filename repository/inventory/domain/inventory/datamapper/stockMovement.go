@@ -0,0 +1,180 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//StockMovement is a struct of datamapper for stock movement domain model
+//Note: stock movements are append only, they can't be updated nor deleted
+type StockMovement struct {
+	txScope
+}
+
+//NewStockMovement creates a new StockMovement datamapper and returns a pointer to it
+func NewStockMovement(dbSession *sql.DB, dialect Dialect) *StockMovement {
+	return &StockMovement{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//FindByID is a function for finding a record by id
+func (sm *StockMovement) FindByID(id string) (model.Model, *errors.Error) {
+	movementID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	stmt, err := sm.conn().Prepare("SELECT ID, SKU, QUANTITY, UNIT_COST, REASON, REFERENCE, NOTE, DATETIME(MOVEMENT_DATE) FROM stock_movements WHERE ID = ?")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(movementID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	movements, errs := sm.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(movements) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return movements[0], nil
+}
+
+//FindAll is a function for finding all records
+func (sm *StockMovement) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := sm.conn().Query("SELECT ID, SKU, QUANTITY, UNIT_COST, REASON, REFERENCE, NOTE, DATETIME(MOVEMENT_DATE) FROM stock_movements ORDER BY MOVEMENT_DATE ASC, ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sm.loadRows(rows)
+}
+
+//FindBySkuAndDateRange is a function for finding movement records of a sku which happened on startDate (inclusive) until endDate (exclusive)
+func (sm *StockMovement) FindBySkuAndDateRange(sku string, startDate, endDate time.Time) ([]model.Model, *errors.Error) {
+	stmt, err := sm.conn().Prepare("SELECT ID, SKU, QUANTITY, UNIT_COST, REASON, REFERENCE, NOTE, DATETIME(MOVEMENT_DATE) FROM stock_movements WHERE SKU = ? AND DATETIME(MOVEMENT_DATE) >= ? AND DATETIME(MOVEMENT_DATE) < ? ORDER BY MOVEMENT_DATE ASC, ID ASC")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(sku, startDate.Format(timeFormat), endDate.Format(timeFormat))
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return sm.loadRows(rows)
+}
+
+//loadRows is a function for composing movement models from the given rows
+func (sm *StockMovement) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var movementID int64
+	var sku, reason, reference, note, date sql.NullString
+	var quantity sql.NullInt64
+	var unitCost sql.NullInt64
+
+	var returnedRow []model.Model
+	for rows.Next() {
+		err := rows.Scan(&movementID, &sku, &quantity, &unitCost, &reason, &reference, &note, &date)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		dateTimeValue, err := time.Parse(timeFormat, date.String)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		movementModel := &model.StockMovement{
+			Sku:       sku.String,
+			Quantity:  quantity.Int64,
+			UnitCost:  model.Money(unitCost.Int64),
+			Reason:    reason.String,
+			Reference: reference.String,
+			Note:      note.String,
+			Date:      dateTimeValue,
+		}
+		movementModel.SetID(movementID)
+		movementModel.SetLoadedFromStorage(true)
+
+		returnedRow = append(returnedRow, movementModel)
+	}
+	return returnedRow, nil
+}
+
+//Insert is a function for inserting a record
+func (sm *StockMovement) Insert(movementModel model.Model) *errors.Error {
+	return sm.inTx(func(tx *sql.Tx) *errors.Error {
+		return sm.InsertWithTx(movementModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (sm *StockMovement) InsertWithTx(movementModel model.Model, tx *sql.Tx) *errors.Error {
+	movementModelObj, ok := movementModel.(*model.StockMovement)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.StockMovement"), 0)
+	}
+	if true == movementModelObj.GetLoadedFromStorage() {
+		return errors.Wrap(fmt.Errorf("cannot insert, movement with id: %v already exists", movementModel.GetID()), 0)
+	}
+
+	dateString := movementModelObj.Date.Format(timeFormat)
+	insertedID, err := sm.dialect.InsertReturningID(tx, "INSERT INTO stock_movements(SKU, QUANTITY, UNIT_COST, REASON, REFERENCE, NOTE, MOVEMENT_DATE) values(?,?,?,?,?,?,?)", movementModelObj.Sku, movementModelObj.Quantity, movementModelObj.UnitCost, movementModelObj.Reason, movementModelObj.Reference, movementModelObj.Note, dateString)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	movementModelObj.SetID(insertedID)
+	return nil
+}
+
+//Update is a function for updating record
+//Note: stock movements are append only
+func (sm *StockMovement) Update(movementModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot update, stock movement %v is append only", movementModel.GetID()), 0)
+}
+
+//Delete is a function for deleting record
+//Note: stock movements are append only
+func (sm *StockMovement) Delete(movementModel model.Model) *errors.Error {
+	return errors.Wrap(fmt.Errorf("cannot delete, stock movement %v is append only", movementModel.GetID()), 0)
+}
+
+//Save is a function for persisting a model object to db
+func (sm *StockMovement) Save(movementModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == movementModel.GetLoadedFromStorage() {
+		//update operation
+		err = sm.Update(movementModel)
+	} else {
+		//insert operation
+		err = sm.Insert(movementModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (sm *StockMovement) WithTx(tx *sql.Tx) DataMapper {
+	return &StockMovement{
+		txScope: sm.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sm *StockMovement) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (sm *StockMovement) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,296 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//Supplier is a struct of datamapper for supplier domain model
+type Supplier struct {
+	txScope
+}
+
+//NewSupplier creates a new Supplier datamapper and returns a pointer to it
+func NewSupplier(dbSession *sql.DB, dialect Dialect) *Supplier {
+	return &Supplier{
+		txScope: newTxScope(dbSession, dialect),
+	}
+}
+
+//supplierSelect is the query selecting suppliers along with their items (one row per item, a supplier without items has a single row of null item columns)
+//rows of a supplier are adjacent (ordered by supplier id) so they can be streamed into models, see loadRows
+const supplierSelect = "SELECT s.SUPPLIER_ID, s.NAME, s.CONTACT_NAME, s.PHONE, s.EMAIL, s.ADDRESS, s.PAYMENT_TERM_DAYS, i.SKU, i.SUPPLIER_SKU, i.LAST_PRICE, DATETIME(i.LAST_PURCHASE_DATE) FROM suppliers s LEFT JOIN supplier_items i ON i.SUPPLIER_ID = s.SUPPLIER_ID"
+
+//supplierOrder is the order of the rows of supplierSelect
+const supplierOrder = " ORDER BY s.SUPPLIER_ID ASC, i.SKU ASC"
+
+//FindByID is a function for finding a record by id
+func (s *Supplier) FindByID(id string) (model.Model, *errors.Error) {
+	stmt, err := s.conn().Prepare(supplierSelect + " WHERE s.SUPPLIER_ID = ?" + supplierOrder)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	returnedRow, errs := s.loadRows(rows)
+	if errs != nil {
+		return nil, errs
+	}
+	if len(returnedRow) == 0 {
+		return nil, errors.Wrap(ErrNotFound, 0)
+	}
+	return returnedRow[0], nil
+}
+
+//FindAll is a function for finding all records
+func (s *Supplier) FindAll() ([]model.Model, *errors.Error) {
+	rows, err := s.conn().Query(supplierSelect + supplierOrder)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return s.loadRows(rows)
+}
+
+//loadRows is a function for composing supplier models (along with their items) from the given rows of supplierSelect, the rows are closed afterwards
+func (s *Supplier) loadRows(rows *sql.Rows) ([]model.Model, *errors.Error) {
+	defer rows.Close()
+
+	var supplierID, name, contactName, phone, email, address sql.NullString
+	var paymentTermDays sql.NullInt64
+	var sku, supplierSku, lastPurchaseDate sql.NullString
+	var lastPrice sql.NullInt64
+
+	var returnedRow []model.Model
+	var supplierModel *model.Supplier
+	for rows.Next() {
+		err := rows.Scan(&supplierID, &name, &contactName, &phone, &email, &address, &paymentTermDays, &sku, &supplierSku, &lastPrice, &lastPurchaseDate)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		//first row of a supplier
+		if supplierModel == nil || supplierModel.SupplierID != supplierID.String {
+			supplierModel = &model.Supplier{
+				SupplierID:      supplierID.String,
+				Name:            name.String,
+				ContactName:     contactName.String,
+				Phone:           phone.String,
+				Email:           email.String,
+				Address:         address.String,
+				PaymentTermDays: paymentTermDays.Int64,
+				Items:           make(map[string]*model.SupplierItem),
+			}
+			supplierModel.SetLoadedFromStorage(true)
+			returnedRow = append(returnedRow, supplierModel)
+		}
+		//supplier without items
+		if false == sku.Valid {
+			continue
+		}
+
+		supplierItemModel := &model.SupplierItem{
+			Sku:         sku.String,
+			SupplierSku: supplierSku.String,
+			LastPrice:   model.Money(lastPrice.Int64),
+		}
+		if true == lastPurchaseDate.Valid {
+			supplierItemModel.LastPurchaseDate, err = time.Parse(timeFormat, lastPurchaseDate.String)
+			if err != nil {
+				return nil, errors.Wrap(err, 0)
+			}
+		}
+		supplierItemModel.SetLoadedFromStorage(true)
+
+		supplierModel.Items[sku.String] = supplierItemModel
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return returnedRow, nil
+}
+
+//nullTime is a function for composing the stored value of a nullable date, a zero time is stored as NULL
+func nullTime(value time.Time) sql.NullString {
+	if true == value.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value.Format(timeFormat), Valid: true}
+}
+
+//insertItem is a function for inserting an item of a supplier (using passed transaction handler)
+func (s *Supplier) insertItem(supplierID string, itemObj *model.SupplierItem, tx *sql.Tx) *errors.Error {
+	stmt, err := s.on(tx).Prepare("INSERT INTO supplier_items(SUPPLIER_ID, SKU, SUPPLIER_SKU, LAST_PRICE, LAST_PURCHASE_DATE) values(?,?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(supplierID, itemObj.Sku, nullText(itemObj.SupplierSku), itemObj.LastPrice, nullTime(itemObj.LastPurchaseDate))
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Insert is a function for inserting a record
+func (s *Supplier) Insert(supplierModel model.Model) *errors.Error {
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		return s.InsertWithTx(supplierModel, tx)
+	})
+}
+
+//InsertWithTx is a function for inserting a record (using passed transaction handler)
+func (s *Supplier) InsertWithTx(supplierModel model.Model, tx *sql.Tx) *errors.Error {
+	supplierModelObj, ok := supplierModel.(*model.Supplier)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Supplier"), 0)
+	}
+
+	foundModel, _ := s.WithTx(tx).FindByID(supplierModel.GetID())
+	if foundModel != nil {
+		return errors.Wrap(fmt.Errorf("cannot insert, model with id: %v already exists", supplierModel.GetID()), 0)
+	}
+
+	stmt, err := s.on(tx).Prepare("INSERT INTO suppliers(SUPPLIER_ID, NAME, CONTACT_NAME, PHONE, EMAIL, ADDRESS, PAYMENT_TERM_DAYS) values(?,?,?,?,?,?,?)")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(supplierModelObj.SupplierID, supplierModelObj.Name, nullText(supplierModelObj.ContactName), nullText(supplierModelObj.Phone), nullText(supplierModelObj.Email), nullText(supplierModelObj.Address), supplierModelObj.PaymentTermDays)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	//insert the items
+	for _, val := range supplierModelObj.Items {
+		errs := s.insertItem(supplierModelObj.SupplierID, val, tx)
+		if errs != nil {
+			return errs
+		}
+	}
+	return nil
+}
+
+//Update is a function for updating record
+func (s *Supplier) Update(supplierModel model.Model) *errors.Error {
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		return s.UpdateWithTx(supplierModel, tx)
+	})
+}
+
+//UpdateWithTx is a function for updating record (using passed transaction handler)
+//new items (not loaded from storage) are added, items missing from the model are kept
+func (s *Supplier) UpdateWithTx(supplierModel model.Model, tx *sql.Tx) *errors.Error {
+	supplierModelObj, ok := supplierModel.(*model.Supplier)
+	if false == ok {
+		return errors.Wrap(fmt.Errorf("Failed asserting to *model.Supplier"), 0)
+	}
+
+	_, errs := s.WithTx(tx).FindByID(supplierModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot update, model with id: %v doesn't exist", supplierModel.GetID()), 0)
+	}
+
+	stmt, err := s.on(tx).Prepare("UPDATE suppliers SET NAME=?, CONTACT_NAME=?, PHONE=?, EMAIL=?, ADDRESS=?, PAYMENT_TERM_DAYS=? WHERE SUPPLIER_ID=?")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(supplierModelObj.Name, nullText(supplierModelObj.ContactName), nullText(supplierModelObj.Phone), nullText(supplierModelObj.Email), nullText(supplierModelObj.Address), supplierModelObj.PaymentTermDays, supplierModelObj.SupplierID)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	//update items
+	for _, val := range supplierModelObj.Items {
+		if false == val.GetLoadedFromStorage() {
+			errs = s.insertItem(supplierModelObj.SupplierID, val, tx)
+			if errs != nil {
+				return errs
+			}
+			continue
+		}
+		itemStmt, err := s.on(tx).Prepare("UPDATE supplier_items SET SUPPLIER_SKU=?, LAST_PRICE=?, LAST_PURCHASE_DATE=? WHERE SUPPLIER_ID=? AND SKU=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer itemStmt.Close()
+		_, err = itemStmt.Exec(nullText(val.SupplierSku), val.LastPrice, nullTime(val.LastPurchaseDate), supplierModelObj.SupplierID, val.Sku)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+	}
+	return nil
+}
+
+//Delete is a function for deleting record
+func (s *Supplier) Delete(supplierModel model.Model) *errors.Error {
+	_, errs := s.FindByID(supplierModel.GetID())
+	if errs != nil && errs.Err == ErrNotFound {
+		return errors.Wrap(fmt.Errorf("cannot delete, model with id: %v doesn't exist", supplierModel.GetID()), 0)
+	}
+
+	return s.inTx(func(tx *sql.Tx) *errors.Error {
+		//delete items
+		itemStmt, err := s.on(tx).Prepare("DELETE FROM supplier_items WHERE SUPPLIER_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer itemStmt.Close()
+		_, err = itemStmt.Exec(supplierModel.GetID())
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+
+		//delete the model
+		stmt, err := s.on(tx).Prepare("DELETE FROM suppliers WHERE SUPPLIER_ID=?")
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		defer stmt.Close()
+		_, err = stmt.Exec(supplierModel.GetID())
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return nil
+	})
+}
+
+//Save is a function for persisting a model object to db
+func (s *Supplier) Save(supplierModel model.Model) *errors.Error {
+	var err *errors.Error
+	if true == supplierModel.GetLoadedFromStorage() {
+		//update operation
+		err = s.Update(supplierModel)
+	} else {
+		//insert operation
+		err = s.Insert(supplierModel)
+	}
+	return err
+}
+
+//WithTx is a function for returning a copy of the datamapper bound to the given transaction handler
+func (s *Supplier) WithTx(tx *sql.Tx) DataMapper {
+	return &Supplier{
+		txScope: s.bind(tx),
+	}
+}
+
+//StartUp allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (s *Supplier) StartUp() {
+	//Note: Perform any initialization or bootstrapping here
+}
+
+//Shutdown allows the datamapper to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (s *Supplier) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,82 @@
+//Package datamapper provides the definitions of datamapper
+package datamapper
+
+import (
+	"database/sql"
+
+	"github.com/go-errors/errors"
+)
+
+//querier is an interface satisfied by both *sql.DB and *sql.Tx, so queries can run either on the db session or inside a transaction
+type querier interface {
+	Prepare(query string) (*sql.Stmt, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+//txScope is a struct holding the db session of a datamapper along with the transaction the datamapper is bound to (if any)
+type txScope struct {
+	db      *sql.DB
+	tx      *sql.Tx //transaction the datamapper is bound to (see TxBinder), nil when running on the db session
+	dialect Dialect //dialect of the database behind the db session
+}
+
+//newTxScope is a function for creating the scope of a datamapper running on the given db session
+//the sqlite dialect is used when no dialect is given
+func newTxScope(dbSession *sql.DB, dialect Dialect) txScope {
+	if dialect == nil {
+		dialect = SQLite
+	}
+	return txScope{
+		db:      dbSession,
+		dialect: dialect,
+	}
+}
+
+//conn is a function for returning the handler queries must run on (the bound transaction if any, otherwise the db session)
+func (ts txScope) conn() querier {
+	if ts.tx != nil {
+		return ts.on(ts.tx)
+	}
+	return ts.on(ts.db)
+}
+
+//on is a function for returning the given handler with its queries translated into the dialect of the datamapper
+func (ts txScope) on(conn querier) querier {
+	return dialectConn{
+		conn:    conn,
+		dialect: ts.dialect,
+	}
+}
+
+//bind is a function for returning a copy of the scope bound to the given transaction handler
+func (ts txScope) bind(tx *sql.Tx) txScope {
+	return txScope{
+		db:      ts.db,
+		tx:      tx,
+		dialect: ts.dialect,
+	}
+}
+
+//inTx is a function for running fn inside the bound transaction
+//when the datamapper isn't bound, fn runs inside a new transaction which is committed when fn succeeds (rolled back otherwise)
+func (ts txScope) inTx(fn func(tx *sql.Tx) *errors.Error) *errors.Error {
+	if ts.tx != nil {
+		return fn(ts.tx)
+	}
+	//start transaction
+	tx, err := ts.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	errs := fn(tx)
+	if errs != nil {
+		tx.Rollback()
+		return errs
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+//Package forecast provides demand forecasting from the quantities sold by completed sales (see service.ForecastDemand)
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+//DefaultConfidence is the default probability of a forecast period falling within its prediction interval
+const DefaultConfidence float64 = 0.95
+
+//Point is the forecast demand of a period along with its prediction interval
+type Point struct {
+	Date  time.Time `json:"date"` //first day of the period
+	Value float64   `json:"value"`
+	Lower float64   `json:"lower"`
+	Upper float64   `json:"upper"`
+}
+
+//Forecast is the forecast of the periods following a demand series
+type Forecast struct {
+	Model      string   `json:"model"`
+	Confidence float64  `json:"confidence"`
+	StdDev     float64  `json:"stdDev"` //standard deviation of the one step ahead errors of the model over the series
+	Points     []*Point `json:"points"`
+}
+
+//Accuracy is the accuracy of a model forecasting the last (holdout) periods of a series from the periods before them
+type Accuracy struct {
+	Model    string  `json:"model"`
+	Holdout  int     `json:"holdout"`
+	MAE      float64 `json:"mae"`      //mean absolute error
+	RMSE     float64 `json:"rmse"`     //root mean squared error
+	Bias     float64 `json:"bias"`     //mean of forecast - actual demand, positive when the model overforecasts
+	Coverage float64 `json:"coverage"` //share of the actual demand within the prediction intervals
+}
+
+//zScore is a function for returning the count of standard deviations around the mean holding the given probability of a normal distribution
+func zScore(confidence float64) float64 {
+	return math.Sqrt2 * math.Erfinv(confidence)
+}
+
+//stdDev is a function for returning the standard deviation of the one step ahead errors (predictions still lacking history are left out)
+func stdDev(values, fitted []float64) float64 {
+	sum := 0.0
+	count := 0
+	for index, val := range fitted {
+		if true == math.IsNaN(val) {
+			continue
+		}
+		sum += (values[index] - val) * (values[index] - val)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return math.Sqrt(sum / float64(count))
+}
+
+//Predict is a function for fitting the model to the series and forecasting the demand of the next horizon periods
+//the interval of the h-th period is the forecast +/- z * stdDev * sqrt(h) (z of the normal distribution holding the confidence), demand is never forecast below 0
+func Predict(series *Series, model Model, horizon int, confidence float64) (*Forecast, error) {
+	if horizon <= 0 {
+		return nil, fmt.Errorf("Invalid horizon %v", horizon)
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return nil, fmt.Errorf("Invalid confidence %v", confidence)
+	}
+	fitted, values, err := model.Fit(series.Values, horizon)
+	if err != nil {
+		return nil, err
+	}
+	result := &Forecast{
+		Model:      model.Name(),
+		Confidence: confidence,
+		StdDev:     stdDev(series.Values, fitted),
+		Points:     make([]*Point, 0, horizon),
+	}
+	z := zScore(confidence)
+	for index, val := range values {
+		margin := z * result.StdDev * math.Sqrt(float64(index+1))
+		result.Points = append(result.Points, &Point{
+			Date:  series.PeriodDate(len(series.Values) + index),
+			Value: math.Max(val, 0),
+			Lower: math.Max(val-margin, 0),
+			Upper: math.Max(val+margin, 0),
+		})
+	}
+	return result, nil
+}
+
+//Backtest is a function for measuring the accuracy of the model forecasting the last holdout periods of the series from the periods before them
+func Backtest(series *Series, model Model, holdout int, confidence float64) (*Accuracy, error) {
+	if holdout <= 0 || holdout >= len(series.Values) {
+		return nil, fmt.Errorf("Invalid holdout of %v periods for a series of %v periods", holdout, len(series.Values))
+	}
+	training := &Series{
+		Granularity: series.Granularity,
+		Start:       series.Start,
+		Values:      series.Values[:len(series.Values)-holdout],
+	}
+	result, err := Predict(training, model, holdout, confidence)
+	if err != nil {
+		return nil, err
+	}
+
+	accuracy := &Accuracy{
+		Model:   model.Name(),
+		Holdout: holdout,
+	}
+	covered := 0
+	for index, val := range result.Points {
+		actual := series.Values[len(training.Values)+index]
+		accuracy.MAE += math.Abs(val.Value - actual)
+		accuracy.RMSE += (val.Value - actual) * (val.Value - actual)
+		accuracy.Bias += val.Value - actual
+		if actual >= val.Lower && actual <= val.Upper {
+			covered++
+		}
+	}
+	accuracy.MAE /= float64(holdout)
+	accuracy.RMSE = math.Sqrt(accuracy.RMSE / float64(holdout))
+	accuracy.Bias /= float64(holdout)
+	accuracy.Coverage = float64(covered) / float64(holdout)
+	return accuracy, nil
+}
+
+//SelectModel is a function for backtesting the models and returning the most accurate one (lowest MAE, the first of the models on a tie)
+//models lacking history for the backtest are left out, the accuracy of the backtested models is returned in the order of the models
+func SelectModel(series *Series, models []Model, holdout int, confidence float64) (Model, []*Accuracy, error) {
+	var selected Model
+	var selectedMAE float64
+	accuracies := make([]*Accuracy, 0, len(models))
+	for _, val := range models {
+		accuracy, err := Backtest(series, val, holdout, confidence)
+		if err != nil {
+			continue
+		}
+		accuracies = append(accuracies, accuracy)
+		if selected == nil || accuracy.MAE < selectedMAE {
+			selected = val
+			selectedMAE = accuracy.MAE
+		}
+	}
+	if selected == nil {
+		return nil, nil, fmt.Errorf("None of the models can be backtested on %v periods of history", len(series.Values))
+	}
+	return selected, accuracies, nil
+}
@@ -0,0 +1,203 @@
+package forecast_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"ijah-inventory/repository/inventory/domain/inventory/forecast"
+)
+
+//newSeasonalSeries is a function for composing 8 weeks of daily demand growing by half an item a day, with weekends selling 10 items more
+func newSeasonalSeries() *forecast.Series {
+	//2018-01-01 is a Monday
+	series := &forecast.Series{
+		Granularity: forecast.Daily,
+		Start:       time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	pattern := []float64{0, 1, 0, 2, 3, 10, 10}
+	for index := 0; index < 56; index++ {
+		series.Values = append(series.Values, 5+0.5*float64(index)+pattern[index%7])
+	}
+	return series
+}
+
+func TestNewSeries(t *testing.T) {
+	observations := []forecast.Observation{
+		{Date: time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC), Quantity: 2},
+		{Date: time.Date(2018, 1, 1, 18, 0, 0, 0, time.UTC), Quantity: 3},
+		{Date: time.Date(2018, 1, 3, 9, 0, 0, 0, time.UTC), Quantity: 4},
+		{Date: time.Date(2018, 1, 8, 9, 0, 0, 0, time.UTC), Quantity: 1},
+		{Date: time.Date(2018, 2, 1, 9, 0, 0, 0, time.UTC), Quantity: 7},
+	}
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2018, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	series, err := forecast.NewSeries(observations, forecast.Daily, start, end)
+	t.Run("daily series must sum the observations of every day", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(series.Values) != 10 || series.Values[0] != 5 || series.Values[1] != 0 || series.Values[2] != 4 || series.Values[7] != 1 {
+			t.Errorf("expected [5 0 4 0 0 0 0 1 0 0] but got %v", series.Values)
+		}
+	})
+
+	//2018-01-03 is a Wednesday
+	series, err = forecast.NewSeries(observations, forecast.Weekly, start.AddDate(0, 0, 2), end)
+	t.Run("weekly series must sum the observations of every week from Monday", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if false == series.Start.Equal(start) || len(series.Values) != 2 || series.Values[0] != 9 || series.Values[1] != 1 {
+			t.Errorf("expected [9 1] from %v but got %v from %v", start, series.Values, series.Start)
+		}
+		if false == series.PeriodDate(2).Equal(time.Date(2018, 1, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected third week on 2018-01-15 but got %v", series.PeriodDate(2))
+		}
+	})
+
+	_, err = forecast.NewSeries(observations, "month", start, end)
+	_, errs := forecast.NewSeries(observations, forecast.Daily, end, start)
+	t.Run("invalid granularity and dates must be rejected", func(t *testing.T) {
+		if err == nil || errs == nil {
+			t.Errorf("expected errors but got %v and %v", err, errs)
+		}
+	})
+}
+
+func TestModels(t *testing.T) {
+	constant := []float64{4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4}
+	for _, val := range forecast.DefaultModels(7) {
+		fitted, values, err := val.Fit(constant, 3)
+		t.Run(val.Name()+" must forecast a constant series as it is", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("expected nil but got %v", err)
+			}
+			if len(fitted) != len(constant) || false == math.IsNaN(fitted[0]) || fitted[len(fitted)-1] != 4 {
+				t.Errorf("expected fitted values of 4 but got %v", fitted)
+			}
+			if len(values) != 3 || math.Abs(values[0]-4) > 1e-9 || math.Abs(values[2]-4) > 1e-9 {
+				t.Errorf("expected [4 4 4] but got %v", values)
+			}
+		})
+	}
+
+	_, _, err := (&forecast.MovingAverage{Window: 3}).Fit([]float64{1, 2}, 1)
+	_, _, errs := (&forecast.HoltWinters{Alpha: 0.3, Beta: 0.1, Gamma: 0.2, SeasonLength: 7}).Fit(constant[:13], 1)
+	t.Run("models lacking history must fail", func(t *testing.T) {
+		if err == nil || errs == nil {
+			t.Errorf("expected errors but got %v and %v", err, errs)
+		}
+	})
+
+	_, values, err := (&forecast.MovingAverage{Window: 2}).Fit([]float64{1, 2, 3, 5}, 1)
+	t.Run("moving average must forecast the mean of the last periods", func(t *testing.T) {
+		if err != nil || values[0] != 4 {
+			t.Errorf("expected 4 but got %v and %v", values, err)
+		}
+	})
+
+	_, values, err = (&forecast.ExponentialSmoothing{Alpha: 0.5}).Fit([]float64{2, 4, 8}, 1)
+	t.Run("exponential smoothing must forecast the smoothed level", func(t *testing.T) {
+		//2 -> 3 -> 5.5
+		if err != nil || values[0] != 5.5 {
+			t.Errorf("expected 5.5 but got %v and %v", values, err)
+		}
+	})
+
+	_, err = forecast.ModelByName("arima", 7)
+	t.Run("unknown model must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+func TestPredict(t *testing.T) {
+	series := newSeasonalSeries()
+	model, _ := forecast.ModelByName(forecast.ModelHoltWinters, series.SeasonLength())
+
+	result, err := forecast.Predict(series, model, 7, forecast.DefaultConfidence)
+	t.Run("forecast must follow the trend and the weekly season", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(result.Points) != 7 || false == result.Points[0].Date.Equal(time.Date(2018, 2, 26, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("expected 7 days from 2018-02-26 but got %+v", result.Points)
+		}
+		//day 56 (Monday) is 33, day 61 (Saturday) is 45.5
+		if math.Abs(result.Points[0].Value-33) > 1 || math.Abs(result.Points[5].Value-45.5) > 1 {
+			t.Errorf("expected about 33 and 45.5 but got %v and %v", result.Points[0].Value, result.Points[5].Value)
+		}
+	})
+	t.Run("prediction intervals must widen with the horizon", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		for index, val := range result.Points {
+			if val.Lower > val.Value || val.Upper < val.Value {
+				t.Errorf("expected %v within [%v %v]", val.Value, val.Lower, val.Upper)
+			}
+			if index > 0 && val.Upper-val.Value <= result.Points[index-1].Upper-result.Points[index-1].Value {
+				t.Errorf("expected wider interval than the previous period but got %+v", val)
+			}
+		}
+	})
+
+	result, err = forecast.Predict(&forecast.Series{Granularity: forecast.Daily, Values: []float64{9, 0, 0, 0, 0}}, &forecast.ExponentialSmoothing{Alpha: 0.5}, 2, 0.8)
+	t.Run("demand must not be forecast below 0", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if result.Points[1].Lower != 0 || result.Points[1].Value < 0 {
+			t.Errorf("expected lower bound 0 but got %+v", result.Points[1])
+		}
+	})
+
+	_, err = forecast.Predict(series, model, 0, forecast.DefaultConfidence)
+	_, errs := forecast.Predict(series, model, 7, 1)
+	t.Run("invalid horizon and confidence must be rejected", func(t *testing.T) {
+		if err == nil || errs == nil {
+			t.Errorf("expected errors but got %v and %v", err, errs)
+		}
+	})
+}
+
+func TestBacktest(t *testing.T) {
+	series := newSeasonalSeries()
+	models := forecast.DefaultModels(series.SeasonLength())
+
+	selected, accuracies, err := forecast.SelectModel(series, models, 14, forecast.DefaultConfidence)
+	t.Run("seasonal demand must be forecast best by Holt-Winters", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if selected.Name() != forecast.ModelHoltWinters || len(accuracies) != 3 {
+			t.Fatalf("expected holtWinters out of 3 models but got %v out of %+v", selected.Name(), accuracies)
+		}
+		if accuracies[2].MAE >= accuracies[0].MAE || accuracies[2].MAE >= accuracies[1].MAE || accuracies[2].MAE > 1 {
+			t.Errorf("expected holtWinters MAE below 1 and below the other models but got %+v, %+v and %+v", accuracies[0], accuracies[1], accuracies[2])
+		}
+		if accuracies[0].Bias >= 0 {
+			t.Errorf("expected moving average to underforecast growing demand but got bias %v", accuracies[0].Bias)
+		}
+	})
+
+	_, accuracies, err = forecast.SelectModel(series, models, 45, forecast.DefaultConfidence)
+	t.Run("models lacking history must be left out", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(accuracies) != 2 {
+			t.Errorf("expected 2 backtested models but got %+v", accuracies)
+		}
+	})
+
+	_, err = forecast.Backtest(series, models[0], 56, forecast.DefaultConfidence)
+	t.Run("holdout of the whole series must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
@@ -0,0 +1,178 @@
+//Package forecast provides demand forecasting from the quantities sold by completed sales (see service.ForecastDemand)
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+//ModelMovingAverage is the name of the moving average model
+const ModelMovingAverage string = "movingAverage"
+
+//ModelExponentialSmoothing is the name of the simple exponential smoothing model
+const ModelExponentialSmoothing string = "exponentialSmoothing"
+
+//ModelHoltWinters is the name of the Holt-Winters (additive trend and seasonality) model
+const ModelHoltWinters string = "holtWinters"
+
+//Model is an interface of the forecasting models fitted to a demand series
+type Model interface {
+	//Name returns the name of the model (see Model consts)
+	Name() string
+	//Fit returns the one step ahead prediction of every value (NaN while the model lacks history) and the forecast of the next horizon periods
+	Fit(values []float64, horizon int) ([]float64, []float64, error)
+}
+
+//MovingAverage is a model forecasting the mean of the last Window periods
+type MovingAverage struct {
+	Window int
+}
+
+//Name returns the name of the model
+func (m *MovingAverage) Name() string {
+	return ModelMovingAverage
+}
+
+//Fit returns the one step ahead predictions and the forecast of the next horizon periods
+func (m *MovingAverage) Fit(values []float64, horizon int) ([]float64, []float64, error) {
+	if m.Window <= 0 {
+		return nil, nil, fmt.Errorf("Invalid moving average window %v", m.Window)
+	}
+	if len(values) < m.Window {
+		return nil, nil, fmt.Errorf("Moving average of %v periods needs at least %v periods of history", m.Window, m.Window)
+	}
+	fitted := make([]float64, len(values))
+	sum := 0.0
+	for index, val := range values {
+		if index < m.Window {
+			fitted[index] = math.NaN()
+		} else {
+			fitted[index] = sum / float64(m.Window)
+			sum -= values[index-m.Window]
+		}
+		sum += val
+	}
+	return fitted, repeat(sum/float64(m.Window), horizon), nil
+}
+
+//ExponentialSmoothing is a model forecasting the level of the series, smoothed by Alpha (0 < Alpha <= 1, higher follows recent periods closer)
+type ExponentialSmoothing struct {
+	Alpha float64
+}
+
+//Name returns the name of the model
+func (m *ExponentialSmoothing) Name() string {
+	return ModelExponentialSmoothing
+}
+
+//Fit returns the one step ahead predictions and the forecast of the next horizon periods
+func (m *ExponentialSmoothing) Fit(values []float64, horizon int) ([]float64, []float64, error) {
+	if m.Alpha <= 0 || m.Alpha > 1 {
+		return nil, nil, fmt.Errorf("Invalid smoothing factor %v", m.Alpha)
+	}
+	if len(values) == 0 {
+		return nil, nil, fmt.Errorf("Exponential smoothing needs at least 1 period of history")
+	}
+	fitted := make([]float64, len(values))
+	fitted[0] = math.NaN()
+	level := values[0]
+	for index := 1; index < len(values); index++ {
+		fitted[index] = level
+		level = m.Alpha*values[index] + (1-m.Alpha)*level
+	}
+	return fitted, repeat(level, horizon), nil
+}
+
+//HoltWinters is a model forecasting the level, trend and seasonality (cycle of SeasonLength periods) of the series, smoothed by Alpha, Beta and Gamma respectively
+type HoltWinters struct {
+	Alpha        float64
+	Beta         float64
+	Gamma        float64
+	SeasonLength int
+}
+
+//Name returns the name of the model
+func (m *HoltWinters) Name() string {
+	return ModelHoltWinters
+}
+
+//Fit returns the one step ahead predictions and the forecast of the next horizon periods
+//the level and trend start from the first two seasons and the seasonal components from the first season
+func (m *HoltWinters) Fit(values []float64, horizon int) ([]float64, []float64, error) {
+	for _, val := range []float64{m.Alpha, m.Beta, m.Gamma} {
+		if val < 0 || val > 1 {
+			return nil, nil, fmt.Errorf("Invalid smoothing factor %v", val)
+		}
+	}
+	length := m.SeasonLength
+	if length < 2 {
+		return nil, nil, fmt.Errorf("Invalid season length %v", length)
+	}
+	if len(values) < 2*length {
+		return nil, nil, fmt.Errorf("Holt-Winters with seasons of %v periods needs at least %v periods of history", length, 2*length)
+	}
+
+	level := mean(values[:length])
+	trend := (mean(values[length:2*length]) - level) / float64(length)
+	seasonal := make([]float64, len(values))
+	fitted := make([]float64, len(values))
+	for index := 0; index < length; index++ {
+		seasonal[index] = values[index] - level
+		fitted[index] = math.NaN()
+	}
+	for index := length; index < len(values); index++ {
+		fitted[index] = level + trend + seasonal[index-length]
+		previousLevel := level
+		level = m.Alpha*(values[index]-seasonal[index-length]) + (1-m.Alpha)*(level+trend)
+		trend = m.Beta*(level-previousLevel) + (1-m.Beta)*trend
+		seasonal[index] = m.Gamma*(values[index]-level) + (1-m.Gamma)*seasonal[index-length]
+	}
+
+	forecast := make([]float64, horizon)
+	for step := 1; step <= horizon; step++ {
+		forecast[step-1] = level + float64(step)*trend + seasonal[len(values)-length+(step-1)%length]
+	}
+	return fitted, forecast, nil
+}
+
+//DefaultModels is a function for returning the models fitted to a series of the given season length (see Series.SeasonLength)
+func DefaultModels(seasonLength int) []Model {
+	//a week of days, a month of weeks
+	window := seasonLength
+	if window > 7 {
+		window = 4
+	}
+	return []Model{
+		&MovingAverage{Window: window},
+		&ExponentialSmoothing{Alpha: 0.3},
+		&HoltWinters{Alpha: 0.3, Beta: 0.1, Gamma: 0.2, SeasonLength: seasonLength},
+	}
+}
+
+//ModelByName is a function for returning the default model of the given name (see Model consts)
+func ModelByName(name string, seasonLength int) (Model, error) {
+	for _, val := range DefaultModels(seasonLength) {
+		if val.Name() == name {
+			return val, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid model %v", name)
+}
+
+//repeat is a function for returning a slice of count copies of the value
+func repeat(value float64, count int) []float64 {
+	values := make([]float64, count)
+	for index := range values {
+		values[index] = value
+	}
+	return values
+}
+
+//mean is a function for returning the mean of the values
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, val := range values {
+		sum += val
+	}
+	return sum / float64(len(values))
+}
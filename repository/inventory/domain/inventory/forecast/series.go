@@ -0,0 +1,106 @@
+//Package forecast provides demand forecasting from the quantities sold by completed sales (see service.ForecastDemand)
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+//Daily is const for series of daily demand
+const Daily string = "day"
+
+//Weekly is const for series of weekly demand (weeks start on Monday)
+const Weekly string = "week"
+
+//Observation is a quantity sold at a point in time (e.g. an item of a completed sale)
+type Observation struct {
+	Date     time.Time
+	Quantity float64
+}
+
+//Period is the demand of a period of a series
+type Period struct {
+	Date     time.Time `json:"date"` //first day of the period
+	Quantity float64   `json:"quantity"`
+}
+
+//Series is a demand series, the quantity sold in every period from its start (0 for the periods without sales)
+type Series struct {
+	Granularity string
+	Start       time.Time //first day of the first period
+	Values      []float64
+}
+
+//PeriodStart is a function for returning the first day of the period the given date falls in
+func PeriodStart(date time.Time, granularity string) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if granularity == Weekly {
+		//time.Sunday is 0
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
+
+//NewSeries is a function for building the demand series of the periods from start to end (inclusive), observations outside the periods are left out
+func NewSeries(observations []Observation, granularity string, start, end time.Time) (*Series, error) {
+	if granularity != Daily && granularity != Weekly {
+		return nil, fmt.Errorf("Invalid granularity %v", granularity)
+	}
+	series := &Series{
+		Granularity: granularity,
+		Start:       PeriodStart(start, granularity),
+	}
+	endPeriod := PeriodStart(end, granularity)
+	if endPeriod.Before(series.Start) {
+		return nil, fmt.Errorf("Invalid start date %v and end date %v", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+	series.Values = make([]float64, series.index(endPeriod)+1)
+	for _, val := range observations {
+		index := series.index(PeriodStart(val.Date, granularity))
+		if index < 0 || index >= len(series.Values) {
+			continue
+		}
+		series.Values[index] += val.Quantity
+	}
+	return series, nil
+}
+
+//index is a function for returning the index of the period starting on the given date
+func (s *Series) index(periodStart time.Time) int {
+	//rounded since days aren't always 24 hours long across daylight saving changes
+	days := int(math.Round(periodStart.Sub(s.Start).Hours() / 24))
+	if s.Granularity == Weekly {
+		//both dates start a week
+		return days / 7
+	}
+	return days
+}
+
+//PeriodDate is a function for returning the first day of the period at the given index (indexes past the last value are forecast periods)
+func (s *Series) PeriodDate(index int) time.Time {
+	if s.Granularity == Weekly {
+		return s.Start.AddDate(0, 0, 7*index)
+	}
+	return s.Start.AddDate(0, 0, index)
+}
+
+//Periods is a function for returning the periods of the series along with their demand
+func (s *Series) Periods() []*Period {
+	periods := make([]*Period, 0, len(s.Values))
+	for index, val := range s.Values {
+		periods = append(periods, &Period{
+			Date:     s.PeriodDate(index),
+			Quantity: val,
+		})
+	}
+	return periods
+}
+
+//SeasonLength is a function for returning the count of periods of the seasonal cycle of the series (a week of days, a year of weeks)
+func (s *Series) SeasonLength() int {
+	if s.Granularity == Weekly {
+		return 52
+	}
+	return 7
+}
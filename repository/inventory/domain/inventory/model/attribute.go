@@ -0,0 +1,58 @@
+//Package model provides the domain model definitions
+package model
+
+//AttributeTypeText is const for attributes holding free text (e.g. brand)
+const AttributeTypeText string = "text"
+
+//AttributeTypeNumber is const for attributes holding a number (e.g. weight)
+const AttributeTypeNumber string = "number"
+
+//AttributeTypeBoolean is const for attributes holding true or false (e.g. waterproof)
+const AttributeTypeBoolean string = "boolean"
+
+//AttributeTypeDate is const for attributes holding a date, formatted as YYYY-MM-DD (e.g. release date)
+const AttributeTypeDate string = "date"
+
+//Attribute is business domain model definition of an attribute stock items can have (e.g. material, brand and season)
+type Attribute struct {
+	Name              string
+	Type              string //type of the values (see AttributeType consts)
+	loadedFromStorage bool   //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (a *Attribute) GetID() string {
+	return a.Name
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (a *Attribute) GetLoadedFromStorage() bool {
+	return a.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (a *Attribute) SetLoadedFromStorage(flagValue bool) {
+	a.loadedFromStorage = flagValue
+}
+
+//ItemAttributes is business domain model definition of the attribute values of a stock item
+type ItemAttributes struct {
+	Sku               string
+	Values            map[string]string //attribute name -> value, stored as text in the format of the attribute type
+	loadedFromStorage bool              //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (ia *ItemAttributes) GetID() string {
+	return ia.Sku
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (ia *ItemAttributes) GetLoadedFromStorage() bool {
+	return ia.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (ia *ItemAttributes) SetLoadedFromStorage(flagValue bool) {
+	ia.loadedFromStorage = flagValue
+}
@@ -0,0 +1,25 @@
+//Package model provides the domain model definitions
+package model
+
+//Category is business domain model definition of a node of the category tree stock items are classified by (see Stock CategoryID)
+type Category struct {
+	CategoryID        string
+	Name              string
+	ParentID          string //parent category, empty for root categories
+	loadedFromStorage bool   //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (c *Category) GetID() string {
+	return c.CategoryID
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (c *Category) GetLoadedFromStorage() bool {
+	return c.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (c *Category) SetLoadedFromStorage(flagValue bool) {
+	c.loadedFromStorage = flagValue
+}
@@ -0,0 +1,40 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+//CostLayer is business domain model definition of a batch of stock bought at the same unit cost
+//Layers are created when items come into stock and consumed when items go out of stock
+type CostLayer struct {
+	id                int64
+	Sku               string
+	Quantity          int64     //quantity of items coming into stock when the layer is created
+	RemainingQuantity int64     //quantity of items of the layer which haven't been consumed
+	UnitCost          Money     //buy price of a single item of the layer
+	Reference         string    //id of the document creating the layer (e.g. purchase id)
+	Date              time.Time //timestamp of the layer creation
+	loadedFromStorage bool      //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (cl *CostLayer) GetID() string {
+	return strconv.FormatInt(cl.id, 10)
+}
+
+//SetID is a function for setting id of the model
+func (cl *CostLayer) SetID(id int64) {
+	cl.id = id
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (cl *CostLayer) GetLoadedFromStorage() bool {
+	return cl.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (cl *CostLayer) SetLoadedFromStorage(flagValue bool) {
+	cl.loadedFromStorage = flagValue
+}
@@ -0,0 +1,67 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//Money is an amount of money in (integer) rupiah
+//Amounts are kept as integer so totals don't pick up floating point rounding errors
+type Money int64
+
+//ParseMoney is a function for parsing a decimal string (e.g. "55000" or "61999.99") into Money
+//fraction of rupiah is rounded to the nearest rupiah (half away from zero)
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	parts := strings.Split(value, ".")
+	if len(parts) > 2 || parts[0] == "" || parts[0] == "-" || parts[0] == "+" {
+		return 0, fmt.Errorf("Invalid money amount %v", value)
+	}
+	amount, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid money amount %v", value)
+	}
+	if len(parts) == 2 {
+		fraction := parts[1]
+		for _, digit := range fraction {
+			if digit < '0' || digit > '9' {
+				return 0, fmt.Errorf("Invalid money amount %v", value)
+			}
+		}
+		if len(fraction) > 0 && fraction[0] >= '5' {
+			if strings.HasPrefix(parts[0], "-") {
+				amount--
+			} else {
+				amount++
+			}
+		}
+	}
+	return Money(amount), nil
+}
+
+//Multiply is a function for returning the amount multiplied by the given quantity
+func (m Money) Multiply(quantity int64) Money {
+	return m * Money(quantity)
+}
+
+//Divide is a function for returning the amount divided by the given quantity
+//the result is rounded to the nearest rupiah (half away from zero)
+func (m Money) Divide(quantity int64) Money {
+	if quantity == 0 {
+		return 0
+	}
+	if quantity < 0 {
+		return (-m).Divide(-quantity)
+	}
+	if m < 0 {
+		return -((-m).Divide(quantity))
+	}
+	return (m + Money(quantity/2)) / Money(quantity)
+}
+
+//String is a function for returning the amount as a decimal string (e.g. "55000")
+func (m Money) String() string {
+	return strconv.FormatInt(int64(m), 10)
+}
@@ -0,0 +1,65 @@
+//model_test provides unit tests for domain model
+package model_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	validCases := map[string]model.Money{
+		"55000":                 55000,
+		"61999.999999999999998": 62000,
+		"61999.49":              61999,
+		"61999.5":               62000,
+		"-100.5":                -101,
+		"0":                     0,
+	}
+	for input, expected := range validCases {
+		money, err := model.ParseMoney(input)
+		t.Run("parsing "+input+" must be successful", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("expected nil but got %v", err)
+			}
+			if money != expected {
+				t.Errorf("expected %v but got %v", expected, money)
+			}
+		})
+	}
+
+	invalidCases := []string{"", "abc", "1.2.3", "1e5", ".5", "12.3a"}
+	for _, input := range invalidCases {
+		_, err := model.ParseMoney(input)
+		t.Run("parsing "+input+" must fail", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestMoneyDivide(t *testing.T) {
+	t.Run("division must be rounded to the nearest rupiah", func(t *testing.T) {
+		if model.Money(100).Divide(3) != 33 {
+			t.Errorf("expected 33 but got %v", model.Money(100).Divide(3))
+		}
+		if model.Money(200).Divide(3) != 67 {
+			t.Errorf("expected 67 but got %v", model.Money(200).Divide(3))
+		}
+		if model.Money(-5).Divide(2) != -3 {
+			t.Errorf("expected -3 but got %v", model.Money(-5).Divide(2))
+		}
+	})
+	t.Run("division by zero must return zero", func(t *testing.T) {
+		if model.Money(100).Divide(0) != 0 {
+			t.Errorf("expected 0 but got %v", model.Money(100).Divide(0))
+		}
+	})
+}
+
+func TestMoneyMultiply(t *testing.T) {
+	if model.Money(61999).Multiply(154) != 9547846 {
+		t.Errorf("expected 9547846 but got %v", model.Money(61999).Multiply(154))
+	}
+}
@@ -0,0 +1,24 @@
+//Package model provides the domain model definitions
+package model
+
+//Product is business domain model definition of a product, the parent of stock items which are its variants (see Stock ProductID)
+type Product struct {
+	ProductID         string
+	Name              string //name shared by the variants (e.g. "Deklia Plain Casual Blouse" of "Deklia Plain Casual Blouse (L,Navy)")
+	loadedFromStorage bool   //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (p *Product) GetID() string {
+	return p.ProductID
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (p *Product) GetLoadedFromStorage() bool {
+	return p.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (p *Product) SetLoadedFromStorage(flagValue bool) {
+	p.loadedFromStorage = flagValue
+}
@@ -14,9 +14,14 @@ const PurchaseStatusCanceled string = "C"
 //PurchaseStatusDone is const for 'Success/Done' purchase status
 const PurchaseStatusDone string = "S"
 
+//PurchaseStatusPartiallyReceived is const for 'partially received' purchase status
+//Note: this status is never stored, it is computed from the received quantity of the purchase items (see GetComputedStatus)
+const PurchaseStatusPartiallyReceived string = "P"
+
 //Purchase is business domain model definition of a purchase
 type Purchase struct {
 	PurchaseID        string
+	SupplierID        string //supplier the items are purchased from, empty when unknown (see Supplier)
 	Date              time.Time
 	Status            string
 	Note              string
@@ -39,12 +44,42 @@ func (p *Purchase) SetLoadedFromStorage(flagValue bool) {
 	p.loadedFromStorage = flagValue
 }
 
+//GetComputedStatus is a function for returning the purchase status, taking received quantity of the items into account
+//a draft purchase with some (but not all) of its items received is considered as partially received
+func (p *Purchase) GetComputedStatus() string {
+	if p.Status != PurchaseStatusDraft {
+		return p.Status
+	}
+	var received bool
+	for _, val := range p.Items {
+		if val.ReceivedQuantity > 0 {
+			received = true
+			break
+		}
+	}
+	if received {
+		return PurchaseStatusPartiallyReceived
+	}
+	return p.Status
+}
+
+//IsFullyReceived is a function for checking whether all items of the purchase have been received
+func (p *Purchase) IsFullyReceived() bool {
+	for _, val := range p.Items {
+		if val.GetRemainingQuantity() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 //PurchaseItem is a business domain model definition of a purchase item
 type PurchaseItem struct {
 	id                int64
 	Sku               string
-	Quantity          int64
-	BuyPrice          float64
+	Quantity          int64 //ordered quantity
+	ReceivedQuantity  int64 //accumulated quantity received from supplier deliveries
+	BuyPrice          Money
 	Note              string
 	loadedFromStorage bool //flag indicating whether the model object was loaded from storage or not
 }
@@ -59,6 +94,14 @@ func (pi *PurchaseItem) SetID(id int64) {
 	pi.id = id
 }
 
+//GetRemainingQuantity is a function for returning the quantity not yet received (zero if the item is fully or over received)
+func (pi *PurchaseItem) GetRemainingQuantity() int64 {
+	if pi.ReceivedQuantity >= pi.Quantity {
+		return 0
+	}
+	return pi.Quantity - pi.ReceivedQuantity
+}
+
 //GetLoadedFromStorage is a function for returning loaded from storage flag value
 func (pi *PurchaseItem) GetLoadedFromStorage() bool {
 	return pi.loadedFromStorage
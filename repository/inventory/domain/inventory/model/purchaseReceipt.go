@@ -0,0 +1,59 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"time"
+)
+
+//PurchaseReceipt is business domain model definition of a goods receipt (a single supplier delivery) against a purchase
+type PurchaseReceipt struct {
+	ReceiptID         string
+	PurchaseID        string
+	Date              time.Time
+	Note              string
+	Items             map[string]*PurchaseReceiptItem
+	loadedFromStorage bool //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (pr *PurchaseReceipt) GetID() string {
+	return pr.ReceiptID
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (pr *PurchaseReceipt) GetLoadedFromStorage() bool {
+	return pr.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (pr *PurchaseReceipt) SetLoadedFromStorage(flagValue bool) {
+	pr.loadedFromStorage = flagValue
+}
+
+//PurchaseReceiptItem is a business domain model definition of a received item in a goods receipt
+type PurchaseReceiptItem struct {
+	id                int64
+	Sku               string
+	Quantity          int64
+	loadedFromStorage bool //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (pri *PurchaseReceiptItem) GetID() int64 {
+	return pri.id
+}
+
+//SetID is a function for setting id of the model
+func (pri *PurchaseReceiptItem) SetID(id int64) {
+	pri.id = id
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (pri *PurchaseReceiptItem) GetLoadedFromStorage() bool {
+	return pri.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (pri *PurchaseReceiptItem) SetLoadedFromStorage(flagValue bool) {
+	pri.loadedFromStorage = flagValue
+}
@@ -0,0 +1,39 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+//SaleStatusTransition is business domain model definition of a single change of a sale status
+//Sale status transitions are append only, they form the status history of a sale
+type SaleStatusTransition struct {
+	id                int64
+	InvoiceID         string
+	FromStatus        string    //status of the sale before the transition
+	ToStatus          string    //status of the sale after the transition
+	ChangedBy         string    //who made the transition
+	Date              time.Time //timestamp of the transition
+	loadedFromStorage bool      //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (sst *SaleStatusTransition) GetID() string {
+	return strconv.FormatInt(sst.id, 10)
+}
+
+//SetID is a function for setting id of the model
+func (sst *SaleStatusTransition) SetID(id int64) {
+	sst.id = id
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (sst *SaleStatusTransition) GetLoadedFromStorage() bool {
+	return sst.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (sst *SaleStatusTransition) SetLoadedFromStorage(flagValue bool) {
+	sst.loadedFromStorage = flagValue
+}
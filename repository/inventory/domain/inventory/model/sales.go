@@ -44,9 +44,19 @@ type SaleItem struct {
 	id                int64
 	Sku               string
 	Quantity          int64
-	BuyPrice          float64
-	SellPrice         float64
-	loadedFromStorage bool //flag indicating whether the model object was loaded from storage or not
+	BuyPrice          Money
+	SellPrice         Money
+	Cost              Money //exact cost of the sold quantity taken from the consumed cost layers, set when the sale is completed
+	loadedFromStorage bool  //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetCost is a function for returning the cost of the sold quantity
+//items completed before the exact cost was recorded (zero cost) are costed at their buy price
+func (si *SaleItem) GetCost() Money {
+	if si.Cost == 0 {
+		return si.BuyPrice.Multiply(si.Quantity)
+	}
+	return si.Cost
 }
 
 //GetID is a function for returning id of the model
@@ -0,0 +1,71 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"time"
+)
+
+//SalesReturn is business domain model definition of items returned by a customer against a completed sale
+type SalesReturn struct {
+	ReturnID          string
+	InvoiceID         string
+	Date              time.Time
+	Note              string
+	Items             map[string]*SalesReturnItem
+	loadedFromStorage bool //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (sr *SalesReturn) GetID() string {
+	return sr.ReturnID
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (sr *SalesReturn) GetLoadedFromStorage() bool {
+	return sr.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (sr *SalesReturn) SetLoadedFromStorage(flagValue bool) {
+	sr.loadedFromStorage = flagValue
+}
+
+//GetRefundAmount is a function for returning the amount refunded to the customer (sell price of all returned items)
+func (sr *SalesReturn) GetRefundAmount() Money {
+	var refund Money
+	for _, val := range sr.Items {
+		refund += val.SellPrice.Multiply(val.Quantity)
+	}
+	return refund
+}
+
+//SalesReturnItem is a business domain model definition of a returned item, referencing the sale item it was sold with
+type SalesReturnItem struct {
+	id                int64
+	SaleItemID        int64 //id of the returned sale item
+	Sku               string
+	Quantity          int64
+	BuyPrice          Money //cost of the item when it was sold
+	SellPrice         Money //price of the item when it was sold
+	loadedFromStorage bool  //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (sri *SalesReturnItem) GetID() int64 {
+	return sri.id
+}
+
+//SetID is a function for setting id of the model
+func (sri *SalesReturnItem) SetID(id int64) {
+	sri.id = id
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (sri *SalesReturnItem) GetLoadedFromStorage() bool {
+	return sri.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (sri *SalesReturnItem) SetLoadedFromStorage(flagValue bool) {
+	sri.loadedFromStorage = flagValue
+}
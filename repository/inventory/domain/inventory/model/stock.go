@@ -6,9 +6,17 @@ type Stock struct {
 	Sku               string
 	Name              string
 	Quantity          int64
-	BuyPrice          float64
-	SellPrice         float64
-	loadedFromStorage bool //flag indicating whether the model object was loaded from storage or not
+	BuyPrice          Money
+	SellPrice         Money
+	Reserved          int64  //quantity held by draft sales, still part of Quantity until the sales are done
+	ProductID         string //product the item is a variant of, empty when the sku isn't a variant (see Product)
+	Size              string //size code of the variant (e.g. LL)
+	Color             string //color code of the variant (e.g. NAV)
+	CategoryID        string //category the item belongs to, empty when the item isn't categorized (see Category)
+	ReorderPoint      int64  //quantity at which the item must be reordered, 0 when stock of the item isn't watched (see StockAlert)
+	ReorderQuantity   int64  //quantity usually ordered when the item is reordered
+	SafetyStock       int64  //quantity kept against demand during the lead time, stock at or below it is critical
+	loadedFromStorage bool   //flag indicating whether the model object was loaded from storage or not
 }
 
 //GetID is a function for returning id of the model
@@ -25,3 +33,28 @@ func (s *Stock) GetLoadedFromStorage() bool {
 func (s *Stock) SetLoadedFromStorage(flagValue bool) {
 	s.loadedFromStorage = flagValue
 }
+
+//Available is a function for returning quantity of the item which isn't reserved by draft sales
+func (s *Stock) Available() int64 {
+	return s.Quantity - s.Reserved
+}
+
+//IsVariant is a function for checking whether the item is a variant of a product
+func (s *Stock) IsVariant() bool {
+	return s.ProductID != ""
+}
+
+//AlertLevel is a function for returning the level of the low stock alert the item needs (see AlertLevel consts)
+//empty when stock of the item isn't watched or is above its reorder point
+func (s *Stock) AlertLevel() string {
+	if s.ReorderPoint <= 0 {
+		return ""
+	}
+	if s.Quantity <= s.SafetyStock {
+		return AlertLevelCritical
+	}
+	if s.Quantity <= s.ReorderPoint {
+		return AlertLevelLow
+	}
+	return ""
+}
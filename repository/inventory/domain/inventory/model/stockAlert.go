@@ -0,0 +1,53 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+//AlertLevelLow is const for alert of an item whose stock dropped to its reorder point
+const AlertLevelLow string = "low"
+
+//AlertLevelCritical is const for alert of an item whose stock dropped to its safety stock
+const AlertLevelCritical string = "critical"
+
+//StockAlert is business domain model definition of a low stock alert of an item
+//An item has at most a single open alert, it's escalated when stock keeps dropping and resolved once stock is above the reorder point again
+type StockAlert struct {
+	id                int64
+	Sku               string
+	Level             string    //level of the alert (see AlertLevel consts)
+	Quantity          int64     //stock quantity when the alert was last checked
+	ReorderPoint      int64     //reorder point of the item when the alert was last checked
+	ReorderQuantity   int64     //quantity usually ordered when the item is reordered
+	SafetyStock       int64     //safety stock of the item when the alert was last checked
+	RaisedAt          time.Time //timestamp of raising the alert
+	ResolvedAt        time.Time //timestamp of resolving the alert, zero while the alert is open
+	loadedFromStorage bool      //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (sa *StockAlert) GetID() string {
+	return strconv.FormatInt(sa.id, 10)
+}
+
+//SetID is a function for setting id of the model
+func (sa *StockAlert) SetID(id int64) {
+	sa.id = id
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (sa *StockAlert) GetLoadedFromStorage() bool {
+	return sa.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (sa *StockAlert) SetLoadedFromStorage(flagValue bool) {
+	sa.loadedFromStorage = flagValue
+}
+
+//IsOpen is a function for checking whether the alert hasn't been resolved yet
+func (sa *StockAlert) IsOpen() bool {
+	return sa.ResolvedAt.IsZero()
+}
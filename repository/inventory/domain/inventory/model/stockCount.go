@@ -0,0 +1,79 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"time"
+)
+
+//StockCountStatusOpen is const for 'open' stock count status (counted quantities can still be recorded)
+const StockCountStatusOpen string = "O"
+
+//StockCountStatusPosted is const for 'posted' stock count status (variances have been applied to stock)
+const StockCountStatusPosted string = "P"
+
+//StockCount is business domain model definition of a stock take (stock opname) document
+type StockCount struct {
+	CountID           string
+	Date              time.Time //timestamp of opening the count
+	Status            string
+	Note              string
+	PostedDate        time.Time //timestamp of posting the count, zero while the count is open
+	Items             map[string]*StockCountItem
+	loadedFromStorage bool //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (sc *StockCount) GetID() string {
+	return sc.CountID
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (sc *StockCount) GetLoadedFromStorage() bool {
+	return sc.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (sc *StockCount) SetLoadedFromStorage(flagValue bool) {
+	sc.loadedFromStorage = flagValue
+}
+
+//IsFullyCounted is a function for checking whether counted quantity of all items of the count has been recorded
+func (sc *StockCount) IsFullyCounted() bool {
+	for _, val := range sc.Items {
+		if false == val.Counted {
+			return false
+		}
+	}
+	return true
+}
+
+//StockCountItem is a business domain model definition of a sku counted in a stock take
+type StockCountItem struct {
+	id                int64
+	Sku               string
+	Counted           bool  //flag indicating whether the counted quantity has been recorded
+	CountedQuantity   int64 //physically counted quantity
+	SystemQuantity    int64 //stock quantity when the count was posted
+	BuyPrice          Money //buy price when the count was posted
+	loadedFromStorage bool  //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (sci *StockCountItem) GetID() int64 {
+	return sci.id
+}
+
+//SetID is a function for setting id of the model
+func (sci *StockCountItem) SetID(id int64) {
+	sci.id = id
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (sci *StockCountItem) GetLoadedFromStorage() bool {
+	return sci.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (sci *StockCountItem) SetLoadedFromStorage(flagValue bool) {
+	sci.loadedFromStorage = flagValue
+}
@@ -0,0 +1,65 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+//MovementReasonInitialStock is const for stock movement caused by adding a new item type (initial quantity)
+const MovementReasonInitialStock string = "initial stock"
+
+//MovementReasonSale is const for stock movement caused by a completed sale
+const MovementReasonSale string = "sale"
+
+//MovementReasonSaleCancel is const for stock movement caused by canceling a completed sale (sold items returned to stock)
+const MovementReasonSaleCancel string = "sale cancel"
+
+//MovementReasonSaleReturn is const for stock movement caused by items returned by a customer
+const MovementReasonSaleReturn string = "sale return"
+
+//MovementReasonPurchase is const for stock movement caused by receiving purchased items
+const MovementReasonPurchase string = "purchase"
+
+//MovementReasonAdjustment is const for stock movement caused by a manual adjustment (including updating SKU quantity)
+const MovementReasonAdjustment string = "adjustment"
+
+//MovementReasonStockOpname is const for stock movement caused by posting a stock take (difference between counted and recorded quantity)
+const MovementReasonStockOpname string = "stock opname"
+
+//MovementReasonCostChange is const for (zero quantity) stock movement recording a change of buy price of an item
+const MovementReasonCostChange string = "cost change"
+
+//StockMovement is business domain model definition of a single (signed) change of stock quantity
+//Stock movements are append only, every change of stock quantity must be recorded as a new movement
+type StockMovement struct {
+	id                int64
+	Sku               string
+	Quantity          int64     //signed quantity, positive for incoming and negative for outgoing items
+	UnitCost          Money     //buy price of the item at the time of the movement
+	Reason            string    //reason of the movement (see MovementReason consts)
+	Reference         string    //id of the document causing the movement (e.g. invoice id or purchase id)
+	Note              string    //additional free text note
+	Date              time.Time //timestamp of the movement
+	loadedFromStorage bool      //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (sm *StockMovement) GetID() string {
+	return strconv.FormatInt(sm.id, 10)
+}
+
+//SetID is a function for setting id of the model
+func (sm *StockMovement) SetID(id int64) {
+	sm.id = id
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (sm *StockMovement) GetLoadedFromStorage() bool {
+	return sm.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (sm *StockMovement) SetLoadedFromStorage(flagValue bool) {
+	sm.loadedFromStorage = flagValue
+}
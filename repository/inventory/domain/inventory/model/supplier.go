@@ -0,0 +1,53 @@
+//Package model provides the domain model definitions
+package model
+
+import (
+	"time"
+)
+
+//Supplier is business domain model definition of a supplier purchases are made from (see Purchase SupplierID)
+type Supplier struct {
+	SupplierID        string
+	Name              string
+	ContactName       string
+	Phone             string
+	Email             string
+	Address           string
+	PaymentTermDays   int64                    //number of days the payment of a purchase is due after the purchase date, 0 for cash on delivery
+	Items             map[string]*SupplierItem //items supplied by the supplier (sku -> item)
+	loadedFromStorage bool                     //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetID is a function for returning id of the model
+func (s *Supplier) GetID() string {
+	return s.SupplierID
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (s *Supplier) GetLoadedFromStorage() bool {
+	return s.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (s *Supplier) SetLoadedFromStorage(flagValue bool) {
+	s.loadedFromStorage = flagValue
+}
+
+//SupplierItem is a business domain model definition of an item supplied by a supplier
+type SupplierItem struct {
+	Sku               string
+	SupplierSku       string    //code of the item in the catalog of the supplier, empty when unknown
+	LastPrice         Money     //buy price of the item in the last purchase from the supplier
+	LastPurchaseDate  time.Time //date of the last purchase of the item from the supplier, zero when never purchased
+	loadedFromStorage bool      //flag indicating whether the model object was loaded from storage or not
+}
+
+//GetLoadedFromStorage is a function for returning loaded from storage flag value
+func (si *SupplierItem) GetLoadedFromStorage() bool {
+	return si.loadedFromStorage
+}
+
+//SetLoadedFromStorage is a function for setting loaded from storage flag value
+func (si *SupplierItem) SetLoadedFromStorage(flagValue bool) {
+	si.loadedFromStorage = flagValue
+}
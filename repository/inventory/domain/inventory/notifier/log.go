@@ -0,0 +1,32 @@
+//Package notifier provides the deliveries of low stock alerts (see service.AlertNotifier)
+package notifier
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//Log is a notifier writing alerts to the app log (see config/http for the log output)
+type Log struct {
+}
+
+//NewLog creates a new Log notifier and returns a pointer to it
+func NewLog() *Log {
+	return &Log{}
+}
+
+//Notify is a function for writing an alert to the app log as a warning
+func (l *Log) Notify(alert *model.StockAlert) error {
+	message := composeMessage(alert)
+	log.WithFields(log.Fields{
+		"alertId":         message.AlertID,
+		"sku":             message.Sku,
+		"level":           message.Level,
+		"quantity":        message.Quantity,
+		"reorderPoint":    message.ReorderPoint,
+		"reorderQuantity": message.ReorderQuantity,
+		"safetyStock":     message.SafetyStock,
+	}).Warn(message.Summary())
+	return nil
+}
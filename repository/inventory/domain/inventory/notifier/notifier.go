@@ -0,0 +1,40 @@
+//Package notifier provides the deliveries of low stock alerts (see service.AlertNotifier)
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//Message is the content of a delivered low stock alert (e.g. the json body posted to a webhook)
+type Message struct {
+	AlertID         string    `json:"alertId"`
+	Sku             string    `json:"sku"`
+	Level           string    `json:"level"` //low or critical (see model.AlertLevel consts)
+	Quantity        int64     `json:"quantity"`
+	ReorderPoint    int64     `json:"reorderPoint"`
+	ReorderQuantity int64     `json:"reorderQuantity"`
+	SafetyStock     int64     `json:"safetyStock"`
+	RaisedAt        time.Time `json:"raisedAt"`
+}
+
+//composeMessage is a function for composing the delivered content of an alert
+func composeMessage(alert *model.StockAlert) *Message {
+	return &Message{
+		AlertID:         alert.GetID(),
+		Sku:             alert.Sku,
+		Level:           alert.Level,
+		Quantity:        alert.Quantity,
+		ReorderPoint:    alert.ReorderPoint,
+		ReorderQuantity: alert.ReorderQuantity,
+		SafetyStock:     alert.SafetyStock,
+		RaisedAt:        alert.RaisedAt,
+	}
+}
+
+//Summary is a function for returning a single line description of the alert (e.g. the subject of an email)
+func (m *Message) Summary() string {
+	return fmt.Sprintf("Stock of %v is %v: %v left (reorder point %v, safety stock %v)", m.Sku, m.Level, m.Quantity, m.ReorderPoint, m.SafetyStock)
+}
@@ -0,0 +1,193 @@
+package notifier_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/notifier"
+)
+
+//newTestAlert is a function for composing a critical alert of dummySku
+func newTestAlert() *model.StockAlert {
+	alertObj := &model.StockAlert{
+		Sku:             "dummySku",
+		Level:           model.AlertLevelCritical,
+		Quantity:        1,
+		ReorderPoint:    5,
+		ReorderQuantity: 20,
+		SafetyStock:     2,
+		RaisedAt:        time.Date(2018, 1, 2, 10, 30, 0, 0, time.UTC),
+	}
+	alertObj.SetID(7)
+	return alertObj
+}
+
+//startTestSMTPServer is a function for starting a minimal smtp server accepting a single mail, the mail content is sent to the returned channel
+func startTestSMTPServer(t *testing.T) (string, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	t.Cleanup(func() {
+		listener.Close()
+	})
+	mails := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		conn.Write([]byte("220 localhost ready\r\n"))
+		var mail strings.Builder
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if true == inData {
+				if line == ".\r\n" {
+					inData = false
+					mails <- mail.String()
+					conn.Write([]byte("250 queued\r\n"))
+					continue
+				}
+				mail.WriteString(line)
+				continue
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "DATA"):
+				inData = true
+				conn.Write([]byte("354 go ahead\r\n"))
+			case strings.HasPrefix(command, "QUIT"):
+				conn.Write([]byte("221 bye\r\n"))
+				return
+			default:
+				conn.Write([]byte("250 ok\r\n"))
+			}
+		}
+	}()
+	return listener.Addr().String(), mails
+}
+
+func TestLog(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+	})
+
+	err := notifier.NewLog().Notify(newTestAlert())
+	t.Run("alert must be written to the log", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if false == strings.Contains(output.String(), "Stock of dummySku is critical") || false == strings.Contains(output.String(), "level=warning") {
+			t.Errorf("expected a warning about dummySku but got %v", output.String())
+		}
+	})
+}
+
+func TestWebhook(t *testing.T) {
+	var message notifier.Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&message)
+	}))
+	defer server.Close()
+
+	err := notifier.NewWebhook(server.URL).Notify(newTestAlert())
+	t.Run("alert must be posted as json", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if message.AlertID != "7" || message.Sku != "dummySku" || message.Level != model.AlertLevelCritical || message.ReorderQuantity != 20 {
+			t.Errorf("expected alert 7 of dummySku but got %+v", message)
+		}
+	})
+
+	failedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failedServer.Close()
+
+	err = notifier.NewWebhook(failedServer.URL).Notify(newTestAlert())
+	t.Run("non 2xx response must be an error", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+func TestSMTP(t *testing.T) {
+	address, mails := startTestSMTPServer(t)
+
+	err := notifier.NewSMTP(address, "inventory@localhost", []string{"purchasing@localhost"}).Notify(newTestAlert())
+	t.Run("alert must be mailed to the recipients", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		select {
+		case mail := <-mails:
+			if false == strings.Contains(mail, "Subject: [critical stock] dummySku") || false == strings.Contains(mail, "Suggested order quantity: 20") {
+				t.Errorf("expected mail about dummySku but got %v", mail)
+			}
+		case <-time.After(5 * time.Second):
+			t.Errorf("expected a mail but got none")
+		}
+	})
+
+	err = notifier.NewSMTP(address, "inventory@localhost", nil).Notify(newTestAlert())
+	t.Run("notifier without recipients must fail", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+func TestSMTPTimeout(t *testing.T) {
+	//server accepting connections without ever greeting
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		time.Sleep(5 * time.Second)
+	}()
+
+	smtpNotifier := notifier.NewSMTP(listener.Addr().String(), "inventory@localhost", []string{"purchasing@localhost"})
+	smtpNotifier.Timeout = 100 * time.Millisecond
+	startTime := time.Now()
+	err = smtpNotifier.Notify(newTestAlert())
+	t.Run("unresponsive server must fail once the timeout passes", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+		if time.Since(startTime) > 2*time.Second {
+			t.Errorf("expected the delivery to be given up after the timeout but it took %v", time.Since(startTime))
+		}
+	})
+}
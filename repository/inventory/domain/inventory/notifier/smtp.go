@@ -0,0 +1,98 @@
+//Package notifier provides the deliveries of low stock alerts (see service.AlertNotifier)
+package notifier
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//smtpTimeout is the time an smtp server is given to accept an alert (connecting included)
+const smtpTimeout = 10 * time.Second
+
+//SMTP is a notifier mailing alerts through an smtp server without authentication
+//e.g. a local stand-in such as MailHog listening on localhost:1025
+type SMTP struct {
+	Address string        //host:port of the smtp server
+	From    string        //sender address
+	To      []string      //recipient addresses
+	Timeout time.Duration //deadline of the whole delivery
+}
+
+//NewSMTP creates a new SMTP notifier and returns a pointer to it
+func NewSMTP(address, from string, to []string) *SMTP {
+	return &SMTP{
+		Address: address,
+		From:    from,
+		To:      to,
+		Timeout: smtpTimeout,
+	}
+}
+
+//Notify is a function for mailing an alert to the recipients
+func (s *SMTP) Notify(alert *model.StockAlert) error {
+	if len(s.To) == 0 {
+		return fmt.Errorf("smtp notifier has no recipients")
+	}
+	message := composeMessage(alert)
+	var mail strings.Builder
+	fmt.Fprintf(&mail, "From: %v\r\n", s.From)
+	fmt.Fprintf(&mail, "To: %v\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&mail, "Subject: [%v stock] %v\r\n", message.Level, message.Sku)
+	fmt.Fprintf(&mail, "Date: %v\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&mail, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&mail, "%v\r\n\r\n", message.Summary())
+	fmt.Fprintf(&mail, "Suggested order quantity: %v\r\n", message.ReorderQuantity)
+	fmt.Fprintf(&mail, "Alert %v raised at %v\r\n", message.AlertID, message.RaisedAt.Format("2006-01-02 15:04:05"))
+	return s.send([]byte(mail.String()))
+}
+
+//send is a function for delivering a mail to the recipients, the connection is given up once the timeout passes
+//Note: smtp.SendMail can't be used since it waits for unresponsive servers forever
+func (s *SMTP) send(mail []byte) error {
+	conn, err := net.DialTimeout("tcp", s.Address, s.Timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	err = conn.SetDeadline(time.Now().Add(s.Timeout))
+	if err != nil {
+		return err
+	}
+	host, _, err := net.SplitHostPort(s.Address)
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.Mail(s.From)
+	if err != nil {
+		return err
+	}
+	for _, val := range s.To {
+		err = client.Rcpt(val)
+		if err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(mail)
+	if err != nil {
+		return err
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,46 @@
+//Package notifier provides the deliveries of low stock alerts (see service.AlertNotifier)
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//webhookTimeout is the time a webhook is given to accept an alert
+const webhookTimeout = 5 * time.Second
+
+//Webhook is a notifier posting alerts as json (see Message) to an url
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+//NewWebhook creates a new Webhook notifier posting to the given url and returns a pointer to it
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		URL:    url,
+		Client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+//Notify is a function for posting an alert to the webhook, any response status other than 2xx is an error
+func (w *Webhook) Notify(alert *model.StockAlert) error {
+	body, err := json.Marshal(composeMessage(alert))
+	if err != nil {
+		return err
+	}
+	response, err := w.Client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook %v responded with status %v", w.URL, response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//DefaultAlertQueueSize is the count of committed units of work whose alerts can wait for delivery at once (see NewAlertSender)
+const DefaultAlertQueueSize = 100
+
+//AlertSender is a background sender delivering low stock alerts through the notifiers
+//alerts are handed over by committed units of work, so slow or unreachable notifiers don't hold up the requests changing stock
+type AlertSender struct {
+	notifiers []AlertNotifier
+	queue     chan []*model.StockAlert
+	pending   sync.WaitGroup //alerts handed over but not delivered yet
+}
+
+//NewAlertSender creates a new AlertSender delivering through the given notifiers and returns a pointer to it
+//the sender keeps delivering in its own goroutine for the lifetime of the process, queueSize bounds the alerts waiting for delivery
+func NewAlertSender(notifiers []AlertNotifier, queueSize int) *AlertSender {
+	sender := &AlertSender{
+		notifiers: notifiers,
+		queue:     make(chan []*model.StockAlert, queueSize),
+	}
+	go sender.run()
+	return sender
+}
+
+//run is a function for delivering the queued alerts one after another
+func (s *AlertSender) run() {
+	for alerts := range s.queue {
+		notifyAlerts(s.notifiers, alerts)
+		s.pending.Done()
+	}
+}
+
+//Send is a function for handing alerts over to the sender, it never waits for the delivery
+//alerts are dropped (and written to the app log) when the queue is full, they stay listed anyway
+func (s *AlertSender) Send(alerts []*model.StockAlert) {
+	if len(alerts) == 0 || len(s.notifiers) == 0 {
+		return
+	}
+	s.pending.Add(1)
+	select {
+	case s.queue <- alerts:
+	default:
+		s.pending.Done()
+		for _, alertObj := range alerts {
+			log.WithFields(log.Fields{
+				"alertId": alertObj.GetID(),
+				"sku":     alertObj.Sku,
+				"level":   alertObj.Level,
+			}).Error("Stock alert dropped, delivery queue is full")
+		}
+	}
+}
+
+//Wait is a function for waiting until every alert handed over so far has been delivered (or its delivery has failed)
+func (s *AlertSender) Wait() {
+	s.pending.Wait()
+}
@@ -0,0 +1,281 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//ItemAttribute is a struct containing an attribute value of an item along with the type of the attribute
+type ItemAttribute struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"` //float64 for numbers, bool for booleans, string for texts and dates (YYYY-MM-DD)
+}
+
+//attributeDateFormat is the format of the values of date attributes
+const attributeDateFormat = "2006-01-02"
+
+//isAttributeType is a function for checking whether a type is a valid attribute type (see AttributeType consts)
+func isAttributeType(attributeType string) bool {
+	switch attributeType {
+	case model.AttributeTypeText, model.AttributeTypeNumber, model.AttributeTypeBoolean, model.AttributeTypeDate:
+		return true
+	}
+	return false
+}
+
+//normalizeAttributeValue is a function for composing the stored value of an attribute of the given type, the value is rejected when it doesn't fit the type
+//e.g. "1.50" is stored as "1.5" and "TRUE" as "true"
+func normalizeAttributeValue(attributeType, value string) (string, error) {
+	switch attributeType {
+	case model.AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q isn't a number", value)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case model.AttributeTypeBoolean:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%q isn't a boolean (should be true or false)", value)
+		}
+		return strconv.FormatBool(flag), nil
+	case model.AttributeTypeDate:
+		date, err := time.Parse(attributeDateFormat, value)
+		if err != nil {
+			return "", fmt.Errorf("%q isn't a date (should be YYYY-MM-DD)", value)
+		}
+		return date.Format(attributeDateFormat), nil
+	}
+	return value, nil
+}
+
+//typedAttributeValue is a function for composing the typed value of a stored attribute value (see ItemAttribute)
+func typedAttributeValue(attributeType, value string) interface{} {
+	switch attributeType {
+	case model.AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err == nil {
+			return number
+		}
+	case model.AttributeTypeBoolean:
+		flag, err := strconv.ParseBool(value)
+		if err == nil {
+			return flag
+		}
+	}
+	return value
+}
+
+//findAttribute is a function for obtaining an attribute definition using the given attribute mapper
+func findAttribute(attributeMapper datamapper.DataMapper, name string) (*model.Attribute, *errors.Error) {
+	foundAttribute, err := attributeMapper.FindByID(name)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return nil, errors.Wrap(fmt.Errorf("Attribute %v doesn't exist", name), 0)
+		}
+		return nil, err
+	}
+	attributeObj, ok := foundAttribute.(*model.Attribute)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	return attributeObj, nil
+}
+
+//findItemAttributes is a function for obtaining the attribute values of an item using the given item attributes mapper (empty when the item has no value)
+func findItemAttributes(attributesMapper datamapper.DataMapper, sku string) (*model.ItemAttributes, *errors.Error) {
+	foundAttributes, err := attributesMapper.FindByID(sku)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return &model.ItemAttributes{Sku: sku, Values: make(map[string]string)}, nil
+		}
+		return nil, err
+	}
+	attributesObj, ok := foundAttributes.(*model.ItemAttributes)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	return attributesObj, nil
+}
+
+//findAttributeValues is a function for obtaining the attribute values of the items having a value of the given attribute (inside the passed unit of work)
+func (uow *UnitOfWork) findAttributeValues(name string) ([]*model.ItemAttributes, *errors.Error) {
+	valueFinder, ok := uow.ItemAttributesDatamapper.(datamapper.AttributeValueFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting item attributes mapper"), 0)
+	}
+	foundAttributes, err := valueFinder.FindByAttribute(name)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make([]*model.ItemAttributes, 0, len(foundAttributes))
+	for _, val := range foundAttributes {
+		valObj, ok := val.(*model.ItemAttributes)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		attributes = append(attributes, valObj)
+	}
+	return attributes, nil
+}
+
+//CreateAttribute is a function for defining a new attribute items can have (e.g. material, brand and season) along with the type of its values (see AttributeType consts)
+func (i *Inventory) CreateAttribute(name, attributeType string) *errors.Error {
+	if name == "" {
+		return errors.Wrap(fmt.Errorf("Attribute name is required"), 0)
+	}
+	if false == isAttributeType(attributeType) {
+		return errors.Wrap(fmt.Errorf("Invalid attribute type %v (should be %v, %v, %v or %v)", attributeType, model.AttributeTypeText, model.AttributeTypeNumber, model.AttributeTypeBoolean, model.AttributeTypeDate), 0)
+	}
+	return i.AttributeDatamapper.Insert(&model.Attribute{
+		Name: name,
+		Type: attributeType,
+	})
+}
+
+//UpdateAttribute is a function for changing the type of an attribute
+//the values items already have are converted to the new type, the change is rejected when a value doesn't fit the new type
+func (i *Inventory) UpdateAttribute(name, attributeType string) *errors.Error {
+	if false == isAttributeType(attributeType) {
+		return errors.Wrap(fmt.Errorf("Invalid attribute type %v (should be %v, %v, %v or %v)", attributeType, model.AttributeTypeText, model.AttributeTypeNumber, model.AttributeTypeBoolean, model.AttributeTypeDate), 0)
+	}
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		attributeObj, err := findAttribute(uow.AttributeDatamapper, name)
+		if err != nil {
+			return err
+		}
+		itemAttributes, err := uow.findAttributeValues(name)
+		if err != nil {
+			return err
+		}
+		for _, val := range itemAttributes {
+			value, errs := normalizeAttributeValue(attributeType, val.Values[name])
+			if errs != nil {
+				return errors.Wrap(fmt.Errorf("Attribute %v of sku %v can't be changed to %v: %v", name, val.Sku, attributeType, errs), 0)
+			}
+			if value == val.Values[name] {
+				continue
+			}
+			val.Values[name] = value
+			err = uow.ItemAttributesDatamapper.Update(val)
+			if err != nil {
+				return err
+			}
+		}
+		attributeObj.Type = attributeType
+		return uow.AttributeDatamapper.Update(attributeObj)
+	})
+}
+
+//DeleteAttribute is a function for removing an attribute definition along with the values items have
+func (i *Inventory) DeleteAttribute(name string) *errors.Error {
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		attributeObj, err := findAttribute(uow.AttributeDatamapper, name)
+		if err != nil {
+			return err
+		}
+		itemAttributes, err := uow.findAttributeValues(name)
+		if err != nil {
+			return err
+		}
+		for _, val := range itemAttributes {
+			delete(val.Values, name)
+			//items left without value have no record
+			err = uow.ItemAttributesDatamapper.Update(val)
+			if err != nil {
+				return err
+			}
+		}
+		return uow.AttributeDatamapper.Delete(attributeObj)
+	})
+}
+
+//ListAttributes is a function for obtaining every attribute definition (ordered by name)
+func (i *Inventory) ListAttributes() ([]*model.Attribute, *errors.Error) {
+	foundAttributes, err := i.AttributeDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	attributes := make([]*model.Attribute, 0, len(foundAttributes))
+	for _, val := range foundAttributes {
+		valObj, ok := val.(*model.Attribute)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		attributes = append(attributes, valObj)
+	}
+	return attributes, nil
+}
+
+//UpdateItemAttributes is a function for setting attribute values of an item (attribute name -> value), an empty value removes the attribute from the item
+//attributes not given are left as they are, every attribute must be defined and every value must fit the type of its attribute
+func (i *Inventory) UpdateItemAttributes(sku string, values map[string]string) *errors.Error {
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		_, err := uow.getStock(sku)
+		if err != nil {
+			return err
+		}
+		attributesObj, err := findItemAttributes(uow.ItemAttributesDatamapper, sku)
+		if err != nil {
+			return err
+		}
+		for name, value := range values {
+			attributeObj, err := findAttribute(uow.AttributeDatamapper, name)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				delete(attributesObj.Values, name)
+				continue
+			}
+			normalizedValue, errs := normalizeAttributeValue(attributeObj.Type, value)
+			if errs != nil {
+				return errors.Wrap(fmt.Errorf("Invalid value of attribute %v: %v", name, errs), 0)
+			}
+			attributesObj.Values[name] = normalizedValue
+		}
+		return uow.ItemAttributesDatamapper.Save(attributesObj)
+	})
+}
+
+//GetItemAttributes is a function for obtaining the attribute values of an item along with their types (ordered by name)
+func (i *Inventory) GetItemAttributes(sku string) ([]*ItemAttribute, *errors.Error) {
+	_, err := i.GetItemInfo(sku)
+	if err != nil {
+		return nil, err
+	}
+	attributesObj, err := findItemAttributes(i.ItemAttributesDatamapper, sku)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := i.ListAttributes()
+	if err != nil {
+		return nil, err
+	}
+	attributeTypes := make(map[string]string, len(attributes))
+	for _, val := range attributes {
+		attributeTypes[val.Name] = val.Type
+	}
+
+	itemAttributes := make([]*ItemAttribute, 0, len(attributesObj.Values))
+	for name, value := range attributesObj.Values {
+		itemAttributes = append(itemAttributes, &ItemAttribute{
+			Name:  name,
+			Type:  attributeTypes[name],
+			Value: typedAttributeValue(attributeTypes[name], value),
+		})
+	}
+	sort.Slice(itemAttributes, func(x, y int) bool {
+		return itemAttributes[x].Name < itemAttributes[y].Name
+	})
+	return itemAttributes, nil
+}
@@ -0,0 +1,78 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+
+	"testing"
+)
+
+func TestItemAttributes(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	attributes := map[string]string{"material": model.AttributeTypeText, "weight": model.AttributeTypeNumber, "since": model.AttributeTypeDate}
+	for name, attributeType := range attributes {
+		err := inventoryObj.CreateAttribute(name, attributeType)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+
+	err := inventoryObj.CreateAttribute("season", "enum")
+	t.Run("invalid attribute type must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	errInvalid := inventoryObj.UpdateItemAttributes("dummySku", map[string]string{"weight": "heavy"})
+	errUndefined := inventoryObj.UpdateItemAttributes("dummySku", map[string]string{"brand": "Deklia"})
+	t.Run("invalid and undefined attribute values must be rejected", func(t *testing.T) {
+		if errInvalid == nil || errUndefined == nil {
+			t.Errorf("expected errors but got %v and %v", errInvalid, errUndefined)
+		}
+	})
+
+	err = inventoryObj.UpdateItemAttributes("dummySku", map[string]string{"material": "cotton", "weight": "0.250", "since": "2018-01-02"})
+	itemAttributes, errs := inventoryObj.GetItemAttributes("dummySku")
+	t.Run("item attributes must be found typed and ordered by name", func(t *testing.T) {
+		if err != nil || errs != nil {
+			t.Fatalf("expected nil but got %v and %v", err, errs)
+		}
+		if len(itemAttributes) != 3 || itemAttributes[0].Name != "material" || itemAttributes[1].Name != "since" {
+			t.Fatalf("expected material, since and weight but got %+v", itemAttributes)
+		}
+		if itemAttributes[2].Value != 0.25 || itemAttributes[2].Type != model.AttributeTypeNumber {
+			t.Errorf("expected number 0.25 but got %+v", itemAttributes[2])
+		}
+	})
+
+	err = inventoryObj.UpdateAttribute("material", model.AttributeTypeNumber)
+	t.Run("type change must be rejected when a value doesn't fit", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	err = inventoryObj.UpdateAttribute("weight", model.AttributeTypeText)
+	itemAttributes, _ = inventoryObj.GetItemAttributes("dummySku")
+	t.Run("values must be converted to the new type", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if itemAttributes[2].Value != "0.25" || itemAttributes[2].Type != model.AttributeTypeText {
+			t.Errorf("expected text 0.25 but got %+v", itemAttributes[2])
+		}
+	})
+
+	err = inventoryObj.DeleteAttribute("weight")
+	errs = inventoryObj.UpdateItemAttributes("dummySku", map[string]string{"since": ""})
+	itemAttributes, _ = inventoryObj.GetItemAttributes("dummySku")
+	t.Run("deleted attribute and empty value must be removed from the item", func(t *testing.T) {
+		if err != nil || errs != nil {
+			t.Fatalf("expected nil but got %v and %v", err, errs)
+		}
+		if len(itemAttributes) != 1 || itemAttributes[0].Name != "material" {
+			t.Errorf("expected material only but got %+v", itemAttributes)
+		}
+	})
+}
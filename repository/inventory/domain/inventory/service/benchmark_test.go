@@ -0,0 +1,120 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"ijah-inventory/repository/inventory/server/migration"
+)
+
+//benchmarkInvoiceCount is the number of invoices stored by newBenchmarkDb
+const benchmarkInvoiceCount = 100000
+
+//newBenchmarkDb is a function for creating a migrated sqlite database holding benchmarkInvoiceCount done sales of 2 items each
+//the sales are spread over the days of 2018 (in a single transaction, seeding through the datamappers one sale at a time would take minutes)
+func newBenchmarkDb(b *testing.B) *sql.DB {
+	db, err := sql.Open(datamapper.DriverSQLite, filepath.Join(b.TempDir(), "ijah.db"))
+	if err != nil {
+		b.Fatalf("expected nil but got %v", err)
+	}
+	b.Cleanup(func() {
+		db.Close()
+	})
+	migrator, errs := migration.NewMigrator(db, datamapper.SQLite)
+	if errs != nil {
+		b.Fatalf("expected nil but got %v", errs)
+	}
+	_, errs = migrator.Up()
+	if errs != nil {
+		b.Fatalf("expected nil but got %v", errs)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		b.Fatalf("expected nil but got %v", err)
+	}
+	defer tx.Rollback()
+	for _, val := range []string{"dummySku", "otherSku"} {
+		_, err = tx.Exec("INSERT INTO stock(SKU, NAME, QUANTITY, BUY_PRICE, SELL_PRICE) values(?,?,?,?,?)", val, "dummy item", 10, 50000, 60000)
+		if err != nil {
+			b.Fatalf("expected nil but got %v", err)
+		}
+	}
+	saleStmt, err := tx.Prepare("INSERT INTO sales(INVOICE_ID, SALE_DATE, STATUS, NOTE) values(?,?,?,?)")
+	if err != nil {
+		b.Fatalf("expected nil but got %v", err)
+	}
+	defer saleStmt.Close()
+	itemStmt, err := tx.Prepare("INSERT INTO sales_items(INVOICE_ID, SKU, QUANTITY, BUY_PRICE, SELL_PRICE) values(?,?,?,?,?)")
+	if err != nil {
+		b.Fatalf("expected nil but got %v", err)
+	}
+	defer itemStmt.Close()
+
+	startDate := time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC)
+	for i := 0; i < benchmarkInvoiceCount; i++ {
+		invoiceID := fmt.Sprintf("INV-%06d", i)
+		_, err = saleStmt.Exec(invoiceID, startDate.AddDate(0, 0, i%365).Format("2006-01-02 15:04:05"), "S", "")
+		if err != nil {
+			b.Fatalf("expected nil but got %v", err)
+		}
+		_, err = itemStmt.Exec(invoiceID, "dummySku", 2, 50000, 60000)
+		if err != nil {
+			b.Fatalf("expected nil but got %v", err)
+		}
+		_, err = itemStmt.Exec(invoiceID, "otherSku", 1, 50000, 65000)
+		if err != nil {
+			b.Fatalf("expected nil but got %v", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		b.Fatalf("expected nil but got %v", err)
+	}
+	return db
+}
+
+//BenchmarkGetAllSalesValue reports the sales value of a month (about 8k of the stored invoices), which is what /getSalesValue does
+func BenchmarkGetAllSalesValue(b *testing.B) {
+	db := newBenchmarkDb(b)
+	inventoryObj := &service.Inventory{
+		SalesDatamapper:       datamapper.NewSale(db, datamapper.SQLite),
+		SalesReturnDatamapper: datamapper.NewSalesReturn(db, datamapper.SQLite),
+	}
+	startDate := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2018, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		salesValue, err := inventoryObj.GetAllSalesValue(startDate, endDate)
+		if err != nil {
+			b.Fatalf("expected nil but got %v", err)
+		}
+		if salesValue.SaleCount == 0 {
+			b.Fatalf("expected sales but got none")
+		}
+	}
+}
+
+//BenchmarkSaleFindAll loads every stored invoice along with its items
+func BenchmarkSaleFindAll(b *testing.B) {
+	db := newBenchmarkDb(b)
+	mapper := datamapper.NewSale(db, datamapper.SQLite)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		all, err := mapper.FindAll()
+		if err != nil {
+			b.Fatalf("expected nil but got %v", err)
+		}
+		if len(all) != benchmarkInvoiceCount {
+			b.Fatalf("expected %v sales but got %v", benchmarkInvoiceCount, len(all))
+		}
+	}
+}
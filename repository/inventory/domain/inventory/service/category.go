@@ -0,0 +1,302 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//GroupByCategory is const for grouping report items by the category they belong to (see Stock CategoryID)
+const GroupByCategory string = "category"
+
+//CategoryNode is a struct containing a category along with its subcategories (see GetCategoryTree)
+type CategoryNode struct {
+	*model.Category
+	Children []*CategoryNode
+}
+
+//findCategory is a function for obtaining a category using the given category mapper
+func findCategory(categoryMapper datamapper.DataMapper, categoryID string) (*model.Category, *errors.Error) {
+	foundCategory, err := categoryMapper.FindByID(categoryID)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return nil, errors.Wrap(fmt.Errorf("Category %v doesn't exist", categoryID), 0)
+		}
+		return nil, err
+	}
+	categoryObj, ok := foundCategory.(*model.Category)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	return categoryObj, nil
+}
+
+//findCategories is a function for obtaining every category using the given category mapper (category id -> category)
+func findCategories(categoryMapper datamapper.DataMapper) (map[string]*model.Category, *errors.Error) {
+	foundCategories, err := categoryMapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	categories := make(map[string]*model.Category, len(foundCategories))
+	for _, val := range foundCategories {
+		valObj, ok := val.(*model.Category)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		categories[valObj.CategoryID] = valObj
+	}
+	return categories, nil
+}
+
+//CreateCategory is a function for adding a new category to the category tree, parentID is empty for root categories
+func (i *Inventory) CreateCategory(categoryID, name, parentID string) *errors.Error {
+	if categoryID == "" || name == "" {
+		return errors.Wrap(fmt.Errorf("Category id and name are required"), 0)
+	}
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		if parentID != "" {
+			_, err := findCategory(uow.CategoryDatamapper, parentID)
+			if err != nil {
+				return err
+			}
+		}
+		return uow.CategoryDatamapper.Insert(&model.Category{
+			CategoryID: categoryID,
+			Name:       name,
+			ParentID:   parentID,
+		})
+	})
+}
+
+//UpdateCategory is a function for renaming a category or moving it (along with its subcategories) to another parent, parentID is empty for root categories
+//a category can't be moved into itself or into one of its subcategories
+func (i *Inventory) UpdateCategory(categoryID, name, parentID string) *errors.Error {
+	if name == "" {
+		return errors.Wrap(fmt.Errorf("Category name is required"), 0)
+	}
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		categories, err := findCategories(uow.CategoryDatamapper)
+		if err != nil {
+			return err
+		}
+		categoryObj, exists := categories[categoryID]
+		if false == exists {
+			return errors.Wrap(fmt.Errorf("Category %v doesn't exist", categoryID), 0)
+		}
+		if parentID != "" {
+			if _, exists := categories[parentID]; false == exists {
+				return errors.Wrap(fmt.Errorf("Category %v doesn't exist", parentID), 0)
+			}
+			if true == categorySubtree(categories, categoryID)[parentID] {
+				return errors.Wrap(fmt.Errorf("Category %v can't be moved into %v, which is part of it", categoryID, parentID), 0)
+			}
+		}
+		categoryObj.Name = name
+		categoryObj.ParentID = parentID
+		return uow.CategoryDatamapper.Update(categoryObj)
+	})
+}
+
+//DeleteCategory is a function for removing a category from the category tree
+//only categories without subcategories and items can be removed
+func (i *Inventory) DeleteCategory(categoryID string) *errors.Error {
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		categories, err := findCategories(uow.CategoryDatamapper)
+		if err != nil {
+			return err
+		}
+		categoryObj, exists := categories[categoryID]
+		if false == exists {
+			return errors.Wrap(fmt.Errorf("Category %v doesn't exist", categoryID), 0)
+		}
+		for _, val := range categories {
+			if val.ParentID == categoryID {
+				return errors.Wrap(fmt.Errorf("Category %v still has subcategories", categoryID), 0)
+			}
+		}
+		stockFinder, ok := uow.StockDatamapper.(datamapper.CriteriaFinder)
+		if false == ok {
+			return errors.Wrap(fmt.Errorf("Failed asserting stock mapper"), 0)
+		}
+		stockPage, err := stockFinder.FindByCriteria(datamapper.Criteria{
+			Filters: []datamapper.Filter{{Field: "categoryId", Operator: datamapper.OperatorEqual, Value: categoryID}},
+			Limit:   1,
+		})
+		if err != nil {
+			return err
+		}
+		if len(stockPage.Items) > 0 {
+			return errors.Wrap(fmt.Errorf("Category %v still has items", categoryID), 0)
+		}
+		return uow.CategoryDatamapper.Delete(categoryObj)
+	})
+}
+
+//GetCategoryTree is a function for obtaining the category tree, the root categories along with their subcategories (ordered by category id)
+//categories whose parent doesn't exist are listed as root categories
+func (i *Inventory) GetCategoryTree() ([]*CategoryNode, *errors.Error) {
+	foundCategories, err := i.CategoryDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	nodes := make(map[string]*CategoryNode, len(foundCategories))
+	for _, val := range foundCategories {
+		valObj, ok := val.(*model.Category)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		nodes[valObj.CategoryID] = &CategoryNode{
+			Category: valObj,
+			Children: make([]*CategoryNode, 0),
+		}
+	}
+	roots := make([]*CategoryNode, 0)
+	//categories are found ordered by category id, so children are added in order
+	for _, val := range foundCategories {
+		node := nodes[val.GetID()]
+		parent, exists := nodes[node.ParentID]
+		if false == exists {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return roots, nil
+}
+
+//UpdateItemCategory is a function for moving an item to a category, the item is no longer categorized when categoryID is empty
+func (i *Inventory) UpdateItemCategory(sku, categoryID string) *errors.Error {
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		stockObj, err := uow.getStock(sku)
+		if err != nil {
+			return err
+		}
+		if categoryID != "" {
+			_, err = findCategory(uow.CategoryDatamapper, categoryID)
+			if err != nil {
+				return err
+			}
+		}
+		stockObj.CategoryID = categoryID
+		return uow.StockDatamapper.Update(stockObj)
+	})
+}
+
+//categorySubtree is a function for obtaining the ids of a category and of every category below it
+func categorySubtree(categories map[string]*model.Category, categoryID string) map[string]bool {
+	subtree := map[string]bool{categoryID: true}
+	//add the children of the categories found so far until no category is added
+	for added := true; true == added; {
+		added = false
+		for _, val := range categories {
+			if true == subtree[val.ParentID] && false == subtree[val.CategoryID] {
+				subtree[val.CategoryID] = true
+				added = true
+			}
+		}
+	}
+	return subtree
+}
+
+//categorySkus is a function for obtaining the skus of the items belonging to a category or to any category below it
+func (i *Inventory) categorySkus(categoryID string) (map[string]bool, *errors.Error) {
+	categories, err := findCategories(i.CategoryDatamapper)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := categories[categoryID]; false == exists {
+		return nil, errors.Wrap(fmt.Errorf("Category %v doesn't exist", categoryID), 0)
+	}
+	subtree := categorySubtree(categories, categoryID)
+
+	currentStock, err := i.StockDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	skus := make(map[string]bool)
+	for _, val := range currentStock {
+		valObj, ok := val.(*model.Stock)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		if true == subtree[valObj.CategoryID] {
+			skus[valObj.Sku] = true
+		}
+	}
+	return skus, nil
+}
+
+//FilterStockValue is a function for keeping only the items of a stock value belonging to a category (or to any category below it), the totals are computed again
+func (i *Inventory) FilterStockValue(stockValue *StockValue, categoryID string) *errors.Error {
+	skus, err := i.categorySkus(categoryID)
+	if err != nil {
+		return err
+	}
+	stockValueItems := make(map[string]*StockValueItem, 0)
+	var kind int
+	var totalAmount model.Money
+	var totalQuantity, totalReserved, totalAvailable int64
+	for sku, val := range stockValue.Items {
+		if false == skus[sku] {
+			continue
+		}
+		stockValueItems[sku] = val
+		totalAmount += val.TotalAmount
+		totalQuantity += val.Quantity
+		totalReserved += val.Reserved
+		totalAvailable += val.Available
+		kind++
+	}
+	stockValue.Items = stockValueItems
+	stockValue.TotalItemKind = kind
+	stockValue.TotalAmount = totalAmount
+	stockValue.TotalQuantity = totalQuantity
+	stockValue.TotalReserved = totalReserved
+	stockValue.TotalAvailable = totalAvailable
+	return nil
+}
+
+//FilterSalesValue is a function for keeping only the items of a sales value belonging to a category (or to any category below it), the totals are computed again
+//sales and returns are counted when they hold an item of the category
+func (i *Inventory) FilterSalesValue(salesValue *SaleValue, categoryID string) *errors.Error {
+	skus, err := i.categorySkus(categoryID)
+	if err != nil {
+		return err
+	}
+	saleValueItems := make([]*SaleValueItem, 0)
+	var totalProfit, salesTurnover, totalRefund model.Money
+	var totalQuantity int64
+	soldSkus := make(map[string]bool)     //for counting total kind of sku sold
+	sales := make(map[string]bool)        //for counting sales
+	salesReturns := make(map[string]bool) //for counting returns
+	for _, val := range salesValue.Items {
+		if false == skus[val.Sku] {
+			continue
+		}
+		saleValueItems = append(saleValueItems, val)
+		totalQuantity += val.Quantity
+		totalProfit += val.Profit
+		salesTurnover += val.SellPrice.Multiply(val.Quantity)
+		if val.Quantity < 0 {
+			//returned items
+			totalRefund += val.SellPrice.Multiply(-val.Quantity)
+			salesReturns[val.reference] = true
+			continue
+		}
+		soldSkus[val.Sku] = true
+		sales[val.reference] = true
+	}
+	salesValue.Items = saleValueItems
+	salesValue.TotalQuantity = totalQuantity
+	salesValue.TotalItemKind = len(soldSkus)
+	salesValue.Profit = totalProfit
+	salesValue.SalesTurnOver = salesTurnover
+	salesValue.SaleCount = len(sales)
+	salesValue.ReturnCount = len(salesReturns)
+	salesValue.Refund = totalRefund
+	return nil
+}
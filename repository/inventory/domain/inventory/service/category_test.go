@@ -0,0 +1,182 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+	"time"
+)
+
+//newCategoryInventory is a function for composing an inventory service on a memory store holding the category tree women > blouse > casual
+//dummySku belongs to casual and otherSku (5 items bought at 40000 and sold at 45000) isn't categorized
+func newCategoryInventory(t *testing.T) *service.Inventory {
+	inventoryObj := newMemoryInventory(t)
+	err := inventoryObj.AddSKU("otherSku", 5, 40000, 45000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	categories := [][]string{{"women", "Women", ""}, {"blouse", "Blouse", "women"}, {"casual", "Casual Blouse", "blouse"}}
+	for _, val := range categories {
+		err = inventoryObj.CreateCategory(val[0], val[1], val[2])
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	err = inventoryObj.UpdateItemCategory("dummySku", "casual")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj
+}
+
+func TestCategoryTree(t *testing.T) {
+	inventoryObj := newCategoryInventory(t)
+
+	err := inventoryObj.CreateCategory("dress", "Dress", "men")
+	t.Run("category below a missing parent must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	err = inventoryObj.CreateCategory("dress", "Dress", "women")
+	tree, errs := inventoryObj.GetCategoryTree()
+	t.Run("category tree must hold the subcategories", func(t *testing.T) {
+		if err != nil || errs != nil {
+			t.Fatalf("expected nil but got %v and %v", err, errs)
+		}
+		if len(tree) != 1 || tree[0].CategoryID != "women" || len(tree[0].Children) != 2 {
+			t.Fatalf("expected women with 2 subcategories but got %+v", tree)
+		}
+		if tree[0].Children[0].CategoryID != "blouse" || tree[0].Children[1].CategoryID != "dress" || len(tree[0].Children[0].Children) != 1 {
+			t.Errorf("expected blouse (with casual) and dress but got %+v and %+v", tree[0].Children[0], tree[0].Children[1])
+		}
+	})
+
+	err = inventoryObj.UpdateCategory("blouse", "Blouse", "casual")
+	t.Run("category must not be moved into its own subcategory", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	err = inventoryObj.UpdateCategory("casual", "Casual", "")
+	tree, _ = inventoryObj.GetCategoryTree()
+	t.Run("category moved to the root must be a root category", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(tree) != 2 || tree[0].CategoryID != "casual" || tree[0].Name != "Casual" {
+			t.Errorf("expected root categories casual and women but got %+v", tree)
+		}
+	})
+
+	errWithChildren := inventoryObj.DeleteCategory("women")
+	errWithItems := inventoryObj.DeleteCategory("casual")
+	err = inventoryObj.DeleteCategory("dress")
+	t.Run("only category without subcategories and items must be deleted", func(t *testing.T) {
+		if errWithChildren == nil || errWithItems == nil {
+			t.Fatalf("expected errors but got %v and %v", errWithChildren, errWithItems)
+		}
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+}
+
+func TestUpdateItemCategory(t *testing.T) {
+	inventoryObj := newCategoryInventory(t)
+
+	err := inventoryObj.UpdateItemCategory("otherSku", "men")
+	t.Run("missing category must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	err = inventoryObj.UpdateItemCategory("dummySku", "")
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	t.Run("empty category must clear the category of the item", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if itemInfo.CategoryID != "" {
+			t.Errorf("expected no category but got %v", itemInfo.CategoryID)
+		}
+	})
+}
+
+func TestFilterStockValue(t *testing.T) {
+	inventoryObj := newCategoryInventory(t)
+	stockValue, err := inventoryObj.GetAllStockValue()
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	err = inventoryObj.FilterStockValue(stockValue, "women")
+	t.Run("stock value must hold only the items below the category", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if _, exists := stockValue.Items["dummySku"]; len(stockValue.Items) != 1 || false == exists {
+			t.Fatalf("expected dummySku only but got %v items", len(stockValue.Items))
+		}
+		if stockValue.TotalItemKind != 1 || stockValue.TotalQuantity != 10 || stockValue.TotalAmount != 500000 {
+			t.Errorf("expected 10 items worth 500000 but got %+v", stockValue)
+		}
+	})
+
+	err = inventoryObj.GroupStockValue(stockValue, service.GroupByCategory)
+	t.Run("stock value must be grouped by category", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(stockValue.Groups) != 1 || stockValue.Groups[0].Key != "casual" || stockValue.Groups[0].Name != "Casual Blouse" {
+			t.Errorf("expected group casual but got %+v", stockValue.Groups)
+		}
+	})
+
+	err = inventoryObj.FilterStockValue(stockValue, "men")
+	t.Run("missing category must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+func TestFilterSalesValue(t *testing.T) {
+	inventoryObj := newCategoryInventory(t)
+	sales := map[string][]service.SaleItem{
+		"INV-1": {{Sku: "dummySku", Quantity: 2}, {Sku: "otherSku", Quantity: 1}},
+		"INV-2": {{Sku: "otherSku", Quantity: 3}},
+	}
+	for invoiceID, items := range sales {
+		_, err := inventoryObj.CreateSale(invoiceID, "", items)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		_, err = inventoryObj.UpdateSale(invoiceID, model.SalesStatusDone, "dummyUser")
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	salesValue, err := inventoryObj.GetAllSalesValue(time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	err = inventoryObj.FilterSalesValue(salesValue, "blouse")
+	t.Run("sales value must hold only the items below the category", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(salesValue.Items) != 1 || salesValue.Items[0].Sku != "dummySku" {
+			t.Fatalf("expected dummySku only but got %v items", len(salesValue.Items))
+		}
+		if salesValue.SaleCount != 1 || salesValue.TotalItemKind != 1 || salesValue.TotalQuantity != 2 || salesValue.SalesTurnOver != 120000 || salesValue.Profit != 20000 {
+			t.Errorf("expected 1 sale of 2 items worth 120000 but got %+v", salesValue)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//CostingMethodFIFO is const for costing method consuming the oldest cost layers first
+const CostingMethodFIFO string = "fifo"
+
+//CostingMethodMovingAverage is const for costing method using weighted average cost of all open cost layers
+const CostingMethodMovingAverage string = "movingAverage"
+
+//addCostLayer is a function for creating a new cost layer of incoming items (inside the passed unit of work)
+func (i *Inventory) addCostLayer(uow *UnitOfWork, sku string, quantity int64, unitCost model.Money, reference string) *errors.Error {
+	if quantity <= 0 {
+		return nil
+	}
+	newLayer := &model.CostLayer{
+		Sku:               sku,
+		Quantity:          quantity,
+		RemainingQuantity: quantity,
+		UnitCost:          unitCost,
+		Reference:         reference,
+		Date:              time.Now(),
+	}
+	err := uow.CostLayerDatamapper.Insert(newLayer)
+	if err != nil {
+		return errors.Wrap(fmt.Errorf("Sku: %v cost layer creation failed: %v", sku, err), 0)
+	}
+	return nil
+}
+
+//consumeCostLayers is a function for consuming cost layers of outgoing items (inside the passed unit of work)
+//it returns the total cost of the consumed items according to the configured costing method (exact, not rounded to a unit cost)
+//quantity not covered by any layer (e.g. stock recorded before cost layers exist) is costed at fallbackCost
+func (i *Inventory) consumeCostLayers(uow *UnitOfWork, sku string, quantity int64, fallbackCost model.Money) (model.Money, *errors.Error) {
+	if quantity <= 0 {
+		return 0, nil
+	}
+	layerFinder, ok := uow.CostLayerDatamapper.(datamapper.CostLayerFinder)
+	if false == ok {
+		return 0, errors.Wrap(fmt.Errorf("Failed asserting cost layer mapper"), 0)
+	}
+	costingMethod := i.CostingMethod
+	if costingMethod == "" {
+		costingMethod = CostingMethodFIFO
+	}
+	if costingMethod != CostingMethodFIFO && costingMethod != CostingMethodMovingAverage {
+		return 0, errors.Wrap(fmt.Errorf("Invalid costing method %v", costingMethod), 0)
+	}
+
+	openLayers, err := layerFinder.FindOpenBySku(sku)
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return 0, errors.Wrap(err, 0)
+	}
+	layers := make([]*model.CostLayer, 0, len(openLayers))
+	var openQuantity int64
+	var openAmount model.Money
+	for _, val := range openLayers {
+		valObj, ok := val.(*model.CostLayer)
+		if false == ok {
+			return 0, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		layers = append(layers, valObj)
+		openQuantity += valObj.RemainingQuantity
+		openAmount += valObj.UnitCost.Multiply(valObj.RemainingQuantity)
+	}
+
+	var averageCost model.Money
+	if costingMethod == CostingMethodMovingAverage && openQuantity > 0 {
+		//all open layers are revalued to their weighted average cost, so the average is kept for the next consumption
+		averageCost = openAmount.Divide(openQuantity)
+	}
+
+	//consume oldest layers first
+	var totalCost model.Money
+	remaining := quantity
+	for _, layer := range layers {
+		if costingMethod == CostingMethodMovingAverage {
+			layer.UnitCost = averageCost
+		}
+		if remaining > 0 {
+			consumed := layer.RemainingQuantity
+			if consumed > remaining {
+				consumed = remaining
+			}
+			layer.RemainingQuantity -= consumed
+			remaining -= consumed
+			totalCost += layer.UnitCost.Multiply(consumed)
+		} else if costingMethod == CostingMethodFIFO {
+			//untouched layer
+			break
+		}
+		err = uow.CostLayerDatamapper.Update(layer)
+		if err != nil {
+			return 0, errors.Wrap(fmt.Errorf("Sku: %v cost layer update failed: %v", sku, err), 0)
+		}
+	}
+	//not enough layers
+	totalCost += fallbackCost.Multiply(remaining)
+
+	return totalCost, nil
+}
+
+//adjustCostLayers is a function for applying a (signed) manual change of stock quantity to the cost layers (inside the passed unit of work)
+//incoming items create a new layer at unitCost, while outgoing items consume the existing layers
+func (i *Inventory) adjustCostLayers(uow *UnitOfWork, sku string, quantity int64, unitCost model.Money, reference string) *errors.Error {
+	if quantity > 0 {
+		return i.addCostLayer(uow, sku, quantity, unitCost, reference)
+	}
+	_, err := i.consumeCostLayers(uow, sku, -quantity, unitCost)
+	return err
+}
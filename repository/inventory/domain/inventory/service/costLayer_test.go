@@ -0,0 +1,204 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+	"time"
+)
+
+//newLayeredInventory is a function for composing an inventory service where layerSku has two open cost layers
+//(10 items at 40000 and 20 items at 52000) and a draft sale of 15 layerSku items
+func newLayeredInventory(t *testing.T, costingMethod string) *service.Inventory {
+	inventoryObj := newEmptyMemoryInventory()
+	inventoryObj.CostingMethod = costingMethod
+	err := inventoryObj.AddSKU("layerSku", 10, 40000, 55000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.UpdateSKU("layerSku", 30, 52000, 55000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.CreateSale("dummyDraftInvoice", "", []service.SaleItem{{Sku: "layerSku", Quantity: 15}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj
+}
+
+//completeDummySale is a function for updating the draft sale to done, it returns the completed sale item
+func completeDummySale(inventoryObj *service.Inventory, sku string) (*model.SaleItem, bool, error) {
+	ok, err := inventoryObj.UpdateSale("dummyDraftInvoice", model.SalesStatusDone, "dummyUser")
+	if err != nil {
+		return nil, ok, err
+	}
+	saleModel, err := inventoryObj.SalesDatamapper.FindByID("dummyDraftInvoice")
+	if err != nil {
+		return nil, ok, err
+	}
+	return saleModel.(*model.Sales).Items[sku], ok, nil
+}
+
+//openLayers is a function for obtaining the cost layers of a sku which still have remaining items, oldest first
+func openLayers(t *testing.T, inventoryObj *service.Inventory, sku string) []*model.CostLayer {
+	layerModels, err := inventoryObj.CostLayerDatamapper.FindAll()
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	layers := make([]*model.CostLayer, 0)
+	for _, val := range layerModels {
+		layer := val.(*model.CostLayer)
+		if layer.Sku == sku && layer.RemainingQuantity > 0 {
+			layers = append(layers, layer)
+		}
+	}
+	return layers
+}
+
+func TestUpdateSaleFIFOCost(t *testing.T) {
+	inventoryObj := newLayeredInventory(t, service.CostingMethodFIFO)
+	saleItem, ok, err := completeDummySale(inventoryObj, "layerSku")
+	t.Run("return must be true", func(t *testing.T) {
+		if true != ok {
+			t.Errorf("expected true but got %v", ok)
+		}
+	})
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	})
+	t.Run("buy price must be taken from the oldest layers", func(t *testing.T) {
+		//10 items at 40000 and 5 items at 52000
+		if saleItem == nil || saleItem.BuyPrice != 44000 {
+			t.Errorf("expected 44000 but got %+v", saleItem)
+		}
+	})
+	layers := openLayers(t, inventoryObj, "layerSku")
+	t.Run("layers must be consumed", func(t *testing.T) {
+		if len(layers) != 1 || layers[0].RemainingQuantity != 15 || layers[0].UnitCost != 52000 {
+			t.Errorf("expected 15 items at 52000 left but got %+v", layers)
+		}
+	})
+}
+
+func TestUpdateSaleMovingAverageCost(t *testing.T) {
+	inventoryObj := newLayeredInventory(t, service.CostingMethodMovingAverage)
+	saleItem, ok, err := completeDummySale(inventoryObj, "layerSku")
+	t.Run("return must be true", func(t *testing.T) {
+		if true != ok {
+			t.Errorf("expected true but got %v", ok)
+		}
+	})
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	})
+	t.Run("buy price must be the weighted average cost", func(t *testing.T) {
+		//(10 * 40000 + 20 * 52000) / 30
+		if saleItem == nil || saleItem.BuyPrice != 48000 {
+			t.Errorf("expected 48000 but got %+v", saleItem)
+		}
+	})
+	layers := openLayers(t, inventoryObj, "layerSku")
+	t.Run("remaining layers must be revalued to the average cost", func(t *testing.T) {
+		if len(layers) != 1 || layers[0].RemainingQuantity != 15 || layers[0].UnitCost != 48000 {
+			t.Errorf("expected 15 items at 48000 but got %+v", layers)
+		}
+	})
+}
+
+func TestUpdateSaleWithoutCostLayers(t *testing.T) {
+	//stock recorded before cost layers existed
+	inventoryObj := newEmptyMemoryInventory()
+	err := inventoryObj.StockDatamapper.Insert(&model.Stock{Sku: "legacySku", Quantity: 20, BuyPrice: 50000, SellPrice: 55000})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.CreateSale("dummyDraftInvoice", "", []service.SaleItem{{Sku: "legacySku", Quantity: 15}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	saleItem, _, saleErr := completeDummySale(inventoryObj, "legacySku")
+	t.Run("err return must be nil", func(t *testing.T) {
+		if saleErr != nil {
+			t.Fatalf("expected nil but got %v", saleErr)
+		}
+	})
+	t.Run("buy price must fall back to stock buy price", func(t *testing.T) {
+		if saleItem == nil || saleItem.BuyPrice != 50000 {
+			t.Errorf("expected 50000 but got %+v", saleItem)
+		}
+	})
+
+	//failed case (invalid costing method)
+	invalidInventoryObj := newLayeredInventory(t, "invalid")
+	_, failedOk, failedErr := completeDummySale(invalidInventoryObj, "layerSku")
+	itemInfo, _ := invalidInventoryObj.GetItemInfo("layerSku")
+	t.Run("Failed return must be false", func(t *testing.T) {
+		if false != failedOk {
+			t.Errorf("expected false but got %v", failedOk)
+		}
+	})
+	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
+		if getType(failedErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(failedErr))
+		}
+	})
+	t.Run("Failed sale must not take items from stock", func(t *testing.T) {
+		if itemInfo.Quantity != 30 || itemInfo.Reserved != 15 {
+			t.Errorf("expected 30 items with 15 reserved but got %+v", itemInfo)
+		}
+	})
+}
+
+func TestUpdateSaleExactCost(t *testing.T) {
+	//3 items taken from 2 layers (2 items at 40000 and 1 item at 40001), the unit cost isn't a whole amount
+	inventoryObj := newEmptyMemoryInventory()
+	err := inventoryObj.AddSKU("oddSku", 2, 40000, 55000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.UpdateSKU("oddSku", 10, 40001, 55000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.CreateSale("dummyDraftInvoice", "", []service.SaleItem{{Sku: "oddSku", Quantity: 3}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	saleItem, _, saleErr := completeDummySale(inventoryObj, "oddSku")
+	t.Run("sale item must hold the exact cost of the consumed layers", func(t *testing.T) {
+		if saleErr != nil {
+			t.Fatalf("expected nil but got %v", saleErr)
+		}
+		if saleItem.Cost != 120001 || saleItem.GetCost() != 120001 || saleItem.BuyPrice != 40000 {
+			t.Errorf("expected cost 120001 at 40000 a unit but got %+v", saleItem)
+		}
+	})
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	salesValue, err := inventoryObj.GetAllSalesValue(today, today)
+	t.Run("profit must be taken from the exact cost", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if salesValue.SalesTurnOver != 165000 || salesValue.Profit != 44999 || salesValue.Items[0].Profit != 44999 {
+			t.Errorf("expected profit 44999 of 165000 but got %+v", salesValue)
+		}
+	})
+	report, err := inventoryObj.GetSalesReport(today, today, service.GroupBySku, false)
+	t.Run("cogs must be the exact cost", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if report.Totals.COGS != 120001 || report.Totals.Profit != 44999 {
+			t.Errorf("expected cogs 120001 and profit 44999 but got %+v", report.Totals)
+		}
+	})
+}
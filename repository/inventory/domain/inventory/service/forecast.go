@@ -0,0 +1,112 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/forecast"
+)
+
+//ForecastParams is a definition of the parameters of a demand forecast (see ForecastDemand)
+type ForecastParams struct {
+	Horizon     int       //count of forecast periods
+	Granularity string    //forecast.Daily (default) or forecast.Weekly
+	Model       string    //model name (see forecast.Model consts), empty for the most accurate model by backtest
+	AsOf        time.Time //last day of the sales the demand series is built from, defaults to today
+	Confidence  float64   //probability of the demand falling within the prediction intervals, defaults to forecast.DefaultConfidence
+}
+
+//DemandForecast is a struct containing the demand series of a specific Sku along with its forecast
+type DemandForecast struct {
+	Sku         string               `json:"sku"`
+	Granularity string               `json:"granularity"`
+	History     []*forecast.Period   `json:"history"` //from the period of the first completed sale of the sku to the period of the as of date
+	Forecast    *forecast.Forecast   `json:"forecast"`
+	Backtest    []*forecast.Accuracy `json:"backtest"` //accuracy of the models forecasting the last periods of the history, empty when the model is given
+}
+
+//ForecastDemand is a function for forecasting the quantity of a specific Sku sold in the periods following the as of date
+//the demand series starts from the first completed sale of the sku, the model is selected by backtesting the default models on the last periods of the series (as many as the horizon, up to a third of the series) unless one is given
+//exponential smoothing is used when the series is too short to backtest any model
+func (i *Inventory) ForecastDemand(sku string, params ForecastParams) (*DemandForecast, *errors.Error) {
+	if params.Horizon <= 0 {
+		return nil, errors.Wrap(fmt.Errorf("Invalid horizon %v from param", params.Horizon), 0)
+	}
+	if params.Granularity == "" {
+		params.Granularity = forecast.Daily
+	}
+	if params.Confidence == 0 {
+		params.Confidence = forecast.DefaultConfidence
+	}
+	if true == params.AsOf.IsZero() {
+		params.AsOf = time.Now()
+	}
+	_, err := i.StockDatamapper.FindByID(sku)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return nil, errors.Wrap(fmt.Errorf("Sku: %v is not found", sku), 0)
+		}
+		return nil, err
+	}
+
+	sales, err := i.doneSales(time.Time{}, params.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	var firstSale time.Time
+	observations := make([]forecast.Observation, 0)
+	for _, val := range sales {
+		itemVal, exists := val.Items[sku]
+		if false == exists {
+			continue
+		}
+		observations = append(observations, forecast.Observation{
+			Date:     val.Date,
+			Quantity: float64(itemVal.Quantity),
+		})
+		if true == firstSale.IsZero() || val.Date.Before(firstSale) {
+			firstSale = val.Date
+		}
+	}
+	if len(observations) == 0 {
+		return nil, errors.Wrap(fmt.Errorf("Sku: %v has no completed sale to forecast from", sku), 0)
+	}
+	series, errs := forecast.NewSeries(observations, params.Granularity, firstSale, params.AsOf)
+	if errs != nil {
+		return nil, errors.Wrap(errs, 0)
+	}
+
+	demandForecast := &DemandForecast{
+		Sku:         sku,
+		Granularity: params.Granularity,
+		History:     series.Periods(),
+		Backtest:    make([]*forecast.Accuracy, 0),
+	}
+	var model forecast.Model
+	if params.Model != "" {
+		model, errs = forecast.ModelByName(params.Model, series.SeasonLength())
+	} else {
+		holdout := params.Horizon
+		if holdout > len(series.Values)/3 {
+			holdout = len(series.Values) / 3
+		}
+		model, demandForecast.Backtest, errs = forecast.SelectModel(series, forecast.DefaultModels(series.SeasonLength()), holdout, params.Confidence)
+		if errs != nil {
+			//history too short to backtest any model
+			model, errs = forecast.ModelByName(forecast.ModelExponentialSmoothing, series.SeasonLength())
+			demandForecast.Backtest = make([]*forecast.Accuracy, 0)
+		}
+	}
+	if errs != nil {
+		return nil, errors.Wrap(errs, 0)
+	}
+	demandForecast.Forecast, errs = forecast.Predict(series, model, params.Horizon, params.Confidence)
+	if errs != nil {
+		return nil, errors.Wrap(errs, 0)
+	}
+	return demandForecast, nil
+}
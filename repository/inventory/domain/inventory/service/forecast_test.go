@@ -0,0 +1,135 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/forecast"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"ijah-inventory/repository/inventory/server/migration"
+)
+
+//dumpPath is the sqlite dump restored by install.sh, relative to the service package
+const dumpPath = "../../../../../ijahDump.sql"
+
+//newDumpInventory is a function for composing an inventory service on a migrated sqlite database restored from the dump
+//the dump holds completed sales from 2017-12-16 to 2017-12-19
+func newDumpInventory(t *testing.T) *service.Inventory {
+	dump, err := ioutil.ReadFile(dumpPath)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	db, err := sql.Open(datamapper.DriverSQLite, filepath.Join(t.TempDir(), "ijah.db"))
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+	_, err = db.Exec(string(dump))
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	migrator, errs := migration.NewMigrator(db, datamapper.SQLite)
+	if errs != nil {
+		t.Fatalf("expected nil but got %v", errs)
+	}
+	_, errs = migrator.Up()
+	if errs != nil {
+		t.Fatalf("expected nil but got %v", errs)
+	}
+	return &service.Inventory{
+		StockDatamapper:         datamapper.NewStock(db, datamapper.SQLite),
+		ProductDatamapper:       datamapper.NewProduct(db, datamapper.SQLite),
+		SalesDatamapper:         datamapper.NewSale(db, datamapper.SQLite),
+		SalesReturnDatamapper:   datamapper.NewSalesReturn(db, datamapper.SQLite),
+		StockMovementDatamapper: datamapper.NewStockMovement(db, datamapper.SQLite),
+		DB:                      db,
+	}
+}
+
+func TestForecastDemand(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
+	_, err := inventoryObj.ForecastDemand("dummySku", service.ForecastParams{Horizon: 0})
+	_, errSku := inventoryObj.ForecastDemand("missingSku", service.ForecastParams{Horizon: 7})
+	_, errSales := inventoryObj.ForecastDemand("dummySku", service.ForecastParams{Horizon: 7})
+	t.Run("invalid horizon, missing sku and sku without sales must be rejected", func(t *testing.T) {
+		if err == nil || errSku == nil || errSales == nil {
+			t.Errorf("expected errors but got %v, %v and %v", err, errSku, errSales)
+		}
+	})
+
+	_, err = inventoryObj.CreateSale("dummyInvoice", "", []service.SaleItem{{Sku: "dummySku", Quantity: 3}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.UpdateSale("dummyInvoice", model.SalesStatusDone, "dummyUser")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	result, err := inventoryObj.ForecastDemand("dummySku", service.ForecastParams{Horizon: 3})
+	t.Run("history too short to backtest must be forecast by exponential smoothing", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(result.History) != 1 || result.History[0].Quantity != 3 || len(result.Backtest) != 0 {
+			t.Errorf("expected a day of 3 items without backtest but got %+v and %+v", result.History, result.Backtest)
+		}
+		if result.Forecast.Model != forecast.ModelExponentialSmoothing || len(result.Forecast.Points) != 3 || result.Forecast.Points[0].Value != 3 {
+			t.Errorf("expected 3 days of 3 items but got %+v", result.Forecast)
+		}
+	})
+
+	_, err = inventoryObj.ForecastDemand("dummySku", service.ForecastParams{Horizon: 3, Model: "arima"})
+	t.Run("unknown model must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+func TestForecastDemandDump(t *testing.T) {
+	inventoryObj := newDumpInventory(t)
+	asOf := time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	//2 items on 2017-12-16 and 7 items on 2017-12-19
+	result, err := inventoryObj.ForecastDemand("SSI-D00791015-LL-BWH", service.ForecastParams{Horizon: 7, AsOf: asOf})
+	t.Run("daily demand must be backtested on the last days of the dump", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(result.History) != 16 || result.History[0].Quantity != 2 || result.History[3].Quantity != 7 {
+			t.Fatalf("expected 16 days from 2 and 7 items but got %+v", result.History)
+		}
+		//holtWinters needs 2 weeks before the 5 backtested days
+		if len(result.Backtest) != 2 || result.Backtest[0].Holdout != 5 || result.Backtest[0].MAE != 0 || result.Backtest[1].MAE <= 0 {
+			t.Fatalf("expected movingAverage and exponentialSmoothing backtested on 5 days but got %+v", result.Backtest)
+		}
+		points := result.Forecast.Points
+		if result.Forecast.Model != forecast.ModelMovingAverage || len(points) != 7 || false == points[0].Date.Equal(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected 7 days of movingAverage from 2018-01-01 but got %+v", result.Forecast)
+		}
+	})
+
+	result, err = inventoryObj.ForecastDemand("SSI-D00791015-LL-BWH", service.ForecastParams{Horizon: 2, Granularity: forecast.Weekly, Model: forecast.ModelExponentialSmoothing, AsOf: asOf})
+	t.Run("weekly demand must be forecast by the given model with prediction intervals", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(result.History) != 3 || result.History[0].Quantity != 2 || result.History[1].Quantity != 7 || len(result.Backtest) != 0 {
+			t.Fatalf("expected weeks of 2, 7 and 0 items but got %+v", result.History)
+		}
+		//2 -> 3.5 -> 2.45, errors 5 and -3.5
+		points := result.Forecast.Points
+		if len(points) != 2 || points[0].Value < 2.449 || points[0].Value > 2.451 || points[0].Lower != 0 || points[0].Upper < 10.9 || points[0].Upper > 11 {
+			t.Errorf("expected 2.45 within [0 10.91] but got %+v", points)
+		}
+	})
+}
@@ -20,46 +20,55 @@ type SaleItem struct {
 
 //StockValue is a struct containing stock value information
 type StockValue struct {
-	Date          time.Time                  `json:"date"`
-	TotalQuantity int64                      `json:"totalQuantity"`
-	TotalAmount   float64                    `json:"totalAmount"`
-	TotalItemKind int                        `json:"totalItemKind"`
-	Items         map[string]*StockValueItem `json:"items"`
+	Date           time.Time                  `json:"date"`
+	TotalQuantity  int64                      `json:"totalQuantity"`
+	TotalReserved  int64                      `json:"totalReserved"`
+	TotalAvailable int64                      `json:"totalAvailable"`
+	TotalAmount    model.Money                `json:"totalAmount"`
+	TotalItemKind  int                        `json:"totalItemKind"`
+	Items          map[string]*StockValueItem `json:"items"`
+	Groups         []*StockValueGroup         `json:"groups,omitempty"` //subtotals by product, size, color or category (see GroupStockValue)
 }
 
 //StockValueItem is a struct containing stock value for a specific Sku
 type StockValueItem struct {
-	Sku         string  `json:"sku"`
-	Quantity    int64   `json:"quantity"`
-	BuyPrice    float64 `json:"buyPrice"`
-	TotalAmount float64 `json:"totalAmount"`
+	Sku         string      `json:"sku"`
+	Quantity    int64       `json:"quantity"`
+	Reserved    int64       `json:"reserved"`  //quantity held by draft sales (still valued as stock)
+	Available   int64       `json:"available"` //quantity - reserved
+	BuyPrice    model.Money `json:"buyPrice"`
+	TotalAmount model.Money `json:"totalAmount"`
 }
 
 //SaleValue is a struct containing sales value information
 type SaleValue struct {
-	StartDate     time.Time        `json:"startDate"`
-	EndDate       time.Time        `json:"endDate"`
-	TotalQuantity int64            `json:"totalQuantity"`
-	TotalItemKind int              `json:"totalItemKind"`
-	SaleCount     int              `json:"saleCount"`
-	SalesTurnOver float64          `json:"omzet"`
-	Profit        float64          `json:"totalProfit"`
-	Items         []*SaleValueItem `json:"items"`
+	StartDate     time.Time         `json:"startDate"`
+	EndDate       time.Time         `json:"endDate"`
+	TotalQuantity int64             `json:"totalQuantity"`
+	TotalItemKind int               `json:"totalItemKind"`
+	SaleCount     int               `json:"saleCount"`
+	ReturnCount   int               `json:"returnCount"`
+	Refund        model.Money       `json:"refund"` //amount refunded for items returned during the period (already netted out of omzet)
+	SalesTurnOver model.Money       `json:"omzet"`
+	Profit        model.Money       `json:"totalProfit"`
+	Items         []*SaleValueItem  `json:"items"`            //returned items are listed with negative quantity and profit
+	Groups        []*SaleValueGroup `json:"groups,omitempty"` //subtotals by product, size, color or category (see GroupSalesValue)
 }
 
 //SaleValueItem is a struct containing sales value for a specific Sku
 type SaleValueItem struct {
-	Sku       string  `json:"sku"`
-	Quantity  int64   `json:"quantity"`
-	BuyPrice  float64 `json:"buyPrice"`
-	SellPrice float64 `json:"sellPrice"`
-	Profit    float64 `json:"profit"`
+	Sku       string      `json:"sku"`
+	Quantity  int64       `json:"quantity"`
+	BuyPrice  model.Money `json:"buyPrice"`
+	SellPrice model.Money `json:"sellPrice"`
+	Profit    model.Money `json:"profit"`
+	reference string      //invoice id of the sale (or return id of the return) the item belongs to, for counting sales and returns (see FilterSalesValue)
 }
 
 //NewInventory returns a new inventory service object
 func NewInventory(stockMapper, purchaseMapper, salesMapper datamapper.DataMapper, db *sql.DB) *Inventory {
-	//TODO: the db session injected here must be the same as the db session used by injected datamappers, so database transactions used here is on the same db connection
-	//Refactor so this validation can be performed here
+	//Note: multi-step operations run in a unit of work (see Begin), which binds the datamappers to a transaction started on db
+	//so the datamappers always run on the same connection as the transaction
 	return &Inventory{
 		StockDatamapper:    stockMapper,
 		PurchaseDatamapper: purchaseMapper,
@@ -70,10 +79,26 @@ func NewInventory(stockMapper, purchaseMapper, salesMapper datamapper.DataMapper
 
 //Inventory is a service object dealing with inventory business domain
 type Inventory struct {
-	StockDatamapper    datamapper.DataMapper `inject:"stockDatamapper"`
-	PurchaseDatamapper datamapper.DataMapper `inject:"purchaseDatamapper"`
-	SalesDatamapper    datamapper.DataMapper `inject:"salesDatamapper"`
-	DB                 *sql.DB               `inject:"dbSession"`
+	StockDatamapper                datamapper.DataMapper   `inject:"stockDatamapper"`
+	ProductDatamapper              datamapper.DataMapper   `inject:"productDatamapper"`
+	CategoryDatamapper             datamapper.DataMapper   `inject:"categoryDatamapper"`
+	AttributeDatamapper            datamapper.DataMapper   `inject:"attributeDatamapper"`
+	ItemAttributesDatamapper       datamapper.DataMapper   `inject:"itemAttributesDatamapper"`
+	SupplierDatamapper             datamapper.DataMapper   `inject:"supplierDatamapper"`
+	PurchaseDatamapper             datamapper.DataMapper   `inject:"purchaseDatamapper"`
+	PurchaseReceiptDatamapper      datamapper.DataMapper   `inject:"purchaseReceiptDatamapper"`
+	SalesDatamapper                datamapper.DataMapper   `inject:"salesDatamapper"`
+	SalesReturnDatamapper          datamapper.DataMapper   `inject:"salesReturnDatamapper"`
+	StockMovementDatamapper        datamapper.DataMapper   `inject:"stockMovementDatamapper"`
+	CostLayerDatamapper            datamapper.DataMapper   `inject:"costLayerDatamapper"`
+	StockCountDatamapper           datamapper.DataMapper   `inject:"stockCountDatamapper"`
+	StockAlertDatamapper           datamapper.DataMapper   `inject:"stockAlertDatamapper"`
+	SaleStatusTransitionDatamapper datamapper.DataMapper   `inject:"saleStatusTransitionDatamapper"`
+	DB                             *sql.DB                 //db session of the sql datamappers, not injected since it's nil for the memory driver
+	MemoryStore                    *datamapper.MemoryStore //store of the memory datamappers, units of work run on it instead of DB when it's set (not injected either)
+	CostingMethod                  string                  //costing method for consuming cost layers (see CostingMethod consts), defaults to fifo
+	SkuPattern                     string                  //pattern of skus which are variants of a product (see CompileSkuPattern), defaults to DefaultSkuPattern
+	AlertSender                    *AlertSender            //background sender delivering raised and escalated low stock alerts (see CheckStockAlerts), alerts aren't delivered when it's nil
 }
 
 //GetItemInfo is a function for obtaining information of an item
@@ -91,7 +116,12 @@ func (i *Inventory) GetItemInfo(sku string) (*model.Stock, *errors.Error) {
 }
 
 //AddSKU is a function for adding a new item type to inventory
-func (i *Inventory) AddSKU(sku string, quantity int64, buyPrice, sellPrice float64) *errors.Error {
+//the item becomes a variant of the product of its sku when the sku follows the sku pattern (see SkuPattern)
+func (i *Inventory) AddSKU(sku string, quantity int64, buyPrice, sellPrice model.Money) *errors.Error {
+	skuPattern, err := CompileSkuPattern(i.SkuPattern)
+	if err != nil {
+		return err
+	}
 	//compose stock model object
 	newSku := &model.Stock{
 		Sku:       sku,
@@ -99,66 +129,116 @@ func (i *Inventory) AddSKU(sku string, quantity int64, buyPrice, sellPrice float
 		BuyPrice:  buyPrice,
 		SellPrice: sellPrice,
 	}
-	return i.StockDatamapper.Insert(newSku)
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		_, _, err := i.assignVariant(uow, skuPattern, newSku)
+		if err != nil {
+			return err
+		}
+		err = uow.StockDatamapper.Insert(newSku)
+		if err != nil {
+			return err
+		}
+		//record the initial quantity as the first movement of the sku
+		err = i.recordMovement(uow, sku, quantity, buyPrice, model.MovementReasonInitialStock, sku, "")
+		if err != nil {
+			return err
+		}
+		return i.addCostLayer(uow, sku, quantity, buyPrice, sku)
+	})
 }
 
 //UpdateSKU is a function for updating SKU info
-func (i *Inventory) UpdateSKU(sku string, quantity int64, buyPrice, sellPrice float64) *errors.Error {
-	stockObj, err := i.GetItemInfo(sku)
-	if err != nil {
-		return err
-	}
-	stockObj.Quantity = quantity
-	stockObj.BuyPrice = buyPrice
-	stockObj.SellPrice = sellPrice
+//Any change of quantity is recorded as an adjustment movement, a change of buy price alone is recorded as a cost change movement
+func (i *Inventory) UpdateSKU(sku string, quantity int64, buyPrice, sellPrice model.Money) *errors.Error {
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		stockObj, err := uow.getStock(sku)
+		if err != nil {
+			return err
+		}
+		if quantity < stockObj.Reserved {
+			return errors.Wrap(fmt.Errorf("Sku: %v quantity can't be less than its reserved quantity %v", sku, stockObj.Reserved), 0)
+		}
+		quantityChange := quantity - stockObj.Quantity
+		costChanged := buyPrice != stockObj.BuyPrice
+
+		stockObj.Quantity = quantity
+		stockObj.BuyPrice = buyPrice
+		stockObj.SellPrice = sellPrice
 
-	return i.StockDatamapper.Update(stockObj)
+		err = uow.StockDatamapper.Update(stockObj)
+		if err != nil {
+			return err
+		}
+		err = i.recordMovement(uow, sku, quantityChange, buyPrice, model.MovementReasonAdjustment, "", "SKU update")
+		if err != nil {
+			return err
+		}
+		err = i.adjustCostLayers(uow, sku, quantityChange, buyPrice, "")
+		if err != nil {
+			return err
+		}
+		if quantityChange < 0 {
+			err = i.checkStockDrop(uow, stockObj)
+			if err != nil {
+				return err
+			}
+		}
+		if quantityChange == 0 && true == costChanged {
+			//keep track of the buy price so stock can be valued at any point in time
+			return i.recordMovement(uow, sku, 0, buyPrice, model.MovementReasonCostChange, "", "SKU update")
+		}
+		return nil
+	})
 }
 
 //CreateSale is a function for creating a new sale
+//the items of the new (draft) sale are reserved until the sale is done or canceled
 func (i *Inventory) CreateSale(invoiceNo, note string, items []SaleItem) (bool, *errors.Error) {
-	existingSale, _ := i.SalesDatamapper.FindByID(invoiceNo)
-	if existingSale != nil {
-		return false, errors.Wrap(fmt.Errorf("Invoice no %v already exists", invoiceNo), 0)
-	}
-
-	//compose sale domain model
-	newSale := &model.Sales{
-		InvoiceID: invoiceNo,
-		Date:      time.Now(),
-		Note:      note,
-		Status:    model.SalesStatusDraft,
-	}
-	newSalesItems := make(map[string]*model.SaleItem, 0)
-	for _, val := range items {
-		//get buy and sell price of the sku
-		foundItem, err := i.StockDatamapper.FindByID(val.Sku)
-		if err != nil {
-			if err.Err == datamapper.ErrNotFound {
-				//invalid sku, cannot continue
-				return false, errors.Wrap(fmt.Errorf("Cannot create sale. Sku %v is not valid item", val.Sku), 0)
-			}
-			return false, errors.Wrap(err, 0)
+	err := i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		existingSale, _ := uow.SalesDatamapper.FindByID(invoiceNo)
+		if existingSale != nil {
+			return errors.Wrap(fmt.Errorf("Invoice no %v already exists", invoiceNo), 0)
 		}
-		foundItemObj, ok := foundItem.(*model.Stock)
-		if false == ok {
-			return false, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+
+		//compose sale domain model
+		newSale := &model.Sales{
+			InvoiceID: invoiceNo,
+			Date:      time.Now(),
+			Note:      note,
+			Status:    model.SalesStatusDraft,
 		}
-		//check whether sale quantity is enough
-		if val.Quantity > foundItemObj.Quantity {
-			return false, errors.Wrap(fmt.Errorf("Cannot create sale. Not enough stock for Sku %v", val.Sku), 0)
+		newSalesItems := make(map[string]*model.SaleItem, 0)
+		for _, val := range items {
+			//get buy and sell price of the sku
+			foundItemObj, err := uow.getStock(val.Sku)
+			if err != nil {
+				if err.Err == datamapper.ErrNotFound {
+					//invalid sku, cannot continue
+					return errors.Wrap(fmt.Errorf("Cannot create sale. Sku %v is not valid item", val.Sku), 0)
+				}
+				return errors.Wrap(err, 0)
+			}
+			//check whether available (unreserved) quantity is enough
+			if val.Quantity > foundItemObj.Available() {
+				return errors.Wrap(fmt.Errorf("Cannot create sale. Not enough available stock for Sku %v", val.Sku), 0)
+			}
+			//compose sale item
+			newItem := &model.SaleItem{
+				Sku:       val.Sku,
+				Quantity:  val.Quantity,
+				BuyPrice:  foundItemObj.BuyPrice,
+				SellPrice: foundItemObj.SellPrice,
+			}
+			newSalesItems[val.Sku] = newItem
 		}
-		//compose sale item
-		newItem := &model.SaleItem{
-			Sku:       val.Sku,
-			Quantity:  val.Quantity,
-			BuyPrice:  foundItemObj.BuyPrice,
-			SellPrice: foundItemObj.SellPrice,
+		newSale.Items = newSalesItems
+
+		err := uow.SalesDatamapper.Insert(newSale)
+		if err != nil {
+			return errors.Wrap(err, 0)
 		}
-		newSalesItems[val.Sku] = newItem
-	}
-	newSale.Items = newSalesItems
-	err := i.SalesDatamapper.Insert(newSale)
+		return i.reserveSale(uow, newSale)
+	})
 	if err != nil {
 		return false, errors.Wrap(err, 0)
 	}
@@ -166,71 +246,107 @@ func (i *Inventory) CreateSale(invoiceNo, note string, items []SaleItem) (bool,
 }
 
 //UpdateSale is a function for updating sale status
-func (i *Inventory) UpdateSale(invoiceNo, status string) (bool, *errors.Error) {
+//only transitions listed in saleStatusTransitions are allowed, every transition is recorded along with who made it
+func (i *Inventory) UpdateSale(invoiceNo, status, changedBy string) (bool, *errors.Error) {
 	//validation, check whether given status is valid
 	if status != model.SalesStatusDraft &&
 		status != model.SalesStatusDone &&
 		status != model.SalesStatusCanceled {
 		return false, errors.Wrap(fmt.Errorf("Invalid status %v from param", status), 0)
 	}
-	//check whether the sale exists or not
-	foundSale, err := i.SalesDatamapper.FindByID(invoiceNo)
-	if err != nil {
-		if err.Err == datamapper.ErrNotFound {
-			//sale not found
-			return false, errors.Wrap(fmt.Errorf("Sale %v is not found", invoiceNo), 0)
-		}
-		return false, errors.Wrap(err, 0)
-	}
-	foundSaleObj, ok := foundSale.(*model.Sales)
-	if false == ok {
-		return false, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
-	}
-
-	stockMapper, ok := i.StockDatamapper.(*datamapper.Stock)
-	if false == ok {
-		return false, errors.Wrap(fmt.Errorf("Failed asserting stock mapper"), 0)
-	}
-
-	//TODO: findan effective way of preventing "database is locked" error
-	//this might be related: https://github.com/mattn/go-sqlite3/issues/274
-	tx, errt := i.DB.Begin()
-	if errt != nil {
-		return false, errors.Wrap(err, 0)
+	if changedBy == "" {
+		return false, errors.Wrap(fmt.Errorf("Invalid changedBy from param, it must not be empty"), 0)
 	}
-	//sale status updated to Done from Other status
-	if status == model.SalesStatusDone && foundSaleObj.Status != model.SalesStatusDone {
-		for _, val := range foundSaleObj.Items {
-			//update stock quantity
-			saleItem, err := stockMapper.FindByID(val.Sku)
+	err := i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		//check whether the sale exists or not
+		foundSale, err := uow.SalesDatamapper.FindByID(invoiceNo)
+		if err != nil {
+			if err.Err == datamapper.ErrNotFound {
+				//sale not found
+				return errors.Wrap(fmt.Errorf("Sale %v is not found", invoiceNo), 0)
+			}
+			return errors.Wrap(err, 0)
+		}
+		foundSaleObj, ok := foundSale.(*model.Sales)
+		if false == ok {
+			return errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		previousStatus := foundSaleObj.Status
+		if false == isSaleTransitionAllowed(previousStatus, status) {
+			return errors.Wrap(fmt.Errorf("Sale %v can't be moved from status %v to %v", invoiceNo, previousStatus, status), 0)
+		}
+		if previousStatus == model.SalesStatusDone && status == model.SalesStatusCanceled {
+			//a sale with returned items can't be canceled, the remaining items must be returned instead
+			returnedQuantities, err := i.getReturnedQuantities(uow, invoiceNo)
 			if err != nil {
-				return false, errors.Wrap(err, 0)
+				return err
 			}
-
-			saleItemObj, ok := saleItem.(*model.Stock)
-			if false == ok {
-				return false, errors.Wrap(fmt.Errorf("Failed asserting stock"), 0)
+			if len(returnedQuantities) > 0 {
+				return errors.Wrap(fmt.Errorf("Sale %v already has returned items and can't be canceled", invoiceNo), 0)
 			}
+		}
 
-			if saleItemObj.Quantity < val.Quantity {
-				return false, errors.Wrap(fmt.Errorf("Sku: %v doesn't have enough stock", saleItemObj.Sku), 0)
+		//sale status updated to Done from Other status
+		if status == model.SalesStatusDone {
+			for _, val := range foundSaleObj.Items {
+				//update stock quantity
+				saleItemObj, err := uow.getStock(val.Sku)
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+				if saleItemObj.Quantity < val.Quantity {
+					return errors.Wrap(fmt.Errorf("Sku: %v doesn't have enough stock", saleItemObj.Sku), 0)
+				}
+				saleItemObj.Quantity -= val.Quantity
+				//the draft reservation is consumed along with the stock
+				releaseReservation(saleItemObj, val.Quantity)
+				err = uow.StockDatamapper.Update(saleItemObj)
+				if err != nil {
+					return errors.Wrap(fmt.Errorf("Sku: %v stock update failed: %v", saleItemObj.Sku, err), 0)
+				}
+				err = i.recordMovement(uow, val.Sku, -val.Quantity, saleItemObj.BuyPrice, model.MovementReasonSale, invoiceNo, "")
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+				//cost of the sold items is taken from the consumed cost layers
+				consumedCost, err := i.consumeCostLayers(uow, val.Sku, val.Quantity, saleItemObj.BuyPrice)
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+				val.Cost = consumedCost
+				val.BuyPrice = consumedCost.Divide(val.Quantity)
+				err = i.checkStockDrop(uow, saleItemObj)
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
 			}
-			saleItemObj.Quantity -= val.Quantity
-			err = stockMapper.UpdateWithTx(saleItemObj, tx)
+		}
+		//draft sale canceled, the reserved items are available again
+		if previousStatus == model.SalesStatusDraft && status == model.SalesStatusCanceled {
+			err = i.releaseSale(uow, foundSaleObj)
 			if err != nil {
-				return false, errors.Wrap(fmt.Errorf("Sku: %v stock update failed: %v", saleItemObj.Sku, err), 0)
+				return errors.Wrap(err, 0)
 			}
 		}
-	}
-	//update sale
-	foundSaleObj.Status = status
+		//completed sale canceled, the sold items go back to stock
+		if previousStatus == model.SalesStatusDone && status == model.SalesStatusCanceled {
+			err = i.restockSale(uow, foundSaleObj)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+		}
+		//update sale
+		foundSaleObj.Status = status
 
-	err = i.SalesDatamapper.Save(foundSaleObj)
+		err = uow.SalesDatamapper.Update(foundSaleObj)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		return i.recordSaleTransition(uow, invoiceNo, previousStatus, status, changedBy)
+	})
 	if err != nil {
-		tx.Rollback()
 		return false, errors.Wrap(err, 0)
 	}
-	tx.Commit()
 	return true, nil
 }
 
@@ -249,9 +365,10 @@ func (i *Inventory) GetAllStockValue() (*StockValue, *errors.Error) {
 	stockValue := &StockValue{
 		Date: time.Now(),
 	}
-	var kind int            //total kind of sku available
-	var totalAmount float64 //total amount of sku value, accumulate buy price * quantity for every sku
-	var totalQuantity int64 //total quantity of all sku, accumulate quantity for every sku
+	var kind int                //total kind of sku available
+	var totalAmount model.Money //total amount of sku value, accumulate buy price * quantity for every sku
+	var totalQuantity int64     //total quantity of all sku, accumulate quantity for every sku
+	var totalReserved int64     //total reserved quantity of all sku, accumulate reserved quantity for every sku
 	stockValueItems := make(map[string]*StockValueItem, 0)
 	for _, val := range currentStock {
 		valObj, ok := val.(*model.Stock)
@@ -261,18 +378,106 @@ func (i *Inventory) GetAllStockValue() (*StockValue, *errors.Error) {
 		newStockValueItem := &StockValueItem{
 			Sku:         valObj.Sku,
 			Quantity:    valObj.Quantity,
+			Reserved:    valObj.Reserved,
+			Available:   valObj.Available(),
 			BuyPrice:    valObj.BuyPrice,
-			TotalAmount: valObj.BuyPrice * float64(valObj.Quantity),
+			TotalAmount: valObj.BuyPrice.Multiply(valObj.Quantity),
 		}
 		stockValueItems[valObj.Sku] = newStockValueItem
 		totalAmount += newStockValueItem.TotalAmount
 		totalQuantity += newStockValueItem.Quantity
+		totalReserved += newStockValueItem.Reserved
 		kind++
 	}
 	stockValue.Items = stockValueItems
 	stockValue.TotalItemKind = kind
 	stockValue.TotalAmount = totalAmount
 	stockValue.TotalQuantity = totalQuantity
+	stockValue.TotalReserved = totalReserved
+	stockValue.TotalAvailable = totalQuantity - totalReserved
+
+	return stockValue, nil
+}
+
+//GetStockValueAsOf is a function for obtaining stock value at the end of the given date
+//Quantities are rebuilt by reverting every stock movement recorded after the date from current stock,
+//while buy price of an item is taken from the last movement recorded on or before the date
+func (i *Inventory) GetStockValueAsOf(asOf time.Time) (*StockValue, *errors.Error) {
+	currentStock, err := i.StockDatamapper.FindAll()
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			//no stock at all
+			return nil, errors.Wrap(fmt.Errorf("No Sku available"), 0)
+		}
+		return nil, errors.Wrap(err, 0)
+	}
+	movements, err := i.StockMovementDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	//the whole asOf date is included
+	cutOffDate := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location()).AddDate(0, 0, 1)
+	quantityAfter := make(map[string]int64, 0)  //accumulated movement quantity after the date for every sku
+	lastCost := make(map[string]model.Money, 0) //buy price at the date for every sku
+	addedAfter := make(map[string]bool, 0)      //flag for sku added to inventory after the date
+	for _, val := range movements {
+		valObj, ok := val.(*model.StockMovement)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		if valObj.Date.Before(cutOffDate) {
+			//movements are ordered by date, so the last one found holds the buy price at the date
+			lastCost[valObj.Sku] = valObj.UnitCost
+			continue
+		}
+		quantityAfter[valObj.Sku] += valObj.Quantity
+		if valObj.Reason == model.MovementReasonInitialStock {
+			addedAfter[valObj.Sku] = true
+		}
+	}
+
+	//compose stock value
+	stockValue := &StockValue{
+		Date: asOf,
+	}
+	var kind int                //total kind of sku available
+	var totalAmount model.Money //total amount of sku value, accumulate buy price * quantity for every sku
+	var totalQuantity int64     //total quantity of all sku, accumulate quantity for every sku
+	stockValueItems := make(map[string]*StockValueItem, 0)
+	for _, val := range currentStock {
+		valObj, ok := val.(*model.Stock)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		if true == addedAfter[valObj.Sku] {
+			//sku didn't exist yet at the date
+			continue
+		}
+		buyPrice, found := lastCost[valObj.Sku]
+		if false == found {
+			//no movement recorded until the date, buy price hasn't changed since then
+			buyPrice = valObj.BuyPrice
+		}
+		quantity := valObj.Quantity - quantityAfter[valObj.Sku]
+		//reservations aren't kept historically, so the whole quantity at the date is listed as available
+		newStockValueItem := &StockValueItem{
+			Sku:         valObj.Sku,
+			Quantity:    quantity,
+			Available:   quantity,
+			BuyPrice:    buyPrice,
+			TotalAmount: buyPrice.Multiply(quantity),
+		}
+		stockValueItems[valObj.Sku] = newStockValueItem
+		totalAmount += newStockValueItem.TotalAmount
+		totalQuantity += newStockValueItem.Quantity
+		kind++
+	}
+	stockValue.Items = stockValueItems
+	stockValue.TotalItemKind = kind
+	stockValue.TotalAmount = totalAmount
+	stockValue.TotalQuantity = totalQuantity
+	stockValue.TotalAvailable = totalQuantity
 
 	return stockValue, nil
 }
@@ -287,33 +492,33 @@ func (i *Inventory) GetAllSalesValue(startTime, endTime time.Time) (*SaleValue,
 		StartDate: startTime,
 		EndDate:   endTime,
 	}
-	//get sales data from db
-	salesDatamapper, ok := i.SalesDatamapper.(*datamapper.Sale)
-	if ok == false {
-		return nil, errors.Wrap(fmt.Errorf("Failed asserting to *datamapper.Sale"), 0)
+	//get sales data from db, the whole end date is included
+	salesData, err := i.doneSales(startTime, endTime)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	returnFinder, ok := i.SalesReturnDatamapper.(datamapper.SalesReturnFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting sales return mapper"), 0)
 	}
-	salesData, err := salesDatamapper.FindByDoneStatusAndDateRange(startTime, endTime)
+	returnsData, err := returnFinder.FindByDateRange(startTime, endTime.AddDate(0, 0, 1))
 	if err != nil {
-		if err.Err == datamapper.ErrNotFound {
-			//no data
-			return nil, errors.Wrap(fmt.Errorf("No data available"), 0)
-		}
 		return nil, errors.Wrap(err, 0)
 	}
+	if len(salesData) == 0 && len(returnsData) == 0 {
+		//no data
+		return nil, errors.Wrap(fmt.Errorf("No data available"), 0)
+	}
 
-	var totalProfit float64   //total profit = sellprice - buyprice, accumulate for every sale item
-	var salesTurnover float64 //salesTurnover (omset), sellprice * quantity, accumulate for every sale item
-	var totalQuantity int64   //total quantity of all items, accumulate quantity for every sale item
-	var totalKind int         //total kind of sku sold during the given period
-	var saleCount int         //total count of sales during the given period
+	var totalProfit model.Money   //total profit = sellprice - buyprice, accumulate for every sale item
+	var salesTurnover model.Money //salesTurnover (omset), sellprice * quantity, accumulate for every sale item
+	var totalQuantity int64       //total quantity of all items, accumulate quantity for every sale item
+	var totalKind int             //total kind of sku sold during the given period
+	var saleCount int             //total count of sales during the given period
 
 	var tempSku = make(map[string]bool) //temporary storage for counting total kind of sku
 	saleValueItems := make([]*SaleValueItem, 0)
-	for _, val := range salesData {
-		valObj, ok := val.(*model.Sales)
-		if false == ok {
-			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
-		}
+	for _, valObj := range salesData {
 		saleCount++
 
 		//get the sales items
@@ -322,16 +527,47 @@ func (i *Inventory) GetAllSalesValue(startTime, endTime time.Time) (*SaleValue,
 				totalKind++
 				tempSku[itemVal.Sku] = true
 			}
+			//profit is taken from the exact cost of the sold items, the buy price is the (rounded) unit cost
+			itemProfit := itemVal.SellPrice.Multiply(itemVal.Quantity) - itemVal.GetCost()
 			totalQuantity += itemVal.Quantity
-			totalProfit += (itemVal.SellPrice - itemVal.BuyPrice) * float64(itemVal.Quantity)
-			salesTurnover += itemVal.SellPrice * float64(itemVal.Quantity)
+			totalProfit += itemProfit
+			salesTurnover += itemVal.SellPrice.Multiply(itemVal.Quantity)
 
 			saleValueItem := &SaleValueItem{
 				Sku:       itemVal.Sku,
 				BuyPrice:  itemVal.BuyPrice,
 				SellPrice: itemVal.SellPrice,
 				Quantity:  itemVal.Quantity,
-				Profit:    (itemVal.SellPrice - itemVal.BuyPrice) * float64(itemVal.Quantity),
+				Profit:    itemProfit,
+				reference: valObj.InvoiceID,
+			}
+			saleValueItems = append(saleValueItems, saleValueItem)
+		}
+	}
+
+	//returns are netted out of the period they happened in (regardless of the sale date)
+	var totalRefund model.Money //total refund, sellprice * returned quantity, accumulate for every returned item
+	var returnCount int         //total count of returns during the given period
+	for _, val := range returnsData {
+		valObj, ok := val.(*model.SalesReturn)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		returnCount++
+
+		for _, itemVal := range valObj.Items {
+			totalQuantity -= itemVal.Quantity
+			totalProfit -= (itemVal.SellPrice - itemVal.BuyPrice).Multiply(itemVal.Quantity)
+			salesTurnover -= itemVal.SellPrice.Multiply(itemVal.Quantity)
+			totalRefund += itemVal.SellPrice.Multiply(itemVal.Quantity)
+
+			saleValueItem := &SaleValueItem{
+				Sku:       itemVal.Sku,
+				BuyPrice:  itemVal.BuyPrice,
+				SellPrice: itemVal.SellPrice,
+				Quantity:  -itemVal.Quantity,
+				Profit:    (itemVal.SellPrice - itemVal.BuyPrice).Multiply(-itemVal.Quantity),
+				reference: valObj.ReturnID,
 			}
 			saleValueItems = append(saleValueItems, saleValueItem)
 		}
@@ -342,6 +578,8 @@ func (i *Inventory) GetAllSalesValue(startTime, endTime time.Time) (*SaleValue,
 	salesValue.Profit = totalProfit
 	salesValue.SalesTurnOver = salesTurnover
 	salesValue.SaleCount = saleCount
+	salesValue.ReturnCount = returnCount
+	salesValue.Refund = totalRefund
 	return salesValue, nil
 }
 
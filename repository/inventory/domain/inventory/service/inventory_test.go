@@ -2,22 +2,14 @@
 package service_test
 
 import (
-	"database/sql"
 	"ijah-inventory/repository/inventory/domain/inventory/model"
 	"ijah-inventory/repository/inventory/domain/inventory/service"
 
-	"os"
 	"reflect"
 	"testing"
 	"time"
-
-	sqlMock "github.com/DATA-DOG/go-sqlmock"
 )
 
-var inventoryService, failedInventoryService, successfulCreateSaleInventoryService *service.Inventory
-var dummyDb *sql.DB
-var dbMock sqlMock.Sqlmock
-
 //getType is a function to get type of something (without package name)
 //see: https://stackoverflow.com/questions/35790935/using-reflection-in-go-to-get-the-name-of-a-struct
 func getType(myvar interface{}) string {
@@ -28,44 +20,11 @@ func getType(myvar interface{}) string {
 	}
 }
 
-func TestMain(m *testing.M) {
-	//test setup
-
-	//mock sql
-	dummyDb, dbMock, _ = sqlMock.New()
-
-	//successful case inventory service object
-	inventoryService = &service.Inventory{
-		StockDatamapper:    &MockStockMapper{},
-		PurchaseDatamapper: &MockPurchaseMapper{},
-		SalesDatamapper:    &MockSalesMapper{},
-		DB:                 dummyDb, //Note: do not use a *sql.DB that connects to production database
-	}
-
-	//failed case inventory service object
-	failedInventoryService = &service.Inventory{
-		StockDatamapper:    &MockFailedStockMapper{},
-		PurchaseDatamapper: &MockFailedPurchaseMapper{},
-		SalesDatamapper:    &MockFailedSalesMapper{},
-		DB:                 dummyDb, //Note: do not use a *sql.DB that connects to production database
-	}
-
-	//special case for createSale (combination of successful and failed datamapper)
-	successfulCreateSaleInventoryService = &service.Inventory{
-		StockDatamapper:    &MockStockMapper{},
-		PurchaseDatamapper: &MockFailedPurchaseMapper{},
-		SalesDatamapper:    &MockCreateSalesMapper{},
-		DB:                 dummyDb, //Note: do not use a *sql.DB that connects to production database
-	}
-
-	//run tests
-	exitCode := m.Run()
-	os.Exit(exitCode)
-}
-
 func TestGetItemInfo(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
 	//successful case
-	itemInfo, err := inventoryService.GetItemInfo("dummySku")
+	itemInfo, err := inventoryObj.GetItemInfo("dummySku")
 
 	t.Run("GetItemInfo return must be stock model object", func(t *testing.T) {
 		if getType(itemInfo) != "*Stock" {
@@ -80,13 +39,13 @@ func TestGetItemInfo(t *testing.T) {
 	})
 
 	t.Run("check returned item", func(t *testing.T) {
-		if itemInfo.Sku != dummyStockModel1.Sku {
-			t.Errorf("expected %v but got %v", dummyStockModel1.Sku, itemInfo.Sku)
+		if itemInfo.Sku != "dummySku" || itemInfo.Quantity != 10 || itemInfo.BuyPrice != 50000 {
+			t.Errorf("expected 10 dummySku items at 50000 but got %+v", itemInfo)
 		}
 	})
 
 	//failed case
-	failedItemInfo, failedErr := failedInventoryService.GetItemInfo("dummySku")
+	failedItemInfo, failedErr := inventoryObj.GetItemInfo("missingSku")
 
 	t.Run("Failed GetItemInfo return must be nil", func(t *testing.T) {
 		if failedItemInfo != nil {
@@ -103,38 +62,60 @@ func TestGetItemInfo(t *testing.T) {
 }
 
 func TestAddSKU(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
 	//successful case
-	err := inventoryService.AddSKU("dummyNewSku", 250, 55000, 60000)
+	err := inventoryObj.AddSKU("dummyNewSku", 250, 55000, 60000)
+	itemInfo, _ := inventoryObj.GetItemInfo("dummyNewSku")
+	movements, _ := inventoryObj.GetStockMovements("dummyNewSku", time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 1))
 	t.Run("err return must be nil", func(t *testing.T) {
 		if err != nil {
 			t.Errorf("expected nil but got %v", err)
 		}
 	})
+	t.Run("new sku must be stored with its initial stock movement", func(t *testing.T) {
+		if itemInfo == nil || itemInfo.Quantity != 250 || itemInfo.SellPrice != 60000 {
+			t.Errorf("expected 250 items sold at 60000 but got %+v", itemInfo)
+		}
+		if len(movements) != 1 || movements[0].Reason != model.MovementReasonInitialStock || movements[0].Quantity != 250 {
+			t.Errorf("expected a single initial stock movement of 250 items but got %+v", movements)
+		}
+	})
 
-	//failed case
-	failedErr := failedInventoryService.AddSKU("dummyNewSku", 250, 55000, 60000)
+	//failed case (existing sku)
+	failedErr := inventoryObj.AddSKU("dummySku", 250, 55000, 60000)
+	itemInfo, _ = inventoryObj.GetItemInfo("dummySku")
 	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
 		if getType(failedErr) != "*Error" {
 			t.Errorf("expected *Error but got %v", getType(failedErr))
 		}
 	})
+	t.Run("Failed add must not change the existing sku", func(t *testing.T) {
+		if itemInfo.Quantity != 10 {
+			t.Errorf("expected 10 but got %v", itemInfo.Quantity)
+		}
+	})
 }
 
 func TestUpdateSKU(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
 	//successful case
-	dbMock.ExpectBegin()
-	dbMock.ExpectCommit()
-	err := inventoryService.UpdateSKU("dummySku", 250, 50000, 55000)
+	err := inventoryObj.UpdateSKU("dummySku", 250, 50000, 55000)
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
 	t.Run("err return must be nil", func(t *testing.T) {
 		if err != nil {
 			t.Errorf("expected nil but got %v", err)
 		}
 	})
+	t.Run("sku must be updated", func(t *testing.T) {
+		if itemInfo.Quantity != 250 || itemInfo.SellPrice != 55000 {
+			t.Errorf("expected 250 items sold at 55000 but got %+v", itemInfo)
+		}
+	})
 
 	//failed case
-	dbMock.ExpectBegin()
-	dbMock.ExpectRollback()
-	failedErr := failedInventoryService.UpdateSKU("dummySku", 250, 50000, 55000)
+	failedErr := inventoryObj.UpdateSKU("missingSku", 250, 50000, 55000)
 	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
 		if getType(failedErr) != "*Error" {
 			t.Errorf("expected *Error but got %v", getType(failedErr))
@@ -143,17 +124,17 @@ func TestUpdateSKU(t *testing.T) {
 }
 
 func TestCreateSale(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
 	//successful case
 	saleItem := service.SaleItem{
 		Sku:      "dummySku",
-		Quantity: 10,
+		Quantity: 4,
 	}
 	saleItemSlice := make([]service.SaleItem, 0)
 	saleItemSlice = append(saleItemSlice, saleItem)
 
-	dbMock.ExpectBegin()
-	dbMock.ExpectCommit()
-	ok, errt := successfulCreateSaleInventoryService.CreateSale("newInvoiceId", "dummy new invoice", saleItemSlice)
+	ok, errt := inventoryObj.CreateSale("newInvoiceId", "dummy new invoice", saleItemSlice)
 	t.Run("return must be true", func(t *testing.T) {
 		if true != ok {
 			t.Errorf("expected true but got %v", ok)
@@ -165,27 +146,32 @@ func TestCreateSale(t *testing.T) {
 		}
 	})
 
-	//failed case
-	dbMock.ExpectBegin()
-	dbMock.ExpectRollback()
-	failedOk, failedErr := failedInventoryService.CreateSale("newInvoiceId", "dummy new invoice", saleItemSlice)
-	t.Run("Failed return must be true", func(t *testing.T) {
-		if false != failedOk {
-			t.Errorf("expected false but got %v", failedOk)
-		}
-	})
-	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
-		if getType(failedErr) != "*Error" {
-			t.Errorf("expected *Error but got %v", getType(failedErr))
-		}
-	})
+	//failed cases (existing invoice, missing sku)
+	failedCases := map[string][]service.SaleItem{
+		"newInvoiceId":  saleItemSlice,
+		"newInvoiceId2": {{Sku: "missingSku", Quantity: 1}},
+	}
+	for invoiceID, items := range failedCases {
+		failedOk, failedErr := inventoryObj.CreateSale(invoiceID, "dummy new invoice", items)
+		t.Run("Failed return of "+invoiceID+" must be false", func(t *testing.T) {
+			if false != failedOk {
+				t.Errorf("expected false but got %v", failedOk)
+			}
+		})
+		t.Run("Failed err returned of "+invoiceID+" must type must be correct", func(t *testing.T) {
+			if getType(failedErr) != "*Error" {
+				t.Errorf("expected *Error but got %v", getType(failedErr))
+			}
+		})
+	}
 }
 
 func TestUpdateSale(t *testing.T) {
-	//successful case
-	dbMock.ExpectBegin()
-	dbMock.ExpectCommit()
-	ok, err := inventoryService.UpdateSale("dummyInvoice", model.SalesStatusDone)
+	inventoryObj := newMemoryInventory(t)
+	createDoneSale(t, inventoryObj, "dummyInvoice", []service.SaleItem{{Sku: "dummySku", Quantity: 3}})
+
+	//successful case (a done sale can only be canceled)
+	ok, err := inventoryObj.UpdateSale("dummyInvoice", model.SalesStatusCanceled, "dummyUser")
 	t.Run("return must be true", func(t *testing.T) {
 		if true != ok {
 			t.Errorf("expected true but got %v", ok)
@@ -198,9 +184,7 @@ func TestUpdateSale(t *testing.T) {
 	})
 
 	//failed case
-	dbMock.ExpectBegin()
-	dbMock.ExpectRollback()
-	failedOk, failedErr := failedInventoryService.UpdateSale("dummyInvoice", model.SalesStatusDone)
+	failedOk, failedErr := inventoryObj.UpdateSale("missingInvoice", model.SalesStatusDone, "dummyUser")
 	t.Run("Failed return must be true", func(t *testing.T) {
 		if false != failedOk {
 			t.Errorf("expected false but got %v", failedOk)
@@ -214,8 +198,14 @@ func TestUpdateSale(t *testing.T) {
 }
 
 func TestGetAllStockValue(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	err := inventoryObj.AddSKU("dummySku2", 120, 60000, 63000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
 	//successful case
-	stockValue, err := inventoryService.GetAllStockValue()
+	stockValue, err := inventoryObj.GetAllStockValue()
 	t.Run("GetAllStockValue return must be stock value object", func(t *testing.T) {
 		if getType(stockValue) != "*StockValue" {
 			t.Errorf("expected *StockValue but got %v", getType(stockValue))
@@ -229,26 +219,26 @@ func TestGetAllStockValue(t *testing.T) {
 	})
 
 	t.Run("check stock value properties", func(t *testing.T) {
-		if stockValue.TotalAmount != 19700000 {
-			t.Errorf("expected totalAmount %v but got %v", 19700000, stockValue.TotalAmount)
+		if stockValue.TotalAmount != 7700000 {
+			t.Errorf("expected totalAmount %v but got %v", 7700000, stockValue.TotalAmount)
 		}
 		if stockValue.TotalItemKind != 2 {
 			t.Errorf("expected totalItemKind %v but got %v", 2, stockValue.TotalItemKind)
 		}
-		if stockValue.TotalQuantity != 370 {
-			t.Errorf("expected totalQuantity %v but got %v", 370, stockValue.TotalQuantity)
+		if stockValue.TotalQuantity != 130 {
+			t.Errorf("expected totalQuantity %v but got %v", 130, stockValue.TotalQuantity)
 		}
 
 		for key, val := range stockValue.Items {
 			if key == "dummySku" {
-				if val.TotalAmount != 12500000 {
-					t.Errorf("sku %v expected totalAmount %v but got %v", key, 12500000, val.TotalAmount)
+				if val.TotalAmount != 500000 {
+					t.Errorf("sku %v expected totalAmount %v but got %v", key, 500000, val.TotalAmount)
 				}
 				if val.BuyPrice != 50000 {
 					t.Errorf("sku %v expected buyPrice %v but got %v", key, 50000, val.BuyPrice)
 				}
-				if val.Quantity != 250 {
-					t.Errorf("sku %v expected quantity %v but got %v", key, 250, val.Quantity)
+				if val.Quantity != 10 {
+					t.Errorf("sku %v expected quantity %v but got %v", key, 10, val.Quantity)
 				}
 				if val.Sku != "dummySku" {
 					t.Errorf("sku %v expected quantity %v but got %v", key, "dummySku", val.Sku)
@@ -270,62 +260,66 @@ func TestGetAllStockValue(t *testing.T) {
 		}
 	})
 
-	//failed case
-	failedStockValue, failedErr := failedInventoryService.GetAllStockValue()
-	t.Run("Failed GetAllStockValue return must be nil", func(t *testing.T) {
-		if failedStockValue != nil {
-			t.Errorf("expected nil but got %v", failedStockValue)
+	//no sku at all
+	emptyStockValue, emptyErr := newEmptyMemoryInventory().GetAllStockValue()
+	t.Run("Empty GetAllStockValue return must be empty", func(t *testing.T) {
+		if emptyErr != nil {
+			t.Fatalf("expected nil but got %v", emptyErr)
 		}
-	})
-	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
-		if getType(failedErr) != "*Error" {
-			t.Errorf("expected *Error but got %v", getType(failedErr))
+		if emptyStockValue.TotalItemKind != 0 || len(emptyStockValue.Items) != 0 {
+			t.Errorf("expected no items but got %+v", emptyStockValue)
 		}
 	})
 }
 
 func TestGetAllSalesValue(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	createDoneSale(t, inventoryObj, "dummyInvoice", []service.SaleItem{{Sku: "dummySku", Quantity: 3}})
+	createDoneSale(t, inventoryObj, "dummyInvoice2", []service.SaleItem{{Sku: "dummySku", Quantity: 3}})
+
 	//successful case
-	saleValue, errs := inventoryService.GetAllSalesValue(time.Now(), time.Now()) //on dummy sales mapper these params are ignored
+	saleValue, errs := inventoryObj.GetAllSalesValue(time.Now().AddDate(0, 0, -1), time.Now())
 	t.Run("GetAllSalesValue return must be sale value object", func(t *testing.T) {
 		if getType(saleValue) != "*SaleValue" {
 			t.Errorf("expected *SaleValue but got %v", getType(saleValue))
 		}
 	})
-	//TODO: this will fail since type assertion from datamapper.DataMapper to *datamapper.Sale in the inventory service fails, since we initiate the sales datamapper with a mock (type MockSSaleMapper)
 	t.Run("err returned must be nil", func(t *testing.T) {
 		if errs != nil {
-			t.Errorf("expected nil but got %v", errs)
+			t.Fatalf("expected nil but got %v", errs)
 		}
 	})
 
 	t.Run("check sale value properties", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
 		if saleValue.TotalQuantity != 6 {
-			t.Errorf("expected totalQuantity %v but got %v", 34, saleValue.TotalQuantity)
+			t.Errorf("expected totalQuantity %v but got %v", 6, saleValue.TotalQuantity)
 		}
 		if saleValue.SaleCount != 2 {
 			t.Errorf("expected saleCount %v but got %v", 2, saleValue.SaleCount)
 		}
 		if saleValue.TotalItemKind != 1 {
-			t.Errorf("expected totalItemKind %v but got %v", 2, saleValue.TotalItemKind)
+			t.Errorf("expected totalItemKind %v but got %v", 1, saleValue.TotalItemKind)
 		}
-		if saleValue.Profit != 30000 {
-			t.Errorf("expected profit %v but got %v", 30000, saleValue.Profit)
+		if saleValue.Profit != 60000 {
+			t.Errorf("expected profit %v but got %v", 60000, saleValue.Profit)
 		}
-		if saleValue.SalesTurnOver != 330000 {
-			t.Errorf("expected salesTurnOver %v but got %v", 330000, saleValue.SalesTurnOver)
+		if saleValue.SalesTurnOver != 360000 {
+			t.Errorf("expected salesTurnOver %v but got %v", 360000, saleValue.SalesTurnOver)
 		}
 		for _, val := range saleValue.Items {
 			if val.Sku == "dummySku" {
-				if val.Profit != 15000 {
-					t.Errorf("sku dummySku expected profit %v but got %v", 15000, val.Profit)
+				if val.Profit != 30000 {
+					t.Errorf("sku dummySku expected profit %v but got %v", 30000, val.Profit)
 				}
 			}
 		}
 	})
 
-	//failed case
-	failedSaleValue, failedErr := failedInventoryService.GetAllSalesValue(time.Now(), time.Now()) //on dummy sales mapper these params are ignored
+	//failed case (start date after end date)
+	failedSaleValue, failedErr := inventoryObj.GetAllSalesValue(time.Now(), time.Now().AddDate(0, 0, -5))
 	t.Run("Failed GetAllSalesValue return must be nil", func(t *testing.T) {
 		if failedSaleValue != nil {
 			t.Errorf("expected nil but got %v", getType(failedSaleValue))
@@ -336,4 +330,108 @@ func TestGetAllSalesValue(t *testing.T) {
 			t.Errorf("expected *Error but got %v", getType(failedErr))
 		}
 	})
+	//failed case (no sale within the dates)
+	emptySaleValue, emptyErr := inventoryObj.GetAllSalesValue(time.Now().AddDate(0, 0, -10), time.Now().AddDate(0, 0, -5))
+	t.Run("Empty GetAllSalesValue return must be nil", func(t *testing.T) {
+		if emptySaleValue != nil {
+			t.Errorf("expected nil but got %v", getType(emptySaleValue))
+		}
+		if getType(emptyErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(emptyErr))
+		}
+	})
+}
+
+func TestGetAllSalesValueEndDate(t *testing.T) {
+	//a sale of 3 dummySku items and a return of 1 of them, both during the end date
+	inventoryObj := newMemoryInventory(t)
+	endDate := time.Date(2017, 12, 20, 0, 0, 0, 0, time.UTC)
+	err := inventoryObj.SalesDatamapper.Insert(&model.Sales{
+		InvoiceID: "dummyInvoice",
+		Date:      endDate.Add(10 * time.Hour),
+		Status:    model.SalesStatusDone,
+		Items: map[string]*model.SaleItem{
+			"dummySku": {Sku: "dummySku", Quantity: 3, BuyPrice: 50000, SellPrice: 60000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.SalesReturnDatamapper.Insert(&model.SalesReturn{
+		ReturnID:  "dummyReturn",
+		InvoiceID: "dummyInvoice",
+		Date:      endDate.Add(15 * time.Hour),
+		Items: map[string]*model.SalesReturnItem{
+			"dummySku": {Sku: "dummySku", Quantity: 1, BuyPrice: 50000, SellPrice: 60000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	saleValue, errs := inventoryObj.GetAllSalesValue(endDate.AddDate(0, 0, -2), endDate)
+	t.Run("err returned must be nil", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
+	})
+	t.Run("sale and return of the end date must be included", func(t *testing.T) {
+		if saleValue == nil {
+			t.Fatalf("expected *SaleValue but got nil")
+		}
+		if saleValue.SaleCount != 1 || saleValue.ReturnCount != 1 {
+			t.Errorf("expected 1 sale and 1 return but got %v and %v", saleValue.SaleCount, saleValue.ReturnCount)
+		}
+		if saleValue.TotalQuantity != 2 {
+			t.Errorf("expected totalQuantity %v but got %v", 2, saleValue.TotalQuantity)
+		}
+		if saleValue.SalesTurnOver != 120000 || saleValue.Refund != 60000 {
+			t.Errorf("expected salesTurnOver 120000 and refund 60000 but got %v and %v", saleValue.SalesTurnOver, saleValue.Refund)
+		}
+	})
+}
+
+func TestGetAllSalesValueReturnsOnly(t *testing.T) {
+	//a return during the period of a sale made before it
+	inventoryObj := newMemoryInventory(t)
+	err := inventoryObj.SalesDatamapper.Insert(&model.Sales{
+		InvoiceID: "dummyInvoice",
+		Date:      time.Date(2017, 12, 28, 10, 0, 0, 0, time.UTC),
+		Status:    model.SalesStatusDone,
+		Items: map[string]*model.SaleItem{
+			"dummySku": {Sku: "dummySku", Quantity: 3, BuyPrice: 50000, SellPrice: 60000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.SalesReturnDatamapper.Insert(&model.SalesReturn{
+		ReturnID:  "dummyReturn",
+		InvoiceID: "dummyInvoice",
+		Date:      time.Date(2018, 1, 3, 10, 0, 0, 0, time.UTC),
+		Items: map[string]*model.SalesReturnItem{
+			"dummySku": {Sku: "dummySku", Quantity: 2, BuyPrice: 50000, SellPrice: 60000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	saleValue, errs := inventoryObj.GetAllSalesValue(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC))
+	t.Run("err returned must be nil", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
+	})
+	t.Run("return must be netted out of the period without sales", func(t *testing.T) {
+		if saleValue == nil {
+			t.Fatalf("expected *SaleValue but got nil")
+		}
+		if saleValue.SaleCount != 0 || saleValue.ReturnCount != 1 {
+			t.Errorf("expected no sale and 1 return but got %v and %v", saleValue.SaleCount, saleValue.ReturnCount)
+		}
+		if saleValue.TotalQuantity != -2 || saleValue.SalesTurnOver != -120000 || saleValue.Profit != -20000 {
+			t.Errorf("expected -2 items, salesTurnOver -120000 and profit -20000 but got %v, %v and %v", saleValue.TotalQuantity, saleValue.SalesTurnOver, saleValue.Profit)
+		}
+	})
 }
@@ -0,0 +1,105 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//StockPage is a page of stock items found by ListStock
+type StockPage struct {
+	Items      []*model.Stock
+	Limit      int
+	NextCursor string //cursor of the next page, empty on the last page
+}
+
+//SalesPage is a page of sales found by ListSales
+type SalesPage struct {
+	Items      []*model.Sales
+	Limit      int
+	NextCursor string //cursor of the next page, empty on the last page
+}
+
+//StockSearchHit is a stock item found by SearchStock along with its rank (higher ranks match better)
+type StockSearchHit struct {
+	Stock *model.Stock
+	Rank  float64
+}
+
+//ListStock is a function for listing a page of the stock items matching a criteria
+//fields: sku, name, quantity, reserved, available, buyPrice, sellPrice, productId, size and color (the items are sorted by sku unless sorted otherwise)
+func (i *Inventory) ListStock(criteria datamapper.Criteria) (*StockPage, *errors.Error) {
+	stockFinder, ok := i.StockDatamapper.(datamapper.CriteriaFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting stock mapper"), 0)
+	}
+	page, err := stockFinder.FindByCriteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+	stockPage := &StockPage{
+		Items:      make([]*model.Stock, 0, len(page.Items)),
+		Limit:      page.Limit,
+		NextCursor: page.NextCursor,
+	}
+	for _, val := range page.Items {
+		valObj, ok := val.(*model.Stock)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		stockPage.Items = append(stockPage.Items, valObj)
+	}
+	return stockPage, nil
+}
+
+//ListSales is a function for listing a page of the sales (along with their items) matching a criteria
+//fields: invoiceId, date and status (the sales are sorted by invoice id unless sorted otherwise)
+func (i *Inventory) ListSales(criteria datamapper.Criteria) (*SalesPage, *errors.Error) {
+	saleFinder, ok := i.SalesDatamapper.(datamapper.CriteriaFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting sales mapper"), 0)
+	}
+	page, err := saleFinder.FindByCriteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+	salesPage := &SalesPage{
+		Items:      make([]*model.Sales, 0, len(page.Items)),
+		Limit:      page.Limit,
+		NextCursor: page.NextCursor,
+	}
+	for _, val := range page.Items {
+		valObj, ok := val.(*model.Sales)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		salesPage.Items = append(salesPage.Items, valObj)
+	}
+	return salesPage, nil
+}
+
+//SearchStock is a function for searching at most limit stock items whose sku or name hold words starting with every word of the query, best match first
+//(limit defaults to datamapper.DefaultSearchLimit and is at most datamapper.MaxSearchLimit)
+func (i *Inventory) SearchStock(query string, limit int) ([]StockSearchHit, *errors.Error) {
+	stockSearcher, ok := i.StockDatamapper.(datamapper.StockSearcher)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting stock mapper"), 0)
+	}
+	searchHits, err := stockSearcher.Search(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	stockHits := make([]StockSearchHit, 0, len(searchHits))
+	for _, val := range searchHits {
+		valObj, ok := val.Record.(*model.Stock)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		stockHits = append(stockHits, StockSearchHit{Stock: valObj, Rank: val.Rank})
+	}
+	return stockHits, nil
+}
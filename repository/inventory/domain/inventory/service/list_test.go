@@ -0,0 +1,108 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+)
+
+func TestListStock(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	for _, val := range []string{"blouse1", "blouse2", "shirt1"} {
+		err := inventoryObj.AddSKU(val, 5, 50000, 60000)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+
+	criteria := datamapper.Criteria{
+		Filters: []datamapper.Filter{{Field: "quantity", Operator: datamapper.OperatorLessOrEqual, Value: 5}},
+		Sorts:   []datamapper.Sort{{Field: "sku", Descending: true}},
+		Limit:   2,
+	}
+	stockPage, err := inventoryObj.ListStock(criteria)
+	t.Run("first page must be listed", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(stockPage.Items) != 2 || stockPage.Items[0].Sku != "shirt1" || stockPage.Items[1].Sku != "blouse2" || stockPage.NextCursor == "" || stockPage.Limit != 2 {
+			t.Errorf("expected shirt1 and blouse2 along with a cursor but got %+v", stockPage)
+		}
+	})
+
+	criteria.Cursor = stockPage.NextCursor
+	stockPage, err = inventoryObj.ListStock(criteria)
+	t.Run("last page must be listed", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(stockPage.Items) != 1 || stockPage.Items[0].Sku != "blouse1" || stockPage.NextCursor != "" {
+			t.Errorf("expected blouse1 without cursor but got %+v", stockPage)
+		}
+	})
+
+	_, err = inventoryObj.ListStock(datamapper.Criteria{Sorts: []datamapper.Sort{{Field: "weight"}}})
+	t.Run("unknown field must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+func TestListSales(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	for _, val := range []string{"dummyInvoice1", "dummyInvoice2"} {
+		_, err := inventoryObj.CreateSale(val, "", []service.SaleItem{{Sku: "dummySku", Quantity: 1}})
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	_, err := inventoryObj.UpdateSale("dummyInvoice2", "S", "dummyUser")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	salesPage, err := inventoryObj.ListSales(datamapper.Criteria{
+		Filters: []datamapper.Filter{{Field: "status", Operator: datamapper.OperatorEqual, Value: "S"}},
+	})
+	t.Run("sales matching the criteria must be listed", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(salesPage.Items) != 1 || salesPage.Items[0].InvoiceID != "dummyInvoice2" || len(salesPage.Items[0].Items) != 1 || salesPage.NextCursor != "" {
+			t.Errorf("expected dummyInvoice2 along with its item but got %+v", salesPage)
+		}
+		if salesPage.Limit != datamapper.DefaultCriteriaLimit {
+			t.Errorf("expected limit %v but got %v", datamapper.DefaultCriteriaLimit, salesPage.Limit)
+		}
+	})
+}
+
+func TestSearchStock(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	for _, val := range []string{"blouse1", "blouse2", "shirt1"} {
+		err := inventoryObj.AddSKU(val, 5, 50000, 60000)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+
+	stockHits, err := inventoryObj.SearchStock("shirt", 0)
+	t.Run("matching items must be found", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(stockHits) != 1 || stockHits[0].Stock.Sku != "shirt1" || stockHits[0].Rank <= 0 {
+			t.Errorf("expected shirt1 but got %+v", stockHits)
+		}
+	})
+
+	_, err = inventoryObj.SearchStock("", 0)
+	t.Run("empty query must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
@@ -0,0 +1,173 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+	"time"
+)
+
+//newEmptyMemoryInventory is a function for composing an inventory service running on a new empty memory store
+func newEmptyMemoryInventory() *service.Inventory {
+	store := datamapper.NewMemoryStore()
+	return &service.Inventory{
+		StockDatamapper:                datamapper.NewMemoryStock(store),
+		ProductDatamapper:              datamapper.NewMemoryProduct(store),
+		CategoryDatamapper:             datamapper.NewMemoryCategory(store),
+		AttributeDatamapper:            datamapper.NewMemoryAttribute(store),
+		ItemAttributesDatamapper:       datamapper.NewMemoryItemAttributes(store),
+		SupplierDatamapper:             datamapper.NewMemorySupplier(store),
+		PurchaseDatamapper:             datamapper.NewMemoryPurchase(store),
+		PurchaseReceiptDatamapper:      datamapper.NewMemoryPurchaseReceipt(store),
+		SalesDatamapper:                datamapper.NewMemorySale(store),
+		SalesReturnDatamapper:          datamapper.NewMemorySalesReturn(store),
+		StockMovementDatamapper:        datamapper.NewMemoryStockMovement(store),
+		CostLayerDatamapper:            datamapper.NewMemoryCostLayer(store),
+		StockCountDatamapper:           datamapper.NewMemoryStockCount(store),
+		StockAlertDatamapper:           datamapper.NewMemoryStockAlert(store),
+		SaleStatusTransitionDatamapper: datamapper.NewMemorySaleStatusTransition(store),
+		MemoryStore:                    store,
+	}
+}
+
+//newMemoryInventory is a function for composing an inventory service running on a new memory store (shared by the service tests)
+//the store holds dummySku (10 items bought at 50000 and sold at 60000)
+func newMemoryInventory(t *testing.T) *service.Inventory {
+	inventoryObj := newEmptyMemoryInventory()
+	err := inventoryObj.AddSKU("dummySku", 10, 50000, 60000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj
+}
+
+//createDoneSale is a function for creating a sale of the given items and completing it
+func createDoneSale(t *testing.T, inventoryObj *service.Inventory, invoiceID string, items []service.SaleItem) {
+	_, err := inventoryObj.CreateSale(invoiceID, "", items)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.UpdateSale(invoiceID, model.SalesStatusDone, "dummyUser")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestMemoryInventoryPurchaseAndSale(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
+	//purchase 5 items, delivered in a single receipt
+	_, err := inventoryObj.CreatePurchase("dummyPurchase", "", "", []service.PurchaseItem{{Sku: "dummySku", Quantity: 5, BuyPrice: 45000}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	ok, err := inventoryObj.ReceivePurchase("dummyPurchase", "dummyReceipt", "", []service.ReceiptItem{{Sku: "dummySku", Quantity: 5}}, false)
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	purchaseObj, _ := inventoryObj.GetPurchase("dummyPurchase")
+	receipts, _ := inventoryObj.GetPurchaseReceipts("dummyPurchase")
+	t.Run("received items must be added to stock", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Fatalf("expected true and nil but got %v and %v", ok, err)
+		}
+		if itemInfo.Quantity != 15 {
+			t.Errorf("expected 15 but got %v", itemInfo.Quantity)
+		}
+		if purchaseObj.Status != model.PurchaseStatusDone || len(receipts) != 1 {
+			t.Errorf("expected done purchase with 1 receipt but got %v and %v receipts", purchaseObj.Status, len(receipts))
+		}
+	})
+
+	//sell 12 items (draft first, then done)
+	_, err = inventoryObj.CreateSale("dummyInvoice", "", []service.SaleItem{{Sku: "dummySku", Quantity: 12}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	ok, err = inventoryObj.UpdateSale("dummyInvoice", model.SalesStatusDone, "dummyUser")
+	itemInfo, _ = inventoryObj.GetItemInfo("dummySku")
+	salesValue, errs := inventoryObj.GetAllSalesValue(time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 1))
+	history, _ := inventoryObj.GetSaleStatusHistory("dummyInvoice")
+	t.Run("done sale must be taken from stock and reported", func(t *testing.T) {
+		if true != ok || err != nil || errs != nil {
+			t.Fatalf("expected true and nil but got %v, %v and %v", ok, err, errs)
+		}
+		if itemInfo.Quantity != 3 || itemInfo.Reserved != 0 {
+			t.Errorf("expected quantity 3 and reserved 0 but got %v and %v", itemInfo.Quantity, itemInfo.Reserved)
+		}
+		if salesValue.SaleCount != 1 || salesValue.TotalQuantity != 12 || salesValue.SalesTurnOver != 720000 {
+			t.Errorf("expected 1 sale of 12 items worth 720000 but got %+v", salesValue)
+		}
+		if len(history) != 1 || history[0].ToStatus != model.SalesStatusDone {
+			t.Errorf("expected a single transition to done but got %v transitions", len(history))
+		}
+	})
+}
+
+func TestMemoryInventoryRollback(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
+	uow, err := inventoryObj.Begin()
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	stockModel, _ := uow.StockDatamapper.FindByID("dummySku")
+	stockModel.(*model.Stock).Quantity = 100
+	err = uow.StockDatamapper.Update(stockModel)
+	inTxModel, _ := uow.StockDatamapper.FindByID("dummySku")
+	t.Run("changes must be visible inside the unit of work", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if inTxModel.(*model.Stock).Quantity != 100 {
+			t.Errorf("expected 100 but got %v", inTxModel.(*model.Stock).Quantity)
+		}
+	})
+	outsideModel, _ := inventoryObj.StockDatamapper.FindByID("dummySku")
+	t.Run("uncommitted changes must not be visible outside the unit of work", func(t *testing.T) {
+		if outsideModel.(*model.Stock).Quantity != 10 {
+			t.Errorf("expected 10 but got %v", outsideModel.(*model.Stock).Quantity)
+		}
+	})
+
+	err = uow.Rollback()
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	t.Run("rolled back changes must be discarded", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if itemInfo.Quantity != 10 {
+			t.Errorf("expected 10 but got %v", itemInfo.Quantity)
+		}
+	})
+}
+
+//unboundMapper is a datamapper which can't join a unit of work transaction
+type unboundMapper struct {
+	datamapper.DataMapper
+}
+
+func TestMemoryInventoryUnboundMapper(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	stockMapper := inventoryObj.StockDatamapper
+	inventoryObj.StockDatamapper = unboundMapper{stockMapper}
+
+	_, err := inventoryObj.Begin()
+	t.Run("unit of work must not begin when a datamapper can't join the transaction", func(t *testing.T) {
+		if err == nil {
+			t.Fatalf("expected error but got nil")
+		}
+	})
+
+	err = inventoryObj.AddSKU("dummySku2", 5, 50000, 60000)
+	stocks, _ := stockMapper.FindAll()
+	t.Run("changes must not be made outside the transaction", func(t *testing.T) {
+		if err == nil {
+			t.Fatalf("expected error but got nil")
+		}
+		if len(stocks) != 1 {
+			t.Errorf("expected 1 but got %v", len(stocks))
+		}
+	})
+}
@@ -0,0 +1,310 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//PurchaseItem is a definition of items in a purchase
+type PurchaseItem struct {
+	Sku      string      `json:"sku"`
+	Quantity int64       `json:"quantity"`
+	BuyPrice model.Money `json:"buyPrice"`
+	Note     string      `json:"note"`
+}
+
+//ReceiptItem is a definition of items received in a supplier delivery
+type ReceiptItem struct {
+	Sku      string `json:"sku"`
+	Quantity int64  `json:"quantity"`
+}
+
+//CreatePurchase is a function for creating a new (draft) purchase, supplierID is empty when the supplier is unknown
+//the buy prices become the last prices of the items supplied by the supplier (see SupplierItem)
+func (i *Inventory) CreatePurchase(purchaseID, supplierID, note string, items []PurchaseItem) (bool, *errors.Error) {
+	if len(items) == 0 {
+		return false, errors.Wrap(fmt.Errorf("Cannot create purchase. Purchase has no items"), 0)
+	}
+	err := i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		return i.insertPurchase(uow, purchaseID, supplierID, note, items)
+	})
+	if err != nil {
+		return false, errors.Wrap(err, 0)
+	}
+	return true, nil
+}
+
+//insertPurchase is a function for inserting a new (draft) purchase of the given items (inside the passed unit of work)
+func (i *Inventory) insertPurchase(uow *UnitOfWork, purchaseID, supplierID, note string, items []PurchaseItem) *errors.Error {
+	//compose purchase domain model
+	newPurchase := &model.Purchase{
+		PurchaseID: purchaseID,
+		SupplierID: supplierID,
+		Date:       time.Now(),
+		Note:       note,
+		Status:     model.PurchaseStatusDraft,
+	}
+	existingPurchase, _ := uow.PurchaseDatamapper.FindByID(purchaseID)
+	if existingPurchase != nil {
+		return errors.Wrap(fmt.Errorf("Purchase %v already exists", purchaseID), 0)
+	}
+	newPurchaseItems := make(map[string]*model.PurchaseItem, 0)
+	for _, val := range items {
+		//check whether the sku is a valid item
+		_, err := uow.StockDatamapper.FindByID(val.Sku)
+		if err != nil {
+			if err.Err == datamapper.ErrNotFound {
+				//invalid sku, cannot continue
+				return errors.Wrap(fmt.Errorf("Cannot create purchase. Sku %v is not valid item", val.Sku), 0)
+			}
+			return errors.Wrap(err, 0)
+		}
+		if val.Quantity <= 0 {
+			return errors.Wrap(fmt.Errorf("Cannot create purchase. Invalid quantity for Sku %v", val.Sku), 0)
+		}
+		//compose purchase item
+		newItem := &model.PurchaseItem{
+			Sku:      val.Sku,
+			Quantity: val.Quantity,
+			BuyPrice: val.BuyPrice,
+			Note:     val.Note,
+		}
+		newPurchaseItems[val.Sku] = newItem
+	}
+	newPurchase.Items = newPurchaseItems
+	if supplierID != "" {
+		//the supplier must exist, its last prices are recorded once the purchase is received
+		_, err := findSupplier(uow.SupplierDatamapper, supplierID)
+		if err != nil {
+			return err
+		}
+	}
+	return uow.PurchaseDatamapper.Insert(newPurchase)
+}
+
+//GetPurchase is a function for obtaining a purchase (along with its items)
+func (i *Inventory) GetPurchase(purchaseID string) (*model.Purchase, *errors.Error) {
+	return findPurchase(i.PurchaseDatamapper, purchaseID)
+}
+
+//findPurchase is a function for obtaining a purchase using the given purchase mapper (e.g. one bound to a unit of work)
+func findPurchase(purchaseMapper datamapper.DataMapper, purchaseID string) (*model.Purchase, *errors.Error) {
+	foundPurchase, err := purchaseMapper.FindByID(purchaseID)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return nil, errors.Wrap(fmt.Errorf("Purchase %v is not found", purchaseID), 0)
+		}
+		return nil, errors.Wrap(err, 0)
+	}
+	foundPurchaseObj, ok := foundPurchase.(*model.Purchase)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	return foundPurchaseObj, nil
+}
+
+//ListPurchases is a function for obtaining all purchases
+func (i *Inventory) ListPurchases() ([]*model.Purchase, *errors.Error) {
+	purchases, err := i.PurchaseDatamapper.FindAll()
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			//no purchase at all
+			return make([]*model.Purchase, 0), nil
+		}
+		return nil, errors.Wrap(err, 0)
+	}
+	purchaseList := make([]*model.Purchase, 0, len(purchases))
+	for _, val := range purchases {
+		valObj, ok := val.(*model.Purchase)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		purchaseList = append(purchaseList, valObj)
+	}
+	return purchaseList, nil
+}
+
+//UpdatePurchaseStatus is a function for updating purchase status
+//Updating a purchase to done adds the purchased quantities to stock (in the same transaction as the status update)
+func (i *Inventory) UpdatePurchaseStatus(purchaseID, status string) (bool, *errors.Error) {
+	//validation, check whether given status is valid
+	if status != model.PurchaseStatusDraft &&
+		status != model.PurchaseStatusDone &&
+		status != model.PurchaseStatusCanceled {
+		return false, errors.Wrap(fmt.Errorf("Invalid status %v from param", status), 0)
+	}
+	err := i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		foundPurchaseObj, err := findPurchase(uow.PurchaseDatamapper, purchaseID)
+		if err != nil {
+			return err
+		}
+		//done and canceled purchases are final
+		if foundPurchaseObj.Status != model.PurchaseStatusDraft && foundPurchaseObj.Status != status {
+			return errors.Wrap(fmt.Errorf("Purchase %v status can no longer be updated", purchaseID), 0)
+		}
+
+		//purchase status updated to Done from Draft status, receive whatever hasn't been received by previous deliveries
+		if status == model.PurchaseStatusDone && foundPurchaseObj.Status != model.PurchaseStatusDone {
+			receivedSkus := make([]string, 0, len(foundPurchaseObj.Items))
+			for _, val := range foundPurchaseObj.Items {
+				remaining := val.GetRemainingQuantity()
+				if remaining == 0 {
+					continue
+				}
+				//update stock quantity
+				stockObj, err := uow.getStock(val.Sku)
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+				stockObj.Quantity += remaining
+				val.ReceivedQuantity += remaining
+				err = uow.StockDatamapper.Update(stockObj)
+				if err != nil {
+					return errors.Wrap(fmt.Errorf("Sku: %v stock update failed: %v", stockObj.Sku, err), 0)
+				}
+				err = i.recordMovement(uow, val.Sku, remaining, stockObj.BuyPrice, model.MovementReasonPurchase, purchaseID, "")
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+				err = i.addCostLayer(uow, val.Sku, remaining, val.BuyPrice, purchaseID)
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+				receivedSkus = append(receivedSkus, val.Sku)
+			}
+			err = recordSupplierPrices(uow, foundPurchaseObj, receivedSkus, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+		//update purchase
+		foundPurchaseObj.Status = status
+
+		return uow.PurchaseDatamapper.Update(foundPurchaseObj)
+	})
+	if err != nil {
+		return false, errors.Wrap(err, 0)
+	}
+	return true, nil
+}
+
+//ReceivePurchase is a function for recording a (possibly partial) supplier delivery against a draft purchase
+//Received quantities are added to stock. The purchase is updated to done once all of its items are fully received.
+//Receiving more than the remaining quantity of an item is rejected unless allowOverReceipt is set
+func (i *Inventory) ReceivePurchase(purchaseID, receiptID, note string, items []ReceiptItem, allowOverReceipt bool) (bool, *errors.Error) {
+	if receiptID == "" {
+		return false, errors.Wrap(fmt.Errorf("Receipt id must not be empty"), 0)
+	}
+	if len(items) == 0 {
+		return false, errors.Wrap(fmt.Errorf("Cannot receive purchase. Receipt has no items"), 0)
+	}
+	err := i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		existingReceipt, _ := uow.PurchaseReceiptDatamapper.FindByID(receiptID)
+		if existingReceipt != nil {
+			return errors.Wrap(fmt.Errorf("Receipt %v already exists", receiptID), 0)
+		}
+		foundPurchaseObj, err := findPurchase(uow.PurchaseDatamapper, purchaseID)
+		if err != nil {
+			return err
+		}
+		if foundPurchaseObj.Status != model.PurchaseStatusDraft {
+			return errors.Wrap(fmt.Errorf("Purchase %v can no longer be received", purchaseID), 0)
+		}
+
+		//compose the receipt, validating received quantities against the purchase items
+		newReceipt := &model.PurchaseReceipt{
+			ReceiptID:  receiptID,
+			PurchaseID: purchaseID,
+			Date:       time.Now(),
+			Note:       note,
+			Items:      make(map[string]*model.PurchaseReceiptItem, 0),
+		}
+		for _, val := range items {
+			purchaseItem, exists := foundPurchaseObj.Items[val.Sku]
+			if false == exists {
+				return errors.Wrap(fmt.Errorf("Sku %v is not part of purchase %v", val.Sku, purchaseID), 0)
+			}
+			if _, exists := newReceipt.Items[val.Sku]; exists {
+				return errors.Wrap(fmt.Errorf("Sku %v is received more than once in receipt %v", val.Sku, receiptID), 0)
+			}
+			if val.Quantity <= 0 {
+				return errors.Wrap(fmt.Errorf("Invalid received quantity for Sku %v", val.Sku), 0)
+			}
+			if val.Quantity > purchaseItem.GetRemainingQuantity() && false == allowOverReceipt {
+				return errors.Wrap(fmt.Errorf("Received quantity %v of Sku %v exceeds remaining quantity %v", val.Quantity, val.Sku, purchaseItem.GetRemainingQuantity()), 0)
+			}
+			newReceipt.Items[val.Sku] = &model.PurchaseReceiptItem{
+				Sku:      val.Sku,
+				Quantity: val.Quantity,
+			}
+		}
+
+		receivedSkus := make([]string, 0, len(newReceipt.Items))
+		for _, val := range newReceipt.Items {
+			//update stock quantity
+			stockObj, err := uow.getStock(val.Sku)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			stockObj.Quantity += val.Quantity
+			err = uow.StockDatamapper.Update(stockObj)
+			if err != nil {
+				return errors.Wrap(fmt.Errorf("Sku: %v stock update failed: %v", stockObj.Sku, err), 0)
+			}
+			err = i.recordMovement(uow, val.Sku, val.Quantity, stockObj.BuyPrice, model.MovementReasonPurchase, purchaseID, "receipt "+receiptID)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			err = i.addCostLayer(uow, val.Sku, val.Quantity, foundPurchaseObj.Items[val.Sku].BuyPrice, purchaseID)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			foundPurchaseObj.Items[val.Sku].ReceivedQuantity += val.Quantity
+			receivedSkus = append(receivedSkus, val.Sku)
+		}
+		err = recordSupplierPrices(uow, foundPurchaseObj, receivedSkus, newReceipt.Date)
+		if err != nil {
+			return err
+		}
+		err = uow.PurchaseReceiptDatamapper.Insert(newReceipt)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		//update purchase, a fully received purchase is done
+		if foundPurchaseObj.IsFullyReceived() {
+			foundPurchaseObj.Status = model.PurchaseStatusDone
+		}
+		return uow.PurchaseDatamapper.Update(foundPurchaseObj)
+	})
+	if err != nil {
+		return false, errors.Wrap(err, 0)
+	}
+	return true, nil
+}
+
+//GetPurchaseReceipts is a function for obtaining all receipts (deliveries) of a purchase
+func (i *Inventory) GetPurchaseReceipts(purchaseID string) ([]*model.PurchaseReceipt, *errors.Error) {
+	receiptFinder, ok := i.PurchaseReceiptDatamapper.(datamapper.PurchaseReceiptFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting purchase receipt mapper"), 0)
+	}
+	receipts, err := receiptFinder.FindByPurchaseID(purchaseID)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	receiptList := make([]*model.PurchaseReceipt, 0, len(receipts))
+	for _, val := range receipts {
+		valObj, ok := val.(*model.PurchaseReceipt)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		receiptList = append(receiptList, valObj)
+	}
+	return receiptList, nil
+}
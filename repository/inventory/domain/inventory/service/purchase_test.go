@@ -0,0 +1,254 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+)
+
+//newPurchaseInventory is a function for composing an inventory service on a memory store holding dummySku (10 items) and dummySku2 (120 items)
+//along with a draft purchase of 12 dummySku items at 48000 and 5 dummySku2 items at 62000
+func newPurchaseInventory(t *testing.T) *service.Inventory {
+	inventoryObj := newMemoryInventory(t)
+	err := inventoryObj.AddSKU("dummySku2", 120, 60000, 63000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.CreatePurchase("dummyPurchaseId", "", "dummy purchase", []service.PurchaseItem{
+		{Sku: "dummySku", Quantity: 12, BuyPrice: 48000, Note: "dummySku purchase"},
+		{Sku: "dummySku2", Quantity: 5, BuyPrice: 62000, Note: "dummySku2 purchase"},
+	})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj
+}
+
+func TestCreatePurchase(t *testing.T) {
+	inventoryObj := newPurchaseInventory(t)
+
+	//successful case
+	purchaseItemSlice := make([]service.PurchaseItem, 0)
+	purchaseItemSlice = append(purchaseItemSlice, service.PurchaseItem{
+		Sku:      "dummySku",
+		Quantity: 10,
+		BuyPrice: 48000,
+	})
+
+	ok, err := inventoryObj.CreatePurchase("newPurchaseId", "", "dummy new purchase", purchaseItemSlice)
+	t.Run("return must be true", func(t *testing.T) {
+		if true != ok {
+			t.Errorf("expected true but got %v", ok)
+		}
+	})
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+
+	//failed case (existing purchase id)
+	failedOk, failedErr := inventoryObj.CreatePurchase("dummyPurchaseId", "", "dummy new purchase", purchaseItemSlice)
+	t.Run("Failed return must be false", func(t *testing.T) {
+		if false != failedOk {
+			t.Errorf("expected false but got %v", failedOk)
+		}
+	})
+	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
+		if getType(failedErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(failedErr))
+		}
+	})
+}
+
+func TestGetPurchase(t *testing.T) {
+	inventoryObj := newPurchaseInventory(t)
+
+	//successful case
+	purchase, err := inventoryObj.GetPurchase("dummyPurchaseId")
+	t.Run("GetPurchase return must be purchase model object", func(t *testing.T) {
+		if getType(purchase) != "*Purchase" {
+			t.Errorf("expected *Purchase but got %v", getType(purchase))
+		}
+	})
+	t.Run("err returned must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(purchase.Items) != 2 || purchase.Items["dummySku2"].BuyPrice != 62000 {
+			t.Errorf("expected 2 items with dummySku2 at 62000 but got %+v", purchase.Items)
+		}
+	})
+
+	//failed case
+	failedPurchase, failedErr := inventoryObj.GetPurchase("missingPurchaseId")
+	t.Run("Failed GetPurchase return must be nil", func(t *testing.T) {
+		if failedPurchase != nil {
+			t.Errorf("expected nil but got %v", failedPurchase)
+		}
+	})
+	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
+		if getType(failedErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(failedErr))
+		}
+	})
+}
+
+func TestListPurchases(t *testing.T) {
+	inventoryObj := newPurchaseInventory(t)
+	_, err := inventoryObj.CreatePurchase("dummyPurchaseIdX", "", "", []service.PurchaseItem{{Sku: "dummySku", Quantity: 1, BuyPrice: 48000}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	//successful case
+	purchases, err := inventoryObj.ListPurchases()
+	t.Run("err returned must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+	t.Run("check returned purchase count", func(t *testing.T) {
+		if len(purchases) != 2 {
+			t.Errorf("expected %v but got %v", 2, len(purchases))
+		}
+	})
+
+	//no purchase case
+	emptyPurchases, emptyErr := newMemoryInventory(t).ListPurchases()
+	t.Run("Empty ListPurchases return must be empty", func(t *testing.T) {
+		if emptyErr != nil {
+			t.Errorf("expected nil but got %v", emptyErr)
+		}
+		if len(emptyPurchases) != 0 {
+			t.Errorf("expected %v but got %v", 0, len(emptyPurchases))
+		}
+	})
+}
+
+func TestUpdatePurchaseStatus(t *testing.T) {
+	inventoryObj := newPurchaseInventory(t)
+
+	//successful case
+	ok, err := inventoryObj.UpdatePurchaseStatus("dummyPurchaseId", model.PurchaseStatusDone)
+	t.Run("return must be true", func(t *testing.T) {
+		if true != ok {
+			t.Errorf("expected true but got %v", ok)
+		}
+	})
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	itemInfo2, _ := inventoryObj.GetItemInfo("dummySku2")
+	t.Run("stock quantity must be increased", func(t *testing.T) {
+		if itemInfo.Quantity != 22 || itemInfo2.Quantity != 125 {
+			t.Errorf("expected 22 and 125 but got %v and %v", itemInfo.Quantity, itemInfo2.Quantity)
+		}
+	})
+
+	//failed case (done purchase can no longer be updated)
+	failedOk, failedErr := inventoryObj.UpdatePurchaseStatus("dummyPurchaseId", model.PurchaseStatusCanceled)
+	t.Run("Failed return must be false", func(t *testing.T) {
+		if false != failedOk {
+			t.Errorf("expected false but got %v", failedOk)
+		}
+	})
+	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
+		if getType(failedErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(failedErr))
+		}
+	})
+
+	//failed case (invalid status)
+	invalidOk, _ := inventoryObj.UpdatePurchaseStatus("dummyPurchaseId", "X")
+	t.Run("Invalid status return must be false", func(t *testing.T) {
+		if false != invalidOk {
+			t.Errorf("expected false but got %v", invalidOk)
+		}
+	})
+}
+
+func TestReceivePurchase(t *testing.T) {
+	inventoryObj := newPurchaseInventory(t)
+
+	//successful case (partial receipt)
+	partialItems := []service.ReceiptItem{
+		{Sku: "dummySku", Quantity: 5},
+	}
+	ok, err := inventoryObj.ReceivePurchase("dummyPurchaseId", "dummyReceipt1", "first delivery", partialItems, false)
+	t.Run("return must be true", func(t *testing.T) {
+		if true != ok {
+			t.Errorf("expected true but got %v", ok)
+		}
+	})
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+	purchase, _ := inventoryObj.GetPurchase("dummyPurchaseId")
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	t.Run("purchase must be partially received", func(t *testing.T) {
+		if purchase.Items["dummySku"].ReceivedQuantity != 5 {
+			t.Errorf("expected received quantity %v but got %v", 5, purchase.Items["dummySku"].ReceivedQuantity)
+		}
+		if purchase.Status != model.PurchaseStatusDraft {
+			t.Errorf("expected status %v but got %v", model.PurchaseStatusDraft, purchase.Status)
+		}
+		if purchase.GetComputedStatus() != model.PurchaseStatusPartiallyReceived {
+			t.Errorf("expected computed status %v but got %v", model.PurchaseStatusPartiallyReceived, purchase.GetComputedStatus())
+		}
+		if itemInfo.Quantity != 15 {
+			t.Errorf("expected stock quantity %v but got %v", 15, itemInfo.Quantity)
+		}
+	})
+
+	//failed case (over receipt without override)
+	overItems := []service.ReceiptItem{
+		{Sku: "dummySku", Quantity: 10},
+	}
+	failedOk, failedErr := inventoryObj.ReceivePurchase("dummyPurchaseId", "dummyReceipt2", "second delivery", overItems, false)
+	t.Run("Over receipt return must be false", func(t *testing.T) {
+		if false != failedOk {
+			t.Errorf("expected false but got %v", failedOk)
+		}
+	})
+	t.Run("Over receipt err returned must type must be correct", func(t *testing.T) {
+		if getType(failedErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(failedErr))
+		}
+	})
+
+	//successful case (over receipt with override, completing the purchase)
+	completingItems := []service.ReceiptItem{
+		{Sku: "dummySku", Quantity: 10},
+		{Sku: "dummySku2", Quantity: 5},
+	}
+	overOk, overErr := inventoryObj.ReceivePurchase("dummyPurchaseId", "dummyReceipt2", "second delivery", completingItems, true)
+	t.Run("Over receipt with override return must be true", func(t *testing.T) {
+		if true != overOk {
+			t.Errorf("expected true but got %v", overOk)
+		}
+		if overErr != nil {
+			t.Errorf("expected nil but got %v", overErr)
+		}
+	})
+	purchase, _ = inventoryObj.GetPurchase("dummyPurchaseId")
+	receipts, _ := inventoryObj.GetPurchaseReceipts("dummyPurchaseId")
+	t.Run("purchase must be done", func(t *testing.T) {
+		if purchase.Status != model.PurchaseStatusDone {
+			t.Errorf("expected status %v but got %v", model.PurchaseStatusDone, purchase.Status)
+		}
+		if purchase.Items["dummySku"].ReceivedQuantity != 15 {
+			t.Errorf("expected received quantity %v but got %v", 15, purchase.Items["dummySku"].ReceivedQuantity)
+		}
+		if len(receipts) != 2 {
+			t.Errorf("expected 2 receipts but got %v", len(receipts))
+		}
+	})
+}
@@ -0,0 +1,279 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//DefaultReplenishmentSalesDays is the default count of days of completed sales the sales velocity is computed from
+const DefaultReplenishmentSalesDays int = 30
+
+//ReplenishmentParams is a definition of the parameters of replenishment suggestions (see GetReplenishmentPlan)
+type ReplenishmentParams struct {
+	AsOf         time.Time //last day of the sales the velocity is computed from, defaults to today
+	SalesDays    int       //count of days of completed sales the velocity is computed from (ending on AsOf), defaults to DefaultReplenishmentSalesDays
+	LeadTimeDays int       //days between ordering items and receiving them
+	CoverageDays int       //days of sales the received items must cover
+}
+
+//ReplenishmentPlan is a struct containing the suggested order quantities of the items which need to be reordered, grouped by supplier
+type ReplenishmentPlan struct {
+	Date          time.Time             `json:"date"`
+	StartDate     time.Time             `json:"startDate"` //first day of the sales the velocity is computed from
+	EndDate       time.Time             `json:"endDate"`   //last day of the sales the velocity is computed from
+	LeadTimeDays  int                   `json:"leadTimeDays"`
+	CoverageDays  int                   `json:"coverageDays"`
+	TotalQuantity int64                 `json:"totalQuantity"`
+	TotalAmount   model.Money           `json:"totalAmount"`
+	Suppliers     []*ReplenishmentGroup `json:"suppliers"` //sorted by supplier id, items without supplier are grouped under an empty supplier id
+}
+
+//ReplenishmentGroup is a struct containing the suggested order quantities of the items supplied by a supplier
+type ReplenishmentGroup struct {
+	SupplierID    string                     `json:"supplierId"`
+	Name          string                     `json:"name"`
+	PurchaseID    string                     `json:"purchaseId,omitempty"` //draft purchase created from the suggestions (see CreateReplenishmentPurchases)
+	TotalQuantity int64                      `json:"totalQuantity"`
+	TotalAmount   model.Money                `json:"totalAmount"`
+	Items         []*ReplenishmentSuggestion `json:"items"` //sorted by sku
+}
+
+//ReplenishmentSuggestion is a struct containing the suggested order quantity of a specific Sku
+//the target stock covers the sales velocity during the lead time and the coverage days on top of the safety stock
+type ReplenishmentSuggestion struct {
+	Sku               string      `json:"sku"`
+	Name              string      `json:"name"`
+	SoldQuantity      int64       `json:"soldQuantity"`  //quantity sold by completed sales during the sales days
+	DailyVelocity     float64     `json:"dailyVelocity"` //sold quantity / sales days
+	Available         int64       `json:"available"`     //stock quantity not reserved by draft sales
+	OnOrder           int64       `json:"onOrder"`       //quantity of draft purchases which hasn't been received yet
+	SafetyStock       int64       `json:"safetyStock"`
+	TargetQuantity    int64       `json:"targetQuantity"`    //ceil(daily velocity * (lead time days + coverage days)) + safety stock
+	SuggestedQuantity int64       `json:"suggestedQuantity"` //target - (available + on order), at least the reorder quantity of the item
+	BuyPrice          model.Money `json:"buyPrice"`          //last price of the supplier, or the buy price of the item
+	Amount            model.Money `json:"amount"`
+	supplierSku       string      //code of the item in the catalog of the supplier, kept as the note of the drafted purchase item
+}
+
+//doneSales is a function for obtaining the sales completed within the given dates (inclusive)
+func (i *Inventory) doneSales(startDate, endDate time.Time) ([]*model.Sales, *errors.Error) {
+	saleFinder, ok := i.SalesDatamapper.(datamapper.SaleFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting sales mapper"), 0)
+	}
+	//the whole end date is included
+	endOfPeriod := endDate.AddDate(0, 0, 1)
+	sales, err := saleFinder.FindByDoneStatusAndDateRange(startDate, endOfPeriod)
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	saleList := make([]*model.Sales, 0, len(sales))
+	for _, val := range sales {
+		saleObj, ok := val.(*model.Sales)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		if saleObj.Date.Before(startDate) || false == saleObj.Date.Before(endOfPeriod) {
+			continue
+		}
+		saleList = append(saleList, saleObj)
+	}
+	return saleList, nil
+}
+
+//soldQuantities is a function for summing the quantity of every sku sold by the sales completed within the given dates (inclusive)
+func (i *Inventory) soldQuantities(startDate, endDate time.Time) (map[string]int64, *errors.Error) {
+	sales, err := i.doneSales(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	quantities := make(map[string]int64)
+	for _, val := range sales {
+		for _, itemVal := range val.Items {
+			quantities[itemVal.Sku] += itemVal.Quantity
+		}
+	}
+	return quantities, nil
+}
+
+//onOrderQuantities is a function for summing the quantity of every sku which is ordered by draft purchases but hasn't been received yet
+func (i *Inventory) onOrderQuantities() (map[string]int64, *errors.Error) {
+	purchases, err := i.ListPurchases()
+	if err != nil {
+		return nil, err
+	}
+	quantities := make(map[string]int64)
+	for _, val := range purchases {
+		if val.Status != model.PurchaseStatusDraft {
+			continue
+		}
+		for _, itemVal := range val.Items {
+			quantities[itemVal.Sku] += itemVal.GetRemainingQuantity()
+		}
+	}
+	return quantities, nil
+}
+
+//GetReplenishmentPlan is a function for computing the suggested order quantity of every item from its sales velocity and stock, grouped by the supplier the item was last purchased from
+//items whose available and ordered stock already covers the target aren't suggested
+func (i *Inventory) GetReplenishmentPlan(params ReplenishmentParams) (*ReplenishmentPlan, *errors.Error) {
+	if params.LeadTimeDays < 0 || params.CoverageDays < 0 || params.SalesDays < 0 {
+		return nil, errors.Wrap(fmt.Errorf("Lead time, coverage and sales days must not be negative"), 0)
+	}
+	if params.SalesDays == 0 {
+		params.SalesDays = DefaultReplenishmentSalesDays
+	}
+	if true == params.AsOf.IsZero() {
+		params.AsOf = time.Now()
+	}
+	endDate := time.Date(params.AsOf.Year(), params.AsOf.Month(), params.AsOf.Day(), 0, 0, 0, 0, params.AsOf.Location())
+	startDate := endDate.AddDate(0, 0, 1-params.SalesDays)
+
+	soldQuantities, err := i.soldQuantities(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	onOrderQuantities, err := i.onOrderQuantities()
+	if err != nil {
+		return nil, err
+	}
+	items, err := i.StockDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	suppliers, err := i.ListSuppliers()
+	if err != nil {
+		return nil, err
+	}
+	//every sku is ordered from the supplier it was last purchased from
+	itemSuppliers := make(map[string]*model.Supplier)
+	for _, val := range suppliers {
+		for sku, itemVal := range val.Items {
+			current, exists := itemSuppliers[sku]
+			if false == exists || itemVal.LastPurchaseDate.After(current.Items[sku].LastPurchaseDate) {
+				itemSuppliers[sku] = val
+			}
+		}
+	}
+
+	plan := &ReplenishmentPlan{
+		Date:         time.Now(),
+		StartDate:    startDate,
+		EndDate:      endDate,
+		LeadTimeDays: params.LeadTimeDays,
+		CoverageDays: params.CoverageDays,
+		Suppliers:    make([]*ReplenishmentGroup, 0),
+	}
+	groups := make(map[string]*ReplenishmentGroup)
+	for _, val := range items {
+		stockObj, ok := val.(*model.Stock)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		suggestion := &ReplenishmentSuggestion{
+			Sku:           stockObj.Sku,
+			Name:          stockObj.Name,
+			SoldQuantity:  soldQuantities[stockObj.Sku],
+			DailyVelocity: float64(soldQuantities[stockObj.Sku]) / float64(params.SalesDays),
+			Available:     stockObj.Available(),
+			OnOrder:       onOrderQuantities[stockObj.Sku],
+			SafetyStock:   stockObj.SafetyStock,
+			BuyPrice:      stockObj.BuyPrice,
+		}
+		suggestion.TargetQuantity = int64(math.Ceil(suggestion.DailyVelocity*float64(params.LeadTimeDays+params.CoverageDays))) + stockObj.SafetyStock
+		suggestion.SuggestedQuantity = suggestion.TargetQuantity - suggestion.Available - suggestion.OnOrder
+		if suggestion.SuggestedQuantity <= 0 {
+			continue
+		}
+		if suggestion.SuggestedQuantity < stockObj.ReorderQuantity {
+			suggestion.SuggestedQuantity = stockObj.ReorderQuantity
+		}
+
+		var supplierID, supplierName string
+		if supplierObj, exists := itemSuppliers[stockObj.Sku]; true == exists {
+			supplierID, supplierName = supplierObj.SupplierID, supplierObj.Name
+			supplierItem := supplierObj.Items[stockObj.Sku]
+			suggestion.supplierSku = supplierItem.SupplierSku
+			if supplierItem.LastPrice > 0 {
+				suggestion.BuyPrice = supplierItem.LastPrice
+			}
+		}
+		suggestion.Amount = suggestion.BuyPrice.Multiply(suggestion.SuggestedQuantity)
+
+		group, exists := groups[supplierID]
+		if false == exists {
+			group = &ReplenishmentGroup{
+				SupplierID: supplierID,
+				Name:       supplierName,
+			}
+			groups[supplierID] = group
+			plan.Suppliers = append(plan.Suppliers, group)
+		}
+		group.Items = append(group.Items, suggestion)
+		group.TotalQuantity += suggestion.SuggestedQuantity
+		group.TotalAmount += suggestion.Amount
+		plan.TotalQuantity += suggestion.SuggestedQuantity
+		plan.TotalAmount += suggestion.Amount
+	}
+
+	sort.Slice(plan.Suppliers, func(x, y int) bool {
+		return plan.Suppliers[x].SupplierID < plan.Suppliers[y].SupplierID
+	})
+	for _, val := range plan.Suppliers {
+		sort.Slice(val.Items, func(x, y int) bool {
+			return val.Items[x].Sku < val.Items[y].Sku
+		})
+	}
+	return plan, nil
+}
+
+//CreateReplenishmentPurchases is a function for turning the replenishment suggestions into draft purchases, one purchase for every supplier
+//the purchase of a supplier is identified by purchaseIDPrefix-supplierId (purchaseIDPrefix alone for the items without supplier), the purchases are created as a whole or not at all
+func (i *Inventory) CreateReplenishmentPurchases(params ReplenishmentParams, purchaseIDPrefix string) (*ReplenishmentPlan, *errors.Error) {
+	if purchaseIDPrefix == "" {
+		return nil, errors.Wrap(fmt.Errorf("Invalid purchase id prefix from param, it must not be empty"), 0)
+	}
+	plan, err := i.GetReplenishmentPlan(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Suppliers) == 0 {
+		return nil, errors.Wrap(fmt.Errorf("No item needs to be reordered"), 0)
+	}
+	note := fmt.Sprintf("Replenishment of sales from %v to %v (lead time %v days, coverage %v days)", plan.StartDate.Format("2006-01-02"), plan.EndDate.Format("2006-01-02"), plan.LeadTimeDays, plan.CoverageDays)
+	err = i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		for _, val := range plan.Suppliers {
+			purchaseID := purchaseIDPrefix
+			if val.SupplierID != "" {
+				purchaseID = purchaseIDPrefix + "-" + val.SupplierID
+			}
+			items := make([]PurchaseItem, 0, len(val.Items))
+			for _, itemVal := range val.Items {
+				items = append(items, PurchaseItem{
+					Sku:      itemVal.Sku,
+					Quantity: itemVal.SuggestedQuantity,
+					BuyPrice: itemVal.BuyPrice,
+					Note:     itemVal.supplierSku,
+				})
+			}
+			err := i.insertPurchase(uow, purchaseID, val.SupplierID, note, items)
+			if err != nil {
+				return err
+			}
+			val.PurchaseID = purchaseID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return plan, nil
+}
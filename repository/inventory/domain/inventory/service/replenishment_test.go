@@ -0,0 +1,163 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+)
+
+//newReplenishmentInventory is a function for composing an inventory service on a memory store where
+//dummySku sold 6 items today (5 left after receiving 1 item from supplier ABC at 45000) and has 5 more items ordered from ABC at 47000, otherSku doesn't sell and thirdSku (1 item) is below its safety stock of 3
+func newReplenishmentInventory(t *testing.T) *service.Inventory {
+	inventoryObj := newMemoryInventory(t)
+	err := inventoryObj.AddSKU("otherSku", 100, 20000, 25000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.AddSKU("thirdSku", 1, 30000, 35000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.UpdateReorderSettings("dummySku", 5, 10, 2)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.UpdateReorderSettings("thirdSku", 3, 0, 3)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.CreateSupplier(service.SupplierDetail{SupplierID: "ABC", Name: "Pabrik ABC"})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.CreatePurchase("receivedPurchase", "ABC", "", []service.PurchaseItem{{Sku: "dummySku", Quantity: 1, BuyPrice: 45000}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.UpdatePurchaseStatus("receivedPurchase", model.PurchaseStatusDone)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.CreatePurchase("dummyPurchase", "ABC", "", []service.PurchaseItem{{Sku: "dummySku", Quantity: 5, BuyPrice: 47000}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.CreateSale("dummyInvoice", "", []service.SaleItem{{Sku: "dummySku", Quantity: 6}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.UpdateSale("dummyInvoice", model.SalesStatusDone, "dummyUser")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj
+}
+
+func TestGetReplenishmentPlan(t *testing.T) {
+	inventoryObj := newReplenishmentInventory(t)
+
+	_, err := inventoryObj.GetReplenishmentPlan(service.ReplenishmentParams{LeadTimeDays: -1})
+	t.Run("negative lead time must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	plan, err := inventoryObj.GetReplenishmentPlan(service.ReplenishmentParams{SalesDays: 3, LeadTimeDays: 5, CoverageDays: 5})
+	t.Run("suggestions must be grouped by the supplier the item was last purchased from", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(plan.Suppliers) != 2 || plan.Suppliers[0].SupplierID != "" || plan.Suppliers[1].SupplierID != "ABC" || plan.Suppliers[1].Name != "Pabrik ABC" {
+			t.Fatalf("expected suggestions without supplier and from ABC but got %+v", plan.Suppliers)
+		}
+		if plan.TotalQuantity != 14 || plan.TotalAmount != 600000 {
+			t.Errorf("expected 14 items worth 600000 but got %v worth %v", plan.TotalQuantity, plan.TotalAmount)
+		}
+	})
+	t.Run("suggested quantity must cover the sales during lead time and coverage on top of the safety stock", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		items := plan.Suppliers[1].Items
+		//2 items a day for 10 days + 2 safety stock - 5 available - 5 on order
+		if len(items) != 1 || items[0].Sku != "dummySku" || items[0].SoldQuantity != 6 || items[0].DailyVelocity != 2 ||
+			items[0].Available != 5 || items[0].OnOrder != 5 || items[0].TargetQuantity != 22 || items[0].SuggestedQuantity != 12 {
+			t.Errorf("expected 12 dummySku items but got %+v", items)
+		}
+		//the price of the draft purchase isn't the last price until it's received
+		if items[0].BuyPrice != 45000 || items[0].Amount != 540000 {
+			t.Errorf("expected last price 45000 of the supplier but got %v", items[0].BuyPrice)
+		}
+	})
+	t.Run("item without supplier must be priced at its buy price", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		items := plan.Suppliers[0].Items
+		if len(items) != 1 || items[0].Sku != "thirdSku" || items[0].SuggestedQuantity != 2 || items[0].BuyPrice != 30000 {
+			t.Errorf("expected 2 thirdSku items at 30000 but got %+v", items)
+		}
+	})
+
+	err = inventoryObj.UpdateReorderSettings("thirdSku", 3, 10, 3)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	plan, err = inventoryObj.GetReplenishmentPlan(service.ReplenishmentParams{SalesDays: 3, LeadTimeDays: 5, CoverageDays: 5})
+	t.Run("suggested quantity must be at least the reorder quantity", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if plan.Suppliers[0].Items[0].SuggestedQuantity != 10 {
+			t.Errorf("expected 10 thirdSku items but got %+v", plan.Suppliers[0].Items[0])
+		}
+	})
+}
+
+func TestCreateReplenishmentPurchases(t *testing.T) {
+	inventoryObj := newReplenishmentInventory(t)
+	params := service.ReplenishmentParams{SalesDays: 3, LeadTimeDays: 5, CoverageDays: 5}
+
+	_, err := inventoryObj.CreateReplenishmentPurchases(params, "")
+	t.Run("empty purchase id prefix must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	plan, err := inventoryObj.CreateReplenishmentPurchases(params, "RPL")
+	purchaseObj, errs := inventoryObj.GetPurchase("RPL-ABC")
+	t.Run("draft purchase must be created for every supplier", func(t *testing.T) {
+		if err != nil || errs != nil {
+			t.Fatalf("expected nil but got %v and %v", err, errs)
+		}
+		if plan.Suppliers[0].PurchaseID != "RPL" || plan.Suppliers[1].PurchaseID != "RPL-ABC" {
+			t.Errorf("expected purchases RPL and RPL-ABC but got %+v", plan.Suppliers)
+		}
+		if purchaseObj.Status != model.PurchaseStatusDraft || purchaseObj.SupplierID != "ABC" || len(purchaseObj.Items) != 1 ||
+			purchaseObj.Items["dummySku"].Quantity != 12 || purchaseObj.Items["dummySku"].BuyPrice != 45000 {
+			t.Errorf("expected draft purchase of 12 dummySku items from ABC but got %+v", purchaseObj)
+		}
+	})
+
+	_, err = inventoryObj.CreateReplenishmentPurchases(params, "RPL2")
+	t.Run("ordered items must no longer be suggested", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	_, err = inventoryObj.UpdatePurchaseStatus("RPL", model.PurchaseStatusCanceled)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.CreateReplenishmentPurchases(params, "RPL")
+	t.Run("existing purchase id must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
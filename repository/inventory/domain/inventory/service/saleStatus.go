@@ -0,0 +1,99 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//saleStatusTransitions is the table of allowed sale status transitions (current status -> allowed next statuses)
+//a draft sale can be completed or canceled, a completed sale can only be canceled, a canceled sale is final
+var saleStatusTransitions = map[string][]string{
+	model.SalesStatusDraft: {model.SalesStatusDone, model.SalesStatusCanceled},
+	model.SalesStatusDone:  {model.SalesStatusCanceled},
+}
+
+//isSaleTransitionAllowed is a function for checking whether a sale may move from one status to another
+func isSaleTransitionAllowed(fromStatus, toStatus string) bool {
+	for _, val := range saleStatusTransitions[fromStatus] {
+		if val == toStatus {
+			return true
+		}
+	}
+	return false
+}
+
+//recordSaleTransition is a function for writing a sale status transition (inside the passed unit of work)
+func (i *Inventory) recordSaleTransition(uow *UnitOfWork, invoiceNo, fromStatus, toStatus, changedBy string) *errors.Error {
+	newTransition := &model.SaleStatusTransition{
+		InvoiceID:  invoiceNo,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		ChangedBy:  changedBy,
+		Date:       time.Now(),
+	}
+	err := uow.SaleStatusTransitionDatamapper.Insert(newTransition)
+	if err != nil {
+		return errors.Wrap(fmt.Errorf("Sale: %v status transition recording failed: %v", invoiceNo, err), 0)
+	}
+	return nil
+}
+
+//restockSale is a function for returning the quantities of a completed sale to stock (inside the passed unit of work)
+//the returned items get a new cost layer at the cost they were sold with
+func (i *Inventory) restockSale(uow *UnitOfWork, saleObj *model.Sales) *errors.Error {
+	for _, val := range saleObj.Items {
+		stockItemObj, err := uow.getStock(val.Sku)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		stockItemObj.Quantity += val.Quantity
+		err = uow.StockDatamapper.Update(stockItemObj)
+		if err != nil {
+			return errors.Wrap(fmt.Errorf("Sku: %v stock update failed: %v", stockItemObj.Sku, err), 0)
+		}
+		err = i.recordMovement(uow, val.Sku, val.Quantity, stockItemObj.BuyPrice, model.MovementReasonSaleCancel, saleObj.InvoiceID, "")
+		if err != nil {
+			return err
+		}
+		err = i.addCostLayer(uow, val.Sku, val.Quantity, val.BuyPrice, saleObj.InvoiceID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//GetSaleStatusHistory is a function for obtaining the status transitions of a sale (ordered by transition time)
+func (i *Inventory) GetSaleStatusHistory(invoiceNo string) ([]*model.SaleStatusTransition, *errors.Error) {
+	//check whether the sale exists or not
+	_, err := i.SalesDatamapper.FindByID(invoiceNo)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return nil, errors.Wrap(fmt.Errorf("Sale %v is not found", invoiceNo), 0)
+		}
+		return nil, errors.Wrap(err, 0)
+	}
+	transitionFinder, ok := i.SaleStatusTransitionDatamapper.(datamapper.SaleStatusTransitionFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting sale status transition mapper"), 0)
+	}
+	transitions, err := transitionFinder.FindByInvoiceID(invoiceNo)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	transitionList := make([]*model.SaleStatusTransition, 0, len(transitions))
+	for _, val := range transitions {
+		valObj, ok := val.(*model.SaleStatusTransition)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		transitionList = append(transitionList, valObj)
+	}
+	return transitionList, nil
+}
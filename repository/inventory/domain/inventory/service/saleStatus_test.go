@@ -0,0 +1,153 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+)
+
+//newStatusSaleInventory is a function for composing an inventory service holding a sale of 3 dummySku items (dummyStatusInvoice) in the given status
+func newStatusSaleInventory(t *testing.T, status string) *service.Inventory {
+	inventoryObj := newMemoryInventory(t)
+	_, err := inventoryObj.CreateSale("dummyStatusInvoice", "", []service.SaleItem{{Sku: "dummySku", Quantity: 3}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	if status == model.SalesStatusDone {
+		_, err = inventoryObj.UpdateSale("dummyStatusInvoice", model.SalesStatusDone, "setupUser")
+	}
+	if status == model.SalesStatusCanceled {
+		_, err = inventoryObj.UpdateSale("dummyStatusInvoice", model.SalesStatusCanceled, "setupUser")
+	}
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj
+}
+
+//updateDummySaleStatus is a function for moving dummyStatusInvoice to toStatus, it returns the transitions recorded by the update
+func updateDummySaleStatus(t *testing.T, inventoryObj *service.Inventory, toStatus, changedBy string) ([]*model.SaleStatusTransition, bool, error) {
+	history, err := inventoryObj.GetSaleStatusHistory("dummyStatusInvoice")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	ok, err := inventoryObj.UpdateSale("dummyStatusInvoice", toStatus, changedBy)
+	newHistory, errh := inventoryObj.GetSaleStatusHistory("dummyStatusInvoice")
+	if errh != nil {
+		t.Fatalf("expected nil but got %v", errh)
+	}
+	if err != nil {
+		return newHistory[len(history):], ok, err
+	}
+	return newHistory[len(history):], ok, nil
+}
+
+func TestUpdateSaleTransitions(t *testing.T) {
+	transitions := []struct {
+		fromStatus string
+		toStatus   string
+		allowed    bool
+	}{
+		{model.SalesStatusDraft, model.SalesStatusDone, true},
+		{model.SalesStatusDraft, model.SalesStatusCanceled, true},
+		{model.SalesStatusDone, model.SalesStatusCanceled, true},
+		{model.SalesStatusDraft, model.SalesStatusDraft, false},
+		{model.SalesStatusDone, model.SalesStatusDraft, false},
+		{model.SalesStatusDone, model.SalesStatusDone, false},
+		{model.SalesStatusCanceled, model.SalesStatusDraft, false},
+		{model.SalesStatusCanceled, model.SalesStatusDone, false},
+		{model.SalesStatusCanceled, model.SalesStatusCanceled, false},
+	}
+	for _, val := range transitions {
+		inventoryObj := newStatusSaleInventory(t, val.fromStatus)
+		recorded, ok, err := updateDummySaleStatus(t, inventoryObj, val.toStatus, "dummyUser")
+		t.Run("transition "+val.fromStatus+" to "+val.toStatus+" must be checked against the transition table", func(t *testing.T) {
+			if val.allowed != ok {
+				t.Errorf("expected %v but got %v", val.allowed, ok)
+			}
+			if true == val.allowed && err != nil {
+				t.Errorf("expected nil but got %v", err)
+			}
+			if false == val.allowed && getType(err) != "*Error" {
+				t.Errorf("expected *Error but got %v", getType(err))
+			}
+		})
+		t.Run("transition "+val.fromStatus+" to "+val.toStatus+" must only be recorded when allowed", func(t *testing.T) {
+			expectedCount := 0
+			if true == val.allowed {
+				expectedCount = 1
+			}
+			if len(recorded) != expectedCount {
+				t.Fatalf("expected %v recorded transitions but got %v", expectedCount, len(recorded))
+			}
+			if expectedCount == 0 {
+				return
+			}
+			if recorded[0].FromStatus != val.fromStatus || recorded[0].ToStatus != val.toStatus {
+				t.Errorf("expected %v to %v but got %v to %v", val.fromStatus, val.toStatus, recorded[0].FromStatus, recorded[0].ToStatus)
+			}
+			if recorded[0].ChangedBy != "dummyUser" {
+				t.Errorf("expected dummyUser but got %v", recorded[0].ChangedBy)
+			}
+			if true == recorded[0].Date.IsZero() {
+				t.Errorf("expected transition time to be set")
+			}
+		})
+	}
+}
+
+func TestCancelDoneSale(t *testing.T) {
+	inventoryObj := newStatusSaleInventory(t, model.SalesStatusDone)
+	_, ok, err := updateDummySaleStatus(t, inventoryObj, model.SalesStatusCanceled, "dummyUser")
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	t.Run("return must be true", func(t *testing.T) {
+		if true != ok {
+			t.Errorf("expected true but got %v", ok)
+		}
+	})
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+	t.Run("sold quantity must be returned to stock", func(t *testing.T) {
+		if itemInfo.Quantity != 10 {
+			t.Errorf("expected %v but got %v", 10, itemInfo.Quantity)
+		}
+	})
+
+	//canceling a draft sale doesn't touch stock
+	inventoryObj = newStatusSaleInventory(t, model.SalesStatusDraft)
+	_, _, err = updateDummySaleStatus(t, inventoryObj, model.SalesStatusCanceled, "dummyUser")
+	itemInfo, _ = inventoryObj.GetItemInfo("dummySku")
+	t.Run("draft cancellation must not change stock", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+		if itemInfo.Quantity != 10 {
+			t.Errorf("expected %v but got %v", 10, itemInfo.Quantity)
+		}
+	})
+}
+
+func TestUpdateSaleWithoutChangedBy(t *testing.T) {
+	inventoryObj := newStatusSaleInventory(t, model.SalesStatusDraft)
+	recorded, ok, err := updateDummySaleStatus(t, inventoryObj, model.SalesStatusCanceled, "")
+	t.Run("return must be false", func(t *testing.T) {
+		if false != ok {
+			t.Errorf("expected false but got %v", ok)
+		}
+	})
+	t.Run("err returned must type must be correct", func(t *testing.T) {
+		if getType(err) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(err))
+		}
+	})
+	t.Run("transition must not be recorded", func(t *testing.T) {
+		if len(recorded) != 0 {
+			t.Errorf("expected no recorded transition but got %v", len(recorded))
+		}
+	})
+}
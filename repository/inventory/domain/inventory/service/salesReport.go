@@ -0,0 +1,308 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/forecast"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//GroupBySku is const for grouping report rows by sku
+const GroupBySku string = "sku"
+
+//GroupByDay is const for grouping report rows by the day of the sale
+const GroupByDay string = "day"
+
+//GroupByWeek is const for grouping report rows by the week of the sale (weeks start on Monday)
+const GroupByWeek string = "week"
+
+//GroupByMonth is const for grouping report rows by the month of the sale
+const GroupByMonth string = "month"
+
+//SalesReport is a struct containing sales figures of a period grouped by sku, product, size, color, category or calendar bucket
+type SalesReport struct {
+	StartDate   time.Time          `json:"startDate"`
+	EndDate     time.Time          `json:"endDate"`
+	GroupBy     string             `json:"groupBy"`
+	SaleCount   int                `json:"saleCount"`
+	ReturnCount int                `json:"returnCount"`
+	Totals      SalesFigures       `json:"totals"`
+	Previous    *SalesReportPeriod `json:"previous,omitempty"` //the period of the same length right before the start date (compared reports only)
+	Rows        []*SalesReportRow  `json:"rows"`               //ordered by key (by date for calendar buckets)
+}
+
+//SalesReportPeriod is a struct containing the totals of the period a sales report is compared to
+type SalesReportPeriod struct {
+	StartDate   time.Time    `json:"startDate"`
+	EndDate     time.Time    `json:"endDate"`
+	SaleCount   int          `json:"saleCount"`
+	ReturnCount int          `json:"returnCount"`
+	Totals      SalesFigures `json:"totals"`
+}
+
+//SalesReportRow is a struct containing sales figures of a group (a sku, product, size, color, category or calendar bucket)
+type SalesReportRow struct {
+	Key  string `json:"key"`            //sku, product id, size, color, category id or first day of the calendar bucket (YYYY-MM-DD)
+	Name string `json:"name,omitempty"` //item, product or category name
+	SalesFigures
+	Previous *SalesFigures `json:"previous,omitempty"` //figures of the same group (of the bucket at the same position for calendar buckets) in the previous period
+}
+
+//SalesFigures is a struct containing the aggregated figures of sold items, returned items are netted out
+type SalesFigures struct {
+	Quantity      int64       `json:"quantity"`
+	SalesTurnOver model.Money `json:"omzet"` //sell price * quantity
+	COGS          model.Money `json:"cogs"`  //buy price * quantity
+	Profit        model.Money `json:"profit"`
+	Margin        float64     `json:"margin"` //profit / omzet in percent (2 decimals), 0 without omzet
+}
+
+//add is a function for adding a sold (negative quantity for returned) item to the figures
+func (f *SalesFigures) add(line salesLine) {
+	f.Quantity += line.quantity
+	f.SalesTurnOver += line.sellPrice.Multiply(line.quantity)
+	f.COGS += line.cost
+	f.Profit = f.SalesTurnOver - f.COGS
+	f.Margin = 0
+	if f.SalesTurnOver != 0 {
+		f.Margin = math.Round(float64(f.Profit)/float64(f.SalesTurnOver)*10000) / 100
+	}
+}
+
+//salesLine is an item sold by a completed sale (or returned by a sales return, with negative quantity)
+type salesLine struct {
+	date      time.Time
+	sku       string
+	quantity  int64
+	cost      model.Money //cost of the sold quantity (negative for returned)
+	sellPrice model.Money
+}
+
+//salesLines is a function for obtaining the items sold by the sales completed within the given dates (inclusive), along with the items returned within them
+//it returns the lines along with the count of sales and the count of returns
+func (i *Inventory) salesLines(startDate, endDate time.Time) ([]salesLine, int, int, *errors.Error) {
+	sales, err := i.doneSales(startDate, endDate)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	lines := make([]salesLine, 0)
+	for _, val := range sales {
+		for _, itemVal := range val.Items {
+			lines = append(lines, salesLine{
+				date:      val.Date,
+				sku:       itemVal.Sku,
+				quantity:  itemVal.Quantity,
+				cost:      itemVal.GetCost(),
+				sellPrice: itemVal.SellPrice,
+			})
+		}
+	}
+
+	//returns are netted out of the period they happened in (regardless of the sale date)
+	returnFinder, ok := i.SalesReturnDatamapper.(datamapper.SalesReturnFinder)
+	if false == ok {
+		return nil, 0, 0, errors.Wrap(fmt.Errorf("Failed asserting sales return mapper"), 0)
+	}
+	returns, err := returnFinder.FindByDateRange(startDate, endDate.AddDate(0, 0, 1))
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, 0, 0, err
+	}
+	for _, val := range returns {
+		valObj, ok := val.(*model.SalesReturn)
+		if false == ok {
+			return nil, 0, 0, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		for _, itemVal := range valObj.Items {
+			lines = append(lines, salesLine{
+				date:      valObj.Date,
+				sku:       itemVal.Sku,
+				quantity:  -itemVal.Quantity,
+				cost:      itemVal.BuyPrice.Multiply(-itemVal.Quantity),
+				sellPrice: itemVal.SellPrice,
+			})
+		}
+	}
+	return lines, len(sales), len(returns), nil
+}
+
+//bucketStart is a function for returning the first day of the calendar bucket (see GroupByDay, GroupByWeek and GroupByMonth) the date falls in
+func bucketStart(date time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case GroupByWeek:
+		return forecast.PeriodStart(date, forecast.Weekly)
+	case GroupByMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	}
+	return forecast.PeriodStart(date, forecast.Daily)
+}
+
+//nextBucket is a function for returning the first day of the calendar bucket following the one starting on the given date
+func nextBucket(start time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case GroupByWeek:
+		return start.AddDate(0, 0, 7)
+	case GroupByMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+//salesReportGroups is a function for obtaining the key function and the group names of the rows of a sales report
+func (i *Inventory) salesReportGroups(groupBy string) (func(line salesLine) string, map[string]string, *errors.Error) {
+	names := make(map[string]string)
+	switch groupBy {
+	case GroupByDay, GroupByWeek, GroupByMonth:
+		return func(line salesLine) string {
+			return bucketStart(line.date, groupBy).Format("2006-01-02")
+		}, names, nil
+	case GroupBySku:
+		items, err := i.StockDatamapper.FindAll()
+		if err != nil && err.Err != datamapper.ErrNotFound {
+			return nil, nil, err
+		}
+		for _, val := range items {
+			names[val.GetID()] = val.(*model.Stock).Name
+		}
+		return func(line salesLine) string {
+			return line.sku
+		}, names, nil
+	}
+	if groupBy != GroupByProduct && groupBy != GroupBySize && groupBy != GroupByColor && groupBy != GroupByCategory {
+		return nil, nil, errors.Wrap(fmt.Errorf("Invalid group by %v (should be %v, %v, %v, %v, %v, %v, %v or %v)", groupBy, GroupBySku, GroupByProduct, GroupBySize, GroupByColor, GroupByCategory, GroupByDay, GroupByWeek, GroupByMonth), 0)
+	}
+	groups, err := i.variantGroups(groupBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, val := range groups {
+		if val.name != "" {
+			names[val.key] = val.name
+		}
+	}
+	return func(line salesLine) string {
+		return groups[line.sku].key
+	}, names, nil
+}
+
+//aggregateSales is a function for summing the figures of the sales lines by the key of their group
+//calendar buckets without sales are listed as well, keys are returned in the order of the rows (see SalesReport Rows)
+func aggregateSales(lines []salesLine, keyOf func(line salesLine) string, groupBy string, startDate, endDate time.Time) (map[string]*SalesFigures, []string) {
+	figures := make(map[string]*SalesFigures)
+	keys := make([]string, 0)
+	if groupBy == GroupByDay || groupBy == GroupByWeek || groupBy == GroupByMonth {
+		for bucket := bucketStart(startDate, groupBy); false == bucket.After(endDate); bucket = nextBucket(bucket, groupBy) {
+			key := bucket.Format("2006-01-02")
+			figures[key] = &SalesFigures{}
+			keys = append(keys, key)
+		}
+	}
+	for _, val := range lines {
+		key := keyOf(val)
+		figure, exists := figures[key]
+		if false == exists {
+			figure = &SalesFigures{}
+			figures[key] = figure
+			keys = append(keys, key)
+		}
+		figure.add(val)
+	}
+	sort.Strings(keys)
+	return figures, keys
+}
+
+//GetSalesReport is a function for obtaining the quantity, omzet, COGS, profit and margin of the items sold within the given dates (inclusive)
+//grouped by sku, product, size, color, category (see GroupBy consts) or by day, week or month, the figures of the period of the same length
+//right before the start date are added to the report and to every row when compare is true
+//returned items are netted out of the period (and the calendar bucket) they were returned in
+func (i *Inventory) GetSalesReport(startTime, endTime time.Time, groupBy string, compare bool) (*SalesReport, *errors.Error) {
+	//validate start and end date
+	if !(startTime.Before(endTime) || startTime.Equal(endTime)) {
+		return nil, errors.Wrap(fmt.Errorf("Invalid start date %v and end date %v from param", startTime.Format("2006-01-02"), endTime.Format("2006-01-02")), 0)
+	}
+	keyOf, names, err := i.salesReportGroups(groupBy)
+	if err != nil {
+		return nil, err
+	}
+	lines, saleCount, returnCount, err := i.salesLines(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SalesReport{
+		StartDate:   startTime,
+		EndDate:     endTime,
+		GroupBy:     groupBy,
+		SaleCount:   saleCount,
+		ReturnCount: returnCount,
+		Rows:        make([]*SalesReportRow, 0),
+	}
+	for _, val := range lines {
+		report.Totals.add(val)
+	}
+	figures, keys := aggregateSales(lines, keyOf, groupBy, startTime, endTime)
+	for _, key := range keys {
+		report.Rows = append(report.Rows, &SalesReportRow{
+			Key:          key,
+			Name:         names[key],
+			SalesFigures: *figures[key],
+		})
+	}
+	if false == compare {
+		return report, nil
+	}
+
+	//previous period of the same count of days
+	days := int(math.Round(endTime.Sub(startTime).Hours()/24)) + 1
+	previousEnd := startTime.AddDate(0, 0, -1)
+	previousStart := startTime.AddDate(0, 0, -days)
+	previousLines, previousSaleCount, previousReturnCount, err := i.salesLines(previousStart, previousEnd)
+	if err != nil {
+		return nil, err
+	}
+	report.Previous = &SalesReportPeriod{
+		StartDate:   previousStart,
+		EndDate:     previousEnd,
+		SaleCount:   previousSaleCount,
+		ReturnCount: previousReturnCount,
+	}
+	for _, val := range previousLines {
+		report.Previous.Totals.add(val)
+	}
+	previousFigures, previousKeys := aggregateSales(previousLines, keyOf, groupBy, previousStart, previousEnd)
+	calendar := groupBy == GroupByDay || groupBy == GroupByWeek || groupBy == GroupByMonth
+	for index, val := range report.Rows {
+		switch {
+		case false == calendar:
+			val.Previous = previousFigures[val.Key]
+		case index < len(previousKeys):
+			val.Previous = previousFigures[previousKeys[index]]
+		}
+		if val.Previous == nil {
+			val.Previous = &SalesFigures{}
+		}
+	}
+	//groups sold only in the previous period
+	if false == calendar {
+		for _, key := range previousKeys {
+			if _, exists := figures[key]; true == exists {
+				continue
+			}
+			report.Rows = append(report.Rows, &SalesReportRow{
+				Key:      key,
+				Name:     names[key],
+				Previous: previousFigures[key],
+			})
+		}
+		sort.Slice(report.Rows, func(x, y int) bool {
+			return report.Rows[x].Key < report.Rows[y].Key
+		})
+	}
+	return report, nil
+}
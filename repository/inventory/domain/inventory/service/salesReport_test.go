@@ -0,0 +1,158 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+	"time"
+)
+
+//newSalesReportInventory is a function for composing an inventory service on a memory store where
+//dummySku (sold at 60000) and otherSku (bought at 20000, sold at 25000) sold 4 and 1 items today (a dummySku item being returned)
+//and dummySku sold 4 items at 55000 a week ago
+func newSalesReportInventory(t *testing.T) (*service.Inventory, time.Time) {
+	inventoryObj := newMemoryInventory(t)
+	err := inventoryObj.AddSKU("otherSku", 10, 20000, 25000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	sales := map[string][]service.SaleItem{
+		"INV-1": {{Sku: "dummySku", Quantity: 2}, {Sku: "otherSku", Quantity: 1}},
+		"INV-2": {{Sku: "dummySku", Quantity: 3}},
+	}
+	for invoiceID, items := range sales {
+		_, err := inventoryObj.CreateSale(invoiceID, "", items)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		_, err = inventoryObj.UpdateSale(invoiceID, model.SalesStatusDone, "dummyUser")
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	_, err = inventoryObj.ReturnSale("INV-2", "RET-1", "", []service.ReturnItem{{Sku: "dummySku", Quantity: 1}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	err = inventoryObj.SalesDatamapper.Insert(&model.Sales{
+		InvoiceID: "INV-0",
+		Date:      today.AddDate(0, 0, -7).Add(10 * time.Hour),
+		Status:    model.SalesStatusDone,
+		Items: map[string]*model.SaleItem{
+			"dummySku": {Sku: "dummySku", Quantity: 4, BuyPrice: 50000, SellPrice: 55000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj, today
+}
+
+func TestGetSalesReport(t *testing.T) {
+	inventoryObj, today := newSalesReportInventory(t)
+	startDate := today.AddDate(0, 0, -6)
+
+	_, err := inventoryObj.GetSalesReport(today, startDate, service.GroupBySku, false)
+	_, errGroup := inventoryObj.GetSalesReport(startDate, today, "year", false)
+	t.Run("invalid dates and group by must be rejected", func(t *testing.T) {
+		if err == nil || errGroup == nil {
+			t.Errorf("expected errors but got %v and %v", err, errGroup)
+		}
+	})
+
+	report, err := inventoryObj.GetSalesReport(startDate, today, service.GroupBySku, true)
+	t.Run("every sku must be reported once with returned items netted out", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if report.SaleCount != 2 || report.ReturnCount != 1 || len(report.Rows) != 2 {
+			t.Fatalf("expected 2 sales, a return and 2 rows but got %+v", report)
+		}
+		row := report.Rows[0]
+		if row.Key != "dummySku" || row.Quantity != 4 || row.SalesTurnOver != 240000 || row.COGS != 200000 || row.Profit != 40000 || row.Margin != 16.67 {
+			t.Errorf("expected 4 dummySku items with 16.67%% margin but got %+v", row.SalesFigures)
+		}
+		totals := report.Totals
+		if totals.Quantity != 5 || totals.SalesTurnOver != 265000 || totals.COGS != 220000 || totals.Profit != 45000 || totals.Margin != 16.98 {
+			t.Errorf("expected 5 items with 16.98%% margin but got %+v", totals)
+		}
+	})
+	t.Run("compared report must hold the figures of the previous period", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if report.Previous == nil || false == report.Previous.EndDate.Equal(startDate.AddDate(0, 0, -1)) || report.Previous.SaleCount != 1 || report.Previous.Totals.SalesTurnOver != 220000 {
+			t.Fatalf("expected a sale of 220000 in the previous week but got %+v", report.Previous)
+		}
+		if report.Rows[0].Previous.Quantity != 4 || report.Rows[0].Previous.Margin != 9.09 || report.Rows[1].Previous.Quantity != 0 {
+			t.Errorf("expected 4 and 0 items in the previous week but got %+v and %+v", report.Rows[0].Previous, report.Rows[1].Previous)
+		}
+	})
+
+	report, err = inventoryObj.GetSalesReport(startDate, today, service.GroupByDay, true)
+	t.Run("every day must be reported and compared to the day at the same position", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(report.Rows) != 7 || report.Rows[0].Key != startDate.Format("2006-01-02") || report.Rows[0].Quantity != 0 {
+			t.Fatalf("expected 7 days from %v but got %+v", startDate.Format("2006-01-02"), report.Rows)
+		}
+		//a week ago is the last day of the previous period
+		if report.Rows[6].Quantity != 5 || report.Rows[6].Previous.Quantity != 4 || report.Rows[0].Previous.Quantity != 0 {
+			t.Errorf("expected 5 items today and 4 items a week ago but got %+v and %+v", report.Rows[6], report.Rows[6].Previous)
+		}
+	})
+
+	report, err = inventoryObj.GetSalesReport(startDate, today, service.GroupByProduct, false)
+	t.Run("items which aren't variants must be reported under an empty product", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(report.Rows) != 1 || report.Rows[0].Key != "" || report.Rows[0].Quantity != 5 || report.Rows[0].Previous != nil {
+			t.Errorf("expected a single row of 5 items but got %+v", report.Rows)
+		}
+	})
+}
+
+func TestGetSalesReportDump(t *testing.T) {
+	inventoryObj := newDumpInventory(t)
+	startDate := time.Date(2017, 12, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	report, err := inventoryObj.GetSalesReport(startDate, endDate, service.GroupBySku, false)
+	t.Run("sku sold by 2 sales must be reported once", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		found := 0
+		for _, val := range report.Rows {
+			if val.Key != "SSI-D00791015-LL-BWH" {
+				continue
+			}
+			found++
+			if val.Quantity != 9 || val.SalesTurnOver != 547000 || val.COGS != 464000 || val.Profit != 83000 || val.Margin != 15.17 {
+				t.Errorf("expected 9 items with 15.17%% margin but got %+v", val.SalesFigures)
+			}
+		}
+		if found != 1 || len(report.Rows) != 4 {
+			t.Errorf("expected 4 rows with a single SSI-D00791015-LL-BWH row but got %+v", report.Rows)
+		}
+	})
+
+	report, err = inventoryObj.GetSalesReport(startDate, endDate, service.GroupByMonth, true)
+	t.Run("month must hold every completed sale", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(report.Rows) != 1 || report.Rows[0].Key != "2017-12-01" || report.SaleCount != 3 {
+			t.Fatalf("expected a month of 3 sales but got %+v", report)
+		}
+		if report.Rows[0].Quantity != 54 || report.Rows[0].SalesTurnOver != 4074200 || report.Rows[0].COGS != 3766000 || report.Previous.Totals.Quantity != 0 {
+			t.Errorf("expected 54 items worth 4074200 but got %+v", report.Rows[0])
+		}
+	})
+}
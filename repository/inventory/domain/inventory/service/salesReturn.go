@@ -0,0 +1,156 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//ReturnItem is a definition of items returned by a customer
+type ReturnItem struct {
+	Sku      string `json:"sku"`
+	Quantity int64  `json:"quantity"`
+}
+
+//getReturnedQuantities is a function for obtaining the quantity already returned of every sku of a sale (inside the passed unit of work)
+func (i *Inventory) getReturnedQuantities(uow *UnitOfWork, invoiceNo string) (map[string]int64, *errors.Error) {
+	returnFinder, ok := uow.SalesReturnDatamapper.(datamapper.SalesReturnFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting sales return mapper"), 0)
+	}
+	salesReturns, err := returnFinder.FindByInvoiceID(invoiceNo)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	returnedQuantities := make(map[string]int64, 0)
+	for _, val := range salesReturns {
+		valObj, ok := val.(*model.SalesReturn)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		for _, itemVal := range valObj.Items {
+			returnedQuantities[itemVal.Sku] += itemVal.Quantity
+		}
+	}
+	return returnedQuantities, nil
+}
+
+//ReturnSale is a function for recording items returned by a customer against a completed sale
+//Returned quantities are put back to stock. An item can be returned (possibly in several returns) up to the quantity sold
+func (i *Inventory) ReturnSale(invoiceNo, returnID, note string, items []ReturnItem) (bool, *errors.Error) {
+	if returnID == "" {
+		return false, errors.Wrap(fmt.Errorf("Return id must not be empty"), 0)
+	}
+	if len(items) == 0 {
+		return false, errors.Wrap(fmt.Errorf("Cannot return sale. Return has no items"), 0)
+	}
+	err := i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		existingReturn, _ := uow.SalesReturnDatamapper.FindByID(returnID)
+		if existingReturn != nil {
+			return errors.Wrap(fmt.Errorf("Return %v already exists", returnID), 0)
+		}
+		foundSale, err := uow.SalesDatamapper.FindByID(invoiceNo)
+		if err != nil {
+			if err.Err == datamapper.ErrNotFound {
+				return errors.Wrap(fmt.Errorf("Sale %v is not found", invoiceNo), 0)
+			}
+			return errors.Wrap(err, 0)
+		}
+		foundSaleObj, ok := foundSale.(*model.Sales)
+		if false == ok {
+			return errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		if foundSaleObj.Status != model.SalesStatusDone {
+			return errors.Wrap(fmt.Errorf("Sale %v is not done, only items of a done sale can be returned", invoiceNo), 0)
+		}
+		returnedQuantities, err := i.getReturnedQuantities(uow, invoiceNo)
+		if err != nil {
+			return err
+		}
+
+		//compose the return, validating returned quantities against the sale items
+		newReturn := &model.SalesReturn{
+			ReturnID:  returnID,
+			InvoiceID: invoiceNo,
+			Date:      time.Now(),
+			Note:      note,
+			Items:     make(map[string]*model.SalesReturnItem, 0),
+		}
+		for _, val := range items {
+			saleItem, exists := foundSaleObj.Items[val.Sku]
+			if false == exists {
+				return errors.Wrap(fmt.Errorf("Sku %v is not part of sale %v", val.Sku, invoiceNo), 0)
+			}
+			if _, exists := newReturn.Items[val.Sku]; exists {
+				return errors.Wrap(fmt.Errorf("Sku %v is returned more than once in return %v", val.Sku, returnID), 0)
+			}
+			if val.Quantity <= 0 {
+				return errors.Wrap(fmt.Errorf("Invalid returned quantity for Sku %v", val.Sku), 0)
+			}
+			returnableQuantity := saleItem.Quantity - returnedQuantities[val.Sku]
+			if val.Quantity > returnableQuantity {
+				return errors.Wrap(fmt.Errorf("Returned quantity %v of Sku %v exceeds returnable quantity %v", val.Quantity, val.Sku, returnableQuantity), 0)
+			}
+			newReturn.Items[val.Sku] = &model.SalesReturnItem{
+				SaleItemID: saleItem.GetID(),
+				Sku:        val.Sku,
+				Quantity:   val.Quantity,
+				BuyPrice:   saleItem.BuyPrice,
+				SellPrice:  saleItem.SellPrice,
+			}
+		}
+
+		for _, val := range newReturn.Items {
+			//update stock quantity
+			stockObj, err := uow.getStock(val.Sku)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			stockObj.Quantity += val.Quantity
+			err = uow.StockDatamapper.Update(stockObj)
+			if err != nil {
+				return errors.Wrap(fmt.Errorf("Sku: %v stock update failed: %v", stockObj.Sku, err), 0)
+			}
+			err = i.recordMovement(uow, val.Sku, val.Quantity, stockObj.BuyPrice, model.MovementReasonSaleReturn, invoiceNo, "return "+returnID)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			//returned items go back to stock at the cost they were sold with
+			err = i.addCostLayer(uow, val.Sku, val.Quantity, val.BuyPrice, invoiceNo)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+		}
+		return uow.SalesReturnDatamapper.Insert(newReturn)
+	})
+	if err != nil {
+		return false, errors.Wrap(err, 0)
+	}
+	return true, nil
+}
+
+//GetSalesReturns is a function for obtaining all returns of a sale
+func (i *Inventory) GetSalesReturns(invoiceNo string) ([]*model.SalesReturn, *errors.Error) {
+	returnFinder, ok := i.SalesReturnDatamapper.(datamapper.SalesReturnFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting sales return mapper"), 0)
+	}
+	salesReturns, err := returnFinder.FindByInvoiceID(invoiceNo)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	returnList := make([]*model.SalesReturn, 0, len(salesReturns))
+	for _, val := range salesReturns {
+		valObj, ok := val.(*model.SalesReturn)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		returnList = append(returnList, valObj)
+	}
+	return returnList, nil
+}
@@ -0,0 +1,129 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+)
+
+func TestReturnSale(t *testing.T) {
+	//a done sale of 5 dummySku items (bought at 50000 and sold at 60000) leaves 5 items in stock
+	inventoryObj := newMemoryInventory(t)
+	createDoneSale(t, inventoryObj, "dummyInvoice", []service.SaleItem{{Sku: "dummySku", Quantity: 5}})
+
+	//successful case (partial return)
+	ok, err := inventoryObj.ReturnSale("dummyInvoice", "dummyReturn1", "", []service.ReturnItem{{Sku: "dummySku", Quantity: 2}})
+	t.Run("return must be true", func(t *testing.T) {
+		if true != ok {
+			t.Errorf("expected true but got %v", ok)
+		}
+	})
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	t.Run("returned quantity must be put back to stock", func(t *testing.T) {
+		if itemInfo.Quantity != 7 {
+			t.Errorf("expected %v but got %v", 7, itemInfo.Quantity)
+		}
+	})
+	returns, _ := inventoryObj.GetSalesReturns("dummyInvoice")
+	t.Run("return must keep the prices of the sale item", func(t *testing.T) {
+		if len(returns) != 1 {
+			t.Fatalf("expected 1 return but got %v", len(returns))
+		}
+		returnedItem := returns[0].Items["dummySku"]
+		if returnedItem.BuyPrice != 50000 || returnedItem.SellPrice != 60000 {
+			t.Errorf("expected 50000 and 60000 but got %v and %v", returnedItem.BuyPrice, returnedItem.SellPrice)
+		}
+		if returns[0].GetRefundAmount() != 120000 {
+			t.Errorf("expected 120000 but got %v", returns[0].GetRefundAmount())
+		}
+	})
+
+	//failed cases, nothing is written
+	failedReturns := map[string][]service.ReturnItem{
+		"more than returnable quantity": {{Sku: "dummySku", Quantity: 4}},
+		"sku not part of the sale":      {{Sku: "dummySku2", Quantity: 1}},
+		"zero quantity":                 {{Sku: "dummySku", Quantity: 0}},
+		"no items":                      {},
+	}
+	for caseName, items := range failedReturns {
+		failedOk, failedErr := inventoryObj.ReturnSale("dummyInvoice", "dummyReturn2", "", items)
+		t.Run("Failed return ("+caseName+") must be rejected", func(t *testing.T) {
+			if false != failedOk {
+				t.Errorf("expected false but got %v", failedOk)
+			}
+			if getType(failedErr) != "*Error" {
+				t.Errorf("expected *Error but got %v", getType(failedErr))
+			}
+		})
+	}
+	itemInfo, _ = inventoryObj.GetItemInfo("dummySku")
+	returns, _ = inventoryObj.GetSalesReturns("dummyInvoice")
+	t.Run("Failed returns must not change stock", func(t *testing.T) {
+		if itemInfo.Quantity != 7 {
+			t.Errorf("expected %v but got %v", 7, itemInfo.Quantity)
+		}
+		if len(returns) != 1 {
+			t.Errorf("expected 1 return but got %v", len(returns))
+		}
+	})
+
+	//the remaining quantity can still be returned
+	ok, err = inventoryObj.ReturnSale("dummyInvoice", "dummyReturn2", "", []service.ReturnItem{{Sku: "dummySku", Quantity: 3}})
+	t.Run("remaining quantity return must be successful", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Errorf("expected true and nil but got %v and %v", ok, err)
+		}
+	})
+	ok, _ = inventoryObj.ReturnSale("dummyInvoice", "dummyReturn3", "", []service.ReturnItem{{Sku: "dummySku", Quantity: 1}})
+	t.Run("fully returned sale must not accept more returns", func(t *testing.T) {
+		if false != ok {
+			t.Errorf("expected false but got %v", ok)
+		}
+	})
+}
+
+func TestReturnDraftSale(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	_, err := inventoryObj.CreateSale("dummyInvoice", "", []service.SaleItem{{Sku: "dummySku", Quantity: 5}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	ok, err := inventoryObj.ReturnSale("dummyInvoice", "dummyReturn", "", []service.ReturnItem{{Sku: "dummySku", Quantity: 1}})
+	t.Run("return must be false", func(t *testing.T) {
+		if false != ok {
+			t.Errorf("expected false but got %v", ok)
+		}
+	})
+	t.Run("err returned must type must be correct", func(t *testing.T) {
+		if getType(err) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(err))
+		}
+	})
+}
+
+func TestCancelSaleWithReturns(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	createDoneSale(t, inventoryObj, "dummyInvoice", []service.SaleItem{{Sku: "dummySku", Quantity: 5}})
+	_, err := inventoryObj.ReturnSale("dummyInvoice", "dummyReturn", "", []service.ReturnItem{{Sku: "dummySku", Quantity: 1}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	ok, err := inventoryObj.UpdateSale("dummyInvoice", model.SalesStatusCanceled, "dummyUser")
+	t.Run("return must be false", func(t *testing.T) {
+		if false != ok {
+			t.Errorf("expected false but got %v", ok)
+		}
+	})
+	t.Run("err returned must type must be correct", func(t *testing.T) {
+		if getType(err) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(err))
+		}
+	})
+}
@@ -0,0 +1,302 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	log "github.com/sirupsen/logrus"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//AlertNotifier is an interface for delivering low stock alerts (e.g. to the app log, a webhook or an email address, see package notifier)
+type AlertNotifier interface {
+	Notify(alert *model.StockAlert) error
+}
+
+//AlertStatusOpen is const for listing the alerts which haven't been resolved (see ListAlerts)
+const AlertStatusOpen string = "open"
+
+//AlertStatusResolved is const for listing the resolved alerts (see ListAlerts)
+const AlertStatusResolved string = "resolved"
+
+//alert changes made by evaluateStockAlert
+const (
+	alertUnchanged = iota
+	alertRaised
+	alertEscalated
+	alertResolved
+)
+
+//StockAlertInfo is a struct containing a low stock alert
+type StockAlertInfo struct {
+	AlertID         string     `json:"alertId"`
+	Sku             string     `json:"sku"`
+	Level           string     `json:"level"`    //low or critical (see model.AlertLevel consts)
+	Quantity        int64      `json:"quantity"` //stock quantity when the alert was last checked
+	ReorderPoint    int64      `json:"reorderPoint"`
+	ReorderQuantity int64      `json:"reorderQuantity"`
+	SafetyStock     int64      `json:"safetyStock"`
+	RaisedAt        time.Time  `json:"raisedAt"`
+	ResolvedAt      *time.Time `json:"resolvedAt,omitempty"` //missing while the alert is open
+}
+
+//AlertCheck is a struct containing the outcome of checking stock of every item against its reorder point (see CheckStockAlerts)
+type AlertCheck struct {
+	Date      time.Time `json:"date"`
+	Checked   int       `json:"checked"` //count of items whose stock is watched or which had an open alert
+	Raised    int       `json:"raised"`
+	Escalated int       `json:"escalated"`
+	Resolved  int       `json:"resolved"`
+	Open      int       `json:"open"` //count of alerts still open after the check
+}
+
+//notifyAlerts is a function for delivering the given alerts through every notifier (see AlertSender)
+//a failed delivery doesn't undo the alert (it stays listed), it's written to the app log instead
+func notifyAlerts(notifiers []AlertNotifier, alerts []*model.StockAlert) {
+	for _, alertObj := range alerts {
+		for _, val := range notifiers {
+			err := val.Notify(alertObj)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"alertId": alertObj.GetID(),
+					"sku":     alertObj.Sku,
+					"level":   alertObj.Level,
+					"error":   err.Error(),
+				}).Error("Stock alert delivery failed")
+			}
+		}
+	}
+}
+
+//evaluateStockAlert is a function for raising, escalating or resolving the alert of an item according to its stock (inside the passed unit of work)
+//openAlert is the open alert of the item (nil when it has none), raised and escalated alerts are delivered once the unit of work is committed
+func evaluateStockAlert(uow *UnitOfWork, stockObj *model.Stock, openAlert *model.StockAlert) (int, *errors.Error) {
+	level := stockObj.AlertLevel()
+	if openAlert == nil {
+		if level == "" {
+			return alertUnchanged, nil
+		}
+		newAlert := &model.StockAlert{
+			Sku:             stockObj.Sku,
+			Level:           level,
+			Quantity:        stockObj.Quantity,
+			ReorderPoint:    stockObj.ReorderPoint,
+			ReorderQuantity: stockObj.ReorderQuantity,
+			SafetyStock:     stockObj.SafetyStock,
+			RaisedAt:        time.Now(),
+		}
+		err := uow.StockAlertDatamapper.Insert(newAlert)
+		if err != nil {
+			return alertUnchanged, err
+		}
+		uow.raisedAlerts = append(uow.raisedAlerts, newAlert)
+		return alertRaised, nil
+	}
+
+	change := alertUnchanged
+	switch {
+	case level == "":
+		openAlert.ResolvedAt = time.Now()
+		change = alertResolved
+	case level == model.AlertLevelCritical && openAlert.Level != model.AlertLevelCritical:
+		change = alertEscalated
+	case level == openAlert.Level && stockObj.Quantity == openAlert.Quantity && stockObj.ReorderPoint == openAlert.ReorderPoint &&
+		stockObj.ReorderQuantity == openAlert.ReorderQuantity && stockObj.SafetyStock == openAlert.SafetyStock:
+		//nothing to update
+		return alertUnchanged, nil
+	}
+	if change != alertResolved {
+		openAlert.Level = level
+		openAlert.Quantity = stockObj.Quantity
+		openAlert.ReorderPoint = stockObj.ReorderPoint
+		openAlert.ReorderQuantity = stockObj.ReorderQuantity
+		openAlert.SafetyStock = stockObj.SafetyStock
+	}
+	err := uow.StockAlertDatamapper.Update(openAlert)
+	if err != nil {
+		return alertUnchanged, err
+	}
+	if change == alertEscalated {
+		uow.raisedAlerts = append(uow.raisedAlerts, openAlert)
+	}
+	return change, nil
+}
+
+//findOpenAlert is a function for obtaining the open alert of a sku inside the unit of work (nil when the sku has no open alert)
+func (uow *UnitOfWork) findOpenAlert(sku string) (*model.StockAlert, *errors.Error) {
+	alertFinder, ok := uow.StockAlertDatamapper.(datamapper.StockAlertFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting stock alert mapper"), 0)
+	}
+	foundAlert, err := alertFinder.FindOpenBySku(sku)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	foundAlertObj, ok := foundAlert.(*model.StockAlert)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	return foundAlertObj, nil
+}
+
+//checkStockDrop is a function for checking an item whose stock just dropped against its reorder point (inside the passed unit of work)
+//items without reorder point aren't watched, alerts are resolved by the scheduled check once stock is replenished (see CheckStockAlerts)
+func (i *Inventory) checkStockDrop(uow *UnitOfWork, stockObj *model.Stock) *errors.Error {
+	if stockObj.ReorderPoint <= 0 {
+		return nil
+	}
+	openAlert, err := uow.findOpenAlert(stockObj.Sku)
+	if err != nil {
+		return err
+	}
+	_, err = evaluateStockAlert(uow, stockObj, openAlert)
+	if err != nil {
+		return errors.Wrap(fmt.Errorf("Sku: %v stock alert check failed: %v", stockObj.Sku, err), 0)
+	}
+	return nil
+}
+
+//CheckStockAlerts is a function for checking stock of every item against its reorder point (run on a schedule)
+//alerts are raised for items at or below their reorder point, escalated once stock drops to the safety stock and resolved once stock is above the reorder point
+func (i *Inventory) CheckStockAlerts() (*AlertCheck, *errors.Error) {
+	alertCheck := &AlertCheck{Date: time.Now()}
+	err := i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		alertFinder, ok := uow.StockAlertDatamapper.(datamapper.StockAlertFinder)
+		if false == ok {
+			return errors.Wrap(fmt.Errorf("Failed asserting stock alert mapper"), 0)
+		}
+		openAlerts, err := alertFinder.FindOpen()
+		if err != nil && err.Err != datamapper.ErrNotFound {
+			return err
+		}
+		openAlertsBySku := make(map[string]*model.StockAlert, len(openAlerts))
+		for _, val := range openAlerts {
+			valObj, ok := val.(*model.StockAlert)
+			if false == ok {
+				return errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+			}
+			openAlertsBySku[valObj.Sku] = valObj
+		}
+		items, err := uow.StockDatamapper.FindAll()
+		if err != nil && err.Err != datamapper.ErrNotFound {
+			return err
+		}
+
+		alertCheck.Open = len(openAlertsBySku)
+		for _, val := range items {
+			stockObj, ok := val.(*model.Stock)
+			if false == ok {
+				return errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+			}
+			openAlert := openAlertsBySku[stockObj.Sku]
+			if stockObj.ReorderPoint <= 0 && openAlert == nil {
+				continue
+			}
+			alertCheck.Checked++
+			change, err := evaluateStockAlert(uow, stockObj, openAlert)
+			if err != nil {
+				return errors.Wrap(fmt.Errorf("Sku: %v stock alert check failed: %v", stockObj.Sku, err), 0)
+			}
+			switch change {
+			case alertRaised:
+				alertCheck.Raised++
+				alertCheck.Open++
+			case alertEscalated:
+				alertCheck.Escalated++
+			case alertResolved:
+				alertCheck.Resolved++
+				alertCheck.Open--
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return alertCheck, nil
+}
+
+//UpdateReorderSettings is a function for setting the reorder point, reorder quantity and safety stock of an item
+//a reorder point of 0 stops watching stock of the item, the alert of the item is raised or resolved right away according to the new settings
+func (i *Inventory) UpdateReorderSettings(sku string, reorderPoint, reorderQuantity, safetyStock int64) *errors.Error {
+	if reorderPoint < 0 || reorderQuantity < 0 || safetyStock < 0 {
+		return errors.Wrap(fmt.Errorf("Reorder point, reorder quantity and safety stock must not be negative"), 0)
+	}
+	if safetyStock > reorderPoint {
+		return errors.Wrap(fmt.Errorf("Safety stock %v must not be greater than reorder point %v", safetyStock, reorderPoint), 0)
+	}
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		stockObj, err := uow.getStock(sku)
+		if err != nil {
+			return err
+		}
+		stockObj.ReorderPoint = reorderPoint
+		stockObj.ReorderQuantity = reorderQuantity
+		stockObj.SafetyStock = safetyStock
+		err = uow.StockDatamapper.Update(stockObj)
+		if err != nil {
+			return err
+		}
+		openAlert, err := uow.findOpenAlert(sku)
+		if err != nil {
+			return err
+		}
+		_, err = evaluateStockAlert(uow, stockObj, openAlert)
+		return err
+	})
+}
+
+//ListAlerts is a function for obtaining the low stock alerts of the given status (see AlertStatus consts, empty for every alert), oldest alert first
+func (i *Inventory) ListAlerts(status string) ([]*StockAlertInfo, *errors.Error) {
+	var alerts []model.Model
+	var err *errors.Error
+	switch status {
+	case AlertStatusOpen:
+		alertFinder, ok := i.StockAlertDatamapper.(datamapper.StockAlertFinder)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting stock alert mapper"), 0)
+		}
+		alerts, err = alertFinder.FindOpen()
+	case AlertStatusResolved, "":
+		alerts, err = i.StockAlertDatamapper.FindAll()
+	default:
+		return nil, errors.Wrap(fmt.Errorf("Invalid status %v from param", status), 0)
+	}
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+
+	alertList := make([]*StockAlertInfo, 0, len(alerts))
+	for _, val := range alerts {
+		alertObj, ok := val.(*model.StockAlert)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		if status == AlertStatusResolved && true == alertObj.IsOpen() {
+			continue
+		}
+		alertInfo := &StockAlertInfo{
+			AlertID:         alertObj.GetID(),
+			Sku:             alertObj.Sku,
+			Level:           alertObj.Level,
+			Quantity:        alertObj.Quantity,
+			ReorderPoint:    alertObj.ReorderPoint,
+			ReorderQuantity: alertObj.ReorderQuantity,
+			SafetyStock:     alertObj.SafetyStock,
+			RaisedAt:        alertObj.RaisedAt,
+		}
+		if false == alertObj.IsOpen() {
+			resolvedAt := alertObj.ResolvedAt
+			alertInfo.ResolvedAt = &resolvedAt
+		}
+		alertList = append(alertList, alertInfo)
+	}
+	return alertList, nil
+}
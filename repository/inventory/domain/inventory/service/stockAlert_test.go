@@ -0,0 +1,230 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+//recordingNotifier is a notifier keeping the levels of the delivered alerts
+type recordingNotifier struct {
+	levels []string
+	fail   bool
+}
+
+func (n *recordingNotifier) Notify(alert *model.StockAlert) error {
+	n.levels = append(n.levels, alert.Level)
+	if true == n.fail {
+		return fmt.Errorf("delivery failed")
+	}
+	return nil
+}
+
+//newAlertInventory is a function for composing an inventory service on a memory store whose dummySku (10 items) is reordered at 5 and critical at 2
+//alerts are delivered in the background, so the deliveries must be waited for (see AlertSender.Wait) before checking them
+func newAlertInventory(t *testing.T, notifiers ...service.AlertNotifier) *service.Inventory {
+	inventoryObj := newMemoryInventory(t)
+	inventoryObj.AlertSender = service.NewAlertSender(notifiers, service.DefaultAlertQueueSize)
+	err := inventoryObj.UpdateReorderSettings("dummySku", 5, 20, 2)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj
+}
+
+func TestUpdateReorderSettings(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
+	errNegative := inventoryObj.UpdateReorderSettings("dummySku", -1, 0, 0)
+	errSafety := inventoryObj.UpdateReorderSettings("dummySku", 5, 20, 6)
+	t.Run("invalid settings must be rejected", func(t *testing.T) {
+		if errNegative == nil || errSafety == nil {
+			t.Errorf("expected errors but got %v and %v", errNegative, errSafety)
+		}
+	})
+
+	err := inventoryObj.UpdateReorderSettings("dummySku", 10, 20, 2)
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	alerts, errs := inventoryObj.ListAlerts(service.AlertStatusOpen)
+	t.Run("item already at its reorder point must be alerted right away", func(t *testing.T) {
+		if err != nil || errs != nil {
+			t.Fatalf("expected nil but got %v and %v", err, errs)
+		}
+		if itemInfo.ReorderPoint != 10 || itemInfo.ReorderQuantity != 20 || itemInfo.SafetyStock != 2 {
+			t.Errorf("expected reorder point 10, reorder quantity 20 and safety stock 2 but got %+v", itemInfo)
+		}
+		if len(alerts) != 1 || alerts[0].Level != model.AlertLevelLow || alerts[0].Quantity != 10 {
+			t.Errorf("expected low alert of 10 items but got %+v", alerts)
+		}
+	})
+
+	err = inventoryObj.UpdateReorderSettings("dummySku", 0, 0, 0)
+	alerts, _ = inventoryObj.ListAlerts(service.AlertStatusResolved)
+	t.Run("alert must be resolved once the item isn't watched", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(alerts) != 1 || alerts[0].ResolvedAt == nil {
+			t.Errorf("expected a resolved alert but got %+v", alerts)
+		}
+	})
+}
+
+func TestStockDropAlerts(t *testing.T) {
+	notifierObj := &recordingNotifier{}
+	inventoryObj := newAlertInventory(t, notifierObj)
+
+	err := inventoryObj.AdjustStock("dummySku", -4, "", "broken items")
+	inventoryObj.AlertSender.Wait()
+	alerts, _ := inventoryObj.ListAlerts("")
+	t.Run("stock above the reorder point must not be alerted", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(alerts) != 0 || len(notifierObj.levels) != 0 {
+			t.Errorf("expected no alert but got %+v and %v", alerts, notifierObj.levels)
+		}
+	})
+
+	_, err = inventoryObj.CreateSale("INV-1", "", []service.SaleItem{{Sku: "dummySku", Quantity: 1}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.UpdateSale("INV-1", model.SalesStatusDone, "dummyUser")
+	inventoryObj.AlertSender.Wait()
+	alerts, _ = inventoryObj.ListAlerts(service.AlertStatusOpen)
+	t.Run("completed sale dropping stock to the reorder point must raise an alert", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(alerts) != 1 || alerts[0].Level != model.AlertLevelLow || alerts[0].Quantity != 5 || alerts[0].ReorderQuantity != 20 {
+			t.Fatalf("expected low alert of 5 items but got %+v", alerts)
+		}
+		if len(notifierObj.levels) != 1 || notifierObj.levels[0] != model.AlertLevelLow {
+			t.Errorf("expected low alert delivered but got %v", notifierObj.levels)
+		}
+	})
+
+	err = inventoryObj.UpdateSKU("dummySku", 4, 50000, 60000)
+	inventoryObj.AlertSender.Wait()
+	alerts, _ = inventoryObj.ListAlerts(service.AlertStatusOpen)
+	t.Run("open alert must be kept up to date without being delivered again", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(alerts) != 1 || alerts[0].Quantity != 4 || len(notifierObj.levels) != 1 {
+			t.Errorf("expected the same alert of 4 items but got %+v and %v", alerts, notifierObj.levels)
+		}
+	})
+
+	err = inventoryObj.AdjustStock("dummySku", -2, "", "lost items")
+	inventoryObj.AlertSender.Wait()
+	alerts, _ = inventoryObj.ListAlerts(service.AlertStatusOpen)
+	t.Run("stock dropping to the safety stock must escalate the alert", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(alerts) != 1 || alerts[0].Level != model.AlertLevelCritical || alerts[0].Quantity != 2 {
+			t.Fatalf("expected critical alert of 2 items but got %+v", alerts)
+		}
+		if len(notifierObj.levels) != 2 || notifierObj.levels[1] != model.AlertLevelCritical {
+			t.Errorf("expected critical alert delivered but got %v", notifierObj.levels)
+		}
+	})
+}
+
+func TestCheckStockAlerts(t *testing.T) {
+	notifierObj := &recordingNotifier{fail: true}
+	inventoryObj := newAlertInventory(t, notifierObj)
+	err := inventoryObj.AddSKU("otherSku", 1, 40000, 45000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.UpdateReorderSettings("otherSku", 3, 10, 0)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.AdjustStock("dummySku", -6, "", "broken items")
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	_, errs := inventoryObj.CreatePurchase("PO-1", "", "", []service.PurchaseItem{{Sku: "otherSku", Quantity: 5, BuyPrice: 40000}})
+	if errs != nil {
+		t.Fatalf("expected nil but got %v", errs)
+	}
+	_, errs = inventoryObj.ReceivePurchase("PO-1", "RCV-1", "", []service.ReceiptItem{{Sku: "otherSku", Quantity: 5}}, false)
+	if errs != nil {
+		t.Fatalf("expected nil but got %v", errs)
+	}
+
+	alertCheck, errs := inventoryObj.CheckStockAlerts()
+	inventoryObj.AlertSender.Wait()
+	openAlerts, _ := inventoryObj.ListAlerts(service.AlertStatusOpen)
+	resolvedAlerts, _ := inventoryObj.ListAlerts(service.AlertStatusResolved)
+	t.Run("check must resolve alerts of replenished items and keep the others open", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
+		if alertCheck.Checked != 2 || alertCheck.Resolved != 1 || alertCheck.Raised != 0 || alertCheck.Open != 1 {
+			t.Errorf("expected 2 checked items, 1 resolved and 1 open alert but got %+v", alertCheck)
+		}
+		if len(openAlerts) != 1 || openAlerts[0].Sku != "dummySku" || len(resolvedAlerts) != 1 || resolvedAlerts[0].Sku != "otherSku" {
+			t.Errorf("expected open alert of dummySku and resolved alert of otherSku but got %+v and %+v", openAlerts, resolvedAlerts)
+		}
+		if len(notifierObj.levels) != 2 {
+			t.Errorf("expected 2 delivery attempts (failures are only logged) but got %v", notifierObj.levels)
+		}
+	})
+
+	_, errs = inventoryObj.ListAlerts("closed")
+	t.Run("invalid status must be rejected", func(t *testing.T) {
+		if errs == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+//blockingNotifier is a notifier which doesn't return until it's released
+type blockingNotifier struct {
+	release chan bool
+}
+
+func (n *blockingNotifier) Notify(alert *model.StockAlert) error {
+	<-n.release
+	return nil
+}
+
+func TestAlertDeliveryInBackground(t *testing.T) {
+	notifierObj := &blockingNotifier{release: make(chan bool)}
+	inventoryObj := newAlertInventory(t, notifierObj)
+
+	//the stock drop must be committed while the delivery of its alert is still blocked
+	done := make(chan *errors.Error)
+	go func() {
+		done <- inventoryObj.AdjustStock("dummySku", -6, "", "broken items")
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		close(notifierObj.release)
+		t.Fatalf("expected stock adjustment not to wait for the alert delivery")
+	}
+	alerts, _ := inventoryObj.ListAlerts(service.AlertStatusOpen)
+	t.Run("alert must be raised before it's delivered", func(t *testing.T) {
+		if len(alerts) != 1 || alerts[0].Level != model.AlertLevelLow {
+			t.Errorf("expected low alert but got %+v", alerts)
+		}
+	})
+	close(notifierObj.release)
+	inventoryObj.AlertSender.Wait()
+}
@@ -0,0 +1,259 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//CountItem is a definition of a physically counted item in a stock take
+type CountItem struct {
+	Sku      string `json:"sku"`
+	Quantity int64  `json:"quantity"`
+}
+
+//StockCountVariance is a struct containing variance information of a stock take
+type StockCountVariance struct {
+	CountID               string                    `json:"countId"`
+	Status                string                    `json:"status"`
+	Date                  time.Time                 `json:"date"`
+	CountedItemKind       int                       `json:"countedItemKind"`
+	UncountedItemKind     int                       `json:"uncountedItemKind"`
+	TotalQuantityVariance int64                     `json:"totalQuantityVariance"`
+	TotalValueVariance    model.Money               `json:"totalValueVariance"`
+	Items                 []*StockCountVarianceItem `json:"items"`
+}
+
+//StockCountVarianceItem is a struct containing variance of a specific Sku in a stock take
+//variance is counted quantity - system quantity (negative for missing items), priced at buy price
+type StockCountVarianceItem struct {
+	Sku              string      `json:"sku"`
+	Counted          bool        `json:"counted"`
+	SystemQuantity   int64       `json:"systemQuantity"`
+	CountedQuantity  int64       `json:"countedQuantity"`
+	QuantityVariance int64       `json:"quantityVariance"`
+	BuyPrice         model.Money `json:"buyPrice"`
+	ValueVariance    model.Money `json:"valueVariance"`
+}
+
+//getStockCount is a function for obtaining a stock count
+func (i *Inventory) getStockCount(countID string) (*model.StockCount, *errors.Error) {
+	return findStockCount(i.StockCountDatamapper, countID)
+}
+
+//findStockCount is a function for obtaining a stock take using the given stock count mapper (e.g. one bound to a unit of work)
+func findStockCount(countMapper datamapper.DataMapper, countID string) (*model.StockCount, *errors.Error) {
+	foundCount, err := countMapper.FindByID(countID)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return nil, errors.Wrap(fmt.Errorf("Stock count %v is not found", countID), 0)
+		}
+		return nil, errors.Wrap(err, 0)
+	}
+	foundCountObj, ok := foundCount.(*model.StockCount)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	return foundCountObj, nil
+}
+
+//OpenStockCount is a function for opening a new stock take of the given skus (all skus if none is given)
+func (i *Inventory) OpenStockCount(countID, note string, skus []string) (bool, *errors.Error) {
+	if countID == "" {
+		return false, errors.Wrap(fmt.Errorf("Stock count id must not be empty"), 0)
+	}
+	existingCount, _ := i.StockCountDatamapper.FindByID(countID)
+	if existingCount != nil {
+		return false, errors.Wrap(fmt.Errorf("Stock count %v already exists", countID), 0)
+	}
+
+	newCount := &model.StockCount{
+		CountID: countID,
+		Date:    time.Now(),
+		Status:  model.StockCountStatusOpen,
+		Note:    note,
+		Items:   make(map[string]*model.StockCountItem, 0),
+	}
+	if len(skus) == 0 {
+		//count all skus
+		currentStock, err := i.StockDatamapper.FindAll()
+		if err != nil {
+			return false, errors.Wrap(err, 0)
+		}
+		for _, val := range currentStock {
+			valObj, ok := val.(*model.Stock)
+			if false == ok {
+				return false, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+			}
+			newCount.Items[valObj.Sku] = &model.StockCountItem{Sku: valObj.Sku}
+		}
+	}
+	for _, val := range skus {
+		if _, exists := newCount.Items[val]; exists {
+			return false, errors.Wrap(fmt.Errorf("Sku %v is listed more than once in stock count %v", val, countID), 0)
+		}
+		_, err := i.StockDatamapper.FindByID(val)
+		if err != nil {
+			if err.Err == datamapper.ErrNotFound {
+				return false, errors.Wrap(fmt.Errorf("Cannot open stock count. Sku %v is not valid item", val), 0)
+			}
+			return false, errors.Wrap(err, 0)
+		}
+		newCount.Items[val] = &model.StockCountItem{Sku: val}
+	}
+	if len(newCount.Items) == 0 {
+		return false, errors.Wrap(fmt.Errorf("Cannot open stock count. No Sku available"), 0)
+	}
+
+	err := i.StockCountDatamapper.Insert(newCount)
+	if err != nil {
+		return false, errors.Wrap(err, 0)
+	}
+	return true, nil
+}
+
+//RecordStockCount is a function for recording physically counted quantities of items of an open stock take
+//recording an already counted item overwrites its counted quantity
+func (i *Inventory) RecordStockCount(countID string, items []CountItem) (bool, *errors.Error) {
+	if len(items) == 0 {
+		return false, errors.Wrap(fmt.Errorf("Cannot record stock count. No counted items"), 0)
+	}
+	foundCountObj, err := i.getStockCount(countID)
+	if err != nil {
+		return false, err
+	}
+	if foundCountObj.Status != model.StockCountStatusOpen {
+		return false, errors.Wrap(fmt.Errorf("Stock count %v is already posted", countID), 0)
+	}
+	for _, val := range items {
+		countItem, exists := foundCountObj.Items[val.Sku]
+		if false == exists {
+			return false, errors.Wrap(fmt.Errorf("Sku %v is not part of stock count %v", val.Sku, countID), 0)
+		}
+		if val.Quantity < 0 {
+			return false, errors.Wrap(fmt.Errorf("Invalid counted quantity for Sku %v", val.Sku), 0)
+		}
+		countItem.Counted = true
+		countItem.CountedQuantity = val.Quantity
+	}
+	err = i.StockCountDatamapper.Update(foundCountObj)
+	if err != nil {
+		return false, errors.Wrap(err, 0)
+	}
+	return true, nil
+}
+
+//GetStockCountVariance is a function for obtaining the variance report of a stock take
+//variance of an open count is computed against current stock, variance of a posted count against stock when it was posted
+func (i *Inventory) GetStockCountVariance(countID string) (*StockCountVariance, *errors.Error) {
+	foundCountObj, err := i.getStockCount(countID)
+	if err != nil {
+		return nil, err
+	}
+	countVariance := &StockCountVariance{
+		CountID: foundCountObj.CountID,
+		Status:  foundCountObj.Status,
+		Date:    foundCountObj.Date,
+		Items:   make([]*StockCountVarianceItem, 0, len(foundCountObj.Items)),
+	}
+	for _, val := range foundCountObj.Items {
+		systemQuantity := val.SystemQuantity
+		buyPrice := val.BuyPrice
+		if foundCountObj.Status == model.StockCountStatusOpen {
+			stockObj, err := i.GetItemInfo(val.Sku)
+			if err != nil {
+				return nil, errors.Wrap(err, 0)
+			}
+			systemQuantity = stockObj.Quantity
+			buyPrice = stockObj.BuyPrice
+		}
+		varianceItem := &StockCountVarianceItem{
+			Sku:             val.Sku,
+			Counted:         val.Counted,
+			SystemQuantity:  systemQuantity,
+			CountedQuantity: val.CountedQuantity,
+			BuyPrice:        buyPrice,
+		}
+		if true == val.Counted {
+			varianceItem.QuantityVariance = val.CountedQuantity - systemQuantity
+			varianceItem.ValueVariance = buyPrice.Multiply(varianceItem.QuantityVariance)
+			countVariance.CountedItemKind++
+		} else {
+			countVariance.UncountedItemKind++
+		}
+		countVariance.TotalQuantityVariance += varianceItem.QuantityVariance
+		countVariance.TotalValueVariance += varianceItem.ValueVariance
+		countVariance.Items = append(countVariance.Items, varianceItem)
+	}
+	sort.Slice(countVariance.Items, func(x, y int) bool {
+		return countVariance.Items[x].Sku < countVariance.Items[y].Sku
+	})
+	return countVariance, nil
+}
+
+//PostStockCount is a function for applying the variances of a fully counted stock take to stock
+//every variance is recorded as a stock opname movement, stock quantity and buy price at posting time are kept in the count
+func (i *Inventory) PostStockCount(countID string) (bool, *errors.Error) {
+	err := i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		foundCountObj, err := findStockCount(uow.StockCountDatamapper, countID)
+		if err != nil {
+			return err
+		}
+		if foundCountObj.Status != model.StockCountStatusOpen {
+			return errors.Wrap(fmt.Errorf("Stock count %v is already posted", countID), 0)
+		}
+		if false == foundCountObj.IsFullyCounted() {
+			return errors.Wrap(fmt.Errorf("Stock count %v still has uncounted items", countID), 0)
+		}
+
+		for _, val := range foundCountObj.Items {
+			stockObj, err := uow.getStock(val.Sku)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			val.SystemQuantity = stockObj.Quantity
+			val.BuyPrice = stockObj.BuyPrice
+
+			variance := val.CountedQuantity - stockObj.Quantity
+			if variance == 0 {
+				continue
+			}
+			if val.CountedQuantity < stockObj.Reserved {
+				//stock reserved by draft sales can't be counted away, the draft sales must be canceled first
+				return errors.Wrap(fmt.Errorf("Sku: %v counted quantity can't be less than its reserved quantity %v", val.Sku, stockObj.Reserved), 0)
+			}
+			stockObj.Quantity = val.CountedQuantity
+			err = uow.StockDatamapper.Update(stockObj)
+			if err != nil {
+				return errors.Wrap(fmt.Errorf("Sku: %v stock update failed: %v", stockObj.Sku, err), 0)
+			}
+			err = i.recordMovement(uow, val.Sku, variance, stockObj.BuyPrice, model.MovementReasonStockOpname, countID, "")
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			err = i.adjustCostLayers(uow, val.Sku, variance, stockObj.BuyPrice, countID)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			if variance < 0 {
+				err = i.checkStockDrop(uow, stockObj)
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+			}
+		}
+		foundCountObj.Status = model.StockCountStatusPosted
+		foundCountObj.PostedDate = time.Now()
+		return uow.StockCountDatamapper.Update(foundCountObj)
+	})
+	if err != nil {
+		return false, errors.Wrap(err, 0)
+	}
+	return true, nil
+}
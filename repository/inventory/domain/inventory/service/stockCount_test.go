@@ -0,0 +1,199 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+)
+
+//newCountInventory is a function for composing an inventory service on a memory store holding dummySku (10 items at 50000) and dummySku2 (120 items at 60000)
+func newCountInventory(t *testing.T) *service.Inventory {
+	inventoryObj := newMemoryInventory(t)
+	err := inventoryObj.AddSKU("dummySku2", 120, 60000, 63000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return inventoryObj
+}
+
+//getDummyStockCount is a function for obtaining the stock count with the given id straight from the datamapper
+func getDummyStockCount(t *testing.T, inventoryObj *service.Inventory, countID string) *model.StockCount {
+	countModel, err := inventoryObj.StockCountDatamapper.FindByID(countID)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	return countModel.(*model.StockCount)
+}
+
+func TestOpenStockCount(t *testing.T) {
+	countInventoryService := newCountInventory(t)
+
+	//successful case (all skus)
+	ok, err := countInventoryService.OpenStockCount("dummyCount", "", nil)
+	t.Run("return must be true", func(t *testing.T) {
+		if true != ok {
+			t.Errorf("expected true but got %v", ok)
+		}
+	})
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+	t.Run("count must contain all skus", func(t *testing.T) {
+		stockCount := getDummyStockCount(t, countInventoryService, "dummyCount")
+		if len(stockCount.Items) != 2 || stockCount.Items["dummySku"] == nil || stockCount.Items["dummySku2"] == nil {
+			t.Errorf("expected dummySku and dummySku2 but got %v", stockCount.Items)
+		}
+		if stockCount.Status != model.StockCountStatusOpen {
+			t.Errorf("expected %v but got %v", model.StockCountStatusOpen, stockCount.Status)
+		}
+	})
+
+	//successful case (subset of skus)
+	ok, err = countInventoryService.OpenStockCount("dummyCount2", "", []string{"dummySku2"})
+	t.Run("subset count must only contain the given skus", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Fatalf("expected true and nil but got %v and %v", ok, err)
+		}
+		items := getDummyStockCount(t, countInventoryService, "dummyCount2").Items
+		if len(items) != 1 || items["dummySku2"] == nil {
+			t.Errorf("expected dummySku2 but got %v", items)
+		}
+	})
+
+	//failed cases
+	failedOpens := map[string][]string{
+		"dummyCount":  {"dummySku"},
+		"dummyCount3": {"unknownSku"},
+		"dummyCount4": {"dummySku", "dummySku"},
+	}
+	for countID, skus := range failedOpens {
+		failedOk, failedErr := countInventoryService.OpenStockCount(countID, "", skus)
+		t.Run("Failed open of "+countID+" must be rejected", func(t *testing.T) {
+			if false != failedOk {
+				t.Errorf("expected false but got %v", failedOk)
+			}
+			if getType(failedErr) != "*Error" {
+				t.Errorf("expected *Error but got %v", getType(failedErr))
+			}
+		})
+	}
+}
+
+func TestPostStockCount(t *testing.T) {
+	countInventoryService := newCountInventory(t)
+	_, err := countInventoryService.OpenStockCount("dummyCount", "", nil)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	//5 dummySku items are missing, 2 more dummySku2 items are found
+	ok, err := countInventoryService.RecordStockCount("dummyCount", []service.CountItem{{Sku: "dummySku", Quantity: 5}})
+	t.Run("record return must be true", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Errorf("expected true and nil but got %v and %v", ok, err)
+		}
+	})
+	failedOk, _ := countInventoryService.PostStockCount("dummyCount")
+	t.Run("partially counted stock count must not be posted", func(t *testing.T) {
+		if false != failedOk {
+			t.Errorf("expected false but got %v", failedOk)
+		}
+	})
+	countInventoryService.RecordStockCount("dummyCount", []service.CountItem{{Sku: "dummySku2", Quantity: 122}})
+
+	variance, err := countInventoryService.GetStockCountVariance("dummyCount")
+	t.Run("variance must be priced at buy price", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if variance.TotalQuantityVariance != -3 {
+			t.Errorf("expected -3 but got %v", variance.TotalQuantityVariance)
+		}
+		if variance.TotalValueVariance != -5*50000+2*60000 {
+			t.Errorf("expected %v but got %v", -5*50000+2*60000, variance.TotalValueVariance)
+		}
+		if variance.Items[0].Sku != "dummySku" || variance.Items[0].QuantityVariance != -5 {
+			t.Errorf("expected dummySku with -5 but got %v with %v", variance.Items[0].Sku, variance.Items[0].QuantityVariance)
+		}
+	})
+
+	ok, err = countInventoryService.PostStockCount("dummyCount")
+	t.Run("post return must be true", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Errorf("expected true and nil but got %v and %v", ok, err)
+		}
+	})
+	t.Run("counted quantities must be applied to stock", func(t *testing.T) {
+		itemInfo, _ := countInventoryService.GetItemInfo("dummySku")
+		itemInfo2, _ := countInventoryService.GetItemInfo("dummySku2")
+		if itemInfo.Quantity != 5 || itemInfo2.Quantity != 122 {
+			t.Errorf("expected 5 and 122 but got %v and %v", itemInfo.Quantity, itemInfo2.Quantity)
+		}
+	})
+
+	//the posted count keeps its variance
+	postedVariance, err := countInventoryService.GetStockCountVariance("dummyCount")
+	t.Run("posted variance must be computed against stock when posted", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if postedVariance.Status != model.StockCountStatusPosted || postedVariance.TotalValueVariance != variance.TotalValueVariance {
+			t.Errorf("expected %v and %v but got %v and %v", model.StockCountStatusPosted, variance.TotalValueVariance, postedVariance.Status, postedVariance.TotalValueVariance)
+		}
+	})
+	failedOk, _ = countInventoryService.RecordStockCount("dummyCount", []service.CountItem{{Sku: "dummySku", Quantity: 1}})
+	t.Run("posted stock count must not be recorded", func(t *testing.T) {
+		if false != failedOk {
+			t.Errorf("expected false but got %v", failedOk)
+		}
+	})
+}
+
+func TestPostStockCountBelowReserved(t *testing.T) {
+	//a draft sale reserves 6 of the 10 dummySku items
+	countInventoryService := newCountInventory(t)
+	_, err := countInventoryService.CreateSale("dummyDraftInvoice", "", []service.SaleItem{{Sku: "dummySku", Quantity: 6}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = countInventoryService.OpenStockCount("dummyCount", "", nil)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = countInventoryService.RecordStockCount("dummyCount", []service.CountItem{{Sku: "dummySku", Quantity: 4}, {Sku: "dummySku2", Quantity: 122}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	ok, err := countInventoryService.PostStockCount("dummyCount")
+	t.Run("count below the reserved quantity must be rejected", func(t *testing.T) {
+		if false != ok {
+			t.Errorf("expected false but got %v", ok)
+		}
+		if getType(err) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(err))
+		}
+	})
+	itemInfo, _ := countInventoryService.GetItemInfo("dummySku")
+	itemInfo2, _ := countInventoryService.GetItemInfo("dummySku2")
+	t.Run("rejected count must not change stock", func(t *testing.T) {
+		if itemInfo.Quantity != 10 || itemInfo.Reserved != 6 || itemInfo2.Quantity != 120 {
+			t.Errorf("expected 10 with 6 reserved and 120 but got %+v and %+v", itemInfo, itemInfo2)
+		}
+		if getDummyStockCount(t, countInventoryService, "dummyCount").Status != model.StockCountStatusOpen {
+			t.Errorf("expected stock count to stay open")
+		}
+	})
+
+	//the reserved items can still be sold
+	ok, err = countInventoryService.UpdateSale("dummyDraftInvoice", model.SalesStatusDone, "dummyUser")
+	t.Run("draft sale must still be completed", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Errorf("expected true and nil but got %v and %v", ok, err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//recordMovement is a function for writing a stock movement (inside the passed unit of work)
+//zero quantity movements are not recorded since they don't change stock (except for cost change movements)
+func (i *Inventory) recordMovement(uow *UnitOfWork, sku string, quantity int64, unitCost model.Money, reason, reference, note string) *errors.Error {
+	if quantity == 0 && reason != model.MovementReasonCostChange {
+		return nil
+	}
+	newMovement := &model.StockMovement{
+		Sku:       sku,
+		Quantity:  quantity,
+		UnitCost:  unitCost,
+		Reason:    reason,
+		Reference: reference,
+		Note:      note,
+		Date:      time.Now(),
+	}
+	err := uow.StockMovementDatamapper.Insert(newMovement)
+	if err != nil {
+		return errors.Wrap(fmt.Errorf("Sku: %v stock movement recording failed: %v", sku, err), 0)
+	}
+	return nil
+}
+
+//AdjustStock is a function for manually adjusting stock quantity of an item by the given (signed) quantity
+func (i *Inventory) AdjustStock(sku string, quantity int64, reference, note string) *errors.Error {
+	if quantity == 0 {
+		return errors.Wrap(fmt.Errorf("Adjustment quantity must not be zero"), 0)
+	}
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		stockObj, err := uow.getStock(sku)
+		if err != nil {
+			return err
+		}
+		if stockObj.Quantity+quantity < stockObj.Reserved {
+			//stock reserved by draft sales can't be adjusted away
+			return errors.Wrap(fmt.Errorf("Sku: %v doesn't have enough available stock", sku), 0)
+		}
+		stockObj.Quantity += quantity
+		err = uow.StockDatamapper.Update(stockObj)
+		if err != nil {
+			return err
+		}
+		err = i.recordMovement(uow, sku, quantity, stockObj.BuyPrice, model.MovementReasonAdjustment, reference, note)
+		if err != nil {
+			return err
+		}
+		err = i.adjustCostLayers(uow, sku, quantity, stockObj.BuyPrice, reference)
+		if err != nil {
+			return err
+		}
+		if quantity < 0 {
+			return i.checkStockDrop(uow, stockObj)
+		}
+		return nil
+	})
+}
+
+//GetStockMovements is a function for obtaining stock movements of a sku during the given period (start and end date inclusive)
+func (i *Inventory) GetStockMovements(sku string, startDate, endDate time.Time) ([]*model.StockMovement, *errors.Error) {
+	//validate start and end date
+	if startDate.After(endDate) {
+		return nil, errors.Wrap(fmt.Errorf("Invalid start date %v and end date %v from param", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")), 0)
+	}
+	movementFinder, ok := i.StockMovementDatamapper.(datamapper.StockMovementFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting stock movement mapper"), 0)
+	}
+	//the whole end date is included
+	movements, err := movementFinder.FindBySkuAndDateRange(sku, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	movementList := make([]*model.StockMovement, 0, len(movements))
+	for _, val := range movements {
+		valObj, ok := val.(*model.StockMovement)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		movementList = append(movementList, valObj)
+	}
+	return movementList, nil
+}
@@ -0,0 +1,144 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+
+	"testing"
+	"time"
+)
+
+func TestAdjustStock(t *testing.T) {
+	adjustInventoryService := newMemoryInventory(t)
+
+	//successful case
+	err := adjustInventoryService.AdjustStock("dummySku", -4, "ADJ01", "broken items")
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+	itemInfo, _ := adjustInventoryService.GetItemInfo("dummySku")
+	t.Run("stock quantity must be decreased", func(t *testing.T) {
+		if itemInfo.Quantity != 6 {
+			t.Errorf("expected %v but got %v", 6, itemInfo.Quantity)
+		}
+	})
+
+	//failed case (resulting stock would be negative)
+	failedErr := adjustInventoryService.AdjustStock("dummySku", -7, "ADJ02", "")
+	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
+		if getType(failedErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(failedErr))
+		}
+	})
+	itemInfo, _ = adjustInventoryService.GetItemInfo("dummySku")
+	t.Run("failed adjustment must not change stock", func(t *testing.T) {
+		if itemInfo.Quantity != 6 {
+			t.Errorf("expected %v but got %v", 6, itemInfo.Quantity)
+		}
+	})
+
+	//failed case (zero quantity)
+	zeroErr := adjustInventoryService.AdjustStock("dummySku", 0, "ADJ03", "")
+	t.Run("Zero quantity err returned must type must be correct", func(t *testing.T) {
+		if getType(zeroErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(zeroErr))
+		}
+	})
+}
+
+func TestGetStockMovements(t *testing.T) {
+	//failed case (start date after end date)
+	startDate := time.Date(2017, 12, 20, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2017, 12, 10, 0, 0, 0, 0, time.UTC)
+	movements, err := newMemoryInventory(t).GetStockMovements("dummySku", startDate, endDate)
+	t.Run("Failed return must be nil", func(t *testing.T) {
+		if movements != nil {
+			t.Errorf("expected nil but got %v", movements)
+		}
+	})
+	t.Run("Failed err returned must type must be correct", func(t *testing.T) {
+		if getType(err) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(err))
+		}
+	})
+}
+
+func TestGetStockValueAsOf(t *testing.T) {
+	//current stock of 250 dummySku and 120 dummySku2 items along with the movements leading to it
+	asOfInventoryService := newEmptyMemoryInventory()
+	stocks := []*model.Stock{
+		{Sku: "dummySku", Name: "dummy sku", Quantity: 250, BuyPrice: 50000, SellPrice: 60000},
+		{Sku: "dummySku2", Name: "dummy sku 2", Quantity: 120, BuyPrice: 60000, SellPrice: 63000},
+	}
+	for _, val := range stocks {
+		if err := asOfInventoryService.StockDatamapper.Insert(val); err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	movements := []*model.StockMovement{
+		{Sku: "dummySku", Quantity: 200, UnitCost: 45000, Reason: model.MovementReasonInitialStock, Date: time.Date(2017, 12, 1, 8, 0, 0, 0, time.UTC)},
+		{Sku: "dummySku", Quantity: 0, UnitCost: 48000, Reason: model.MovementReasonCostChange, Date: time.Date(2017, 12, 10, 8, 0, 0, 0, time.UTC)},
+		{Sku: "dummySku2", Quantity: 120, UnitCost: 60000, Reason: model.MovementReasonInitialStock, Date: time.Date(2017, 12, 12, 8, 0, 0, 0, time.UTC)},
+		{Sku: "dummySku", Quantity: 50, UnitCost: 50000, Reason: model.MovementReasonPurchase, Date: time.Date(2017, 12, 15, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, val := range movements {
+		if err := asOfInventoryService.StockMovementDatamapper.Insert(val); err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+
+	//successful case (before the purchase and before dummySku2 was added)
+	stockValue, err := asOfInventoryService.GetStockValueAsOf(time.Date(2017, 12, 11, 0, 0, 0, 0, time.UTC))
+	t.Run("err return must be nil", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("expected nil but got %v", err)
+		}
+	})
+	t.Run("sku added after the date must not be included", func(t *testing.T) {
+		if stockValue.TotalItemKind != 1 {
+			t.Errorf("expected 1 but got %v", stockValue.TotalItemKind)
+		}
+	})
+	t.Run("quantity must be rebuilt", func(t *testing.T) {
+		if stockValue.Items["dummySku"].Quantity != 200 {
+			t.Errorf("expected %v but got %v", 200, stockValue.Items["dummySku"].Quantity)
+		}
+	})
+	t.Run("buy price must be taken from last movement", func(t *testing.T) {
+		if stockValue.Items["dummySku"].BuyPrice != 48000 {
+			t.Errorf("expected 48000 but got %v", stockValue.Items["dummySku"].BuyPrice)
+		}
+	})
+	t.Run("total amount must be correct", func(t *testing.T) {
+		expected := model.Money(48000).Multiply(200)
+		if stockValue.TotalAmount != expected {
+			t.Errorf("expected %v but got %v", expected, stockValue.TotalAmount)
+		}
+	})
+
+	//successful case (movements on the date itself are included)
+	laterStockValue, _ := asOfInventoryService.GetStockValueAsOf(time.Date(2017, 12, 15, 0, 0, 0, 0, time.UTC))
+	t.Run("all sku must be included", func(t *testing.T) {
+		if laterStockValue.TotalItemKind != 2 {
+			t.Errorf("expected 2 but got %v", laterStockValue.TotalItemKind)
+		}
+	})
+	t.Run("quantity must equal current quantity", func(t *testing.T) {
+		if laterStockValue.TotalQuantity != 370 {
+			t.Errorf("expected %v but got %v", 370, laterStockValue.TotalQuantity)
+		}
+	})
+
+	//no sku at all
+	emptyStockValue, emptyErr := newEmptyMemoryInventory().GetStockValueAsOf(time.Date(2017, 12, 11, 0, 0, 0, 0, time.UTC))
+	t.Run("Empty return must be empty", func(t *testing.T) {
+		if emptyErr != nil {
+			t.Fatalf("expected nil but got %v", emptyErr)
+		}
+		if emptyStockValue.TotalItemKind != 0 || emptyStockValue.TotalQuantity != 0 {
+			t.Errorf("expected no items but got %+v", emptyStockValue)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//reserveSale is a function for reserving the quantities of a draft sale (inside the passed unit of work)
+//reserved quantities stay in stock but are no longer available for other sales
+func (i *Inventory) reserveSale(uow *UnitOfWork, saleObj *model.Sales) *errors.Error {
+	for _, val := range saleObj.Items {
+		stockObj, err := uow.getStock(val.Sku)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		if stockObj.Available() < val.Quantity {
+			return errors.Wrap(fmt.Errorf("Sku: %v doesn't have enough available stock", val.Sku), 0)
+		}
+		stockObj.Reserved += val.Quantity
+		err = uow.StockDatamapper.Update(stockObj)
+		if err != nil {
+			return errors.Wrap(fmt.Errorf("Sku: %v stock reservation failed: %v", val.Sku, err), 0)
+		}
+	}
+	return nil
+}
+
+//releaseSale is a function for releasing the reserved quantities of a canceled draft sale (inside the passed unit of work)
+func (i *Inventory) releaseSale(uow *UnitOfWork, saleObj *model.Sales) *errors.Error {
+	for _, val := range saleObj.Items {
+		stockObj, err := uow.getStock(val.Sku)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		releaseReservation(stockObj, val.Quantity)
+		err = uow.StockDatamapper.Update(stockObj)
+		if err != nil {
+			return errors.Wrap(fmt.Errorf("Sku: %v stock reservation release failed: %v", val.Sku, err), 0)
+		}
+	}
+	return nil
+}
+
+//releaseReservation is a function for taking the given quantity off the reserved quantity of an item
+//reserved quantity never goes below zero (e.g. for drafts created before reservations were kept)
+func releaseReservation(stockObj *model.Stock, quantity int64) {
+	stockObj.Reserved -= quantity
+	if stockObj.Reserved < 0 {
+		stockObj.Reserved = 0
+	}
+}
@@ -0,0 +1,77 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+)
+
+func TestCreateSaleReservesStock(t *testing.T) {
+	reserveInventoryService := newMemoryInventory(t)
+
+	ok, err := reserveInventoryService.CreateSale("dummyDraftInvoice", "", []service.SaleItem{{Sku: "dummySku", Quantity: 4}})
+	t.Run("create sale return must be true", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Errorf("expected true and nil but got %v and %v", ok, err)
+		}
+	})
+	itemInfo, _ := reserveInventoryService.GetItemInfo("dummySku")
+	t.Run("draft sale items must be reserved", func(t *testing.T) {
+		if itemInfo.Reserved != 4 {
+			t.Errorf("expected %v but got %v", 4, itemInfo.Reserved)
+		}
+		if itemInfo.Available() != 6 {
+			t.Errorf("expected %v but got %v", 6, itemInfo.Available())
+		}
+	})
+
+	//reserved items can't be sold again by another draft
+	failedOk, failedErr := reserveInventoryService.CreateSale("dummyDraftInvoice2", "", []service.SaleItem{{Sku: "dummySku", Quantity: 7}})
+	t.Run("sale over available quantity must be rejected", func(t *testing.T) {
+		if false != failedOk {
+			t.Errorf("expected false but got %v", failedOk)
+		}
+		if getType(failedErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(failedErr))
+		}
+	})
+}
+
+func TestDraftSaleReservation(t *testing.T) {
+	//canceling the draft (3 dummySku items) releases the reservation
+	inventoryObj := newStatusSaleInventory(t, model.SalesStatusDraft)
+	_, ok, err := updateDummySaleStatus(t, inventoryObj, model.SalesStatusCanceled, "dummyUser")
+	itemInfo, _ := inventoryObj.GetItemInfo("dummySku")
+	t.Run("canceled draft must release its reservation", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Fatalf("expected true and nil but got %v and %v", ok, err)
+		}
+		if itemInfo.Reserved != 0 || itemInfo.Quantity != 10 {
+			t.Errorf("expected 0 and %v but got %v and %v", 10, itemInfo.Reserved, itemInfo.Quantity)
+		}
+	})
+
+	//completing the draft consumes both the reservation and the stock
+	inventoryObj = newStatusSaleInventory(t, model.SalesStatusDraft)
+	_, ok, err = updateDummySaleStatus(t, inventoryObj, model.SalesStatusDone, "dummyUser")
+	itemInfo, _ = inventoryObj.GetItemInfo("dummySku")
+	t.Run("done draft must consume its reservation", func(t *testing.T) {
+		if true != ok || err != nil {
+			t.Fatalf("expected true and nil but got %v and %v", ok, err)
+		}
+		if itemInfo.Reserved != 0 || itemInfo.Quantity != 7 {
+			t.Errorf("expected 0 and %v but got %v and %v", 7, itemInfo.Reserved, itemInfo.Quantity)
+		}
+	})
+
+	//reserved quantity can't be adjusted away
+	inventoryObj = newStatusSaleInventory(t, model.SalesStatusDraft)
+	failedErr := inventoryObj.UpdateSKU("dummySku", 2, 50000, 60000)
+	t.Run("quantity below reserved quantity must be rejected", func(t *testing.T) {
+		if getType(failedErr) != "*Error" {
+			t.Errorf("expected *Error but got %v", getType(failedErr))
+		}
+	})
+}
@@ -0,0 +1,227 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//StockTurnover is a struct containing inventory turnover, days of inventory on hand and sell-through rate of the items over a period
+type StockTurnover struct {
+	StartDate time.Time            `json:"startDate"`
+	EndDate   time.Time            `json:"endDate"`
+	Days      int                  `json:"days"` //count of days of the period
+	Totals    TurnoverFigures      `json:"totals"`
+	Items     []*StockTurnoverItem `json:"items"` //sorted by sku
+}
+
+//StockTurnoverItem is a struct containing inventory turnover, days of inventory on hand and sell-through rate of a specific Sku
+type StockTurnoverItem struct {
+	Sku  string `json:"sku"`
+	Name string `json:"name"`
+	TurnoverFigures
+}
+
+//TurnoverFigures is a struct containing the stock and sales figures of a period along with the ratios computed from them
+type TurnoverFigures struct {
+	BeginningQuantity int64       `json:"beginningQuantity"` //stock at the end of the day before the period
+	EndingQuantity    int64       `json:"endingQuantity"`    //stock at the end of the period
+	AverageValue      model.Money `json:"averageValue"`      //(beginning + ending stock value) / 2, valued at buy price
+	SoldQuantity      int64       `json:"soldQuantity"`      //quantity sold by completed sales, returned items are netted out
+	COGS              model.Money `json:"cogs"`
+	TurnoverRatio     float64     `json:"turnoverRatio"`        //COGS / average value, 0 without stock
+	DaysOnHand        *float64    `json:"daysOnHand,omitempty"` //days of the period / turnover ratio, missing when nothing was sold
+	SellThrough       float64     `json:"sellThrough"`          //sold quantity / (sold + ending quantity) in percent, 0 when neither was any
+	beginningValue    model.Money
+	endingValue       model.Money
+}
+
+//compute is a function for computing the average value and the ratios of the figures over a period of the given count of days
+func (f *TurnoverFigures) compute(days int) {
+	f.AverageValue = (f.beginningValue + f.endingValue).Divide(2)
+	f.TurnoverRatio = 0
+	f.DaysOnHand = nil
+	if f.AverageValue > 0 {
+		f.TurnoverRatio = round2(float64(f.COGS) / float64(f.AverageValue))
+	}
+	if f.COGS > 0 {
+		daysOnHand := round2(float64(days) * float64(f.AverageValue) / float64(f.COGS))
+		f.DaysOnHand = &daysOnHand
+	}
+	f.SellThrough = 0
+	if f.SoldQuantity+f.EndingQuantity > 0 {
+		f.SellThrough = round2(float64(f.SoldQuantity) / float64(f.SoldQuantity+f.EndingQuantity) * 100)
+	}
+}
+
+//DeadStock is a struct containing the items which haven't been sold for a count of days, along with the stock value tied up in them
+type DeadStock struct {
+	AsOf          time.Time        `json:"asOf"`
+	Days          int              `json:"days"`
+	TotalQuantity int64            `json:"totalQuantity"`
+	TotalAmount   model.Money      `json:"totalAmount"`
+	Items         []*DeadStockItem `json:"items"` //highest stock value first
+}
+
+//DeadStockItem is a struct containing the stock value tied up in a specific Sku which hasn't been sold for a count of days
+type DeadStockItem struct {
+	Sku          string      `json:"sku"`
+	Name         string      `json:"name"`
+	Quantity     int64       `json:"quantity"`
+	BuyPrice     model.Money `json:"buyPrice"`
+	TotalAmount  model.Money `json:"totalAmount"`            //buy price * quantity
+	LastSaleDate *time.Time  `json:"lastSaleDate,omitempty"` //missing when the item has never been sold
+}
+
+//round2 is a function for rounding a ratio to 2 decimals
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+//GetStockTurnover is a function for obtaining inventory turnover ratio, days of inventory on hand and sell-through rate of every item over the given dates (inclusive)
+//stock at the start and at the end of the period is rebuilt from stock movements (see GetStockValueAsOf), items added after the period aren't listed
+func (i *Inventory) GetStockTurnover(startTime, endTime time.Time) (*StockTurnover, *errors.Error) {
+	//validate start and end date
+	if !(startTime.Before(endTime) || startTime.Equal(endTime)) {
+		return nil, errors.Wrap(fmt.Errorf("Invalid start date %v and end date %v from param", startTime.Format("2006-01-02"), endTime.Format("2006-01-02")), 0)
+	}
+	beginningStock, err := i.GetStockValueAsOf(startTime.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	endingStock, err := i.GetStockValueAsOf(endTime)
+	if err != nil {
+		return nil, err
+	}
+	lines, _, _, err := i.salesLines(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	currentStock, err := i.StockDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	names := make(map[string]string, len(currentStock))
+	for _, val := range currentStock {
+		names[val.GetID()] = val.(*model.Stock).Name
+	}
+
+	turnover := &StockTurnover{
+		StartDate: startTime,
+		EndDate:   endTime,
+		Days:      int(math.Round(endTime.Sub(startTime).Hours()/24)) + 1,
+		Items:     make([]*StockTurnoverItem, 0, len(endingStock.Items)),
+	}
+	items := make(map[string]*StockTurnoverItem, len(endingStock.Items))
+	for sku, val := range endingStock.Items {
+		item := &StockTurnoverItem{
+			Sku:  sku,
+			Name: names[sku],
+		}
+		item.EndingQuantity = val.Quantity
+		item.endingValue = val.TotalAmount
+		if beginningVal, exists := beginningStock.Items[sku]; true == exists {
+			item.BeginningQuantity = beginningVal.Quantity
+			item.beginningValue = beginningVal.TotalAmount
+		}
+		items[sku] = item
+		turnover.Items = append(turnover.Items, item)
+	}
+	for _, val := range lines {
+		item, exists := items[val.sku]
+		if false == exists {
+			//item removed since
+			continue
+		}
+		item.SoldQuantity += val.quantity
+		item.COGS += val.cost
+	}
+
+	for _, val := range turnover.Items {
+		val.compute(turnover.Days)
+		turnover.Totals.BeginningQuantity += val.BeginningQuantity
+		turnover.Totals.EndingQuantity += val.EndingQuantity
+		turnover.Totals.beginningValue += val.beginningValue
+		turnover.Totals.endingValue += val.endingValue
+		turnover.Totals.SoldQuantity += val.SoldQuantity
+		turnover.Totals.COGS += val.COGS
+	}
+	turnover.Totals.compute(turnover.Days)
+	sort.Slice(turnover.Items, func(x, y int) bool {
+		return turnover.Items[x].Sku < turnover.Items[y].Sku
+	})
+	return turnover, nil
+}
+
+//GetDeadStock is a function for obtaining the items in stock which haven't been sold by any completed sale during the given count of days up to the as of date (inclusive)
+//the stock value tied up in them is their quantity at buy price as of the date, rebuilt from stock movements (see GetStockValueAsOf)
+func (i *Inventory) GetDeadStock(days int, asOf time.Time) (*DeadStock, *errors.Error) {
+	if days <= 0 {
+		return nil, errors.Wrap(fmt.Errorf("Invalid count of days %v from param", days), 0)
+	}
+	stockValue, err := i.GetStockValueAsOf(asOf)
+	if err != nil {
+		return nil, err
+	}
+	currentStock, err := i.StockDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	names := make(map[string]string, len(currentStock))
+	for _, val := range currentStock {
+		names[val.GetID()] = val.(*model.Stock).Name
+	}
+	sales, err := i.doneSales(time.Time{}, asOf)
+	if err != nil {
+		return nil, err
+	}
+	lastSaleDates := make(map[string]time.Time)
+	for _, val := range sales {
+		for sku := range val.Items {
+			if val.Date.After(lastSaleDates[sku]) {
+				lastSaleDates[sku] = val.Date
+			}
+		}
+	}
+
+	deadStock := &DeadStock{
+		AsOf:  asOf,
+		Days:  days,
+		Items: make([]*DeadStockItem, 0),
+	}
+	//first day of the sales which keep an item alive
+	startDate := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location()).AddDate(0, 0, 1-days)
+	for sku, val := range stockValue.Items {
+		lastSaleDate, sold := lastSaleDates[sku]
+		if val.Quantity <= 0 || (true == sold && false == lastSaleDate.Before(startDate)) {
+			continue
+		}
+		item := &DeadStockItem{
+			Sku:         sku,
+			Name:        names[sku],
+			Quantity:    val.Quantity,
+			BuyPrice:    val.BuyPrice,
+			TotalAmount: val.TotalAmount,
+		}
+		if true == sold {
+			item.LastSaleDate = &lastSaleDate
+		}
+		deadStock.Items = append(deadStock.Items, item)
+		deadStock.TotalQuantity += item.Quantity
+		deadStock.TotalAmount += item.TotalAmount
+	}
+	sort.Slice(deadStock.Items, func(x, y int) bool {
+		if deadStock.Items[x].TotalAmount != deadStock.Items[y].TotalAmount {
+			return deadStock.Items[x].TotalAmount > deadStock.Items[y].TotalAmount
+		}
+		return deadStock.Items[x].Sku < deadStock.Items[y].Sku
+	})
+	return deadStock, nil
+}
@@ -0,0 +1,208 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+
+	"testing"
+	"time"
+)
+
+func TestGetStockTurnover(t *testing.T) {
+	inventoryObj, today := newSalesReportInventory(t)
+	startDate := today.AddDate(0, 0, -6)
+
+	_, err := inventoryObj.GetStockTurnover(today, startDate)
+	t.Run("invalid dates must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	turnover, err := inventoryObj.GetStockTurnover(startDate, today)
+	t.Run("every sku must hold its turnover, days on hand and sell-through", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if turnover.Days != 7 || len(turnover.Items) != 2 || turnover.Items[0].Sku != "dummySku" {
+			t.Fatalf("expected 2 skus over 7 days but got %+v", turnover)
+		}
+		//added today, so no stock before the period
+		item := turnover.Items[0]
+		if item.BeginningQuantity != 0 || item.EndingQuantity != 6 || item.SoldQuantity != 4 || item.COGS != 200000 || item.AverageValue != 150000 {
+			t.Fatalf("expected 4 items sold out of 6 left but got %+v", item.TurnoverFigures)
+		}
+		if item.TurnoverRatio != 1.33 || item.DaysOnHand == nil || *item.DaysOnHand != 5.25 || item.SellThrough != 40 {
+			t.Errorf("expected turnover 1.33, 5.25 days on hand and 40%% sell-through but got %+v", item.TurnoverFigures)
+		}
+		totals := turnover.Totals
+		if totals.COGS != 220000 || totals.AverageValue != 240000 || totals.TurnoverRatio != 0.92 || totals.DaysOnHand == nil || *totals.DaysOnHand != 7.64 || totals.SellThrough != 25 {
+			t.Errorf("expected turnover 0.92, 7.64 days on hand and 25%% sell-through but got %+v", totals)
+		}
+	})
+
+	turnover, err = inventoryObj.GetStockTurnover(startDate.AddDate(0, 0, -2), startDate.AddDate(0, 0, -1))
+	t.Run("items added after the period must not be listed", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(turnover.Items) != 0 || turnover.Totals.DaysOnHand != nil || turnover.Totals.TurnoverRatio != 0 {
+			t.Errorf("expected no items but got %+v", turnover.Items)
+		}
+	})
+}
+
+func TestGetDeadStock(t *testing.T) {
+	inventoryObj, today := newSalesReportInventory(t)
+	err := inventoryObj.AddSKU("idleSku", 3, 10000, 15000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.AddSKU("emptySku", 0, 10000, 15000)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	_, errs := inventoryObj.GetDeadStock(0, today)
+	t.Run("invalid count of days must be rejected", func(t *testing.T) {
+		if errs == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	deadStock, errs := inventoryObj.GetDeadStock(3, today)
+	t.Run("only items in stock without sales must be listed", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
+		if len(deadStock.Items) != 1 || deadStock.Items[0].Sku != "idleSku" || deadStock.Items[0].LastSaleDate != nil || deadStock.TotalAmount != 30000 {
+			t.Errorf("expected idleSku worth 30000 but got %+v", deadStock.Items)
+		}
+	})
+
+	//every item was added today
+	deadStock, errs = inventoryObj.GetDeadStock(3, today.AddDate(0, 0, -1))
+	t.Run("items added after the as of date must not be listed", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
+		if len(deadStock.Items) != 0 || deadStock.TotalAmount != 0 {
+			t.Errorf("expected no items but got %+v", deadStock.Items)
+		}
+	})
+}
+
+func TestGetDeadStockAsOf(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	//deadSku: 10 items at 40000 added 10 days ago, 3 of them sold a week ago, bought at 45000 since today and 5 broken today
+	//lateSku: 5 items at 10000 added today
+	inventoryObj := newEmptyMemoryInventory()
+	stocks := []*model.Stock{
+		{Sku: "deadSku", Name: "dead sku", Quantity: 2, BuyPrice: 45000, SellPrice: 60000},
+		{Sku: "lateSku", Name: "late sku", Quantity: 5, BuyPrice: 10000, SellPrice: 15000},
+	}
+	for _, val := range stocks {
+		if err := inventoryObj.StockDatamapper.Insert(val); err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	movements := []*model.StockMovement{
+		{Sku: "deadSku", Quantity: 10, UnitCost: 40000, Reason: model.MovementReasonInitialStock, Date: today.AddDate(0, 0, -10).Add(8 * time.Hour)},
+		{Sku: "deadSku", Quantity: -3, UnitCost: 40000, Reason: model.MovementReasonSale, Reference: "INV-0", Date: today.AddDate(0, 0, -7).Add(10 * time.Hour)},
+		{Sku: "deadSku", Quantity: 0, UnitCost: 45000, Reason: model.MovementReasonCostChange, Date: today},
+		{Sku: "deadSku", Quantity: -5, UnitCost: 45000, Reason: model.MovementReasonAdjustment, Date: today},
+		{Sku: "lateSku", Quantity: 5, UnitCost: 10000, Reason: model.MovementReasonInitialStock, Date: today},
+	}
+	for _, val := range movements {
+		if err := inventoryObj.StockMovementDatamapper.Insert(val); err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	err := inventoryObj.SalesDatamapper.Insert(&model.Sales{
+		InvoiceID: "INV-0",
+		Date:      today.AddDate(0, 0, -7).Add(10 * time.Hour),
+		Status:    model.SalesStatusDone,
+		Items: map[string]*model.SaleItem{
+			"deadSku": {Sku: "deadSku", Quantity: 3, BuyPrice: 40000, SellPrice: 60000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	deadStock, errs := inventoryObj.GetDeadStock(3, today.AddDate(0, 0, -1))
+	t.Run("quantity and buy price must be taken as of the date", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
+		if len(deadStock.Items) != 1 || deadStock.TotalQuantity != 7 || deadStock.TotalAmount != 280000 {
+			t.Fatalf("expected 7 deadSku items worth 280000 but got %+v", deadStock.Items)
+		}
+		item := deadStock.Items[0]
+		if item.Sku != "deadSku" || item.Name != "dead sku" || item.BuyPrice != 40000 || item.LastSaleDate == nil || false == item.LastSaleDate.Equal(today.AddDate(0, 0, -7).Add(10*time.Hour)) {
+			t.Errorf("expected dead sku at 40000 last sold a week ago but got %+v", item)
+		}
+	})
+
+	deadStock, errs = inventoryObj.GetDeadStock(3, today)
+	t.Run("current stock must be listed as of today", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
+		if len(deadStock.Items) != 2 || deadStock.TotalQuantity != 7 || deadStock.TotalAmount != 140000 {
+			t.Fatalf("expected 7 items worth 140000 but got %+v", deadStock.Items)
+		}
+		if deadStock.Items[0].Sku != "deadSku" || deadStock.Items[0].TotalAmount != 90000 {
+			t.Errorf("expected deadSku worth 90000 first but got %+v", deadStock.Items[0])
+		}
+	})
+}
+
+func TestStockTurnoverDump(t *testing.T) {
+	inventoryObj := newDumpInventory(t)
+	startDate := time.Date(2017, 12, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	turnover, err := inventoryObj.GetStockTurnover(startDate, endDate)
+	t.Run("sold quantity and COGS of a sku sold by 2 sales must be summed", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		found := false
+		for _, val := range turnover.Items {
+			if val.Sku != "SSI-D00791015-LL-BWH" {
+				continue
+			}
+			found = true
+			if val.SoldQuantity != 9 || val.COGS != 464000 || val.TurnoverRatio <= 0 || val.DaysOnHand == nil {
+				t.Errorf("expected 9 items sold at 464000 but got %+v", val.TurnoverFigures)
+			}
+		}
+		if false == found || turnover.Totals.SoldQuantity != 54 || turnover.Totals.COGS != 3766000 {
+			t.Errorf("expected 54 items sold at 3766000 but got %+v", turnover.Totals)
+		}
+	})
+
+	deadStock, errs := inventoryObj.GetDeadStock(2, time.Date(2017, 12, 19, 0, 0, 0, 0, time.UTC))
+	t.Run("items last sold before the days must be listed", func(t *testing.T) {
+		if errs != nil {
+			t.Fatalf("expected nil but got %v", errs)
+		}
+		foundNAV := false
+		for _, val := range deadStock.Items {
+			switch val.Sku {
+			case "SSI-D00864612-LL-NAV":
+				if val.LastSaleDate == nil || val.LastSaleDate.Day() != 16 || val.TotalAmount != 4675000 {
+					t.Errorf("expected 85 items at 55000 last sold on 2017-12-16 but got %+v", val)
+				}
+				foundNAV = true
+			case "SSI-D01037807-X3-BWH", "SSI-D01322234-LL-WHI", "SSI-D00791015-LL-BWH":
+				t.Errorf("expected items sold on 2017-12-18 or later to be left out but got %+v", val)
+			}
+		}
+		if false == foundNAV {
+			t.Errorf("expected SSI-D00864612-LL-NAV to be listed but got %+v", deadStock.Items)
+		}
+	})
+}
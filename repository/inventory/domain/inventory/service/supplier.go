@@ -0,0 +1,261 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//SupplierDetail is a definition of the master data of a supplier (see CreateSupplier and UpdateSupplier)
+type SupplierDetail struct {
+	SupplierID      string `json:"supplierId"`
+	Name            string `json:"name"`
+	ContactName     string `json:"contactName"`
+	Phone           string `json:"phone"`
+	Email           string `json:"email"`
+	Address         string `json:"address"`
+	PaymentTermDays int64  `json:"paymentTermDays"` //0 for cash on delivery
+}
+
+//SupplierReport is a struct containing purchase volume and average cost by supplier
+type SupplierReport struct {
+	StartDate     time.Time             `json:"startDate"`
+	EndDate       time.Time             `json:"endDate"`
+	PurchaseCount int                   `json:"purchaseCount"`
+	TotalQuantity int64                 `json:"totalQuantity"`
+	TotalAmount   model.Money           `json:"totalAmount"`
+	Suppliers     []*SupplierReportItem `json:"suppliers"` //sorted by supplier id, purchases without supplier are reported under an empty supplier id
+}
+
+//SupplierReportItem is a struct containing purchase volume and average cost of a supplier
+type SupplierReportItem struct {
+	SupplierID    string                   `json:"supplierId"`
+	Name          string                   `json:"name"`
+	PurchaseCount int                      `json:"purchaseCount"`
+	TotalQuantity int64                    `json:"totalQuantity"`
+	TotalAmount   model.Money              `json:"totalAmount"`
+	AverageCost   model.Money              `json:"averageCost"` //total amount / total quantity
+	Items         []*SupplierReportSkuItem `json:"items"`       //sorted by sku
+}
+
+//SupplierReportSkuItem is a struct containing purchase volume and average cost of a specific Sku purchased from a supplier
+type SupplierReportSkuItem struct {
+	Sku           string      `json:"sku"`
+	TotalQuantity int64       `json:"totalQuantity"`
+	TotalAmount   model.Money `json:"totalAmount"`
+	AverageCost   model.Money `json:"averageCost"` //total amount / total quantity
+}
+
+//findSupplier is a function for obtaining a supplier using the given supplier mapper
+func findSupplier(supplierMapper datamapper.DataMapper, supplierID string) (*model.Supplier, *errors.Error) {
+	foundSupplier, err := supplierMapper.FindByID(supplierID)
+	if err != nil {
+		if err.Err == datamapper.ErrNotFound {
+			return nil, errors.Wrap(fmt.Errorf("Supplier %v doesn't exist", supplierID), 0)
+		}
+		return nil, err
+	}
+	supplierObj, ok := foundSupplier.(*model.Supplier)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	return supplierObj, nil
+}
+
+//validateSupplierDetail is a function for checking the master data of a supplier
+func validateSupplierDetail(detail SupplierDetail) *errors.Error {
+	if detail.SupplierID == "" || detail.Name == "" {
+		return errors.Wrap(fmt.Errorf("Supplier id and name are required"), 0)
+	}
+	if detail.PaymentTermDays < 0 {
+		return errors.Wrap(fmt.Errorf("Invalid payment term %v days", detail.PaymentTermDays), 0)
+	}
+	return nil
+}
+
+//CreateSupplier is a function for adding a new supplier
+func (i *Inventory) CreateSupplier(detail SupplierDetail) *errors.Error {
+	err := validateSupplierDetail(detail)
+	if err != nil {
+		return err
+	}
+	return i.SupplierDatamapper.Insert(&model.Supplier{
+		SupplierID:      detail.SupplierID,
+		Name:            detail.Name,
+		ContactName:     detail.ContactName,
+		Phone:           detail.Phone,
+		Email:           detail.Email,
+		Address:         detail.Address,
+		PaymentTermDays: detail.PaymentTermDays,
+		Items:           make(map[string]*model.SupplierItem),
+	})
+}
+
+//UpdateSupplier is a function for updating the master data of a supplier (the items it supplies are left as they are)
+func (i *Inventory) UpdateSupplier(detail SupplierDetail) *errors.Error {
+	err := validateSupplierDetail(detail)
+	if err != nil {
+		return err
+	}
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		supplierObj, err := findSupplier(uow.SupplierDatamapper, detail.SupplierID)
+		if err != nil {
+			return err
+		}
+		supplierObj.Name = detail.Name
+		supplierObj.ContactName = detail.ContactName
+		supplierObj.Phone = detail.Phone
+		supplierObj.Email = detail.Email
+		supplierObj.Address = detail.Address
+		supplierObj.PaymentTermDays = detail.PaymentTermDays
+		return uow.SupplierDatamapper.Update(supplierObj)
+	})
+}
+
+//GetSupplier is a function for obtaining a supplier along with the items it supplies
+func (i *Inventory) GetSupplier(supplierID string) (*model.Supplier, *errors.Error) {
+	return findSupplier(i.SupplierDatamapper, supplierID)
+}
+
+//ListSuppliers is a function for obtaining every supplier (ordered by supplier id)
+func (i *Inventory) ListSuppliers() ([]*model.Supplier, *errors.Error) {
+	foundSuppliers, err := i.SupplierDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	suppliers := make([]*model.Supplier, 0, len(foundSuppliers))
+	for _, val := range foundSuppliers {
+		valObj, ok := val.(*model.Supplier)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		suppliers = append(suppliers, valObj)
+	}
+	return suppliers, nil
+}
+
+//UpdateSupplierItem is a function for setting the code of an item in the catalog of a supplier, the item is added to the items of the supplier when needed
+func (i *Inventory) UpdateSupplierItem(supplierID, sku, supplierSku string) *errors.Error {
+	return i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		_, err := uow.getStock(sku)
+		if err != nil {
+			return err
+		}
+		supplierObj, err := findSupplier(uow.SupplierDatamapper, supplierID)
+		if err != nil {
+			return err
+		}
+		itemObj, exists := supplierObj.Items[sku]
+		if false == exists {
+			itemObj = &model.SupplierItem{Sku: sku}
+			supplierObj.Items[sku] = itemObj
+		}
+		itemObj.SupplierSku = supplierSku
+		return uow.SupplierDatamapper.Update(supplierObj)
+	})
+}
+
+//recordSupplierPrices is a function for recording the buy prices of the received items of a purchase as the last prices of the items supplied by its supplier (inside the passed unit of work)
+//prices are recorded on receipt, so draft purchases which are never received (e.g. canceled) don't change the last prices
+func recordSupplierPrices(uow *UnitOfWork, purchaseObj *model.Purchase, receivedSkus []string, receivedDate time.Time) *errors.Error {
+	if purchaseObj.SupplierID == "" || len(receivedSkus) == 0 {
+		return nil
+	}
+	supplierObj, err := findSupplier(uow.SupplierDatamapper, purchaseObj.SupplierID)
+	if err != nil {
+		return err
+	}
+	for _, sku := range receivedSkus {
+		itemObj, exists := supplierObj.Items[sku]
+		if false == exists {
+			itemObj = &model.SupplierItem{Sku: sku}
+			supplierObj.Items[sku] = itemObj
+		}
+		itemObj.LastPrice = purchaseObj.Items[sku].BuyPrice
+		itemObj.LastPurchaseDate = receivedDate
+	}
+	return uow.SupplierDatamapper.Update(supplierObj)
+}
+
+//GetSupplierReport is a function for obtaining the purchase volume and average cost by supplier of the purchases made within the given dates (inclusive)
+//canceled purchases aren't included, the volume is the ordered quantity of draft and done purchases
+func (i *Inventory) GetSupplierReport(startTime, endTime time.Time) (*SupplierReport, *errors.Error) {
+	//validate start and end date
+	if !(startTime.Before(endTime) || startTime.Equal(endTime)) {
+		return nil, errors.Wrap(fmt.Errorf("Invalid start date %v and end date %v from param", startTime.Format("2006-01-02"), endTime.Format("2006-01-02")), 0)
+	}
+	purchases, err := i.ListPurchases()
+	if err != nil {
+		return nil, err
+	}
+	suppliers, err := i.ListSuppliers()
+	if err != nil {
+		return nil, err
+	}
+	supplierNames := make(map[string]string, len(suppliers))
+	for _, val := range suppliers {
+		supplierNames[val.SupplierID] = val.Name
+	}
+
+	report := &SupplierReport{
+		StartDate: startTime,
+		EndDate:   endTime,
+		Suppliers: make([]*SupplierReportItem, 0),
+	}
+	reportItems := make(map[string]*SupplierReportItem)
+	skuItems := make(map[string]map[string]*SupplierReportSkuItem) //supplier id -> sku -> item
+	//the end date is included as a whole
+	endOfPeriod := endTime.AddDate(0, 0, 1)
+	for _, val := range purchases {
+		if val.Status == model.PurchaseStatusCanceled || val.Date.Before(startTime) || false == val.Date.Before(endOfPeriod) {
+			continue
+		}
+		reportItem, exists := reportItems[val.SupplierID]
+		if false == exists {
+			reportItem = &SupplierReportItem{
+				SupplierID: val.SupplierID,
+				Name:       supplierNames[val.SupplierID],
+			}
+			reportItems[val.SupplierID] = reportItem
+			skuItems[val.SupplierID] = make(map[string]*SupplierReportSkuItem)
+			report.Suppliers = append(report.Suppliers, reportItem)
+		}
+		reportItem.PurchaseCount++
+		report.PurchaseCount++
+		for _, itemVal := range val.Items {
+			amount := itemVal.BuyPrice.Multiply(itemVal.Quantity)
+			skuItem, exists := skuItems[val.SupplierID][itemVal.Sku]
+			if false == exists {
+				skuItem = &SupplierReportSkuItem{Sku: itemVal.Sku}
+				skuItems[val.SupplierID][itemVal.Sku] = skuItem
+				reportItem.Items = append(reportItem.Items, skuItem)
+			}
+			skuItem.TotalQuantity += itemVal.Quantity
+			skuItem.TotalAmount += amount
+			reportItem.TotalQuantity += itemVal.Quantity
+			reportItem.TotalAmount += amount
+			report.TotalQuantity += itemVal.Quantity
+			report.TotalAmount += amount
+		}
+	}
+
+	sort.Slice(report.Suppliers, func(x, y int) bool {
+		return report.Suppliers[x].SupplierID < report.Suppliers[y].SupplierID
+	})
+	for _, val := range report.Suppliers {
+		val.AverageCost = val.TotalAmount.Divide(val.TotalQuantity)
+		for _, itemVal := range val.Items {
+			itemVal.AverageCost = itemVal.TotalAmount.Divide(itemVal.TotalQuantity)
+		}
+		sort.Slice(val.Items, func(x, y int) bool {
+			return val.Items[x].Sku < val.Items[y].Sku
+		})
+	}
+	return report, nil
+}
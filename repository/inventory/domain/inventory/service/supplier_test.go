@@ -0,0 +1,160 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+	"time"
+)
+
+func TestSupplier(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+
+	err := inventoryObj.CreateSupplier(service.SupplierDetail{SupplierID: "ABC", PaymentTermDays: 30})
+	t.Run("supplier without name must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	err = inventoryObj.CreateSupplier(service.SupplierDetail{SupplierID: "ABC", Name: "Pabrik ABC", Phone: "021-555", PaymentTermDays: 30})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	err = inventoryObj.UpdateSupplier(service.SupplierDetail{SupplierID: "ABC", Name: "Pabrik ABC", Phone: "021-777", PaymentTermDays: 14})
+	errs := inventoryObj.UpdateSupplierItem("ABC", "dummySku", "ABC-001")
+	supplierObj, errg := inventoryObj.GetSupplier("ABC")
+	t.Run("supplier must be updated along with its item codes", func(t *testing.T) {
+		if err != nil || errs != nil || errg != nil {
+			t.Fatalf("expected nil but got %v, %v and %v", err, errs, errg)
+		}
+		if supplierObj.Phone != "021-777" || supplierObj.PaymentTermDays != 14 {
+			t.Errorf("expected phone 021-777 and payment term 14 but got %+v", supplierObj)
+		}
+		if itemObj, exists := supplierObj.Items["dummySku"]; false == exists || itemObj.SupplierSku != "ABC-001" {
+			t.Errorf("expected supplier sku ABC-001 but got %+v", supplierObj.Items)
+		}
+	})
+
+	_, err = inventoryObj.CreatePurchase("PO-1", "XYZ", "", []service.PurchaseItem{{Sku: "dummySku", Quantity: 5, BuyPrice: 45000}})
+	t.Run("purchase from missing supplier must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+
+	_, err = inventoryObj.CreatePurchase("PO-1", "ABC", "", []service.PurchaseItem{{Sku: "dummySku", Quantity: 5, BuyPrice: 45000}})
+	purchaseObj, _ := inventoryObj.GetPurchase("PO-1")
+	supplierObj, _ = inventoryObj.GetSupplier("ABC")
+	t.Run("buy prices of a draft purchase must not become the last prices of the supplier", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if purchaseObj.SupplierID != "ABC" {
+			t.Errorf("expected supplier ABC but got %v", purchaseObj.SupplierID)
+		}
+		itemObj := supplierObj.Items["dummySku"]
+		if itemObj.LastPrice != 0 || false == itemObj.LastPurchaseDate.IsZero() {
+			t.Errorf("expected no last price but got %+v", itemObj)
+		}
+	})
+
+	_, err = inventoryObj.UpdatePurchaseStatus("PO-1", model.PurchaseStatusCanceled)
+	supplierObj, _ = inventoryObj.GetSupplier("ABC")
+	t.Run("buy prices of a canceled purchase must not become the last prices of the supplier", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if itemObj := supplierObj.Items["dummySku"]; itemObj.LastPrice != 0 {
+			t.Errorf("expected no last price but got %+v", itemObj)
+		}
+	})
+
+	_, err = inventoryObj.CreatePurchase("PO-2", "ABC", "", []service.PurchaseItem{{Sku: "dummySku", Quantity: 5, BuyPrice: 47000}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.ReceivePurchase("PO-2", "RCV-1", "", []service.ReceiptItem{{Sku: "dummySku", Quantity: 2}}, false)
+	supplierObj, _ = inventoryObj.GetSupplier("ABC")
+	t.Run("buy prices must become the last prices of the supplier once received", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		itemObj := supplierObj.Items["dummySku"]
+		if itemObj.LastPrice != 47000 || itemObj.SupplierSku != "ABC-001" || true == itemObj.LastPurchaseDate.IsZero() {
+			t.Errorf("expected last price 47000 of ABC-001 but got %+v", itemObj)
+		}
+	})
+
+	_, err = inventoryObj.CreatePurchase("PO-3", "ABC", "", []service.PurchaseItem{{Sku: "dummySku", Quantity: 5, BuyPrice: 46000}})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	_, err = inventoryObj.UpdatePurchaseStatus("PO-3", model.PurchaseStatusDone)
+	supplierObj, _ = inventoryObj.GetSupplier("ABC")
+	t.Run("buy prices must become the last prices of the supplier once the purchase is done", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if itemObj := supplierObj.Items["dummySku"]; itemObj.LastPrice != 46000 {
+			t.Errorf("expected last price 46000 but got %+v", itemObj)
+		}
+	})
+}
+
+func TestSupplierReport(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	err := inventoryObj.CreateSupplier(service.SupplierDetail{SupplierID: "ABC", Name: "Pabrik ABC"})
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	purchases := []struct {
+		purchaseID, supplierID string
+		quantity               int64
+		buyPrice               model.Money
+	}{
+		{"PO-1", "ABC", 10, 45000},
+		{"PO-2", "ABC", 5, 48000},
+		{"PO-3", "", 4, 50000},
+		{"PO-4", "ABC", 100, 10000},
+	}
+	for _, val := range purchases {
+		_, err = inventoryObj.CreatePurchase(val.purchaseID, val.supplierID, "", []service.PurchaseItem{{Sku: "dummySku", Quantity: val.quantity, BuyPrice: val.buyPrice}})
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	_, err = inventoryObj.UpdatePurchaseStatus("PO-4", model.PurchaseStatusCanceled)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	report, err := inventoryObj.GetSupplierReport(time.Now().AddDate(0, 0, -1), time.Now())
+	t.Run("purchases must be reported by supplier", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if report.PurchaseCount != 3 || report.TotalQuantity != 19 || len(report.Suppliers) != 2 {
+			t.Fatalf("expected 3 purchases of 19 items from 2 suppliers but got %+v", report)
+		}
+		if report.Suppliers[0].SupplierID != "" || report.Suppliers[0].TotalAmount != 200000 {
+			t.Errorf("expected purchases without supplier worth 200000 but got %+v", report.Suppliers[0])
+		}
+		supplierItem := report.Suppliers[1]
+		if supplierItem.Name != "Pabrik ABC" || supplierItem.PurchaseCount != 2 || supplierItem.TotalQuantity != 15 || supplierItem.AverageCost != 46000 {
+			t.Errorf("expected 2 purchases of 15 items at 46000 (canceled excluded) but got %+v", supplierItem)
+		}
+		if len(supplierItem.Items) != 1 || supplierItem.Items[0].TotalAmount != 690000 {
+			t.Errorf("expected dummySku worth 690000 but got %+v", supplierItem.Items)
+		}
+	})
+
+	_, err = inventoryObj.GetSupplierReport(time.Now(), time.Now().AddDate(0, 0, -1))
+	t.Run("invalid period must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
@@ -0,0 +1,153 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//UnitOfWork is a set of the inventory datamappers bound to a single database transaction (or memory store transaction)
+//every find and change made through the unit of work is part of the transaction, so they're committed or rolled back as a whole
+type UnitOfWork struct {
+	Tx                             datamapper.Tx
+	StockDatamapper                datamapper.DataMapper
+	ProductDatamapper              datamapper.DataMapper
+	CategoryDatamapper             datamapper.DataMapper
+	AttributeDatamapper            datamapper.DataMapper
+	ItemAttributesDatamapper       datamapper.DataMapper
+	SupplierDatamapper             datamapper.DataMapper
+	PurchaseDatamapper             datamapper.DataMapper
+	PurchaseReceiptDatamapper      datamapper.DataMapper
+	SalesDatamapper                datamapper.DataMapper
+	SalesReturnDatamapper          datamapper.DataMapper
+	StockMovementDatamapper        datamapper.DataMapper
+	CostLayerDatamapper            datamapper.DataMapper
+	StockCountDatamapper           datamapper.DataMapper
+	StockAlertDatamapper           datamapper.DataMapper
+	SaleStatusTransitionDatamapper datamapper.DataMapper
+	alertSender                    *AlertSender        //alert sender of the service the unit of work was begun on
+	raisedAlerts                   []*model.StockAlert //alerts raised or escalated in the unit of work, handed to the alert sender once it's committed
+}
+
+//bindMapper is a function for binding a datamapper to the given transaction (a *sql.Tx or a *datamapper.MemoryTx)
+//a datamapper which can't join the transaction is an error, since its changes wouldn't be committed or rolled back with the others
+func bindMapper(mapper datamapper.DataMapper, tx datamapper.Tx) (datamapper.DataMapper, *errors.Error) {
+	if mapper == nil {
+		//datamapper not set on the service (e.g. see NewInventory), it can't be used inside the unit of work either
+		return nil, nil
+	}
+	switch txObj := tx.(type) {
+	case *sql.Tx:
+		if binder, ok := mapper.(datamapper.TxBinder); true == ok {
+			return binder.WithTx(txObj), nil
+		}
+	case *datamapper.MemoryTx:
+		if binder, ok := mapper.(datamapper.MemoryTxBinder); true == ok {
+			return binder.WithMemoryTx(txObj), nil
+		}
+	}
+	return nil, errors.Wrap(fmt.Errorf("Datamapper %T can't join transaction %T", mapper, tx), 0)
+}
+
+//Begin is a function for starting a new unit of work on the db session of the service
+//the unit of work runs on the memory store instead when the service has one (see MemoryStore)
+func (i *Inventory) Begin() (*UnitOfWork, *errors.Error) {
+	var tx datamapper.Tx
+	if i.MemoryStore != nil {
+		tx = i.MemoryStore.Begin()
+	} else {
+		sqlTx, err := i.DB.Begin()
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		tx = sqlTx
+	}
+	uow := &UnitOfWork{
+		Tx:          tx,
+		alertSender: i.AlertSender,
+	}
+	bindings := []struct {
+		target *datamapper.DataMapper
+		mapper datamapper.DataMapper
+	}{
+		{&uow.StockDatamapper, i.StockDatamapper},
+		{&uow.ProductDatamapper, i.ProductDatamapper},
+		{&uow.CategoryDatamapper, i.CategoryDatamapper},
+		{&uow.AttributeDatamapper, i.AttributeDatamapper},
+		{&uow.ItemAttributesDatamapper, i.ItemAttributesDatamapper},
+		{&uow.SupplierDatamapper, i.SupplierDatamapper},
+		{&uow.PurchaseDatamapper, i.PurchaseDatamapper},
+		{&uow.PurchaseReceiptDatamapper, i.PurchaseReceiptDatamapper},
+		{&uow.SalesDatamapper, i.SalesDatamapper},
+		{&uow.SalesReturnDatamapper, i.SalesReturnDatamapper},
+		{&uow.StockMovementDatamapper, i.StockMovementDatamapper},
+		{&uow.CostLayerDatamapper, i.CostLayerDatamapper},
+		{&uow.StockCountDatamapper, i.StockCountDatamapper},
+		{&uow.StockAlertDatamapper, i.StockAlertDatamapper},
+		{&uow.SaleStatusTransitionDatamapper, i.SaleStatusTransitionDatamapper},
+	}
+	for _, val := range bindings {
+		boundMapper, err := bindMapper(val.mapper, tx)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		*val.target = boundMapper
+	}
+	return uow, nil
+}
+
+//Commit is a function for committing every change made through the unit of work
+//the low stock alerts raised in the unit of work are handed to the alert sender once the changes are committed (delivered in the background)
+func (uow *UnitOfWork) Commit() *errors.Error {
+	err := uow.Tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	if uow.alertSender != nil {
+		uow.alertSender.Send(uow.raisedAlerts)
+	}
+	return nil
+}
+
+//Rollback is a function for discarding every change made through the unit of work
+func (uow *UnitOfWork) Rollback() *errors.Error {
+	err := uow.Tx.Rollback()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//inTransaction is a function for running fn in a new unit of work
+//the unit of work is committed when fn succeeds and rolled back when fn returns an error
+func (i *Inventory) inTransaction(fn func(uow *UnitOfWork) *errors.Error) *errors.Error {
+	uow, err := i.Begin()
+	if err != nil {
+		return err
+	}
+	err = fn(uow)
+	if err != nil {
+		uow.Rollback()
+		return err
+	}
+	return uow.Commit()
+}
+
+//getStock is a function for obtaining an item inside the unit of work
+func (uow *UnitOfWork) getStock(sku string) (*model.Stock, *errors.Error) {
+	foundItem, err := uow.StockDatamapper.FindByID(sku)
+	if err != nil {
+		return nil, err
+	}
+	foundItemObj, ok := foundItem.(*model.Stock)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	return foundItemObj, nil
+}
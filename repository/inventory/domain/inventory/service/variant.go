@@ -0,0 +1,334 @@
+//Package service provide definitions for inventory service layer
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+)
+
+//DefaultSkuPattern is the sku pattern used when none is configured (see CompileSkuPattern)
+//e.g. SSI-D00864612-LL-NAV is size LL and color NAV of product D00864612
+const DefaultSkuPattern string = `^[A-Z0-9]+-(?P<product>[A-Z0-9]+)-(?P<size>[A-Z0-9]+)-(?P<color>[A-Z0-9]+)$`
+
+//GroupByProduct is const for grouping report items by the product their sku is a variant of
+const GroupByProduct string = "product"
+
+//GroupBySize is const for grouping report items by the size of their sku
+const GroupBySize string = "size"
+
+//GroupByColor is const for grouping report items by the color of their sku
+const GroupByColor string = "color"
+
+//productNameSuffix matches the variant part closing the name of an item, e.g. " (L,Navy)" of "Deklia Plain Casual Blouse (L,Navy)"
+var productNameSuffix = regexp.MustCompile(`\s*\([^()]*\)\s*$`)
+
+//ProductInfo is a struct containing a product along with its variants
+type ProductInfo struct {
+	*model.Product
+	Variants []*model.Stock
+}
+
+//VariantBackfill is a struct containing the result of assigning stock items to their products (see BackfillVariants)
+type VariantBackfill struct {
+	Assigned    int      `json:"assigned"`    //number of items assigned to a product
+	NewProducts int      `json:"newProducts"` //number of products created for the assigned items
+	Unmatched   []string `json:"unmatched"`   //skus which don't follow the sku pattern (left without product)
+}
+
+//StockValueGroup is a struct containing stock value subtotals of the items sharing a product, size, color or category
+type StockValueGroup struct {
+	Key           string      `json:"key"`            //product id, size, color or category id, empty for items which aren't variants (or aren't categorized)
+	Name          string      `json:"name,omitempty"` //product or category name (grouped by product or category only)
+	TotalQuantity int64       `json:"totalQuantity"`
+	TotalAmount   model.Money `json:"totalAmount"`
+	TotalItemKind int         `json:"totalItemKind"`
+}
+
+//SaleValueGroup is a struct containing sales value subtotals of the items sharing a product, size, color or category
+type SaleValueGroup struct {
+	Key           string      `json:"key"`            //product id, size, color or category id, empty for items which aren't variants (or aren't categorized)
+	Name          string      `json:"name,omitempty"` //product or category name (grouped by product or category only)
+	TotalQuantity int64       `json:"totalQuantity"`
+	TotalItemKind int         `json:"totalItemKind"`
+	SalesTurnOver model.Money `json:"omzet"`
+	Profit        model.Money `json:"totalProfit"`
+}
+
+//CompileSkuPattern is a function for compiling a sku pattern, a regular expression capturing the product id of a sku in a group named product
+//and optionally the size and color in groups named size and color (see DefaultSkuPattern), the default pattern is used when pattern is empty
+func CompileSkuPattern(pattern string) (*regexp.Regexp, *errors.Error) {
+	if pattern == "" {
+		pattern = DefaultSkuPattern
+	}
+	skuPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("Invalid sku pattern %v: %v", pattern, err), 0)
+	}
+	if skuPattern.SubexpIndex("product") < 0 {
+		return nil, errors.Wrap(fmt.Errorf("Invalid sku pattern %v: missing product group (?P<product>...)", pattern), 0)
+	}
+	return skuPattern, nil
+}
+
+//parseSku is a function for assigning the product, size and color of a sku to the item, it returns false when the sku doesn't follow the pattern
+func parseSku(skuPattern *regexp.Regexp, stockObj *model.Stock) bool {
+	match := skuPattern.FindStringSubmatch(stockObj.Sku)
+	if match == nil || match[skuPattern.SubexpIndex("product")] == "" {
+		return false
+	}
+	stockObj.ProductID = match[skuPattern.SubexpIndex("product")]
+	stockObj.Size = ""
+	if index := skuPattern.SubexpIndex("size"); index >= 0 {
+		stockObj.Size = match[index]
+	}
+	stockObj.Color = ""
+	if index := skuPattern.SubexpIndex("color"); index >= 0 {
+		stockObj.Color = match[index]
+	}
+	return true
+}
+
+//productName is a function for obtaining the product name of an item name (the name without its variant part)
+func productName(itemName string) string {
+	return strings.TrimSpace(productNameSuffix.ReplaceAllString(itemName, ""))
+}
+
+//assignVariant is a function for assigning an item to the product of its sku (inside the passed unit of work), the item itself isn't saved
+//the product is created when it doesn't exist yet, it returns whether the sku follows the pattern and whether the product was created
+func (i *Inventory) assignVariant(uow *UnitOfWork, skuPattern *regexp.Regexp, stockObj *model.Stock) (bool, bool, *errors.Error) {
+	if false == parseSku(skuPattern, stockObj) {
+		return false, false, nil
+	}
+
+	foundProduct, err := uow.ProductDatamapper.FindByID(stockObj.ProductID)
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return false, false, err
+	}
+	if err != nil {
+		//first variant of the product
+		err = uow.ProductDatamapper.Insert(&model.Product{
+			ProductID: stockObj.ProductID,
+			Name:      productName(stockObj.Name),
+		})
+		if err != nil {
+			return false, false, err
+		}
+		return true, true, nil
+	}
+	productObj, ok := foundProduct.(*model.Product)
+	if false == ok {
+		return false, false, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	if productObj.Name == "" && stockObj.Name != "" {
+		//product created by a variant without name
+		productObj.Name = productName(stockObj.Name)
+		err = uow.ProductDatamapper.Update(productObj)
+		if err != nil {
+			return false, false, err
+		}
+	}
+	return true, false, nil
+}
+
+//BackfillVariants is a function for assigning every item which isn't a variant yet to the product of its sku (see SkuPattern)
+//items whose sku doesn't follow the pattern are left as they are, items already assigned aren't parsed again
+func (i *Inventory) BackfillVariants() (*VariantBackfill, *errors.Error) {
+	skuPattern, err := CompileSkuPattern(i.SkuPattern)
+	if err != nil {
+		return nil, err
+	}
+	backfill := &VariantBackfill{
+		Unmatched: make([]string, 0),
+	}
+	err = i.inTransaction(func(uow *UnitOfWork) *errors.Error {
+		currentStock, err := uow.StockDatamapper.FindAll()
+		if err != nil && err.Err != datamapper.ErrNotFound {
+			return err
+		}
+		for _, val := range currentStock {
+			valObj, ok := val.(*model.Stock)
+			if false == ok {
+				return errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+			}
+			if true == valObj.IsVariant() {
+				continue
+			}
+			matched, created, err := i.assignVariant(uow, skuPattern, valObj)
+			if err != nil {
+				return err
+			}
+			if false == matched {
+				backfill.Unmatched = append(backfill.Unmatched, valObj.Sku)
+				continue
+			}
+			err = uow.StockDatamapper.Update(valObj)
+			if err != nil {
+				return err
+			}
+			backfill.Assigned++
+			if true == created {
+				backfill.NewProducts++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backfill, nil
+}
+
+//GetProduct is a function for obtaining a product along with its variants (ordered by sku)
+func (i *Inventory) GetProduct(productID string) (*ProductInfo, *errors.Error) {
+	foundProduct, err := i.ProductDatamapper.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	productObj, ok := foundProduct.(*model.Product)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+	}
+	variantFinder, ok := i.StockDatamapper.(datamapper.VariantFinder)
+	if false == ok {
+		return nil, errors.Wrap(fmt.Errorf("Failed asserting stock mapper"), 0)
+	}
+	variants, err := variantFinder.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	productInfo := &ProductInfo{
+		Product:  productObj,
+		Variants: make([]*model.Stock, 0, len(variants)),
+	}
+	for _, val := range variants {
+		valObj, ok := val.(*model.Stock)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		productInfo.Variants = append(productInfo.Variants, valObj)
+	}
+	return productInfo, nil
+}
+
+//variantGroup is a group of report items along with the name of the group
+type variantGroup struct {
+	key  string
+	name string
+}
+
+//variantGroups is a function for obtaining the group of every current sku when grouping by product, size, color or category
+//skus missing from the result (e.g. removed items) belong to the group with empty key
+func (i *Inventory) variantGroups(groupBy string) (map[string]variantGroup, *errors.Error) {
+	if groupBy != GroupByProduct && groupBy != GroupBySize && groupBy != GroupByColor && groupBy != GroupByCategory {
+		return nil, errors.Wrap(fmt.Errorf("Invalid group by %v (should be %v, %v, %v or %v)", groupBy, GroupByProduct, GroupBySize, GroupByColor, GroupByCategory), 0)
+	}
+	currentStock, err := i.StockDatamapper.FindAll()
+	if err != nil && err.Err != datamapper.ErrNotFound {
+		return nil, err
+	}
+	groupNames := make(map[string]string) //product or category names
+	switch groupBy {
+	case GroupByProduct:
+		products, err := i.ProductDatamapper.FindAll()
+		if err != nil && err.Err != datamapper.ErrNotFound {
+			return nil, err
+		}
+		for _, val := range products {
+			groupNames[val.GetID()] = val.(*model.Product).Name
+		}
+	case GroupByCategory:
+		categories, err := findCategories(i.CategoryDatamapper)
+		if err != nil {
+			return nil, err
+		}
+		for key, val := range categories {
+			groupNames[key] = val.Name
+		}
+	}
+
+	groups := make(map[string]variantGroup, len(currentStock))
+	for _, val := range currentStock {
+		valObj, ok := val.(*model.Stock)
+		if false == ok {
+			return nil, errors.Wrap(fmt.Errorf("Failed asserting returned model"), 0)
+		}
+		switch groupBy {
+		case GroupByProduct:
+			groups[valObj.Sku] = variantGroup{key: valObj.ProductID, name: groupNames[valObj.ProductID]}
+		case GroupBySize:
+			groups[valObj.Sku] = variantGroup{key: valObj.Size}
+		case GroupByColor:
+			groups[valObj.Sku] = variantGroup{key: valObj.Color}
+		case GroupByCategory:
+			groups[valObj.Sku] = variantGroup{key: valObj.CategoryID, name: groupNames[valObj.CategoryID]}
+		}
+	}
+	return groups, nil
+}
+
+//GroupStockValue is a function for composing the subtotals of the items of a stock value by product, size, color or category (see GroupBy consts)
+//groups are ordered by key
+func (i *Inventory) GroupStockValue(stockValue *StockValue, groupBy string) *errors.Error {
+	groups, err := i.variantGroups(groupBy)
+	if err != nil {
+		return err
+	}
+	groupMap := make(map[string]*StockValueGroup)
+	stockValue.Groups = make([]*StockValueGroup, 0)
+	for _, val := range stockValue.Items {
+		group := groups[val.Sku]
+		groupObj, exists := groupMap[group.key]
+		if false == exists {
+			groupObj = &StockValueGroup{Key: group.key, Name: group.name}
+			groupMap[group.key] = groupObj
+			stockValue.Groups = append(stockValue.Groups, groupObj)
+		}
+		groupObj.TotalQuantity += val.Quantity
+		groupObj.TotalAmount += val.TotalAmount
+		groupObj.TotalItemKind++
+	}
+	sort.Slice(stockValue.Groups, func(x, y int) bool {
+		return stockValue.Groups[x].Key < stockValue.Groups[y].Key
+	})
+	return nil
+}
+
+//GroupSalesValue is a function for composing the subtotals of the items of a sales value by product, size, color or category (see GroupBy consts)
+//returned items are netted out of their group, groups are ordered by key
+func (i *Inventory) GroupSalesValue(salesValue *SaleValue, groupBy string) *errors.Error {
+	groups, err := i.variantGroups(groupBy)
+	if err != nil {
+		return err
+	}
+	groupMap := make(map[string]*SaleValueGroup)
+	groupSkus := make(map[string]map[string]bool) //skus sold in every group, for counting total kind of sku
+	salesValue.Groups = make([]*SaleValueGroup, 0)
+	for _, val := range salesValue.Items {
+		group := groups[val.Sku]
+		groupObj, exists := groupMap[group.key]
+		if false == exists {
+			groupObj = &SaleValueGroup{Key: group.key, Name: group.name}
+			groupMap[group.key] = groupObj
+			groupSkus[group.key] = make(map[string]bool)
+			salesValue.Groups = append(salesValue.Groups, groupObj)
+		}
+		if val.Quantity > 0 && false == groupSkus[group.key][val.Sku] {
+			groupSkus[group.key][val.Sku] = true
+			groupObj.TotalItemKind++
+		}
+		groupObj.TotalQuantity += val.Quantity
+		groupObj.SalesTurnOver += val.SellPrice.Multiply(val.Quantity)
+		groupObj.Profit += val.Profit
+	}
+	sort.Slice(salesValue.Groups, func(x, y int) bool {
+		return salesValue.Groups[x].Key < salesValue.Groups[y].Key
+	})
+	return nil
+}
@@ -0,0 +1,165 @@
+//service_test provides unit tests for user domain service layer
+package service_test
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"testing"
+)
+
+//newVariantInventory is a function for composing an inventory service on a memory store holding dummySku and 3 items added before products existed
+//(2 variants of D00864612 and 1 variant of D01322234)
+func newVariantInventory(t *testing.T) *service.Inventory {
+	inventoryObj := newMemoryInventory(t)
+	stocks := []*model.Stock{
+		{Sku: "SSI-D00864612-LL-NAV", Name: "Deklia Plain Casual Blouse (L,Navy)", Quantity: 10, BuyPrice: 55000, SellPrice: 60000},
+		{Sku: "SSI-D00864612-XL-NAV", Name: "Deklia Plain Casual Blouse (XL,Navy)", Quantity: 5, BuyPrice: 55000, SellPrice: 60000},
+		{Sku: "SSI-D01322234-LL-WHI", Name: "Thafqya Plain Raglan Blouse (L,White)", Quantity: 2, BuyPrice: 61000, SellPrice: 65000},
+	}
+	for _, val := range stocks {
+		err := inventoryObj.StockDatamapper.Insert(val)
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+	}
+	return inventoryObj
+}
+
+func TestBackfillVariants(t *testing.T) {
+	inventoryObj := newVariantInventory(t)
+
+	backfill, err := inventoryObj.BackfillVariants()
+	t.Run("items following the sku pattern must be assigned", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if backfill.Assigned != 3 || backfill.NewProducts != 2 || len(backfill.Unmatched) != 1 || backfill.Unmatched[0] != "dummySku" {
+			t.Errorf("expected 3 items of 2 new products and unmatched dummySku but got %+v", backfill)
+		}
+	})
+
+	productInfo, err := inventoryObj.GetProduct("D00864612")
+	t.Run("product must be found along with its variants", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if productInfo.Name != "Deklia Plain Casual Blouse" || len(productInfo.Variants) != 2 {
+			t.Fatalf("expected Deklia Plain Casual Blouse with 2 variants but got %+v", productInfo)
+		}
+		if productInfo.Variants[0].Size != "LL" || productInfo.Variants[1].Size != "XL" || productInfo.Variants[1].Color != "NAV" {
+			t.Errorf("expected sizes LL and XL of color NAV but got %+v and %+v", productInfo.Variants[0], productInfo.Variants[1])
+		}
+	})
+
+	backfill, err = inventoryObj.BackfillVariants()
+	t.Run("assigned items must not be assigned again", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if backfill.Assigned != 0 || backfill.NewProducts != 0 {
+			t.Errorf("expected nothing assigned but got %+v", backfill)
+		}
+	})
+}
+
+func TestAddSKUVariant(t *testing.T) {
+	inventoryObj := newMemoryInventory(t)
+	inventoryObj.SkuPattern = `^TS-(?P<product>[0-9]+)(?P<size>[SML])$`
+
+	err := inventoryObj.AddSKU("TS-001M", 5, 50000, 60000)
+	itemInfo, _ := inventoryObj.GetItemInfo("TS-001M")
+	productInfo, errs := inventoryObj.GetProduct("001")
+	t.Run("added item must be a variant of the product of its sku", func(t *testing.T) {
+		if err != nil || errs != nil {
+			t.Fatalf("expected nil but got %v and %v", err, errs)
+		}
+		if itemInfo.ProductID != "001" || itemInfo.Size != "M" || itemInfo.Color != "" {
+			t.Errorf("expected size M of 001 but got %+v", itemInfo)
+		}
+		if len(productInfo.Variants) != 1 || productInfo.Variants[0].Sku != "TS-001M" {
+			t.Errorf("expected variant TS-001M but got %+v", productInfo.Variants)
+		}
+	})
+
+	inventoryObj.SkuPattern = `^TS-(?P<item>[0-9]+)$`
+	err = inventoryObj.AddSKU("TS-002", 5, 50000, 60000)
+	t.Run("sku pattern without product group must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+func TestGroupStockValue(t *testing.T) {
+	inventoryObj := newVariantInventory(t)
+	_, err := inventoryObj.BackfillVariants()
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	stockValue, err := inventoryObj.GetAllStockValue()
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+
+	err = inventoryObj.GroupStockValue(stockValue, service.GroupByProduct)
+	t.Run("stock value must be grouped by product", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(stockValue.Groups) != 3 || stockValue.Groups[0].Key != "" || stockValue.Groups[1].Key != "D00864612" {
+			t.Fatalf("expected groups of no product, D00864612 and D01322234 but got %v groups", len(stockValue.Groups))
+		}
+		groupObj := stockValue.Groups[1]
+		if groupObj.Name != "Deklia Plain Casual Blouse" || groupObj.TotalQuantity != 15 || groupObj.TotalAmount != 825000 || groupObj.TotalItemKind != 2 {
+			t.Errorf("expected 2 items worth 825000 but got %+v", groupObj)
+		}
+	})
+
+	err = inventoryObj.GroupStockValue(stockValue, service.GroupBySize)
+	t.Run("stock value must be grouped by size", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(stockValue.Groups) != 3 || stockValue.Groups[1].Key != "LL" || stockValue.Groups[1].TotalQuantity != 12 || stockValue.Groups[1].Name != "" {
+			t.Errorf("expected 12 items of size LL but got %+v", stockValue.Groups[1])
+		}
+	})
+
+	err = inventoryObj.GroupStockValue(stockValue, "supplier")
+	t.Run("unknown group must be rejected", func(t *testing.T) {
+		if err == nil {
+			t.Errorf("expected error but got nil")
+		}
+	})
+}
+
+func TestGroupSalesValue(t *testing.T) {
+	inventoryObj := newVariantInventory(t)
+	_, err := inventoryObj.BackfillVariants()
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	salesValue := &service.SaleValue{
+		Items: []*service.SaleValueItem{
+			{Sku: "SSI-D00864612-LL-NAV", Quantity: 3, BuyPrice: 55000, SellPrice: 60000, Profit: 15000},
+			{Sku: "SSI-D01322234-LL-WHI", Quantity: 1, BuyPrice: 61000, SellPrice: 65000, Profit: 4000},
+			{Sku: "SSI-D00864612-XL-NAV", Quantity: 2, BuyPrice: 55000, SellPrice: 60000, Profit: 10000},
+			{Sku: "SSI-D00864612-LL-NAV", Quantity: -1, BuyPrice: 55000, SellPrice: 60000, Profit: -5000},
+		},
+	}
+
+	err = inventoryObj.GroupSalesValue(salesValue, service.GroupByColor)
+	t.Run("sales value must be grouped by color", func(t *testing.T) {
+		if err != nil {
+			t.Fatalf("expected nil but got %v", err)
+		}
+		if len(salesValue.Groups) != 2 || salesValue.Groups[0].Key != "NAV" || salesValue.Groups[1].Key != "WHI" {
+			t.Fatalf("expected groups NAV and WHI but got %v groups", len(salesValue.Groups))
+		}
+		groupObj := salesValue.Groups[0]
+		if groupObj.TotalQuantity != 4 || groupObj.TotalItemKind != 2 || groupObj.SalesTurnOver != 240000 || groupObj.Profit != 20000 {
+			t.Errorf("expected 4 items of 2 kinds, omzet 240000 and profit 20000 (returns netted out) but got %+v", groupObj)
+		}
+	})
+}
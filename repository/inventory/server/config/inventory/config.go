@@ -0,0 +1,29 @@
+//Package inventory is for inventory domain related configurations
+package inventory
+
+//Config is a collection of configuration items
+type Config struct {
+	CostingMethod string //costing method used for consuming cost layers of outgoing items ("fifo" or "movingAverage")
+	SkuPattern    string //regular expression of skus which are variants of a product, capturing the product, size and color (empty for the default pattern)
+	Alerts        Alerts //low stock alerts
+}
+
+//Alerts is a collection of configuration items of low stock alerts
+type Alerts struct {
+	CheckInterval string   //interval of the scheduled check of every item against its reorder point (e.g. "1h"), empty to disable the schedule
+	Notifiers     []string //notifiers delivering the alerts ("log", "webhook" and/or "smtp")
+	WebhookURL    string   //url the webhook notifier posts alerts to
+	SMTPAddress   string   //host:port of the smtp server the smtp notifier mails alerts through (e.g. a local MailHog on localhost:1025)
+	SMTPFrom      string   //sender address of the mailed alerts
+	SMTPTo        []string //recipient addresses of the mailed alerts
+}
+
+//StartUp allows the config to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (c *Config) StartUp() {
+	//initialize the startup process here
+}
+
+//Shutdown allows the config to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (c *Config) Shutdown() {
+	//perform any shutdown process here
+}
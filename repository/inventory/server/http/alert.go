@@ -0,0 +1,60 @@
+package http
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"ijah-inventory/repository/inventory/domain/inventory/notifier"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	inventoryConfig "ijah-inventory/repository/inventory/server/config/inventory"
+)
+
+//composeAlertNotifiers is a function for composing the low stock alert notifiers listed in the alerts config
+func composeAlertNotifiers(alertsConfig inventoryConfig.Alerts) ([]service.AlertNotifier, error) {
+	notifiers := make([]service.AlertNotifier, 0, len(alertsConfig.Notifiers))
+	for _, val := range alertsConfig.Notifiers {
+		switch val {
+		case "log":
+			notifiers = append(notifiers, notifier.NewLog())
+		case "webhook":
+			if alertsConfig.WebhookURL == "" {
+				return nil, fmt.Errorf("webhook notifier needs a webhook url")
+			}
+			notifiers = append(notifiers, notifier.NewWebhook(alertsConfig.WebhookURL))
+		case "smtp":
+			if alertsConfig.SMTPAddress == "" || alertsConfig.SMTPFrom == "" || len(alertsConfig.SMTPTo) == 0 {
+				return nil, fmt.Errorf("smtp notifier needs an smtp address, a sender and recipients")
+			}
+			notifiers = append(notifiers, notifier.NewSMTP(alertsConfig.SMTPAddress, alertsConfig.SMTPFrom, alertsConfig.SMTPTo))
+		default:
+			return nil, fmt.Errorf("unknown notifier %v", val)
+		}
+	}
+	return notifiers, nil
+}
+
+//scheduleAlertCheck is a function for checking stock of every item against its reorder point every interval (until the server stops)
+//the outcome of every check is written to the app log
+func scheduleAlertCheck(inventoryService *service.Inventory, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			alertCheck, errs := inventoryService.CheckStockAlerts()
+			if errs != nil {
+				log.WithFields(log.Fields{
+					"error": errs.Error(),
+				}).Error("Scheduled stock alert check failed")
+				continue
+			}
+			log.WithFields(log.Fields{
+				"checked":   alertCheck.Checked,
+				"raised":    alertCheck.Raised,
+				"escalated": alertCheck.Escalated,
+				"resolved":  alertCheck.Resolved,
+				"open":      alertCheck.Open,
+			}).Info("Scheduled stock alert check done")
+		}
+	}()
+}
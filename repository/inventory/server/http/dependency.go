@@ -4,14 +4,15 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
-
-	_ "github.com/mattn/go-sqlite3"
+	"time"
 
 	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
 	"ijah-inventory/repository/inventory/domain/inventory/service"
 	dbConfig "ijah-inventory/repository/inventory/server/config/database"
 	httpConfig "ijah-inventory/repository/inventory/server/config/http"
+	inventoryConfig "ijah-inventory/repository/inventory/server/config/inventory"
 	"ijah-inventory/repository/inventory/server/http/handler"
+	"ijah-inventory/repository/inventory/server/migration"
 )
 
 //setup is a function where setup of the http server is performed
@@ -40,31 +41,172 @@ func (s *Server) setup() {
 
 	//database config
 	databaseConfig := &dbConfig.Config{
-		DbFile: s.config.GetString("database.filePath"),
+		Driver:     s.config.GetString("database.driver"),
+		DbFile:     s.config.GetString("database.filePath"),
+		DataSource: s.config.GetString("database.dataSource"),
+		SeedFile:   s.config.GetString("database.seedFile"),
 	}
-	//open db session
-	dbSession, err := sql.Open("sqlite3", databaseConfig.DbFile)
-	if err != nil {
-		panic(fmt.Sprintf("Database initialization failed: %v", err))
+
+	var dbSession *sql.DB
+	var memoryStore *datamapper.MemoryStore
+	var stockDatamapper, productDatamapper, purchaseDatamapper, purchaseReceiptDatamapper, stockMovementDatamapper, costLayerDatamapper datamapper.DataMapper
+	var stockCountDatamapper, salesDatamapper, salesReturnDatamapper, saleStatusTransitionDatamapper datamapper.DataMapper
+	var categoryDatamapper, attributeDatamapper, itemAttributesDatamapper, supplierDatamapper, stockAlertDatamapper datamapper.DataMapper
+	if databaseConfig.DriverName() == datamapper.DriverMemory {
+		//in-memory store seeded from the dump (changes are lost when the server stops)
+		memoryStore, err = openMemoryStore(databaseConfig.SeedFile)
+		if err != nil {
+			panic(fmt.Sprintf("Memory store initialization failed: %v", err))
+		}
+
+		stockDatamapper = datamapper.NewMemoryStock(memoryStore)
+		productDatamapper = datamapper.NewMemoryProduct(memoryStore)
+		categoryDatamapper = datamapper.NewMemoryCategory(memoryStore)
+		attributeDatamapper = datamapper.NewMemoryAttribute(memoryStore)
+		itemAttributesDatamapper = datamapper.NewMemoryItemAttributes(memoryStore)
+		supplierDatamapper = datamapper.NewMemorySupplier(memoryStore)
+		purchaseDatamapper = datamapper.NewMemoryPurchase(memoryStore)
+		purchaseReceiptDatamapper = datamapper.NewMemoryPurchaseReceipt(memoryStore)
+		stockMovementDatamapper = datamapper.NewMemoryStockMovement(memoryStore)
+		costLayerDatamapper = datamapper.NewMemoryCostLayer(memoryStore)
+		stockCountDatamapper = datamapper.NewMemoryStockCount(memoryStore)
+		stockAlertDatamapper = datamapper.NewMemoryStockAlert(memoryStore)
+		salesDatamapper = datamapper.NewMemorySale(memoryStore)
+		salesReturnDatamapper = datamapper.NewMemorySalesReturn(memoryStore)
+		saleStatusTransitionDatamapper = datamapper.NewMemorySaleStatusTransition(memoryStore)
+	} else {
+		//sql dialect of the configured database
+		dialect, errm := datamapper.DialectOf(databaseConfig.DriverName())
+		if errm != nil {
+			panic(fmt.Sprintf("Invalid database driver config: %v", errm))
+		}
+		//open db session
+		dbSession, err = sql.Open(dialect.DriverName(), databaseConfig.DataSourceName())
+		if err != nil {
+			panic(fmt.Sprintf("Database initialization failed: %v", err))
+		}
+
+		//bring the database schema up to date by applying pending migrations
+		migrator, errm := migration.NewMigrator(dbSession, dialect)
+		if errm != nil {
+			panic(fmt.Sprintf("Database migration initialization failed: %v", errm))
+		}
+		_, errm = migrator.Up()
+		if errm != nil {
+			panic(fmt.Sprintf("Database migration failed: %v", errm))
+		}
+
+		stockDatamapper = datamapper.NewStock(dbSession, dialect)
+		productDatamapper = datamapper.NewProduct(dbSession, dialect)
+		categoryDatamapper = datamapper.NewCategory(dbSession, dialect)
+		attributeDatamapper = datamapper.NewAttribute(dbSession, dialect)
+		itemAttributesDatamapper = datamapper.NewItemAttributes(dbSession, dialect)
+		supplierDatamapper = datamapper.NewSupplier(dbSession, dialect)
+		purchaseDatamapper = datamapper.NewPurchase(dbSession, dialect)
+		purchaseReceiptDatamapper = datamapper.NewPurchaseReceipt(dbSession, dialect)
+		stockMovementDatamapper = datamapper.NewStockMovement(dbSession, dialect)
+		costLayerDatamapper = datamapper.NewCostLayer(dbSession, dialect)
+		stockCountDatamapper = datamapper.NewStockCount(dbSession, dialect)
+		stockAlertDatamapper = datamapper.NewStockAlert(dbSession, dialect)
+		salesDatamapper = datamapper.NewSale(dbSession, dialect)
+		salesReturnDatamapper = datamapper.NewSalesReturn(dbSession, dialect)
+		saleStatusTransitionDatamapper = datamapper.NewSaleStatusTransition(dbSession, dialect)
 	}
 
-	//register the db session as a service object
-	s.sc.RegisterService("dbSession", dbSession)
+	//register the db session or the memory store as service object, depending on the driver
+	//Note: only the one which is set is registered, the injector can't populate a nil pointer
+	if memoryStore != nil {
+		s.sc.RegisterService("memoryStore", memoryStore)
+	} else {
+		s.sc.RegisterService("dbSession", dbSession)
+	}
 
 	//stock datamapper
-	stockDatamapper := datamapper.NewStock(dbSession)
 	s.sc.RegisterService("stockDatamapper", stockDatamapper)
 
+	//product datamapper
+	s.sc.RegisterService("productDatamapper", productDatamapper)
+
+	//category datamapper
+	s.sc.RegisterService("categoryDatamapper", categoryDatamapper)
+
+	//attribute datamapper
+	s.sc.RegisterService("attributeDatamapper", attributeDatamapper)
+
+	//item attributes datamapper
+	s.sc.RegisterService("itemAttributesDatamapper", itemAttributesDatamapper)
+
+	//supplier datamapper
+	s.sc.RegisterService("supplierDatamapper", supplierDatamapper)
+
 	//purchase datamapper
-	purchaseDatamapper := datamapper.NewPurchase(dbSession)
 	s.sc.RegisterService("purchaseDatamapper", purchaseDatamapper)
 
+	//purchase receipt datamapper
+	s.sc.RegisterService("purchaseReceiptDatamapper", purchaseReceiptDatamapper)
+
+	//stock movement datamapper
+	s.sc.RegisterService("stockMovementDatamapper", stockMovementDatamapper)
+
+	//cost layer datamapper
+	s.sc.RegisterService("costLayerDatamapper", costLayerDatamapper)
+
+	//stock count datamapper
+	s.sc.RegisterService("stockCountDatamapper", stockCountDatamapper)
+
+	//stock alert datamapper
+	s.sc.RegisterService("stockAlertDatamapper", stockAlertDatamapper)
+
 	//sales datamapper
-	salesDatamapper := datamapper.NewSale(dbSession)
 	s.sc.RegisterService("salesDatamapper", salesDatamapper)
 
+	//sales return datamapper
+	s.sc.RegisterService("salesReturnDatamapper", salesReturnDatamapper)
+
+	//sale status transition datamapper
+	s.sc.RegisterService("saleStatusTransitionDatamapper", saleStatusTransitionDatamapper)
+
+	//inventory config
+	inventoryConfigObj := &inventoryConfig.Config{
+		CostingMethod: s.config.GetString("inventory.costingMethod"),
+		SkuPattern:    s.config.GetString("inventory.skuPattern"),
+		Alerts: inventoryConfig.Alerts{
+			CheckInterval: s.config.GetString("inventory.alerts.checkInterval"),
+			Notifiers:     s.config.GetStringSlice("inventory.alerts.notifiers"),
+			WebhookURL:    s.config.GetString("inventory.alerts.webhookUrl"),
+			SMTPAddress:   s.config.GetString("inventory.alerts.smtp.address"),
+			SMTPFrom:      s.config.GetString("inventory.alerts.smtp.from"),
+			SMTPTo:        s.config.GetStringSlice("inventory.alerts.smtp.to"),
+		},
+	}
+	if inventoryConfigObj.CostingMethod != service.CostingMethodFIFO && inventoryConfigObj.CostingMethod != service.CostingMethodMovingAverage {
+		panic(fmt.Sprintf("Invalid costing method config: %v", inventoryConfigObj.CostingMethod))
+	}
+	if _, errs := service.CompileSkuPattern(inventoryConfigObj.SkuPattern); errs != nil {
+		panic(fmt.Sprintf("Invalid sku pattern config: %v", errs))
+	}
+	var alertCheckInterval time.Duration
+	if inventoryConfigObj.Alerts.CheckInterval != "" {
+		alertCheckInterval, err = time.ParseDuration(inventoryConfigObj.Alerts.CheckInterval)
+		if err != nil || alertCheckInterval <= 0 {
+			panic(fmt.Sprintf("Invalid alert check interval config: %v", inventoryConfigObj.Alerts.CheckInterval))
+		}
+	}
+	alertNotifiers, err := composeAlertNotifiers(inventoryConfigObj.Alerts)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid alert notifiers config: %v", err))
+	}
+	s.sc.RegisterService("inventoryConfig", inventoryConfigObj)
+
 	//inventory service
-	inventoryService := &service.Inventory{}
+	inventoryService := &service.Inventory{
+		CostingMethod: inventoryConfigObj.CostingMethod,
+		SkuPattern:    inventoryConfigObj.SkuPattern,
+		AlertSender:   service.NewAlertSender(alertNotifiers, service.DefaultAlertQueueSize),
+		//only one of the db session and the memory store is set, so they are assigned here instead of being injected
+		DB:          dbSession,
+		MemoryStore: memoryStore,
+	}
 	s.sc.RegisterService("inventoryService", inventoryService)
 
 	//test handler
@@ -127,8 +269,294 @@ func (s *Server) setup() {
 	exportSalesCSVHandler.Handle = exportSalesCSVHandler.ExportSalesCSVHandle
 	s.sc.RegisterService("exportSalesCSVHandler", exportSalesCSVHandler)
 
+	//createPurchase Handler
+	createPurchaseHandler := &handler.CreatePurchaseHandler{}
+	createPurchaseHandler.SetContainer(s.sc)
+	createPurchaseHandler.Handle = createPurchaseHandler.CreatePurchaseHandle
+	s.sc.RegisterService("createPurchaseHandler", createPurchaseHandler)
+
+	//getPurchase Handler
+	getPurchaseHandler := &handler.GetPurchaseHandler{}
+	getPurchaseHandler.SetContainer(s.sc)
+	getPurchaseHandler.Handle = getPurchaseHandler.GetPurchaseHandle
+	s.sc.RegisterService("getPurchaseHandler", getPurchaseHandler)
+
+	//listPurchases Handler
+	listPurchasesHandler := &handler.ListPurchasesHandler{}
+	listPurchasesHandler.SetContainer(s.sc)
+	listPurchasesHandler.Handle = listPurchasesHandler.ListPurchasesHandle
+	s.sc.RegisterService("listPurchasesHandler", listPurchasesHandler)
+
+	//updatePurchase Handler
+	updatePurchaseHandler := &handler.UpdatePurchaseHandler{}
+	updatePurchaseHandler.SetContainer(s.sc)
+	updatePurchaseHandler.Handle = updatePurchaseHandler.UpdatePurchaseHandle
+	s.sc.RegisterService("updatePurchaseHandler", updatePurchaseHandler)
+
+	//receivePurchase Handler
+	receivePurchaseHandler := &handler.ReceivePurchaseHandler{}
+	receivePurchaseHandler.SetContainer(s.sc)
+	receivePurchaseHandler.Handle = receivePurchaseHandler.ReceivePurchaseHandle
+	s.sc.RegisterService("receivePurchaseHandler", receivePurchaseHandler)
+
+	//getPurchaseReceipts Handler
+	getPurchaseReceiptsHandler := &handler.GetPurchaseReceiptsHandler{}
+	getPurchaseReceiptsHandler.SetContainer(s.sc)
+	getPurchaseReceiptsHandler.Handle = getPurchaseReceiptsHandler.GetPurchaseReceiptsHandle
+	s.sc.RegisterService("getPurchaseReceiptsHandler", getPurchaseReceiptsHandler)
+
+	//adjustStock Handler
+	adjustStockHandler := &handler.AdjustStockHandler{}
+	adjustStockHandler.SetContainer(s.sc)
+	adjustStockHandler.Handle = adjustStockHandler.AdjustStockHandle
+	s.sc.RegisterService("adjustStockHandler", adjustStockHandler)
+
+	//getStockMovements Handler
+	getStockMovementsHandler := &handler.GetStockMovementsHandler{}
+	getStockMovementsHandler.SetContainer(s.sc)
+	getStockMovementsHandler.Handle = getStockMovementsHandler.GetStockMovementsHandle
+	s.sc.RegisterService("getStockMovementsHandler", getStockMovementsHandler)
+
+	//getSaleHistory Handler
+	getSaleHistoryHandler := &handler.GetSaleHistoryHandler{}
+	getSaleHistoryHandler.SetContainer(s.sc)
+	getSaleHistoryHandler.Handle = getSaleHistoryHandler.GetSaleHistoryHandle
+	s.sc.RegisterService("getSaleHistoryHandler", getSaleHistoryHandler)
+
+	//returnSale Handler
+	returnSaleHandler := &handler.ReturnSaleHandler{}
+	returnSaleHandler.SetContainer(s.sc)
+	returnSaleHandler.Handle = returnSaleHandler.ReturnSaleHandle
+	s.sc.RegisterService("returnSaleHandler", returnSaleHandler)
+
+	//getSalesReturns Handler
+	getSalesReturnsHandler := &handler.GetSalesReturnsHandler{}
+	getSalesReturnsHandler.SetContainer(s.sc)
+	getSalesReturnsHandler.Handle = getSalesReturnsHandler.GetSalesReturnsHandle
+	s.sc.RegisterService("getSalesReturnsHandler", getSalesReturnsHandler)
+
+	//openStockCount Handler
+	openStockCountHandler := &handler.OpenStockCountHandler{}
+	openStockCountHandler.SetContainer(s.sc)
+	openStockCountHandler.Handle = openStockCountHandler.OpenStockCountHandle
+	s.sc.RegisterService("openStockCountHandler", openStockCountHandler)
+
+	//recordStockCount Handler
+	recordStockCountHandler := &handler.RecordStockCountHandler{}
+	recordStockCountHandler.SetContainer(s.sc)
+	recordStockCountHandler.Handle = recordStockCountHandler.RecordStockCountHandle
+	s.sc.RegisterService("recordStockCountHandler", recordStockCountHandler)
+
+	//postStockCount Handler
+	postStockCountHandler := &handler.PostStockCountHandler{}
+	postStockCountHandler.SetContainer(s.sc)
+	postStockCountHandler.Handle = postStockCountHandler.PostStockCountHandle
+	s.sc.RegisterService("postStockCountHandler", postStockCountHandler)
+
+	//getStockCountVariance Handler
+	getStockCountVarianceHandler := &handler.GetStockCountVarianceHandler{}
+	getStockCountVarianceHandler.SetContainer(s.sc)
+	getStockCountVarianceHandler.Handle = getStockCountVarianceHandler.GetStockCountVarianceHandle
+	s.sc.RegisterService("getStockCountVarianceHandler", getStockCountVarianceHandler)
+
+	//listStock Handler
+	listStockHandler := &handler.ListStockHandler{}
+	listStockHandler.SetContainer(s.sc)
+	listStockHandler.Handle = listStockHandler.ListStockHandle
+	s.sc.RegisterService("listStockHandler", listStockHandler)
+
+	//listSales Handler
+	listSalesHandler := &handler.ListSalesHandler{}
+	listSalesHandler.SetContainer(s.sc)
+	listSalesHandler.Handle = listSalesHandler.ListSalesHandle
+	s.sc.RegisterService("listSalesHandler", listSalesHandler)
+
+	//searchStock Handler
+	searchStockHandler := &handler.SearchStockHandler{}
+	searchStockHandler.SetContainer(s.sc)
+	searchStockHandler.Handle = searchStockHandler.SearchStockHandle
+	s.sc.RegisterService("searchStockHandler", searchStockHandler)
+
+	//getProduct Handler
+	getProductHandler := &handler.GetProductHandler{}
+	getProductHandler.SetContainer(s.sc)
+	getProductHandler.Handle = getProductHandler.GetProductHandle
+	s.sc.RegisterService("getProductHandler", getProductHandler)
+
+	//createCategory Handler
+	createCategoryHandler := &handler.CreateCategoryHandler{}
+	createCategoryHandler.SetContainer(s.sc)
+	createCategoryHandler.Handle = createCategoryHandler.CreateCategoryHandle
+	s.sc.RegisterService("createCategoryHandler", createCategoryHandler)
+
+	//updateCategory Handler
+	updateCategoryHandler := &handler.UpdateCategoryHandler{}
+	updateCategoryHandler.SetContainer(s.sc)
+	updateCategoryHandler.Handle = updateCategoryHandler.UpdateCategoryHandle
+	s.sc.RegisterService("updateCategoryHandler", updateCategoryHandler)
+
+	//deleteCategory Handler
+	deleteCategoryHandler := &handler.DeleteCategoryHandler{}
+	deleteCategoryHandler.SetContainer(s.sc)
+	deleteCategoryHandler.Handle = deleteCategoryHandler.DeleteCategoryHandle
+	s.sc.RegisterService("deleteCategoryHandler", deleteCategoryHandler)
+
+	//getCategories Handler
+	getCategoriesHandler := &handler.GetCategoriesHandler{}
+	getCategoriesHandler.SetContainer(s.sc)
+	getCategoriesHandler.Handle = getCategoriesHandler.GetCategoriesHandle
+	s.sc.RegisterService("getCategoriesHandler", getCategoriesHandler)
+
+	//updateItemCategory Handler
+	updateItemCategoryHandler := &handler.UpdateItemCategoryHandler{}
+	updateItemCategoryHandler.SetContainer(s.sc)
+	updateItemCategoryHandler.Handle = updateItemCategoryHandler.UpdateItemCategoryHandle
+	s.sc.RegisterService("updateItemCategoryHandler", updateItemCategoryHandler)
+
+	//createAttribute Handler
+	createAttributeHandler := &handler.CreateAttributeHandler{}
+	createAttributeHandler.SetContainer(s.sc)
+	createAttributeHandler.Handle = createAttributeHandler.CreateAttributeHandle
+	s.sc.RegisterService("createAttributeHandler", createAttributeHandler)
+
+	//updateAttribute Handler
+	updateAttributeHandler := &handler.UpdateAttributeHandler{}
+	updateAttributeHandler.SetContainer(s.sc)
+	updateAttributeHandler.Handle = updateAttributeHandler.UpdateAttributeHandle
+	s.sc.RegisterService("updateAttributeHandler", updateAttributeHandler)
+
+	//deleteAttribute Handler
+	deleteAttributeHandler := &handler.DeleteAttributeHandler{}
+	deleteAttributeHandler.SetContainer(s.sc)
+	deleteAttributeHandler.Handle = deleteAttributeHandler.DeleteAttributeHandle
+	s.sc.RegisterService("deleteAttributeHandler", deleteAttributeHandler)
+
+	//getAttributes Handler
+	getAttributesHandler := &handler.GetAttributesHandler{}
+	getAttributesHandler.SetContainer(s.sc)
+	getAttributesHandler.Handle = getAttributesHandler.GetAttributesHandle
+	s.sc.RegisterService("getAttributesHandler", getAttributesHandler)
+
+	//updateItemAttributes Handler
+	updateItemAttributesHandler := &handler.UpdateItemAttributesHandler{}
+	updateItemAttributesHandler.SetContainer(s.sc)
+	updateItemAttributesHandler.Handle = updateItemAttributesHandler.UpdateItemAttributesHandle
+	s.sc.RegisterService("updateItemAttributesHandler", updateItemAttributesHandler)
+
+	//getItemAttributes Handler
+	getItemAttributesHandler := &handler.GetItemAttributesHandler{}
+	getItemAttributesHandler.SetContainer(s.sc)
+	getItemAttributesHandler.Handle = getItemAttributesHandler.GetItemAttributesHandle
+	s.sc.RegisterService("getItemAttributesHandler", getItemAttributesHandler)
+
+	//createSupplier Handler
+	createSupplierHandler := &handler.CreateSupplierHandler{}
+	createSupplierHandler.SetContainer(s.sc)
+	createSupplierHandler.Handle = createSupplierHandler.CreateSupplierHandle
+	s.sc.RegisterService("createSupplierHandler", createSupplierHandler)
+
+	//updateSupplier Handler
+	updateSupplierHandler := &handler.UpdateSupplierHandler{}
+	updateSupplierHandler.SetContainer(s.sc)
+	updateSupplierHandler.Handle = updateSupplierHandler.UpdateSupplierHandle
+	s.sc.RegisterService("updateSupplierHandler", updateSupplierHandler)
+
+	//getSuppliers Handler
+	getSuppliersHandler := &handler.GetSuppliersHandler{}
+	getSuppliersHandler.SetContainer(s.sc)
+	getSuppliersHandler.Handle = getSuppliersHandler.GetSuppliersHandle
+	s.sc.RegisterService("getSuppliersHandler", getSuppliersHandler)
+
+	//getSupplier Handler
+	getSupplierHandler := &handler.GetSupplierHandler{}
+	getSupplierHandler.SetContainer(s.sc)
+	getSupplierHandler.Handle = getSupplierHandler.GetSupplierHandle
+	s.sc.RegisterService("getSupplierHandler", getSupplierHandler)
+
+	//updateSupplierItem Handler
+	updateSupplierItemHandler := &handler.UpdateSupplierItemHandler{}
+	updateSupplierItemHandler.SetContainer(s.sc)
+	updateSupplierItemHandler.Handle = updateSupplierItemHandler.UpdateSupplierItemHandle
+	s.sc.RegisterService("updateSupplierItemHandler", updateSupplierItemHandler)
+
+	//getSupplierReport Handler
+	getSupplierReportHandler := &handler.GetSupplierReportHandler{}
+	getSupplierReportHandler.SetContainer(s.sc)
+	getSupplierReportHandler.Handle = getSupplierReportHandler.GetSupplierReportHandle
+	s.sc.RegisterService("getSupplierReportHandler", getSupplierReportHandler)
+
+	//getAlerts Handler
+	getAlertsHandler := &handler.GetAlertsHandler{}
+	getAlertsHandler.SetContainer(s.sc)
+	getAlertsHandler.Handle = getAlertsHandler.GetAlertsHandle
+	s.sc.RegisterService("getAlertsHandler", getAlertsHandler)
+
+	//updateReorderSettings Handler
+	updateReorderSettingsHandler := &handler.UpdateReorderSettingsHandler{}
+	updateReorderSettingsHandler.SetContainer(s.sc)
+	updateReorderSettingsHandler.Handle = updateReorderSettingsHandler.UpdateReorderSettingsHandle
+	s.sc.RegisterService("updateReorderSettingsHandler", updateReorderSettingsHandler)
+
+	//checkAlerts Handler
+	checkAlertsHandler := &handler.CheckAlertsHandler{}
+	checkAlertsHandler.SetContainer(s.sc)
+	checkAlertsHandler.Handle = checkAlertsHandler.CheckAlertsHandle
+	s.sc.RegisterService("checkAlertsHandler", checkAlertsHandler)
+
+	//getReplenishment Handler
+	getReplenishmentHandler := &handler.GetReplenishmentHandler{}
+	getReplenishmentHandler.SetContainer(s.sc)
+	getReplenishmentHandler.Handle = getReplenishmentHandler.GetReplenishmentHandle
+	s.sc.RegisterService("getReplenishmentHandler", getReplenishmentHandler)
+
+	//createReplenishmentPurchases Handler
+	createReplenishmentPurchasesHandler := &handler.CreateReplenishmentPurchasesHandler{}
+	createReplenishmentPurchasesHandler.SetContainer(s.sc)
+	createReplenishmentPurchasesHandler.Handle = createReplenishmentPurchasesHandler.CreateReplenishmentPurchasesHandle
+	s.sc.RegisterService("createReplenishmentPurchasesHandler", createReplenishmentPurchasesHandler)
+
+	//forecast Handler
+	forecastHandler := &handler.ForecastHandler{}
+	forecastHandler.SetContainer(s.sc)
+	forecastHandler.Handle = forecastHandler.ForecastHandle
+	s.sc.RegisterService("forecastHandler", forecastHandler)
+
+	//getSalesReport Handler
+	getSalesReportHandler := &handler.GetSalesReportHandler{}
+	getSalesReportHandler.SetContainer(s.sc)
+	getSalesReportHandler.Handle = getSalesReportHandler.GetSalesReportHandle
+	s.sc.RegisterService("getSalesReportHandler", getSalesReportHandler)
+
+	//exportSalesReportCSV Handler
+	exportSalesReportCSVHandler := &handler.ExportSalesReportCSVHandler{}
+	exportSalesReportCSVHandler.SetContainer(s.sc)
+	exportSalesReportCSVHandler.Handle = exportSalesReportCSVHandler.ExportSalesReportCSVHandle
+	s.sc.RegisterService("exportSalesReportCSVHandler", exportSalesReportCSVHandler)
+
+	//getStockTurnover Handler
+	getStockTurnoverHandler := &handler.GetStockTurnoverHandler{}
+	getStockTurnoverHandler.SetContainer(s.sc)
+	getStockTurnoverHandler.Handle = getStockTurnoverHandler.GetStockTurnoverHandle
+	s.sc.RegisterService("getStockTurnoverHandler", getStockTurnoverHandler)
+
+	//getDeadStock Handler
+	getDeadStockHandler := &handler.GetDeadStockHandler{}
+	getDeadStockHandler.SetContainer(s.sc)
+	getDeadStockHandler.Handle = getDeadStockHandler.GetDeadStockHandle
+	s.sc.RegisterService("getDeadStockHandler", getDeadStockHandler)
+
 	//perform injection
 	if err := s.sc.Ready(); err != nil {
 		panic(fmt.Sprintf("Service initialization failed with error: %+v", err))
 	}
+
+	//assign items added before products existed (or added directly to the database) to the products of their skus
+	if _, errs := inventoryService.BackfillVariants(); errs != nil {
+		panic(fmt.Sprintf("Variant backfill failed: %v", errs))
+	}
+
+	//check stock of every item against its reorder point on a schedule (stock drops are checked right away)
+	if alertCheckInterval > 0 {
+		scheduleAlertCheck(inventoryService, alertCheckInterval)
+	}
 }
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"testing"
+
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"github.com/gorilla/mux"
+	"github.com/ncrypthic/gocontainer"
+	"github.com/spf13/viper"
+)
+
+func TestSetupMemoryDriver(t *testing.T) {
+	config := viper.New()
+	config.Set("http.port", "8080")
+	config.Set("database.driver", "memory")
+	config.Set("inventory.costingMethod", service.CostingMethodFIFO)
+	sc := gocontainer.NewContainer()
+	server := NewServer(mux.NewRouter(), sc, config)
+
+	//setup panics when the services can't be composed
+	server.setup()
+
+	inventoryServiceObj, found := sc.GetService("inventoryService")
+	if false == found {
+		t.Fatalf("expected inventory service to be registered")
+	}
+	inventoryService, ok := inventoryServiceObj.(*service.Inventory)
+	if false == ok {
+		t.Fatalf("expected *service.Inventory but got %T", inventoryServiceObj)
+	}
+	t.Run("inventory service must use the memory store", func(t *testing.T) {
+		if inventoryService.MemoryStore == nil {
+			t.Errorf("expected memory store to be set")
+		}
+		if inventoryService.DB != nil {
+			t.Errorf("expected nil db session but got %v", inventoryService.DB)
+		}
+	})
+	t.Run("db session must not be registered", func(t *testing.T) {
+		if _, found := sc.GetService("dbSession"); true == found {
+			t.Errorf("expected db session not to be registered")
+		}
+	})
+	t.Run("memory store must be usable", func(t *testing.T) {
+		if errs := inventoryService.AddSKU("bootSku", 1, 1000, 2000); errs != nil {
+			t.Errorf("expected nil but got %v", errs)
+		}
+	})
+}
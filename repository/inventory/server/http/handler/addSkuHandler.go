@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
 	"ijah-inventory/repository/inventory/domain/inventory/service"
 	"net/http"
 	"strconv"
@@ -28,11 +29,11 @@ func (h *AddSKUHandler) AddSKUHandle(w http.ResponseWriter, r *http.Request) err
 	if err != nil {
 		return composeError(err)
 	}
-	buyPriceParam, err := strconv.ParseFloat(buyPrice, 64)
+	buyPriceParam, err := model.ParseMoney(buyPrice)
 	if err != nil {
 		return composeError(err)
 	}
-	sellPriceParam, err := strconv.ParseFloat(sellPrice, 64)
+	sellPriceParam, err := model.ParseMoney(sellPrice)
 	if err != nil {
 		return composeError(err)
 	}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"strconv"
+)
+
+//AdjustStockHandler is a specific http handler for manually adjusting stock quantity of a sku
+type AdjustStockHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//AdjustStockHandle is the implementation of http handler for a AdjustStockHandler object
+func (h *AdjustStockHandler) AdjustStockHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - sku
+	// - quantity (signed, negative value decreases stock)
+	// - reference
+	// - note
+	sku := r.PostFormValue("sku")
+	quantity := r.PostFormValue("quantity")
+	reference := r.PostFormValue("reference")
+	note := r.PostFormValue("note")
+
+	quantityInt, err := strconv.ParseInt(quantity, 10, 64)
+	if err != nil {
+		return composeError(err)
+	}
+
+	errs := h.InventoryService.AdjustStock(sku, quantityInt, reference, note)
+	if errs != nil {
+		return composeError(errs)
+	}
+
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Stock adjusted successfully"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *AdjustStockHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *AdjustStockHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//CheckAlertsHandler is a specific http handler for checking stock of every item against its reorder point right away (besides the scheduled check)
+type CheckAlertsHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//CheckAlertsHandle is the implementation of http handler for a CheckAlertsHandler object
+func (h *CheckAlertsHandler) CheckAlertsHandle(w http.ResponseWriter, r *http.Request) error {
+	alertCheck, err := h.InventoryService.CheckStockAlerts()
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Stock alerts checked successfully"
+	response.Data = alertCheck
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CheckAlertsHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CheckAlertsHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
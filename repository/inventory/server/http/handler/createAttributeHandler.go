@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//CreateAttributeHandler is a specific http handler for defining an attribute items can have
+type CreateAttributeHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//CreateAttributeHandle is the implementation of http handler for a CreateAttributeHandler object
+func (h *CreateAttributeHandler) CreateAttributeHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - name
+	// - type (text, number, boolean or date)
+	name := r.PostFormValue("name")
+	attributeType := r.PostFormValue("type")
+
+	err := h.InventoryService.CreateAttribute(name, attributeType)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Attribute created"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreateAttributeHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreateAttributeHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
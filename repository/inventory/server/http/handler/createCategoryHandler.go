@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//CreateCategoryHandler is a specific http handler for adding a category to the category tree
+type CreateCategoryHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//CreateCategoryHandle is the implementation of http handler for a CreateCategoryHandler object
+func (h *CreateCategoryHandler) CreateCategoryHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - categoryId
+	// - name
+	// - parentId (optional, empty for root categories)
+	categoryID := r.PostFormValue("categoryId")
+	name := r.PostFormValue("name")
+	parentID := r.PostFormValue("parentId")
+
+	err := h.InventoryService.CreateCategory(categoryID, name, parentID)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Category created"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreateCategoryHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreateCategoryHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+//CreatePurchaseHandler is a specific http handler for creating purchase
+type CreatePurchaseHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//CreatePurchaseHandle is the implementation of http handler for a CreatePurchaseHandler object
+func (h *CreatePurchaseHandler) CreatePurchaseHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - purchaseId
+	// - supplierId (optional)
+	// - note
+	//repeating items
+	// - sku[x]
+	// - quantity[x]
+	// - buyPrice[x]
+	// - itemNote[x]
+	errForm := r.ParseForm()
+	if errForm != nil {
+		return composeError(errForm)
+	}
+
+	var purchaseID, supplierID, note string
+	var itemsSku, itemsQuantity, itemsBuyPrice, itemsNote map[string]string
+
+	itemsSku = make(map[string]string, 0)
+	itemsQuantity = make(map[string]string, 0)
+	itemsBuyPrice = make(map[string]string, 0)
+	itemsNote = make(map[string]string, 0)
+
+	//regex for parsing items in form post data
+	skuRegxp := regexp.MustCompile(`^sku\[(?P<sku>\d+)\]$`)
+	quantityRegxp := regexp.MustCompile(`^quantity\[(?P<quantity>\d+)\]$`)
+	buyPriceRegxp := regexp.MustCompile(`^buyPrice\[(?P<buyPrice>\d+)\]$`)
+	itemNoteRegxp := regexp.MustCompile(`^itemNote\[(?P<itemNote>\d+)\]$`)
+
+	//parse through all post data
+	for key, val := range r.PostForm {
+		if key == "purchaseId" {
+			purchaseID = val[0]
+		}
+		if key == "supplierId" {
+			supplierID = val[0]
+		}
+		if key == "note" {
+			note = val[0]
+		}
+		skuFound := skuRegxp.FindStringSubmatch(key)
+		if len(skuFound) > 0 {
+			//found "sku[x]" pattern in post data
+			itemsSku[skuFound[1]] = val[0]
+		}
+		quantityFound := quantityRegxp.FindStringSubmatch(key)
+		if len(quantityFound) > 0 {
+			//found "quantity[x]" pattern in post data
+			itemsQuantity[quantityFound[1]] = val[0]
+		}
+		buyPriceFound := buyPriceRegxp.FindStringSubmatch(key)
+		if len(buyPriceFound) > 0 {
+			//found "buyPrice[x]" pattern in post data
+			itemsBuyPrice[buyPriceFound[1]] = val[0]
+		}
+		itemNoteFound := itemNoteRegxp.FindStringSubmatch(key)
+		if len(itemNoteFound) > 0 {
+			//found "itemNote[x]" pattern in post data
+			itemsNote[itemNoteFound[1]] = val[0]
+		}
+	}
+
+	//parse obtained sku, quantity and buy price
+	purchaseItemSlice := make([]service.PurchaseItem, 0)
+	for skuKey, skuVal := range itemsSku {
+		theQuantity, err := strconv.ParseInt(itemsQuantity[skuKey], 10, 64)
+		if err != nil {
+			return composeError(err)
+		}
+		theBuyPrice, err := model.ParseMoney(itemsBuyPrice[skuKey])
+		if err != nil {
+			return composeError(err)
+		}
+		newPurchaseItem := service.PurchaseItem{
+			Sku:      skuVal,
+			Quantity: theQuantity,
+			BuyPrice: theBuyPrice,
+			Note:     itemsNote[skuKey],
+		}
+		purchaseItemSlice = append(purchaseItemSlice, newPurchaseItem)
+	}
+
+	_, errc := h.InventoryService.CreatePurchase(purchaseID, supplierID, note, purchaseItemSlice)
+	if errc != nil {
+		return composeError(errc)
+	}
+
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Purchase created successfully"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreatePurchaseHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreatePurchaseHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"time"
+)
+
+//CreateReplenishmentPurchasesHandler is a specific http handler for turning the replenishment suggestions into draft purchases, one purchase for every supplier
+type CreateReplenishmentPurchasesHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//CreateReplenishmentPurchasesHandle is the implementation of http handler for a CreateReplenishmentPurchasesHandler object
+func (h *CreateReplenishmentPurchasesHandler) CreateReplenishmentPurchasesHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - leadTimeDays
+	// - coverageDays
+	// - salesDays (optional)
+	// - asOf (optional)
+	// - purchaseIdPrefix (optional, defaults to RPL-<current time>)
+	err := r.ParseForm()
+	if err != nil {
+		return composeError(err)
+	}
+	params, err := composeReplenishmentParams(r.PostForm)
+	if err != nil {
+		return composeError(err)
+	}
+	purchaseIDPrefix := r.PostFormValue("purchaseIdPrefix")
+	if purchaseIDPrefix == "" {
+		purchaseIDPrefix = "RPL-" + time.Now().Format("20060102150405")
+	}
+
+	plan, errs := h.InventoryService.CreateReplenishmentPurchases(params, purchaseIDPrefix)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Draft purchases created successfully"
+	response.Data = plan
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreateReplenishmentPurchasesHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreateReplenishmentPurchasesHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
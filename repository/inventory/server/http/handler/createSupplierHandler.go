@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"strconv"
+)
+
+//CreateSupplierHandler is a specific http handler for adding a supplier
+type CreateSupplierHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//CreateSupplierHandle is the implementation of http handler for a CreateSupplierHandler object
+func (h *CreateSupplierHandler) CreateSupplierHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - supplierId
+	// - name
+	// - contactName, phone, email and address (optional)
+	// - paymentTermDays (optional, days the payment is due after the purchase date, 0 for cash on delivery)
+	detail, err := composeSupplierDetail(r)
+	if err != nil {
+		return composeError(err)
+	}
+
+	errs := h.InventoryService.CreateSupplier(detail)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Supplier created"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//composeSupplierDetail is a helper function for composing the master data of a supplier from the POST data
+func composeSupplierDetail(r *http.Request) (service.SupplierDetail, error) {
+	detail := service.SupplierDetail{
+		SupplierID:  r.PostFormValue("supplierId"),
+		Name:        r.PostFormValue("name"),
+		ContactName: r.PostFormValue("contactName"),
+		Phone:       r.PostFormValue("phone"),
+		Email:       r.PostFormValue("email"),
+		Address:     r.PostFormValue("address"),
+	}
+	if paymentTermDays := r.PostFormValue("paymentTermDays"); paymentTermDays != "" {
+		paymentTermDaysValue, err := strconv.ParseInt(paymentTermDays, 10, 64)
+		if err != nil {
+			return detail, fmt.Errorf("paymentTermDays %v is invalid (should be a number)", paymentTermDays)
+		}
+		detail.PaymentTermDays = paymentTermDaysValue
+	}
+	return detail, nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreateSupplierHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *CreateSupplierHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
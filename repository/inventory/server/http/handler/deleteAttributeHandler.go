@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//DeleteAttributeHandler is a specific http handler for removing an attribute along with the values items have
+type DeleteAttributeHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//DeleteAttributeHandle is the implementation of http handler for a DeleteAttributeHandler object
+func (h *DeleteAttributeHandler) DeleteAttributeHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - name
+	name := r.PostFormValue("name")
+
+	err := h.InventoryService.DeleteAttribute(name)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Attribute deleted"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *DeleteAttributeHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *DeleteAttributeHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
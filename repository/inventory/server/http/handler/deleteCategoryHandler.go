@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//DeleteCategoryHandler is a specific http handler for removing a category without subcategories and items
+type DeleteCategoryHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//DeleteCategoryHandle is the implementation of http handler for a DeleteCategoryHandler object
+func (h *DeleteCategoryHandler) DeleteCategoryHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - categoryId
+	categoryID := r.PostFormValue("categoryId")
+
+	err := h.InventoryService.DeleteCategory(categoryID)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Category deleted"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *DeleteCategoryHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *DeleteCategoryHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
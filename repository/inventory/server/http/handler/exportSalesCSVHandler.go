@@ -53,8 +53,8 @@ func (h *ExportSalesCSVHandler) ExportSalesCSVHandle(w http.ResponseWriter, r *h
 	firstRow = append(firstRow, strconv.Itoa(salesData.TotalItemKind))
 	firstRow = append(firstRow, strconv.FormatInt(salesData.TotalQuantity, 10))
 	firstRow = append(firstRow, strconv.Itoa(salesData.SaleCount))
-	firstRow = append(firstRow, strconv.FormatFloat(salesData.Profit, 'f', 2, 64))
-	firstRow = append(firstRow, strconv.FormatFloat(salesData.SalesTurnOver, 'f', 2, 64))
+	firstRow = append(firstRow, salesData.Profit.String())
+	firstRow = append(firstRow, salesData.SalesTurnOver.String())
 	csvString = append(csvString, firstRow)
 
 	//the remaining rows are for the items
@@ -62,9 +62,9 @@ func (h *ExportSalesCSVHandler) ExportSalesCSVHandle(w http.ResponseWriter, r *h
 	//sku, quantity, buy price, total amount (buy price * quantity)
 	for _, val := range salesData.Items {
 		quantityStr := strconv.FormatInt(val.Quantity, 10)
-		buyPriceStr := strconv.FormatFloat(val.BuyPrice, 'f', 2, 64)
-		sellPriceStr := strconv.FormatFloat(val.SellPrice, 'f', 2, 64)
-		profitStr := strconv.FormatFloat(val.Profit, 'f', 2, 64)
+		buyPriceStr := val.BuyPrice.String()
+		sellPriceStr := val.SellPrice.String()
+		profitStr := val.Profit.String()
 
 		newRow := make([]string, 0)
 		newRow = append(newRow, val.Sku)
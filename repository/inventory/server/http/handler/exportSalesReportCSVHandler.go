@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+)
+
+//ExportSalesReportCSVHandler is a specific http handler for downloading a sales report in CSV format
+type ExportSalesReportCSVHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//salesFiguresColumns is a function for composing the csv columns of sales figures
+//data order:
+//quantity, omzet, cogs, profit, margin (%)
+func salesFiguresColumns(figures *service.SalesFigures) []string {
+	return []string{
+		strconv.FormatInt(figures.Quantity, 10),
+		figures.SalesTurnOver.String(),
+		figures.COGS.String(),
+		figures.Profit.String(),
+		strconv.FormatFloat(figures.Margin, 'f', 2, 64),
+	}
+}
+
+//ExportSalesReportCSVHandle is the implementation of http handler for a ExportSalesReportCSVHandler object
+func (h *ExportSalesReportCSVHandler) ExportSalesReportCSVHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - startTime
+	// - endTime
+	// - groupBy (optional)
+	// - compare (optional)
+	startTimeObj, endTimeObj, groupBy, compare, err := composeSalesReportQuery(r.URL.Query())
+	if err != nil {
+		return composeError(err)
+	}
+
+	report, errs := h.InventoryService.GetSalesReport(startTimeObj, endTimeObj, groupBy, compare)
+	if errs != nil {
+		//compose failed response
+		return composeError(errs)
+	}
+
+	//compose the csv data
+	//1st row is the header, the following rows are for the groups and the last row is for the totals
+	//data order:
+	//key, name, quantity, omzet, cogs, profit, margin (%), followed by the same figures of the previous period when compared
+	header := []string{groupBy, "name", "quantity", "omzet", "cogs", "profit", "margin"}
+	if true == compare {
+		header = append(header, "previous quantity", "previous omzet", "previous cogs", "previous profit", "previous margin")
+	}
+	csvString := [][]string{header}
+	for _, val := range report.Rows {
+		newRow := append([]string{val.Key, val.Name}, salesFiguresColumns(&val.SalesFigures)...)
+		if true == compare {
+			newRow = append(newRow, salesFiguresColumns(val.Previous)...)
+		}
+		csvString = append(csvString, newRow)
+	}
+	totalRow := append([]string{"total", ""}, salesFiguresColumns(&report.Totals)...)
+	if true == compare {
+		totalRow = append(totalRow, salesFiguresColumns(&report.Previous.Totals)...)
+	}
+	csvString = append(csvString, totalRow)
+
+	//create csv writer
+	buff := &bytes.Buffer{} //placeholder buffer
+	csvWriter := csv.NewWriter(buff)
+	for _, val := range csvString {
+		errw := csvWriter.Write(val)
+		if errw != nil {
+			return composeError(errw)
+		}
+	}
+	csvWriter.Flush() //flush to buffer
+
+	//output the csv
+	w.Header().Set("Content-Description", "File Transfer")
+	w.Header().Set("Content-Disposition", "attachment; filename=SalesReport_"+groupBy+"_"+startTimeObj.Format(csvDateLayout)+"-"+endTimeObj.Format(csvDateLayout)+".csv")
+	_, errOutput := buff.WriteTo(w)
+	if errOutput != nil {
+		return composeError(errOutput)
+	}
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ExportSalesReportCSVHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ExportSalesReportCSVHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
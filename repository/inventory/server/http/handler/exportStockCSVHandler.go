@@ -2,7 +2,6 @@ package handler
 
 import (
 	"ijah-inventory/repository/inventory/domain/inventory/service"
-	"time"
 
 	"bytes"
 	"encoding/csv"
@@ -22,7 +21,9 @@ const csvDateLayout = "2006/01/02"
 //ExportStockCSVHandle is the implementation of http handler for a ExportStockCSVHandler object
 func (h *ExportStockCSVHandler) ExportStockCSVHandle(w http.ResponseWriter, r *http.Request) error {
 
-	stockData, err := h.InventoryService.GetAllStockValue()
+	//read the following GET data:
+	// - asOf (optional, YYYY-MM-DD), exports stock value at the end of the given date instead of current stock
+	stockData, err := getStockValue(h.InventoryService, r.URL.Query().Get("asOf"))
 	if err != nil {
 		//compose failed response
 		return composeError(err)
@@ -39,7 +40,7 @@ func (h *ExportStockCSVHandler) ExportStockCSVHandle(w http.ResponseWriter, r *h
 	firstRow = append(firstRow, stockData.Date.Format(csvDateLayout))
 	firstRow = append(firstRow, strconv.Itoa(stockData.TotalItemKind))
 	firstRow = append(firstRow, strconv.FormatInt(stockData.TotalQuantity, 10))
-	firstRow = append(firstRow, strconv.FormatFloat(stockData.TotalAmount, 'f', 2, 64))
+	firstRow = append(firstRow, stockData.TotalAmount.String())
 	csvString = append(csvString, firstRow)
 
 	//the remaining rows are for the items
@@ -47,8 +48,8 @@ func (h *ExportStockCSVHandler) ExportStockCSVHandle(w http.ResponseWriter, r *h
 	//sku, quantity, buy price, total amount (buy price * quantity)
 	for _, val := range stockData.Items {
 		quantityStr := strconv.FormatInt(val.Quantity, 10)
-		buyPriceStr := strconv.FormatFloat(val.BuyPrice, 'f', 2, 64)
-		totalAmountStr := strconv.FormatFloat(val.TotalAmount, 'f', 2, 64)
+		buyPriceStr := val.BuyPrice.String()
+		totalAmountStr := val.TotalAmount.String()
 
 		newRow := make([]string, 0)
 		newRow = append(newRow, val.Sku)
@@ -70,9 +71,8 @@ func (h *ExportStockCSVHandler) ExportStockCSVHandle(w http.ResponseWriter, r *h
 	csvWriter.Flush() //flush to buffer
 
 	//output the csv
-	now := time.Now()
 	w.Header().Set("Content-Description", "File Transfer")
-	w.Header().Set("Content-Disposition", "attachment; filename=StockValue_"+now.Format(csvDateLayout)+".csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=StockValue_"+stockData.Date.Format(csvDateLayout)+".csv")
 	_, errOutput := buff.WriteTo(w)
 	if errOutput != nil {
 		return composeError(errOutput)
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//ForecastHandler is a specific http handler for forecasting the demand of an item from its completed sales
+type ForecastHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//ForecastHandle is the implementation of http handler for a ForecastHandler object
+func (h *ForecastHandler) ForecastHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - sku
+	// - horizon (count of forecast periods)
+	// - granularity (optional, day or week)
+	// - model (optional, movingAverage, exponentialSmoothing or holtWinters, the most accurate model by backtest when empty)
+	// - asOf (optional, YYYY-MM-DD)
+	// - confidence (optional, e.g. 0.8 for 80% prediction intervals)
+	query := r.URL.Query()
+	sku := query.Get("sku")
+	params := service.ForecastParams{
+		Granularity: query.Get("granularity"),
+		Model:       query.Get("model"),
+	}
+	horizon, err := strconv.Atoi(query.Get("horizon"))
+	if err != nil {
+		return composeError(fmt.Errorf("horizon must be a number"))
+	}
+	params.Horizon = horizon
+	if asOf := query.Get("asOf"); asOf != "" {
+		params.AsOf, err = time.Parse(inputDateLayout, asOf)
+		if err != nil {
+			return composeError(fmt.Errorf("asOf format is invalid (should be YYYY-MM-DD)"))
+		}
+	}
+	if confidence := query.Get("confidence"); confidence != "" {
+		params.Confidence, err = strconv.ParseFloat(confidence, 64)
+		if err != nil {
+			return composeError(fmt.Errorf("confidence must be a number"))
+		}
+	}
+
+	demandForecast, errs := h.InventoryService.ForecastDemand(sku, params)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = demandForecast
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ForecastHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ForecastHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
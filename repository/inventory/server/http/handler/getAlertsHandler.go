@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetAlertsHandler is a specific http handler for listing low stock alerts
+type GetAlertsHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetAlertsHandle is the implementation of http handler for a GetAlertsHandler object
+func (h *GetAlertsHandler) GetAlertsHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET params:
+	// - status (open, resolved or empty for every alert)
+	status := r.URL.Query().Get("status")
+
+	alertList, err := h.InventoryService.ListAlerts(status)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = alertList
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetAlertsHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetAlertsHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
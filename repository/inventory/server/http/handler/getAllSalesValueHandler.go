@@ -23,6 +23,8 @@ func (h *GetAllSalesValueHandler) GetAllSalesValueHandle(w http.ResponseWriter,
 	//read the following GET data:
 	// - starttime
 	// - endTime
+	// - category (optional), keeps only the items of the category and of its subcategories
+	// - groupBy (optional, product, size, color or category), adds the subtotals of the groups
 	startTime := r.URL.Query().Get("startTime")
 	endTime := r.URL.Query().Get("endTime")
 
@@ -40,6 +42,18 @@ func (h *GetAllSalesValueHandler) GetAllSalesValueHandle(w http.ResponseWriter,
 	if errs != nil {
 		return composeError(errs)
 	}
+	if categoryID := r.URL.Query().Get("category"); categoryID != "" {
+		errs = h.InventoryService.FilterSalesValue(saleValueObj, categoryID)
+		if errs != nil {
+			return composeError(errs)
+		}
+	}
+	if groupBy := r.URL.Query().Get("groupBy"); groupBy != "" {
+		errs = h.InventoryService.GroupSalesValue(saleValueObj, groupBy)
+		if errs != nil {
+			return composeError(errs)
+		}
+	}
 	//compose successful response
 	response := SimpleResponseStruct{}
 	response.Code = ErrCodeSuccessful
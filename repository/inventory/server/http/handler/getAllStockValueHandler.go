@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"fmt"
 	"ijah-inventory/repository/inventory/domain/inventory/service"
 	"net/http"
+	"time"
+
+	"github.com/go-errors/errors"
 )
 
 //GetAllStockValueHandler is a specific http handler for creating sale
@@ -14,11 +18,27 @@ type GetAllStockValueHandler struct {
 //GetAllStockValueHandle is the implementation of http handler for a GetAllStockValueHandler object
 func (h *GetAllStockValueHandler) GetAllStockValueHandle(w http.ResponseWriter, r *http.Request) error {
 
-	stockValueObj, err := h.InventoryService.GetAllStockValue()
+	//read the following GET data:
+	// - asOf (optional, YYYY-MM-DD), values stock at the end of the given date instead of current stock
+	// - category (optional), keeps only the items of the category and of its subcategories
+	// - groupBy (optional, product, size, color or category), adds the subtotals of the groups
+	stockValueObj, err := getStockValue(h.InventoryService, r.URL.Query().Get("asOf"))
 	if err != nil {
 		//compose failed response
 		return composeError(err)
 	}
+	if categoryID := r.URL.Query().Get("category"); categoryID != "" {
+		err = h.InventoryService.FilterStockValue(stockValueObj, categoryID)
+		if err != nil {
+			return composeError(err)
+		}
+	}
+	if groupBy := r.URL.Query().Get("groupBy"); groupBy != "" {
+		err = h.InventoryService.GroupStockValue(stockValueObj, groupBy)
+		if err != nil {
+			return composeError(err)
+		}
+	}
 	//compose successful response
 	response := SimpleResponseStruct{}
 	response.Code = ErrCodeSuccessful
@@ -34,6 +54,18 @@ func (h *GetAllStockValueHandler) GetAllStockValueHandle(w http.ResponseWriter,
 	return nil
 }
 
+//getStockValue is a function for obtaining current stock value, or stock value at the end of asOf date if given
+func getStockValue(inventoryService *service.Inventory, asOf string) (*service.StockValue, *errors.Error) {
+	if asOf == "" {
+		return inventoryService.GetAllStockValue()
+	}
+	asOfObj, err := time.Parse(inputDateLayout, asOf)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("asOf format is invalid (should be YYYY-MM-DD)"), 0)
+	}
+	return inventoryService.GetStockValueAsOf(asOfObj)
+}
+
 //StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
 func (h *GetAllStockValueHandler) StartUp() {
 	//Note: perform initialization/bootstrapping here
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetAttributesHandler is a specific http handler for getting every attribute definition
+type GetAttributesHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetAttributesHandle is the implementation of http handler for a GetAttributesHandler object
+func (h *GetAttributesHandler) GetAttributesHandle(w http.ResponseWriter, r *http.Request) error {
+	attributes, err := h.InventoryService.ListAttributes()
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = attributes
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetAttributesHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetAttributesHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
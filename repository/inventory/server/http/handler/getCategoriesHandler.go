@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetCategoriesHandler is a specific http handler for getting the category tree
+type GetCategoriesHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetCategoriesHandle is the implementation of http handler for a GetCategoriesHandler object
+func (h *GetCategoriesHandler) GetCategoriesHandle(w http.ResponseWriter, r *http.Request) error {
+	tree, err := h.InventoryService.GetCategoryTree()
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = tree
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetCategoriesHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetCategoriesHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//GetDeadStockHandler is a specific http handler for listing the skus in stock without completed sales for a count of days, along with the stock value tied up in them
+type GetDeadStockHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetDeadStockHandle is the implementation of http handler for a GetDeadStockHandler object
+func (h *GetDeadStockHandler) GetDeadStockHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - days
+	// - asOf (optional, YYYY-MM-DD), today by default
+	days, err := strconv.Atoi(r.URL.Query().Get("days"))
+	if err != nil {
+		return composeError(fmt.Errorf("days must be a number"))
+	}
+	asOfObj := time.Now()
+	if asOf := r.URL.Query().Get("asOf"); asOf != "" {
+		asOfObj, err = time.Parse(inputDateLayout, asOf)
+		if err != nil {
+			return composeError(fmt.Errorf("asOf format is invalid (should be YYYY-MM-DD)"))
+		}
+	}
+
+	deadStock, errs := h.InventoryService.GetDeadStock(days, asOfObj)
+	if errs != nil {
+		//compose failed response
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = deadStock
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetDeadStockHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetDeadStockHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
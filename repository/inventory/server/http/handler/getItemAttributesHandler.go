@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetItemAttributesHandler is a specific http handler for getting the attribute values of an item
+type GetItemAttributesHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetItemAttributesHandle is the implementation of http handler for a GetItemAttributesHandler object
+func (h *GetItemAttributesHandler) GetItemAttributesHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - sku
+	sku := r.URL.Query().Get("sku")
+	itemAttributes, err := h.InventoryService.GetItemAttributes(sku)
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = itemAttributes
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetItemAttributesHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetItemAttributesHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
 	"ijah-inventory/repository/inventory/domain/inventory/service"
 	"net/http"
 )
 
+//itemInfoResponse is the item information returned by GetItemInfoHandler (stock along with its available quantity)
+type itemInfoResponse struct {
+	*model.Stock
+	Available int64 //quantity which isn't reserved by draft sales
+}
+
 //GetItemInfoHandler is a specific http handler for creating sale
 type GetItemInfoHandler struct {
 	Handler
@@ -25,7 +32,10 @@ func (h *GetItemInfoHandler) GetItemInfoHandle(w http.ResponseWriter, r *http.Re
 	response := SimpleResponseStruct{}
 	response.Code = ErrCodeSuccessful
 	response.Message = "Inquiry successful"
-	response.Data = stockObj
+	response.Data = itemInfoResponse{
+		Stock:     stockObj,
+		Available: stockObj.Available(),
+	}
 
 	successfulResponse, statusError := composeJSONResponse(response)
 	if statusError != nil {
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetProductHandler is a specific http handler for getting a product along with its variants
+type GetProductHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//productData is the representation of a product returned to the http client (variants in the same format as item info)
+type productData struct {
+	*model.Product
+	Variants []itemInfoResponse
+}
+
+//GetProductHandle is the implementation of http handler for a GetProductHandler object
+func (h *GetProductHandler) GetProductHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - productId
+	productID := r.URL.Query().Get("productId")
+	productInfo, err := h.InventoryService.GetProduct(productID)
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	productObj := productData{
+		Product:  productInfo.Product,
+		Variants: make([]itemInfoResponse, 0, len(productInfo.Variants)),
+	}
+	for _, val := range productInfo.Variants {
+		productObj.Variants = append(productObj.Variants, itemInfoResponse{
+			Stock:     val,
+			Available: val.Available(),
+		})
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = productObj
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetProductHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetProductHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/model"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetPurchaseHandler is a specific http handler for getting a purchase
+type GetPurchaseHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//purchaseData is the representation of a purchase returned to the http client
+//ComputedStatus takes received quantities into account (i.e. it can be partially received)
+type purchaseData struct {
+	*model.Purchase
+	ComputedStatus string
+}
+
+//composePurchaseData is a helper function for composing purchase data returned to the http client
+func composePurchaseData(purchaseObj *model.Purchase) *purchaseData {
+	return &purchaseData{
+		Purchase:       purchaseObj,
+		ComputedStatus: purchaseObj.GetComputedStatus(),
+	}
+}
+
+//GetPurchaseHandle is the implementation of http handler for a GetPurchaseHandler object
+func (h *GetPurchaseHandler) GetPurchaseHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - purchaseId
+	purchaseID := r.URL.Query().Get("purchaseId")
+	purchaseObj, err := h.InventoryService.GetPurchase(purchaseID)
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = composePurchaseData(purchaseObj)
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetPurchaseHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetPurchaseHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
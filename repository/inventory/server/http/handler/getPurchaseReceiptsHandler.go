@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetPurchaseReceiptsHandler is a specific http handler for getting all receipts (deliveries) of a purchase
+type GetPurchaseReceiptsHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetPurchaseReceiptsHandle is the implementation of http handler for a GetPurchaseReceiptsHandler object
+func (h *GetPurchaseReceiptsHandler) GetPurchaseReceiptsHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - purchaseId
+	purchaseID := r.URL.Query().Get("purchaseId")
+	receiptList, err := h.InventoryService.GetPurchaseReceipts(purchaseID)
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = receiptList
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetPurchaseReceiptsHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetPurchaseReceiptsHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
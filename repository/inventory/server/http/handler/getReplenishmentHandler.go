@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetReplenishmentHandler is a specific http handler for getting the suggested order quantities of the items which need to be reordered, grouped by supplier
+type GetReplenishmentHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetReplenishmentHandle is the implementation of http handler for a GetReplenishmentHandler object
+func (h *GetReplenishmentHandler) GetReplenishmentHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - leadTimeDays
+	// - coverageDays
+	// - salesDays (optional)
+	// - asOf (optional)
+	params, err := composeReplenishmentParams(r.URL.Query())
+	if err != nil {
+		return composeError(err)
+	}
+
+	plan, errs := h.InventoryService.GetReplenishmentPlan(params)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = plan
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetReplenishmentHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetReplenishmentHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
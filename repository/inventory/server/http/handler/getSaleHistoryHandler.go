@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"net/http"
+)
+
+//GetSaleHistoryHandler is a specific http handler for getting status history of a sale
+type GetSaleHistoryHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetSaleHistoryHandle is the implementation of http handler for a GetSaleHistoryHandler object
+func (h *GetSaleHistoryHandler) GetSaleHistoryHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - invoiceId
+	invoiceNo := r.URL.Query().Get("invoiceId")
+
+	transitionList, err := h.InventoryService.GetSaleStatusHistory(invoiceNo)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = transitionList
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSaleHistoryHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSaleHistoryHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetSalesReportHandler is a specific http handler for getting quantity, omzet, COGS, profit and margin of the items sold in a period, grouped by sku, product or calendar bucket
+type GetSalesReportHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetSalesReportHandle is the implementation of http handler for a GetSalesReportHandler object
+func (h *GetSalesReportHandler) GetSalesReportHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - startTime
+	// - endTime
+	// - groupBy (optional)
+	// - compare (optional)
+	startTimeObj, endTimeObj, groupBy, compare, err := composeSalesReportQuery(r.URL.Query())
+	if err != nil {
+		return composeError(err)
+	}
+
+	report, errs := h.InventoryService.GetSalesReport(startTimeObj, endTimeObj, groupBy, compare)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = report
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSalesReportHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSalesReportHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
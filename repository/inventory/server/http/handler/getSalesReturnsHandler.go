@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetSalesReturnsHandler is a specific http handler for getting all returns of a sale
+type GetSalesReturnsHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetSalesReturnsHandle is the implementation of http handler for a GetSalesReturnsHandler object
+func (h *GetSalesReturnsHandler) GetSalesReturnsHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - invoiceId
+	invoiceID := r.URL.Query().Get("invoiceId")
+	returnList, err := h.InventoryService.GetSalesReturns(invoiceID)
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = returnList
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSalesReturnsHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSalesReturnsHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetStockCountVarianceHandler is a specific http handler for getting the variance report of a stock take
+type GetStockCountVarianceHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetStockCountVarianceHandle is the implementation of http handler for a GetStockCountVarianceHandler object
+func (h *GetStockCountVarianceHandler) GetStockCountVarianceHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - countId
+	countID := r.URL.Query().Get("countId")
+	countVariance, err := h.InventoryService.GetStockCountVariance(countID)
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = countVariance
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetStockCountVarianceHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetStockCountVarianceHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+
+	"net/http"
+	"time"
+)
+
+//GetStockMovementsHandler is a specific http handler for getting stock movements of a sku
+type GetStockMovementsHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetStockMovementsHandle is the implementation of http handler for a GetStockMovementsHandler object
+func (h *GetStockMovementsHandler) GetStockMovementsHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - sku
+	// - startTime
+	// - endTime
+	sku := r.URL.Query().Get("sku")
+	startTime := r.URL.Query().Get("startTime")
+	endTime := r.URL.Query().Get("endTime")
+
+	startTimeObj, err := time.Parse(inputDateLayout, startTime)
+	if err != nil {
+		return composeError(fmt.Errorf("startTime format is invalid (should be YYYY-MM-DD)"))
+	}
+	endTimeObj, err := time.Parse(inputDateLayout, endTime)
+	if err != nil {
+		return composeError(fmt.Errorf("endTime format is invalid (should be YYYY-MM-DD)"))
+	}
+
+	movementList, errs := h.InventoryService.GetStockMovements(sku, startTimeObj, endTimeObj)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = movementList
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetStockMovementsHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetStockMovementsHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"time"
+)
+
+//GetStockTurnoverHandler is a specific http handler for getting inventory turnover ratio, days of inventory on hand and sell-through rate of every sku over a period
+type GetStockTurnoverHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetStockTurnoverHandle is the implementation of http handler for a GetStockTurnoverHandler object
+func (h *GetStockTurnoverHandler) GetStockTurnoverHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - startTime
+	// - endTime
+	startTimeObj, err := time.Parse(inputDateLayout, r.URL.Query().Get("startTime"))
+	if err != nil {
+		return composeError(fmt.Errorf("startTime format is invalid (should be YYYY-MM-DD)"))
+	}
+	endTimeObj, err := time.Parse(inputDateLayout, r.URL.Query().Get("endTime"))
+	if err != nil {
+		return composeError(fmt.Errorf("endTime format is invalid (should be YYYY-MM-DD)"))
+	}
+
+	turnover, errs := h.InventoryService.GetStockTurnover(startTimeObj, endTimeObj)
+	if errs != nil {
+		//compose failed response
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = turnover
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetStockTurnoverHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetStockTurnoverHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
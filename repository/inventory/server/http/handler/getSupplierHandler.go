@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//GetSupplierHandler is a specific http handler for getting a supplier along with the items it supplies
+type GetSupplierHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetSupplierHandle is the implementation of http handler for a GetSupplierHandler object
+func (h *GetSupplierHandler) GetSupplierHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - supplierId
+	supplierID := r.URL.Query().Get("supplierId")
+	supplierObj, err := h.InventoryService.GetSupplier(supplierID)
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = supplierObj
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSupplierHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSupplierHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
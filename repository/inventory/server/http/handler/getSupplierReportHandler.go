@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"time"
+)
+
+//GetSupplierReportHandler is a specific http handler for getting purchase volume and average cost by supplier
+type GetSupplierReportHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//GetSupplierReportHandle is the implementation of http handler for a GetSupplierReportHandler object
+func (h *GetSupplierReportHandler) GetSupplierReportHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - startTime
+	// - endTime
+	startTime := r.URL.Query().Get("startTime")
+	endTime := r.URL.Query().Get("endTime")
+
+	startTimeObj, err := time.Parse(inputDateLayout, startTime)
+	if err != nil {
+		return composeError(fmt.Errorf("startTime format is invalid (should be YYYY-MM-DD)"))
+	}
+	endTimeObj, err := time.Parse(inputDateLayout, endTime)
+	if err != nil {
+		return composeError(fmt.Errorf("endTime format is invalid (should be YYYY-MM-DD)"))
+	}
+
+	report, errs := h.InventoryService.GetSupplierReport(startTimeObj, endTimeObj)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = report
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSupplierReportHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *GetSupplierReportHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -2,7 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 )
@@ -16,9 +23,114 @@ var (
 
 //SimpleResponseStruct is representation of simple response returned to the http client
 type SimpleResponseStruct struct {
-	Code    string      `json:"code"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
+	Code       string          `json:"code"`
+	Message    string          `json:"message"`
+	Data       interface{}     `json:"data"`
+	Pagination *PaginationData `json:"pagination,omitempty"` //only returned by list handlers (e.g. ListStockHandler)
+}
+
+//PaginationData is the cursor based pagination information of a list response
+//the next page is requested with the same parameters along with cursor=<nextCursor>
+type PaginationData struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor"` //empty on the last page
+	HasMore    bool   `json:"hasMore"`
+}
+
+//composePaginationData is a helper function for composing the pagination information of a page
+func composePaginationData(limit int, nextCursor string) *PaginationData {
+	return &PaginationData{
+		Limit:      limit,
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
+	}
+}
+
+//composeCriteria is a helper function for composing the criteria of a list request from the following GET data:
+// - sort (comma separated fields, descending when prefixed with '-', e.g. sort=-quantity,name)
+// - limit (page size)
+// - cursor (nextCursor of the previous page)
+//filters are added by the list handlers
+func composeCriteria(query url.Values) (datamapper.Criteria, error) {
+	criteria := datamapper.Criteria{
+		Cursor: query.Get("cursor"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		limitValue, err := strconv.Atoi(limit)
+		if err != nil || limitValue <= 0 {
+			return criteria, fmt.Errorf("limit %v is invalid (should be a positive number)", limit)
+		}
+		criteria.Limit = limitValue
+	}
+	if sort := query.Get("sort"); sort != "" {
+		for _, val := range strings.Split(sort, ",") {
+			sortObj := datamapper.Sort{Field: strings.TrimSpace(val)}
+			if true == strings.HasPrefix(sortObj.Field, "-") {
+				sortObj.Field = strings.TrimPrefix(sortObj.Field, "-")
+				sortObj.Descending = true
+			}
+			criteria.Sorts = append(criteria.Sorts, sortObj)
+		}
+	}
+	return criteria, nil
+}
+
+//composeReplenishmentParams is a helper function for composing the parameters of replenishment suggestions from the following data:
+// - leadTimeDays
+// - coverageDays
+// - salesDays (optional, defaults to service.DefaultReplenishmentSalesDays)
+// - asOf (optional, YYYY-MM-DD, defaults to today)
+func composeReplenishmentParams(values url.Values) (service.ReplenishmentParams, error) {
+	params := service.ReplenishmentParams{}
+	keys := []string{"leadTimeDays", "coverageDays", "salesDays"}
+	targets := []*int{&params.LeadTimeDays, &params.CoverageDays, &params.SalesDays}
+	for index, key := range keys {
+		value := values.Get(key)
+		if value == "" && key == "salesDays" {
+			continue
+		}
+		dayValue, err := strconv.Atoi(value)
+		if err != nil || dayValue < 0 {
+			return params, fmt.Errorf("%v %v is invalid (should be a number of days)", key, value)
+		}
+		*targets[index] = dayValue
+	}
+	if asOf := values.Get("asOf"); asOf != "" {
+		asOfValue, err := time.Parse(inputDateLayout, asOf)
+		if err != nil {
+			return params, fmt.Errorf("asOf format is invalid (should be YYYY-MM-DD)")
+		}
+		params.AsOf = asOfValue
+	}
+	return params, nil
+}
+
+//composeSalesReportQuery is a helper function for reading the parameters of a sales report from the following GET data:
+// - startTime
+// - endTime
+// - groupBy (optional, sku, product, size, color, category, day, week or month, defaults to sku)
+// - compare (optional, true for adding the figures of the previous period)
+func composeSalesReportQuery(query url.Values) (time.Time, time.Time, string, bool, error) {
+	startTimeObj, err := time.Parse(inputDateLayout, query.Get("startTime"))
+	if err != nil {
+		return startTimeObj, startTimeObj, "", false, fmt.Errorf("startTime format is invalid (should be YYYY-MM-DD)")
+	}
+	endTimeObj, err := time.Parse(inputDateLayout, query.Get("endTime"))
+	if err != nil {
+		return startTimeObj, endTimeObj, "", false, fmt.Errorf("endTime format is invalid (should be YYYY-MM-DD)")
+	}
+	groupBy := query.Get("groupBy")
+	if groupBy == "" {
+		groupBy = service.GroupBySku
+	}
+	compare := false
+	if value := query.Get("compare"); value != "" {
+		compare, err = strconv.ParseBool(value)
+		if err != nil {
+			return startTimeObj, endTimeObj, groupBy, false, fmt.Errorf("compare must be true or false")
+		}
+	}
+	return startTimeObj, endTimeObj, groupBy, compare, nil
 }
 
 //composeJSONResponse is a helper function for composing JSON string for http response (will be displayed to user's browser)
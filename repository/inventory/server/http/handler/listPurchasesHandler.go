@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//ListPurchasesHandler is a specific http handler for listing all purchases
+type ListPurchasesHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//ListPurchasesHandle is the implementation of http handler for a ListPurchasesHandler object
+func (h *ListPurchasesHandler) ListPurchasesHandle(w http.ResponseWriter, r *http.Request) error {
+	purchaseList, err := h.InventoryService.ListPurchases()
+	if err != nil {
+		//compose failed response
+		return composeError(err)
+	}
+	purchaseDataList := make([]*purchaseData, 0, len(purchaseList))
+	for _, val := range purchaseList {
+		purchaseDataList = append(purchaseDataList, composePurchaseData(val))
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = purchaseDataList
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ListPurchasesHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ListPurchasesHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
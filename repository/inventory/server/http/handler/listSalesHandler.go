@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"time"
+)
+
+//ListSalesHandler is a specific http handler for listing sales page by page
+type ListSalesHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//ListSalesHandle is the implementation of http handler for a ListSalesHandler object
+func (h *ListSalesHandler) ListSalesHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data (all optional):
+	// - status (D, C or S)
+	// - from, to (sale date range, both days inclusive, YYYY-MM-DD)
+	// - sort, limit and cursor (see composeCriteria), fields: invoiceId, date, status
+	query := r.URL.Query()
+	criteria, err := composeCriteria(query)
+	if err != nil {
+		return composeError(err)
+	}
+	if status := query.Get("status"); status != "" {
+		criteria.Filters = append(criteria.Filters, datamapper.Filter{Field: "status", Operator: datamapper.OperatorEqual, Value: status})
+	}
+	if from := query.Get("from"); from != "" {
+		fromObj, err := time.Parse(inputDateLayout, from)
+		if err != nil {
+			return composeError(fmt.Errorf("from format is invalid (should be YYYY-MM-DD)"))
+		}
+		criteria.Filters = append(criteria.Filters, datamapper.Filter{Field: "date", Operator: datamapper.OperatorGreaterOrEqual, Value: fromObj})
+	}
+	if to := query.Get("to"); to != "" {
+		toObj, err := time.Parse(inputDateLayout, to)
+		if err != nil {
+			return composeError(fmt.Errorf("to format is invalid (should be YYYY-MM-DD)"))
+		}
+		//sales of the whole day
+		criteria.Filters = append(criteria.Filters, datamapper.Filter{Field: "date", Operator: datamapper.OperatorLess, Value: toObj.AddDate(0, 0, 1)})
+	}
+
+	salesPage, errs := h.InventoryService.ListSales(criteria)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = salesPage.Items
+	response.Pagination = composePaginationData(salesPage.Limit, salesPage.NextCursor)
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ListSalesHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ListSalesHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"strconv"
+)
+
+//ListStockHandler is a specific http handler for listing stock items page by page
+type ListStockHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//ListStockHandle is the implementation of http handler for a ListStockHandler object
+func (h *ListStockHandler) ListStockHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data (all optional):
+	// - name (items whose name contains it, case insensitive)
+	// - minQty, maxQty (quantity range, inclusive)
+	// - productId, size, color (variants of the product, of the size or of the color)
+	// - categoryId (items directly in the category)
+	// - sort, limit and cursor (see composeCriteria), fields: sku, name, quantity, reserved, available, buyPrice, sellPrice, productId, size, color, categoryId
+	query := r.URL.Query()
+	criteria, err := composeCriteria(query)
+	if err != nil {
+		return composeError(err)
+	}
+	if name := query.Get("name"); name != "" {
+		criteria.Filters = append(criteria.Filters, datamapper.Filter{Field: "name", Operator: datamapper.OperatorContains, Value: name})
+	}
+	if minQty := query.Get("minQty"); minQty != "" {
+		minQtyValue, err := strconv.ParseInt(minQty, 10, 64)
+		if err != nil {
+			return composeError(fmt.Errorf("minQty %v is invalid (should be a number)", minQty))
+		}
+		criteria.Filters = append(criteria.Filters, datamapper.Filter{Field: "quantity", Operator: datamapper.OperatorGreaterOrEqual, Value: minQtyValue})
+	}
+	if maxQty := query.Get("maxQty"); maxQty != "" {
+		maxQtyValue, err := strconv.ParseInt(maxQty, 10, 64)
+		if err != nil {
+			return composeError(fmt.Errorf("maxQty %v is invalid (should be a number)", maxQty))
+		}
+		criteria.Filters = append(criteria.Filters, datamapper.Filter{Field: "quantity", Operator: datamapper.OperatorLessOrEqual, Value: maxQtyValue})
+	}
+
+	for _, field := range []string{"productId", "size", "color", "categoryId"} {
+		if value := query.Get(field); value != "" {
+			criteria.Filters = append(criteria.Filters, datamapper.Filter{Field: field, Operator: datamapper.OperatorEqual, Value: value})
+		}
+	}
+
+	stockPage, errs := h.InventoryService.ListStock(criteria)
+	if errs != nil {
+		return composeError(errs)
+	}
+	itemList := make([]itemInfoResponse, 0, len(stockPage.Items))
+	for _, val := range stockPage.Items {
+		itemList = append(itemList, itemInfoResponse{
+			Stock:     val,
+			Available: val.Available(),
+		})
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = itemList
+	response.Pagination = composePaginationData(stockPage.Limit, stockPage.NextCursor)
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ListStockHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ListStockHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"regexp"
+)
+
+//OpenStockCountHandler is a specific http handler for opening a stock take
+type OpenStockCountHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//OpenStockCountHandle is the implementation of http handler for a OpenStockCountHandler object
+func (h *OpenStockCountHandler) OpenStockCountHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - countId
+	// - note
+	//repeating items (optional, all skus are counted if none is given)
+	// - sku[x]
+	errForm := r.ParseForm()
+	if errForm != nil {
+		return composeError(errForm)
+	}
+
+	var countID, note string
+	skus := make([]string, 0)
+
+	//regex for parsing items in form post data
+	skuRegxp := regexp.MustCompile(`^sku\[(?P<sku>\d+)\]$`)
+
+	//parse through all post data
+	for key, val := range r.PostForm {
+		switch key {
+		case "countId":
+			countID = val[0]
+		case "note":
+			note = val[0]
+		}
+		skuFound := skuRegxp.FindStringSubmatch(key)
+		if len(skuFound) > 0 {
+			//found "sku[x]" pattern in post data
+			skus = append(skus, val[0])
+		}
+	}
+
+	_, errc := h.InventoryService.OpenStockCount(countID, note, skus)
+	if errc != nil {
+		return composeError(errc)
+	}
+
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Stock count opened successfully"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *OpenStockCountHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *OpenStockCountHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//PostStockCountHandler is a specific http handler for posting a stock take (applying its variances to stock)
+type PostStockCountHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//PostStockCountHandle is the implementation of http handler for a PostStockCountHandler object
+func (h *PostStockCountHandler) PostStockCountHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - countId
+	countID := r.PostFormValue("countId")
+
+	_, err := h.InventoryService.PostStockCount(countID)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Stock count posted successfully"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *PostStockCountHandler) StartUp() {
+	//TODO: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *PostStockCountHandler) Shutdown() {
+	//TODO: perform any cleanup here
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+//ReceivePurchaseHandler is a specific http handler for receiving a supplier delivery against a purchase
+type ReceivePurchaseHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//ReceivePurchaseHandle is the implementation of http handler for a ReceivePurchaseHandler object
+func (h *ReceivePurchaseHandler) ReceivePurchaseHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - purchaseId
+	// - receiptId
+	// - note
+	// - allowOverReceipt (optional, defaults to false)
+	//repeating items
+	// - sku[x]
+	// - quantity[x]
+	errForm := r.ParseForm()
+	if errForm != nil {
+		return composeError(errForm)
+	}
+
+	var purchaseID, receiptID, note string
+	var allowOverReceipt bool
+	var itemsSku, itemsQuantity map[string]string
+
+	itemsSku = make(map[string]string, 0)
+	itemsQuantity = make(map[string]string, 0)
+
+	//regex for parsing items in form post data
+	skuRegxp := regexp.MustCompile(`^sku\[(?P<sku>\d+)\]$`)
+	quantityRegxp := regexp.MustCompile(`^quantity\[(?P<quantity>\d+)\]$`)
+
+	//parse through all post data
+	for key, val := range r.PostForm {
+		switch key {
+		case "purchaseId":
+			purchaseID = val[0]
+		case "receiptId":
+			receiptID = val[0]
+		case "note":
+			note = val[0]
+		case "allowOverReceipt":
+			parsed, err := strconv.ParseBool(val[0])
+			if err != nil {
+				return composeError(err)
+			}
+			allowOverReceipt = parsed
+		}
+		skuFound := skuRegxp.FindStringSubmatch(key)
+		if len(skuFound) > 0 {
+			//found "sku[x]" pattern in post data
+			itemsSku[skuFound[1]] = val[0]
+		}
+		quantityFound := quantityRegxp.FindStringSubmatch(key)
+		if len(quantityFound) > 0 {
+			//found "quantity[x]" pattern in post data
+			itemsQuantity[quantityFound[1]] = val[0]
+		}
+	}
+
+	//parse obtained sku and quantity
+	receiptItemSlice := make([]service.ReceiptItem, 0)
+	for skuKey, skuVal := range itemsSku {
+		theQuantity, err := strconv.ParseInt(itemsQuantity[skuKey], 10, 64)
+		if err != nil {
+			return composeError(err)
+		}
+		newReceiptItem := service.ReceiptItem{
+			Sku:      skuVal,
+			Quantity: theQuantity,
+		}
+		receiptItemSlice = append(receiptItemSlice, newReceiptItem)
+	}
+
+	_, errc := h.InventoryService.ReceivePurchase(purchaseID, receiptID, note, receiptItemSlice, allowOverReceipt)
+	if errc != nil {
+		return composeError(errc)
+	}
+
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Receipt recorded successfully"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ReceivePurchaseHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ReceivePurchaseHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+//ReturnSaleHandler is a specific http handler for recording items returned by a customer against a sale
+type ReturnSaleHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//ReturnSaleHandle is the implementation of http handler for a ReturnSaleHandler object
+func (h *ReturnSaleHandler) ReturnSaleHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - invoiceId
+	// - returnId
+	// - note
+	//repeating items
+	// - sku[x]
+	// - quantity[x]
+	errForm := r.ParseForm()
+	if errForm != nil {
+		return composeError(errForm)
+	}
+
+	var invoiceID, returnID, note string
+	var itemsSku, itemsQuantity map[string]string
+
+	itemsSku = make(map[string]string, 0)
+	itemsQuantity = make(map[string]string, 0)
+
+	//regex for parsing items in form post data
+	skuRegxp := regexp.MustCompile(`^sku\[(?P<sku>\d+)\]$`)
+	quantityRegxp := regexp.MustCompile(`^quantity\[(?P<quantity>\d+)\]$`)
+
+	//parse through all post data
+	for key, val := range r.PostForm {
+		switch key {
+		case "invoiceId":
+			invoiceID = val[0]
+		case "returnId":
+			returnID = val[0]
+		case "note":
+			note = val[0]
+		}
+		skuFound := skuRegxp.FindStringSubmatch(key)
+		if len(skuFound) > 0 {
+			//found "sku[x]" pattern in post data
+			itemsSku[skuFound[1]] = val[0]
+		}
+		quantityFound := quantityRegxp.FindStringSubmatch(key)
+		if len(quantityFound) > 0 {
+			//found "quantity[x]" pattern in post data
+			itemsQuantity[quantityFound[1]] = val[0]
+		}
+	}
+
+	//parse obtained sku and quantity
+	returnItemSlice := make([]service.ReturnItem, 0)
+	for skuKey, skuVal := range itemsSku {
+		theQuantity, err := strconv.ParseInt(itemsQuantity[skuKey], 10, 64)
+		if err != nil {
+			return composeError(err)
+		}
+		newReturnItem := service.ReturnItem{
+			Sku:      skuVal,
+			Quantity: theQuantity,
+		}
+		returnItemSlice = append(returnItemSlice, newReturnItem)
+	}
+
+	_, errc := h.InventoryService.ReturnSale(invoiceID, returnID, note, returnItemSlice)
+	if errc != nil {
+		return composeError(errc)
+	}
+
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Return recorded successfully"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ReturnSaleHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *ReturnSaleHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"strconv"
+)
+
+//searchStockResponse is a stock item found by SearchStockHandler (item information along with its rank)
+type searchStockResponse struct {
+	itemInfoResponse
+	Rank float64 //higher ranks match better
+}
+
+//SearchStockHandler is a specific http handler for searching stock items by sku and name
+type SearchStockHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//SearchStockHandle is the implementation of http handler for a SearchStockHandler object
+func (h *SearchStockHandler) SearchStockHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following GET data:
+	// - q (words, items whose sku or name hold words starting with every one of them are found)
+	// - limit (optional, number of items)
+	query := r.URL.Query()
+	q := query.Get("q")
+	if q == "" {
+		return composeError(fmt.Errorf("q is required"))
+	}
+	var limit int
+	if limitValue := query.Get("limit"); limitValue != "" {
+		var err error
+		limit, err = strconv.Atoi(limitValue)
+		if err != nil || limit <= 0 {
+			return composeError(fmt.Errorf("limit %v is invalid (should be a positive number)", limitValue))
+		}
+	}
+
+	stockHits, errs := h.InventoryService.SearchStock(q, limit)
+	if errs != nil {
+		return composeError(errs)
+	}
+	itemList := make([]searchStockResponse, 0, len(stockHits))
+	for _, val := range stockHits {
+		itemList = append(itemList, searchStockResponse{
+			itemInfoResponse: itemInfoResponse{
+				Stock:     val.Stock,
+				Available: val.Stock.Available(),
+			},
+			Rank: val.Rank,
+		})
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Inquiry successful"
+	response.Data = itemList
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *SearchStockHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *SearchStockHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -11,8 +11,8 @@ import (
 //TestHandler is a specific http handler for testing
 type TestHandler struct {
 	Handler
-	StockMapper    *datamapper.Stock    `inject:"stockDatamapper"`
-	PurchaseMapper *datamapper.Purchase `inject:"purchaseDatamapper"`
+	StockMapper    datamapper.DataMapper `inject:"stockDatamapper"`
+	PurchaseMapper datamapper.DataMapper `inject:"purchaseDatamapper"`
 }
 
 /*
@@ -102,12 +102,12 @@ func (th *TestHandler) TestHandle(w http.ResponseWriter, r *http.Request) error
 //StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
 func (th *TestHandler) StartUp() {
 	//perform initialization/bootstrapping here
-	var stockMapper *datamapper.Stock
+	var stockMapper datamapper.DataMapper
 	serviceObj, found := th.Sc.GetService("stockDatamapper")
 	if false == found {
 		panic("service 'stockDatamapper' is not found")
 	}
-	stockMapper, ok := serviceObj.(*datamapper.Stock)
+	stockMapper, ok := serviceObj.(datamapper.DataMapper)
 	if false == ok {
 		panic("Failed asserting service 'stockDatamapper'")
 	}
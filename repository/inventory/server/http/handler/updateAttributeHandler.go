@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//UpdateAttributeHandler is a specific http handler for changing the type of an attribute
+type UpdateAttributeHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//UpdateAttributeHandle is the implementation of http handler for a UpdateAttributeHandler object
+func (h *UpdateAttributeHandler) UpdateAttributeHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - name
+	// - type (text, number, boolean or date), the values items already have are converted
+	name := r.PostFormValue("name")
+	attributeType := r.PostFormValue("type")
+
+	err := h.InventoryService.UpdateAttribute(name, attributeType)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Update successful"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateAttributeHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateAttributeHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//UpdateCategoryHandler is a specific http handler for renaming a category or moving it to another parent
+type UpdateCategoryHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//UpdateCategoryHandle is the implementation of http handler for a UpdateCategoryHandler object
+func (h *UpdateCategoryHandler) UpdateCategoryHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - categoryId
+	// - name
+	// - parentId (optional, empty for root categories)
+	categoryID := r.PostFormValue("categoryId")
+	name := r.PostFormValue("name")
+	parentID := r.PostFormValue("parentId")
+
+	err := h.InventoryService.UpdateCategory(categoryID, name, parentID)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Update successful"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateCategoryHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateCategoryHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"regexp"
+)
+
+//UpdateItemAttributesHandler is a specific http handler for setting attribute values of an item
+type UpdateItemAttributesHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//UpdateItemAttributesHandle is the implementation of http handler for a UpdateItemAttributesHandler object
+func (h *UpdateItemAttributesHandler) UpdateItemAttributesHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - sku
+	//repeating values
+	// - attribute[name] (an empty value removes the attribute from the item)
+
+	errForm := r.ParseForm()
+	if errForm != nil {
+		return composeError(errForm)
+	}
+
+	var sku string
+	values := make(map[string]string, 0)
+
+	//regex for parsing attribute values in form post data
+	attributeRegxp := regexp.MustCompile(`^attribute\[(?P<name>[^\]]+)\]$`)
+
+	//parse through all post data
+	for key, val := range r.PostForm {
+		if key == "sku" {
+			sku = val[0]
+		}
+		attributeFound := attributeRegxp.FindStringSubmatch(key)
+		if len(attributeFound) > 0 {
+			//found "attribute[name]" pattern in post data
+			values[attributeFound[1]] = val[0]
+		}
+	}
+
+	err := h.InventoryService.UpdateItemAttributes(sku, values)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Update successful"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateItemAttributesHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateItemAttributesHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//UpdateItemCategoryHandler is a specific http handler for moving an item to a category
+type UpdateItemCategoryHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//UpdateItemCategoryHandle is the implementation of http handler for a UpdateItemCategoryHandler object
+func (h *UpdateItemCategoryHandler) UpdateItemCategoryHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - sku
+	// - categoryId (optional, the item is no longer categorized when empty)
+	sku := r.PostFormValue("sku")
+	categoryID := r.PostFormValue("categoryId")
+
+	err := h.InventoryService.UpdateItemCategory(sku, categoryID)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Update successful"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateItemCategoryHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateItemCategoryHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//UpdatePurchaseHandler is a specific http handler for updating purchase status
+type UpdatePurchaseHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//UpdatePurchaseHandle is the implementation of http handler for a UpdatePurchaseHandler object
+func (h *UpdatePurchaseHandler) UpdatePurchaseHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - purchaseId
+	// - status
+	purchaseID := r.PostFormValue("purchaseId")
+	status := r.PostFormValue("status")
+
+	_, err := h.InventoryService.UpdatePurchaseStatus(purchaseID, status)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Update successful"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdatePurchaseHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdatePurchaseHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
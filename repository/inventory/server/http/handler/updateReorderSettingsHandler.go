@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fmt"
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+	"strconv"
+)
+
+//UpdateReorderSettingsHandler is a specific http handler for setting the reorder point, reorder quantity and safety stock of an item
+type UpdateReorderSettingsHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//UpdateReorderSettingsHandle is the implementation of http handler for a UpdateReorderSettingsHandler object
+func (h *UpdateReorderSettingsHandler) UpdateReorderSettingsHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - sku
+	// - reorderPoint (0 to stop watching stock of the item)
+	// - reorderQuantity
+	// - safetyStock
+	sku := r.PostFormValue("sku")
+	settings := make([]int64, 0, 3)
+	for _, val := range []string{"reorderPoint", "reorderQuantity", "safetyStock"} {
+		value, err := strconv.ParseInt(r.PostFormValue(val), 10, 64)
+		if err != nil {
+			return composeError(fmt.Errorf("%v must be a number", val))
+		}
+		settings = append(settings, value)
+	}
+
+	errs := h.InventoryService.UpdateReorderSettings(sku, settings[0], settings[1], settings[2])
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Update successful"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateReorderSettingsHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateReorderSettingsHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
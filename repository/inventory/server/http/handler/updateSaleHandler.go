@@ -16,10 +16,12 @@ func (h *UpdateSaleHandler) UpdateSaleHandle(w http.ResponseWriter, r *http.Requ
 	//read the following POST data:
 	// - invoiceId
 	// - status
+	// - changedBy
 	invoiceNo := r.PostFormValue("invoiceId")
 	status := r.PostFormValue("status")
+	changedBy := r.PostFormValue("changedBy")
 
-	_, err := h.InventoryService.UpdateSale(invoiceNo, status)
+	_, err := h.InventoryService.UpdateSale(invoiceNo, status, changedBy)
 	if err != nil {
 		return composeError(err)
 	}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//UpdateSupplierHandler is a specific http handler for updating the master data of a supplier
+type UpdateSupplierHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//UpdateSupplierHandle is the implementation of http handler for a UpdateSupplierHandler object
+func (h *UpdateSupplierHandler) UpdateSupplierHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - supplierId
+	// - name
+	// - contactName, phone, email and address (optional)
+	// - paymentTermDays (optional, days the payment is due after the purchase date, 0 for cash on delivery)
+	detail, err := composeSupplierDetail(r)
+	if err != nil {
+		return composeError(err)
+	}
+
+	errs := h.InventoryService.UpdateSupplier(detail)
+	if errs != nil {
+		return composeError(errs)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Update successful"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateSupplierHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateSupplierHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"ijah-inventory/repository/inventory/domain/inventory/service"
+	"net/http"
+)
+
+//UpdateSupplierItemHandler is a specific http handler for setting the code of an item in the catalog of a supplier
+type UpdateSupplierItemHandler struct {
+	Handler
+	InventoryService *service.Inventory `inject:"inventoryService"`
+}
+
+//UpdateSupplierItemHandle is the implementation of http handler for a UpdateSupplierItemHandler object
+func (h *UpdateSupplierItemHandler) UpdateSupplierItemHandle(w http.ResponseWriter, r *http.Request) error {
+	//read the following POST data:
+	// - supplierId
+	// - sku
+	// - supplierSku (code of the item in the catalog of the supplier)
+	supplierID := r.PostFormValue("supplierId")
+	sku := r.PostFormValue("sku")
+	supplierSku := r.PostFormValue("supplierSku")
+
+	err := h.InventoryService.UpdateSupplierItem(supplierID, sku, supplierSku)
+	if err != nil {
+		return composeError(err)
+	}
+	//compose successful response
+	response := SimpleResponseStruct{}
+	response.Code = ErrCodeSuccessful
+	response.Message = "Update successful"
+
+	successfulResponse, statusError := composeJSONResponse(response)
+	if statusError != nil {
+		return statusError
+	}
+	//else no problem in json marshalling the response
+	w.Write([]byte(successfulResponse))
+	return nil
+}
+
+//StartUp allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateSupplierItemHandler) StartUp() {
+	//Note: perform initialization/bootstrapping here
+}
+
+//Shutdown allows the handler to satisfy gocontainer.Service interface (import package github.com/ncrypthic/gocontainer)
+func (h *UpdateSupplierItemHandler) Shutdown() {
+	//Note: perform any cleanup here
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"path"
 	"runtime"
 
@@ -24,6 +25,7 @@ func main() {
 	//parse config file and compose the config objects
 	httpConfigPath := path.Join(path.Dir(currentFilePath), "../../config/http")
 	dbConfigPath := path.Join(path.Dir(currentFilePath), "../../config/database")
+	inventoryConfigPath := path.Join(path.Dir(currentFilePath), "../../config/inventory")
 
 	config := viper.New()
 	//http config
@@ -41,6 +43,25 @@ func main() {
 	if err != nil {
 		panic(fmt.Errorf("Failed merging database config: %v", err))
 	}
+	//seed file of the memory driver is relative to the database config directory
+	seedFile := config.GetString("database.seedFile")
+	if seedFile != "" && false == path.IsAbs(seedFile) {
+		config.Set("database.seedFile", path.Join(dbConfigPath, seedFile))
+	}
+
+	//inventory config
+	config.SetConfigName("inventoryConfig")   //name of config file (without extension)
+	config.AddConfigPath(inventoryConfigPath) //path to look for the config file in
+	err = config.MergeInConfig()              //merge the config file
+	if err != nil {
+		panic(fmt.Errorf("Failed merging inventory config: %v", err))
+	}
+
+	//migrate subcommand (e.g. `go run main.go migrate status`), runs the database migrations instead of the http server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(config, os.Args[2:])
+		return
+	}
 
 	//service container
 	sc = gocontainer.NewContainer()
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	dbConfig "ijah-inventory/repository/inventory/server/config/database"
+	"ijah-inventory/repository/inventory/server/migration"
+
+	"github.com/spf13/viper"
+)
+
+//migrateUsage is the usage of the migrate subcommand
+const migrateUsage = `usage: go run main.go migrate [command]
+commands:
+  up           apply all pending migrations (default)
+  down [steps] revert the given number of most recently applied migrations (defaults to 1)
+  status       list all migrations along with whether they're applied`
+
+//runMigrate is a function for running the migrate subcommand against the configured database
+func runMigrate(config *viper.Viper, args []string) {
+	databaseConfig := &dbConfig.Config{
+		Driver:     config.GetString("database.driver"),
+		DbFile:     config.GetString("database.filePath"),
+		DataSource: config.GetString("database.dataSource"),
+	}
+	dialect, errm := datamapper.DialectOf(databaseConfig.DriverName())
+	if errm != nil {
+		exitMigrate(errm)
+	}
+	dbSession, err := sql.Open(dialect.DriverName(), databaseConfig.DataSourceName())
+	if err != nil {
+		exitMigrate(fmt.Errorf("Database initialization failed: %v", err))
+	}
+	defer dbSession.Close()
+
+	migrator, errm := migration.NewMigrator(dbSession, dialect)
+	if errm != nil {
+		exitMigrate(errm)
+	}
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+	switch command {
+	case "up":
+		applied, errm := migrator.Up()
+		for _, val := range applied {
+			fmt.Printf("applied %03d_%v\n", val.Version, val.Name)
+		}
+		if errm != nil {
+			exitMigrate(errm)
+		}
+		if len(applied) == 0 {
+			fmt.Println("database is up to date")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				exitMigrate(fmt.Errorf("Invalid number of steps %v", args[1]))
+			}
+		}
+		reverted, errm := migrator.Down(steps)
+		for _, val := range reverted {
+			fmt.Printf("reverted %03d_%v\n", val.Version, val.Name)
+		}
+		if errm != nil {
+			exitMigrate(errm)
+		}
+	case "status":
+		statuses, errm := migrator.Status()
+		if errm != nil {
+			exitMigrate(errm)
+		}
+		for _, val := range statuses {
+			state := "pending"
+			if true == val.Applied {
+				state = "applied at " + val.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%03d_%v: %v\n", val.Version, val.Name, state)
+		}
+	default:
+		exitMigrate(fmt.Errorf("Unknown migrate command %v\n%v", command, migrateUsage))
+	}
+}
+
+//exitMigrate is a function for reporting a failed migrate subcommand and exiting with a non zero status
+func exitMigrate(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
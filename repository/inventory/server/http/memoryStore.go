@@ -0,0 +1,54 @@
+package http
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+	"ijah-inventory/repository/inventory/server/migration"
+)
+
+//openMemoryStore is a function for creating the store of the memory database driver, seeded from the sqlite dump at seedFile (empty store when seedFile isn't set)
+//the dump is restored to a temporary sqlite database, brought up to date by the migrations and copied into the store, the temporary database is removed afterwards
+func openMemoryStore(seedFile string) (*datamapper.MemoryStore, error) {
+	store := datamapper.NewMemoryStore()
+	if seedFile == "" {
+		return store, nil
+	}
+
+	dump, err := ioutil.ReadFile(seedFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading seed file: %v", err)
+	}
+	tempDir, err := ioutil.TempDir("", "ijah")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	dbSession, err := sql.Open(datamapper.DriverSQLite, filepath.Join(tempDir, "seed.db"))
+	if err != nil {
+		return nil, err
+	}
+	defer dbSession.Close()
+
+	_, err = dbSession.Exec(string(dump))
+	if err != nil {
+		return nil, fmt.Errorf("Failed restoring seed file: %v", err)
+	}
+	migrator, errm := migration.NewMigrator(dbSession, datamapper.SQLite)
+	if errm != nil {
+		return nil, errm
+	}
+	_, errm = migrator.Up()
+	if errm != nil {
+		return nil, errm
+	}
+	errm = datamapper.SeedMemoryStore(store, dbSession, datamapper.SQLite)
+	if errm != nil {
+		return nil, errm
+	}
+	return store, nil
+}
@@ -144,4 +144,602 @@ func (s *Server) routeSetup() {
 		panic("failed asserting 'exportSalesCSVHandler'")
 	}
 	exportSalesCSVRoute.Handler(exportSalesCSVHandler)
+
+	//createPurchase route
+	createPurchaseRoute := s.router.Path("/createPurchase")
+	createPurchaseRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("createPurchaseHandler")
+	if false == found {
+		panic("service 'createPurchaseHandler' not found")
+	}
+	createPurchaseHandler, ok := serviceObj.(*handler.CreatePurchaseHandler)
+	if false == ok {
+		panic("failed asserting 'createPurchaseHandler'")
+	}
+	createPurchaseRoute.Handler(createPurchaseHandler)
+
+	//getPurchase route
+	getPurchaseRoute := s.router.Path("/purchase")
+	getPurchaseRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getPurchaseHandler")
+	if false == found {
+		panic("service 'getPurchaseHandler' not found")
+	}
+	getPurchaseHandler, ok := serviceObj.(*handler.GetPurchaseHandler)
+	if false == ok {
+		panic("failed asserting 'getPurchaseHandler'")
+	}
+	getPurchaseRoute.Handler(getPurchaseHandler)
+
+	//listPurchases route
+	listPurchasesRoute := s.router.Path("/purchases")
+	listPurchasesRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("listPurchasesHandler")
+	if false == found {
+		panic("service 'listPurchasesHandler' not found")
+	}
+	listPurchasesHandler, ok := serviceObj.(*handler.ListPurchasesHandler)
+	if false == ok {
+		panic("failed asserting 'listPurchasesHandler'")
+	}
+	listPurchasesRoute.Handler(listPurchasesHandler)
+
+	//updatePurchase route
+	updatePurchaseRoute := s.router.Path("/updatePurchase")
+	updatePurchaseRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("updatePurchaseHandler")
+	if false == found {
+		panic("service 'updatePurchaseHandler' not found")
+	}
+	updatePurchaseHandler, ok := serviceObj.(*handler.UpdatePurchaseHandler)
+	if false == ok {
+		panic("failed asserting 'updatePurchaseHandler'")
+	}
+	updatePurchaseRoute.Handler(updatePurchaseHandler)
+
+	//receivePurchase route
+	receivePurchaseRoute := s.router.Path("/receivePurchase")
+	receivePurchaseRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("receivePurchaseHandler")
+	if false == found {
+		panic("service 'receivePurchaseHandler' not found")
+	}
+	receivePurchaseHandler, ok := serviceObj.(*handler.ReceivePurchaseHandler)
+	if false == ok {
+		panic("failed asserting 'receivePurchaseHandler'")
+	}
+	receivePurchaseRoute.Handler(receivePurchaseHandler)
+
+	//getPurchaseReceipts route
+	getPurchaseReceiptsRoute := s.router.Path("/purchaseReceipts")
+	getPurchaseReceiptsRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getPurchaseReceiptsHandler")
+	if false == found {
+		panic("service 'getPurchaseReceiptsHandler' not found")
+	}
+	getPurchaseReceiptsHandler, ok := serviceObj.(*handler.GetPurchaseReceiptsHandler)
+	if false == ok {
+		panic("failed asserting 'getPurchaseReceiptsHandler'")
+	}
+	getPurchaseReceiptsRoute.Handler(getPurchaseReceiptsHandler)
+
+	//adjustStock route
+	adjustStockRoute := s.router.Path("/adjustStock")
+	adjustStockRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("adjustStockHandler")
+	if false == found {
+		panic("service 'adjustStockHandler' not found")
+	}
+	adjustStockHandler, ok := serviceObj.(*handler.AdjustStockHandler)
+	if false == ok {
+		panic("failed asserting 'adjustStockHandler'")
+	}
+	adjustStockRoute.Handler(adjustStockHandler)
+
+	//getStockMovements route
+	getStockMovementsRoute := s.router.Path("/stockMovements")
+	getStockMovementsRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getStockMovementsHandler")
+	if false == found {
+		panic("service 'getStockMovementsHandler' not found")
+	}
+	getStockMovementsHandler, ok := serviceObj.(*handler.GetStockMovementsHandler)
+	if false == ok {
+		panic("failed asserting 'getStockMovementsHandler'")
+	}
+	getStockMovementsRoute.Handler(getStockMovementsHandler)
+
+	//getSaleHistory route
+	getSaleHistoryRoute := s.router.Path("/saleHistory")
+	getSaleHistoryRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getSaleHistoryHandler")
+	if false == found {
+		panic("service 'getSaleHistoryHandler' not found")
+	}
+	getSaleHistoryHandler, ok := serviceObj.(*handler.GetSaleHistoryHandler)
+	if false == ok {
+		panic("failed asserting 'getSaleHistoryHandler'")
+	}
+	getSaleHistoryRoute.Handler(getSaleHistoryHandler)
+
+	//returnSale route
+	returnSaleRoute := s.router.Path("/returnSale")
+	returnSaleRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("returnSaleHandler")
+	if false == found {
+		panic("service 'returnSaleHandler' not found")
+	}
+	returnSaleHandler, ok := serviceObj.(*handler.ReturnSaleHandler)
+	if false == ok {
+		panic("failed asserting 'returnSaleHandler'")
+	}
+	returnSaleRoute.Handler(returnSaleHandler)
+
+	//getSalesReturns route
+	getSalesReturnsRoute := s.router.Path("/salesReturns")
+	getSalesReturnsRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getSalesReturnsHandler")
+	if false == found {
+		panic("service 'getSalesReturnsHandler' not found")
+	}
+	getSalesReturnsHandler, ok := serviceObj.(*handler.GetSalesReturnsHandler)
+	if false == ok {
+		panic("failed asserting 'getSalesReturnsHandler'")
+	}
+	getSalesReturnsRoute.Handler(getSalesReturnsHandler)
+
+	//openStockCount route
+	openStockCountRoute := s.router.Path("/openStockCount")
+	openStockCountRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("openStockCountHandler")
+	if false == found {
+		panic("service 'openStockCountHandler' not found")
+	}
+	openStockCountHandler, ok := serviceObj.(*handler.OpenStockCountHandler)
+	if false == ok {
+		panic("failed asserting 'openStockCountHandler'")
+	}
+	openStockCountRoute.Handler(openStockCountHandler)
+
+	//recordStockCount route
+	recordStockCountRoute := s.router.Path("/recordStockCount")
+	recordStockCountRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("recordStockCountHandler")
+	if false == found {
+		panic("service 'recordStockCountHandler' not found")
+	}
+	recordStockCountHandler, ok := serviceObj.(*handler.RecordStockCountHandler)
+	if false == ok {
+		panic("failed asserting 'recordStockCountHandler'")
+	}
+	recordStockCountRoute.Handler(recordStockCountHandler)
+
+	//postStockCount route
+	postStockCountRoute := s.router.Path("/postStockCount")
+	postStockCountRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("postStockCountHandler")
+	if false == found {
+		panic("service 'postStockCountHandler' not found")
+	}
+	postStockCountHandler, ok := serviceObj.(*handler.PostStockCountHandler)
+	if false == ok {
+		panic("failed asserting 'postStockCountHandler'")
+	}
+	postStockCountRoute.Handler(postStockCountHandler)
+
+	//getStockCountVariance route
+	getStockCountVarianceRoute := s.router.Path("/stockCountVariance")
+	getStockCountVarianceRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getStockCountVarianceHandler")
+	if false == found {
+		panic("service 'getStockCountVarianceHandler' not found")
+	}
+	getStockCountVarianceHandler, ok := serviceObj.(*handler.GetStockCountVarianceHandler)
+	if false == ok {
+		panic("failed asserting 'getStockCountVarianceHandler'")
+	}
+	getStockCountVarianceRoute.Handler(getStockCountVarianceHandler)
+
+	//listStock route
+	listStockRoute := s.router.Path("/stock")
+	listStockRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("listStockHandler")
+	if false == found {
+		panic("service 'listStockHandler' not found")
+	}
+	listStockHandler, ok := serviceObj.(*handler.ListStockHandler)
+	if false == ok {
+		panic("failed asserting 'listStockHandler'")
+	}
+	listStockRoute.Handler(listStockHandler)
+
+	//listSales route
+	listSalesRoute := s.router.Path("/sales")
+	listSalesRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("listSalesHandler")
+	if false == found {
+		panic("service 'listSalesHandler' not found")
+	}
+	listSalesHandler, ok := serviceObj.(*handler.ListSalesHandler)
+	if false == ok {
+		panic("failed asserting 'listSalesHandler'")
+	}
+	listSalesRoute.Handler(listSalesHandler)
+
+	//searchStock route
+	searchStockRoute := s.router.Path("/stock/search")
+	searchStockRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("searchStockHandler")
+	if false == found {
+		panic("service 'searchStockHandler' not found")
+	}
+	searchStockHandler, ok := serviceObj.(*handler.SearchStockHandler)
+	if false == ok {
+		panic("failed asserting 'searchStockHandler'")
+	}
+	searchStockRoute.Handler(searchStockHandler)
+
+	//getProduct route
+	getProductRoute := s.router.Path("/product")
+	getProductRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getProductHandler")
+	if false == found {
+		panic("service 'getProductHandler' not found")
+	}
+	getProductHandler, ok := serviceObj.(*handler.GetProductHandler)
+	if false == ok {
+		panic("failed asserting 'getProductHandler'")
+	}
+	getProductRoute.Handler(getProductHandler)
+
+	//createCategory route
+	createCategoryRoute := s.router.Path("/createCategory")
+	createCategoryRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("createCategoryHandler")
+	if false == found {
+		panic("service 'createCategoryHandler' not found")
+	}
+	createCategoryHandler, ok := serviceObj.(*handler.CreateCategoryHandler)
+	if false == ok {
+		panic("failed asserting 'createCategoryHandler'")
+	}
+	createCategoryRoute.Handler(createCategoryHandler)
+
+	//updateCategory route
+	updateCategoryRoute := s.router.Path("/updateCategory")
+	updateCategoryRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("updateCategoryHandler")
+	if false == found {
+		panic("service 'updateCategoryHandler' not found")
+	}
+	updateCategoryHandler, ok := serviceObj.(*handler.UpdateCategoryHandler)
+	if false == ok {
+		panic("failed asserting 'updateCategoryHandler'")
+	}
+	updateCategoryRoute.Handler(updateCategoryHandler)
+
+	//deleteCategory route
+	deleteCategoryRoute := s.router.Path("/deleteCategory")
+	deleteCategoryRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("deleteCategoryHandler")
+	if false == found {
+		panic("service 'deleteCategoryHandler' not found")
+	}
+	deleteCategoryHandler, ok := serviceObj.(*handler.DeleteCategoryHandler)
+	if false == ok {
+		panic("failed asserting 'deleteCategoryHandler'")
+	}
+	deleteCategoryRoute.Handler(deleteCategoryHandler)
+
+	//getCategories route
+	getCategoriesRoute := s.router.Path("/categories")
+	getCategoriesRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getCategoriesHandler")
+	if false == found {
+		panic("service 'getCategoriesHandler' not found")
+	}
+	getCategoriesHandler, ok := serviceObj.(*handler.GetCategoriesHandler)
+	if false == ok {
+		panic("failed asserting 'getCategoriesHandler'")
+	}
+	getCategoriesRoute.Handler(getCategoriesHandler)
+
+	//updateItemCategory route
+	updateItemCategoryRoute := s.router.Path("/updateItemCategory")
+	updateItemCategoryRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("updateItemCategoryHandler")
+	if false == found {
+		panic("service 'updateItemCategoryHandler' not found")
+	}
+	updateItemCategoryHandler, ok := serviceObj.(*handler.UpdateItemCategoryHandler)
+	if false == ok {
+		panic("failed asserting 'updateItemCategoryHandler'")
+	}
+	updateItemCategoryRoute.Handler(updateItemCategoryHandler)
+
+	//createAttribute route
+	createAttributeRoute := s.router.Path("/createAttribute")
+	createAttributeRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("createAttributeHandler")
+	if false == found {
+		panic("service 'createAttributeHandler' not found")
+	}
+	createAttributeHandler, ok := serviceObj.(*handler.CreateAttributeHandler)
+	if false == ok {
+		panic("failed asserting 'createAttributeHandler'")
+	}
+	createAttributeRoute.Handler(createAttributeHandler)
+
+	//updateAttribute route
+	updateAttributeRoute := s.router.Path("/updateAttribute")
+	updateAttributeRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("updateAttributeHandler")
+	if false == found {
+		panic("service 'updateAttributeHandler' not found")
+	}
+	updateAttributeHandler, ok := serviceObj.(*handler.UpdateAttributeHandler)
+	if false == ok {
+		panic("failed asserting 'updateAttributeHandler'")
+	}
+	updateAttributeRoute.Handler(updateAttributeHandler)
+
+	//deleteAttribute route
+	deleteAttributeRoute := s.router.Path("/deleteAttribute")
+	deleteAttributeRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("deleteAttributeHandler")
+	if false == found {
+		panic("service 'deleteAttributeHandler' not found")
+	}
+	deleteAttributeHandler, ok := serviceObj.(*handler.DeleteAttributeHandler)
+	if false == ok {
+		panic("failed asserting 'deleteAttributeHandler'")
+	}
+	deleteAttributeRoute.Handler(deleteAttributeHandler)
+
+	//getAttributes route
+	getAttributesRoute := s.router.Path("/attributes")
+	getAttributesRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getAttributesHandler")
+	if false == found {
+		panic("service 'getAttributesHandler' not found")
+	}
+	getAttributesHandler, ok := serviceObj.(*handler.GetAttributesHandler)
+	if false == ok {
+		panic("failed asserting 'getAttributesHandler'")
+	}
+	getAttributesRoute.Handler(getAttributesHandler)
+
+	//updateItemAttributes route
+	updateItemAttributesRoute := s.router.Path("/updateItemAttributes")
+	updateItemAttributesRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("updateItemAttributesHandler")
+	if false == found {
+		panic("service 'updateItemAttributesHandler' not found")
+	}
+	updateItemAttributesHandler, ok := serviceObj.(*handler.UpdateItemAttributesHandler)
+	if false == ok {
+		panic("failed asserting 'updateItemAttributesHandler'")
+	}
+	updateItemAttributesRoute.Handler(updateItemAttributesHandler)
+
+	//getItemAttributes route
+	getItemAttributesRoute := s.router.Path("/itemAttributes")
+	getItemAttributesRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getItemAttributesHandler")
+	if false == found {
+		panic("service 'getItemAttributesHandler' not found")
+	}
+	getItemAttributesHandler, ok := serviceObj.(*handler.GetItemAttributesHandler)
+	if false == ok {
+		panic("failed asserting 'getItemAttributesHandler'")
+	}
+	getItemAttributesRoute.Handler(getItemAttributesHandler)
+
+	//createSupplier route
+	createSupplierRoute := s.router.Path("/createSupplier")
+	createSupplierRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("createSupplierHandler")
+	if false == found {
+		panic("service 'createSupplierHandler' not found")
+	}
+	createSupplierHandler, ok := serviceObj.(*handler.CreateSupplierHandler)
+	if false == ok {
+		panic("failed asserting 'createSupplierHandler'")
+	}
+	createSupplierRoute.Handler(createSupplierHandler)
+
+	//updateSupplier route
+	updateSupplierRoute := s.router.Path("/updateSupplier")
+	updateSupplierRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("updateSupplierHandler")
+	if false == found {
+		panic("service 'updateSupplierHandler' not found")
+	}
+	updateSupplierHandler, ok := serviceObj.(*handler.UpdateSupplierHandler)
+	if false == ok {
+		panic("failed asserting 'updateSupplierHandler'")
+	}
+	updateSupplierRoute.Handler(updateSupplierHandler)
+
+	//getSuppliers route
+	getSuppliersRoute := s.router.Path("/suppliers")
+	getSuppliersRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getSuppliersHandler")
+	if false == found {
+		panic("service 'getSuppliersHandler' not found")
+	}
+	getSuppliersHandler, ok := serviceObj.(*handler.GetSuppliersHandler)
+	if false == ok {
+		panic("failed asserting 'getSuppliersHandler'")
+	}
+	getSuppliersRoute.Handler(getSuppliersHandler)
+
+	//getSupplier route
+	getSupplierRoute := s.router.Path("/supplier")
+	getSupplierRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getSupplierHandler")
+	if false == found {
+		panic("service 'getSupplierHandler' not found")
+	}
+	getSupplierHandler, ok := serviceObj.(*handler.GetSupplierHandler)
+	if false == ok {
+		panic("failed asserting 'getSupplierHandler'")
+	}
+	getSupplierRoute.Handler(getSupplierHandler)
+
+	//updateSupplierItem route
+	updateSupplierItemRoute := s.router.Path("/updateSupplierItem")
+	updateSupplierItemRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("updateSupplierItemHandler")
+	if false == found {
+		panic("service 'updateSupplierItemHandler' not found")
+	}
+	updateSupplierItemHandler, ok := serviceObj.(*handler.UpdateSupplierItemHandler)
+	if false == ok {
+		panic("failed asserting 'updateSupplierItemHandler'")
+	}
+	updateSupplierItemRoute.Handler(updateSupplierItemHandler)
+
+	//getSupplierReport route
+	getSupplierReportRoute := s.router.Path("/supplierReport")
+	getSupplierReportRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getSupplierReportHandler")
+	if false == found {
+		panic("service 'getSupplierReportHandler' not found")
+	}
+	getSupplierReportHandler, ok := serviceObj.(*handler.GetSupplierReportHandler)
+	if false == ok {
+		panic("failed asserting 'getSupplierReportHandler'")
+	}
+	getSupplierReportRoute.Handler(getSupplierReportHandler)
+
+	//getAlerts route
+	getAlertsRoute := s.router.Path("/alerts")
+	getAlertsRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getAlertsHandler")
+	if false == found {
+		panic("service 'getAlertsHandler' not found")
+	}
+	getAlertsHandler, ok := serviceObj.(*handler.GetAlertsHandler)
+	if false == ok {
+		panic("failed asserting 'getAlertsHandler'")
+	}
+	getAlertsRoute.Handler(getAlertsHandler)
+
+	//updateReorderSettings route
+	updateReorderSettingsRoute := s.router.Path("/updateReorderSettings")
+	updateReorderSettingsRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("updateReorderSettingsHandler")
+	if false == found {
+		panic("service 'updateReorderSettingsHandler' not found")
+	}
+	updateReorderSettingsHandler, ok := serviceObj.(*handler.UpdateReorderSettingsHandler)
+	if false == ok {
+		panic("failed asserting 'updateReorderSettingsHandler'")
+	}
+	updateReorderSettingsRoute.Handler(updateReorderSettingsHandler)
+
+	//checkAlerts route
+	checkAlertsRoute := s.router.Path("/checkAlerts")
+	checkAlertsRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("checkAlertsHandler")
+	if false == found {
+		panic("service 'checkAlertsHandler' not found")
+	}
+	checkAlertsHandler, ok := serviceObj.(*handler.CheckAlertsHandler)
+	if false == ok {
+		panic("failed asserting 'checkAlertsHandler'")
+	}
+	checkAlertsRoute.Handler(checkAlertsHandler)
+
+	//getReplenishment route
+	getReplenishmentRoute := s.router.Path("/replenishment")
+	getReplenishmentRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getReplenishmentHandler")
+	if false == found {
+		panic("service 'getReplenishmentHandler' not found")
+	}
+	getReplenishmentHandler, ok := serviceObj.(*handler.GetReplenishmentHandler)
+	if false == ok {
+		panic("failed asserting 'getReplenishmentHandler'")
+	}
+	getReplenishmentRoute.Handler(getReplenishmentHandler)
+
+	//createReplenishmentPurchases route
+	createReplenishmentPurchasesRoute := s.router.Path("/createReplenishmentPurchases")
+	createReplenishmentPurchasesRoute.Methods("POST")
+	serviceObj, found = s.sc.GetService("createReplenishmentPurchasesHandler")
+	if false == found {
+		panic("service 'createReplenishmentPurchasesHandler' not found")
+	}
+	createReplenishmentPurchasesHandler, ok := serviceObj.(*handler.CreateReplenishmentPurchasesHandler)
+	if false == ok {
+		panic("failed asserting 'createReplenishmentPurchasesHandler'")
+	}
+	createReplenishmentPurchasesRoute.Handler(createReplenishmentPurchasesHandler)
+
+	//forecast route
+	forecastRoute := s.router.Path("/forecast")
+	forecastRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("forecastHandler")
+	if false == found {
+		panic("service 'forecastHandler' not found")
+	}
+	forecastHandler, ok := serviceObj.(*handler.ForecastHandler)
+	if false == ok {
+		panic("failed asserting 'forecastHandler'")
+	}
+	forecastRoute.Handler(forecastHandler)
+
+	//getSalesReport route
+	getSalesReportRoute := s.router.Path("/salesReport")
+	getSalesReportRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getSalesReportHandler")
+	if false == found {
+		panic("service 'getSalesReportHandler' not found")
+	}
+	getSalesReportHandler, ok := serviceObj.(*handler.GetSalesReportHandler)
+	if false == ok {
+		panic("failed asserting 'getSalesReportHandler'")
+	}
+	getSalesReportRoute.Handler(getSalesReportHandler)
+
+	//exportSalesReportCSV route
+	exportSalesReportCSVRoute := s.router.Path("/exportSalesReportCSV")
+	exportSalesReportCSVRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("exportSalesReportCSVHandler")
+	if false == found {
+		panic("service 'exportSalesReportCSVHandler' not found")
+	}
+	exportSalesReportCSVHandler, ok := serviceObj.(*handler.ExportSalesReportCSVHandler)
+	if false == ok {
+		panic("failed asserting 'exportSalesReportCSVHandler'")
+	}
+	exportSalesReportCSVRoute.Handler(exportSalesReportCSVHandler)
+
+	//getStockTurnover route
+	getStockTurnoverRoute := s.router.Path("/getStockTurnover")
+	getStockTurnoverRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getStockTurnoverHandler")
+	if false == found {
+		panic("service 'getStockTurnoverHandler' not found")
+	}
+	getStockTurnoverHandler, ok := serviceObj.(*handler.GetStockTurnoverHandler)
+	if false == ok {
+		panic("failed asserting 'getStockTurnoverHandler'")
+	}
+	getStockTurnoverRoute.Handler(getStockTurnoverHandler)
+
+	//getDeadStock route
+	getDeadStockRoute := s.router.Path("/getDeadStock")
+	getDeadStockRoute.Methods("GET")
+	serviceObj, found = s.sc.GetService("getDeadStockHandler")
+	if false == found {
+		panic("service 'getDeadStockHandler' not found")
+	}
+	getDeadStockHandler, ok := serviceObj.(*handler.GetDeadStockHandler)
+	if false == ok {
+		panic("failed asserting 'getDeadStockHandler'")
+	}
+	getDeadStockRoute.Handler(getDeadStockHandler)
 }
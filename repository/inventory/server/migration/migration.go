@@ -0,0 +1,312 @@
+//Package migration provides the versioned schema migrations of the inventory database along with the runner applying them
+package migration
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"ijah-inventory/repository/inventory/domain/inventory/datamapper"
+)
+
+//migrationFiles are the sql scripts of all migrations, every migration has an up script (applying it) and a down script (reverting it)
+//scripts are named <version>_<name>.up.sql and <version>_<name>.down.sql, they must not start or commit transactions themselves
+//every dialect has its own scripts in sql/<driver name> (e.g. sql/postgres), each migration version must exist for every dialect
+//
+//go:embed sql/sqlite3/*.sql sql/postgres/*.sql
+var migrationFiles embed.FS
+
+//fileNameRegxp is the regex for parsing the version, name and direction of a migration script file name
+var fileNameRegxp = regexp.MustCompile(`^(?P<version>\d+)_(?P<name>\w+)\.(?P<direction>up|down)\.sql$`)
+
+//schemaTableQueries are the queries (of each dialect) telling whether the schema_migrations table exists
+var schemaTableQueries = map[string]string{
+	datamapper.DriverSQLite:   "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'",
+	datamapper.DriverPostgres: "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA() AND table_name = 'schema_migrations'",
+}
+
+//legacyProbes are queries telling whether a migration has already been applied by hand (the scripts used to be run with sqlite3 before the runner existed)
+//they're only used once, when a database without the schema_migrations table is migrated for the first time
+//migrations without a probe (e.g. the initial schema, which only creates missing tables) are simply applied
+//Note: the probes only apply to sqlite databases, other dialects have always been migrated by the runner
+var legacyProbes = map[int]string{
+	1: "SELECT COUNT(*) FROM pragma_table_info('stock') WHERE name = 'BUY_PRICE' AND type = 'INTEGER'",
+	2: "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sale_status_transitions'",
+	3: "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sales_returns'",
+	4: "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'stock_counts'",
+	5: "SELECT COUNT(*) FROM pragma_table_info('stock') WHERE name = 'RESERVED_QUANTITY'",
+}
+
+const timeFormat = "2006-01-02 15:04:05"
+
+//Migration is a definition of a single versioned change of the database schema
+type Migration struct {
+	Version int
+	Name    string
+	Up      string //sql script applying the change
+	Down    string //sql script reverting the change
+}
+
+//Status is a definition of the state of a migration in a database
+type Status struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	Applied   bool      `json:"applied"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+//Migrator is a runner applying and reverting the migrations of a database in order of version
+//every applied migration is kept in the schema_migrations table, so each migration is applied only once
+type Migrator struct {
+	db         *sql.DB
+	dialect    datamapper.Dialect
+	migrations []*Migration
+}
+
+//NewMigrator creates a new Migrator of the embedded migrations of the given dialect and returns a pointer to it
+func NewMigrator(dbSession *sql.DB, dialect datamapper.Dialect) (*Migrator, *errors.Error) {
+	migrations, err := loadMigrations(path.Join("sql", dialect.DriverName()))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         dbSession,
+		dialect:    dialect,
+		migrations: migrations,
+	}, nil
+}
+
+//loadMigrations is a function for reading the embedded migration scripts of the given directory (ordered by version)
+func loadMigrations(dir string) ([]*Migration, *errors.Error) {
+	entries, err := migrationFiles.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	migrationMap := make(map[int]*Migration, 0)
+	for _, entry := range entries {
+		found := fileNameRegxp.FindStringSubmatch(entry.Name())
+		if len(found) == 0 {
+			return nil, errors.Wrap(fmt.Errorf("Invalid migration file name %v", entry.Name()), 0)
+		}
+		version, err := strconv.Atoi(found[1])
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		script, err := migrationFiles.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		migration, exists := migrationMap[version]
+		if false == exists {
+			migration = &Migration{
+				Version: version,
+				Name:    found[2],
+			}
+			migrationMap[version] = migration
+		}
+		if migration.Name != found[2] {
+			return nil, errors.Wrap(fmt.Errorf("Migration version %v is used by both %v and %v", version, migration.Name, found[2]), 0)
+		}
+		if found[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+	migrations := make([]*Migration, 0, len(migrationMap))
+	for _, val := range migrationMap {
+		if val.Up == "" || val.Down == "" {
+			return nil, errors.Wrap(fmt.Errorf("Migration %03d_%v must have both up and down scripts", val.Version, val.Name), 0)
+		}
+		migrations = append(migrations, val)
+	}
+	sort.Slice(migrations, func(x, y int) bool {
+		return migrations[x].Version < migrations[y].Version
+	})
+	return migrations, nil
+}
+
+//prepare is a function for creating the schema_migrations table when the database doesn't have it yet
+//migrations already applied by hand (see legacyProbes) are recorded without being applied again
+func (m *Migrator) prepare() *errors.Error {
+	var tableCount int
+	err := m.db.QueryRow(schemaTableQueries[m.dialect.DriverName()]).Scan(&tableCount)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	if tableCount > 0 {
+		return nil
+	}
+
+	//start transaction
+	tx, err := m.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	_, err = tx.Exec("CREATE TABLE schema_migrations (VERSION INTEGER PRIMARY KEY, NAME VARCHAR(64), APPLIED_AT TIMESTAMP)")
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, 0)
+	}
+	for _, val := range m.migrations {
+		probe, exists := legacyProbes[val.Version]
+		if false == exists || m.dialect.DriverName() != datamapper.DriverSQLite {
+			continue
+		}
+		var appliedCount int
+		err = tx.QueryRow(probe).Scan(&appliedCount)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, 0)
+		}
+		if appliedCount == 0 {
+			continue
+		}
+		_, err = tx.Exec(m.dialect.Translate("INSERT INTO schema_migrations(VERSION, NAME, APPLIED_AT) VALUES(?, ?, ?)"), val.Version, val.Name, time.Now().Format(timeFormat))
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, 0)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//appliedVersions is a function for obtaining the applied migrations of the database (version -> time applied)
+func (m *Migrator) appliedVersions() (map[int]time.Time, *errors.Error) {
+	errs := m.prepare()
+	if errs != nil {
+		return nil, errs
+	}
+	rows, err := m.db.Query(m.dialect.Translate("SELECT VERSION, DATETIME(APPLIED_AT) FROM schema_migrations"))
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	defer rows.Close()
+
+	appliedMap := make(map[int]time.Time, 0)
+	for rows.Next() {
+		var version int
+		var appliedAt sql.NullString
+		err = rows.Scan(&version, &appliedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		var appliedTime time.Time
+		if true == appliedAt.Valid {
+			appliedTime, _ = time.Parse(timeFormat, appliedAt.String)
+		}
+		appliedMap[version] = appliedTime
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return appliedMap, nil
+}
+
+//run is a function for running a migration script and recording the result in schema_migrations (in a single transaction)
+func (m *Migrator) run(migration *Migration, up bool) *errors.Error {
+	//start transaction
+	tx, err := m.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	script := migration.Down
+	if true == up {
+		script = migration.Up
+	}
+	_, err = tx.Exec(script)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(fmt.Errorf("Migration %03d_%v failed: %v", migration.Version, migration.Name, err), 0)
+	}
+	if true == up {
+		_, err = tx.Exec(m.dialect.Translate("INSERT INTO schema_migrations(VERSION, NAME, APPLIED_AT) VALUES(?, ?, ?)"), migration.Version, migration.Name, time.Now().Format(timeFormat))
+	} else {
+		_, err = tx.Exec(m.dialect.Translate("DELETE FROM schema_migrations WHERE VERSION = ?"), migration.Version)
+	}
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, 0)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+//Up is a function for applying all pending migrations (in order of version)
+//it returns the migrations applied, migrations applied before a failed one stay applied
+func (m *Migrator) Up() ([]*Migration, *errors.Error) {
+	appliedMap, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+	appliedMigrations := make([]*Migration, 0)
+	for _, val := range m.migrations {
+		if _, applied := appliedMap[val.Version]; applied {
+			continue
+		}
+		err = m.run(val, true)
+		if err != nil {
+			return appliedMigrations, err
+		}
+		appliedMigrations = append(appliedMigrations, val)
+	}
+	return appliedMigrations, nil
+}
+
+//Down is a function for reverting the given number of most recently applied migrations (in reverse order of version)
+//it returns the migrations reverted
+func (m *Migrator) Down(steps int) ([]*Migration, *errors.Error) {
+	if steps <= 0 {
+		return nil, errors.Wrap(fmt.Errorf("Invalid number of migrations to revert %v", steps), 0)
+	}
+	appliedMap, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+	revertedMigrations := make([]*Migration, 0, steps)
+	for x := len(m.migrations) - 1; x >= 0 && len(revertedMigrations) < steps; x-- {
+		if _, applied := appliedMap[m.migrations[x].Version]; false == applied {
+			continue
+		}
+		err = m.run(m.migrations[x], false)
+		if err != nil {
+			return revertedMigrations, err
+		}
+		revertedMigrations = append(revertedMigrations, m.migrations[x])
+	}
+	return revertedMigrations, nil
+}
+
+//Status is a function for obtaining the state of every migration in the database (ordered by version)
+func (m *Migrator) Status() ([]*Status, *errors.Error) {
+	appliedMap, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*Status, 0, len(m.migrations))
+	for _, val := range m.migrations {
+		appliedAt, applied := appliedMap[val.Version]
+		statuses = append(statuses, &Status{
+			Version:   val.Version,
+			Name:      val.Name,
+			Applied:   applied,
+			AppliedAt: appliedAt,
+		})
+	}
+	return statuses, nil
+}
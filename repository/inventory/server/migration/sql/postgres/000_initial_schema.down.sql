@@ -0,0 +1,10 @@
+/* Drops the initial schema (every record is lost) */
+DROP TABLE IF EXISTS cost_layers;
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS purchase_receipt_items;
+DROP TABLE IF EXISTS purchase_receipts;
+DROP TABLE IF EXISTS purchase_items;
+DROP TABLE IF EXISTS purchase;
+DROP TABLE IF EXISTS sales_items;
+DROP TABLE IF EXISTS sales;
+DROP TABLE IF EXISTS stock;
@@ -0,0 +1,71 @@
+/* Creates the initial schema (the schema before any versioned migration) */
+CREATE TABLE IF NOT EXISTS stock (
+SKU VARCHAR(64) PRIMARY KEY,
+NAME VARCHAR(64),
+QUANTITY BIGINT,
+BUY_PRICE DOUBLE PRECISION,
+SELL_PRICE DOUBLE PRECISION
+);
+CREATE TABLE IF NOT EXISTS sales (
+INVOICE_ID VARCHAR(64) PRIMARY KEY,
+SALE_DATE TIMESTAMP,
+STATUS VARCHAR(3), /* D = Draft, C = Canceled, S = Sold/Done */
+NOTE TEXT NULL
+);
+CREATE TABLE IF NOT EXISTS sales_items (
+ID BIGSERIAL PRIMARY KEY,
+INVOICE_ID VARCHAR(64) REFERENCES sales(INVOICE_ID),
+SKU VARCHAR(64) REFERENCES stock(SKU),
+QUANTITY BIGINT,
+BUY_PRICE DOUBLE PRECISION NULL,
+SELL_PRICE DOUBLE PRECISION NULL,
+UNIQUE(INVOICE_ID, SKU)
+);
+CREATE TABLE IF NOT EXISTS purchase (
+PURCHASE_ID VARCHAR(64) PRIMARY KEY,
+PURCHASE_DATE TIMESTAMP,
+STATUS VARCHAR(3), /* D = Draft, C = Canceled, S = Sold/Done */
+NOTE TEXT
+);
+CREATE TABLE IF NOT EXISTS purchase_items (
+ID BIGSERIAL PRIMARY KEY,
+PURCHASE_ID VARCHAR(64) REFERENCES purchase(PURCHASE_ID),
+SKU VARCHAR(64) REFERENCES stock(SKU),
+QUANTITY BIGINT,
+BUY_PRICE DOUBLE PRECISION,
+NOTE TEXT NULL,
+RECEIVED_QUANTITY BIGINT DEFAULT 0,
+UNIQUE(PURCHASE_ID, SKU)
+);
+CREATE TABLE IF NOT EXISTS purchase_receipts (
+RECEIPT_ID VARCHAR(64) PRIMARY KEY,
+PURCHASE_ID VARCHAR(64) REFERENCES purchase(PURCHASE_ID),
+RECEIPT_DATE TIMESTAMP,
+NOTE TEXT NULL
+);
+CREATE TABLE IF NOT EXISTS purchase_receipt_items (
+ID BIGSERIAL PRIMARY KEY,
+RECEIPT_ID VARCHAR(64) REFERENCES purchase_receipts(RECEIPT_ID),
+SKU VARCHAR(64) REFERENCES stock(SKU),
+QUANTITY BIGINT,
+UNIQUE(RECEIPT_ID, SKU)
+);
+CREATE TABLE IF NOT EXISTS stock_movements (
+ID BIGSERIAL PRIMARY KEY,
+SKU VARCHAR(64) REFERENCES stock(SKU),
+QUANTITY BIGINT, /* signed, negative quantity decreases stock */
+UNIT_COST DOUBLE PRECISION, /* buy price of the item at the time of the movement */
+REASON VARCHAR(64),
+REFERENCE VARCHAR(64),
+NOTE TEXT NULL,
+MOVEMENT_DATE TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS cost_layers (
+ID BIGSERIAL PRIMARY KEY,
+SKU VARCHAR(64) REFERENCES stock(SKU),
+QUANTITY BIGINT,
+REMAINING_QUANTITY BIGINT,
+UNIT_COST DOUBLE PRECISION,
+REFERENCE VARCHAR(64),
+LAYER_DATE TIMESTAMP
+);
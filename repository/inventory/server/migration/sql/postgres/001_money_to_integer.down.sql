@@ -0,0 +1,6 @@
+/* Converts money columns (buy/sell prices and unit costs) back from BIGINT rupiah to DOUBLE PRECISION */
+ALTER TABLE stock ALTER COLUMN BUY_PRICE TYPE DOUBLE PRECISION, ALTER COLUMN SELL_PRICE TYPE DOUBLE PRECISION;
+ALTER TABLE sales_items ALTER COLUMN BUY_PRICE TYPE DOUBLE PRECISION, ALTER COLUMN SELL_PRICE TYPE DOUBLE PRECISION;
+ALTER TABLE purchase_items ALTER COLUMN BUY_PRICE TYPE DOUBLE PRECISION;
+ALTER TABLE stock_movements ALTER COLUMN UNIT_COST TYPE DOUBLE PRECISION;
+ALTER TABLE cost_layers ALTER COLUMN UNIT_COST TYPE DOUBLE PRECISION;
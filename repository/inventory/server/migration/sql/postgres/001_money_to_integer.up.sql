@@ -0,0 +1,7 @@
+/* Converts money columns (buy/sell prices and unit costs) from DOUBLE PRECISION to BIGINT rupiah */
+/* existing amounts are rounded to the nearest rupiah, e.g. 61999.999999999999998 becomes 62000 */
+ALTER TABLE stock ALTER COLUMN BUY_PRICE TYPE BIGINT USING ROUND(BUY_PRICE), ALTER COLUMN SELL_PRICE TYPE BIGINT USING ROUND(SELL_PRICE);
+ALTER TABLE sales_items ALTER COLUMN BUY_PRICE TYPE BIGINT USING ROUND(BUY_PRICE), ALTER COLUMN SELL_PRICE TYPE BIGINT USING ROUND(SELL_PRICE);
+ALTER TABLE purchase_items ALTER COLUMN BUY_PRICE TYPE BIGINT USING ROUND(BUY_PRICE);
+ALTER TABLE stock_movements ALTER COLUMN UNIT_COST TYPE BIGINT USING ROUND(UNIT_COST);
+ALTER TABLE cost_layers ALTER COLUMN UNIT_COST TYPE BIGINT USING ROUND(UNIT_COST);
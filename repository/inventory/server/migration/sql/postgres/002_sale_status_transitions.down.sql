@@ -0,0 +1,2 @@
+/* Drops the sale status history table */
+DROP TABLE sale_status_transitions;
@@ -0,0 +1,9 @@
+/* Adds the sale status history table (every sale status transition along with who made it and when) */
+CREATE TABLE sale_status_transitions (
+ID BIGSERIAL PRIMARY KEY,
+INVOICE_ID VARCHAR(64) REFERENCES sales(INVOICE_ID),
+FROM_STATUS VARCHAR(3),
+TO_STATUS VARCHAR(3),
+CHANGED_BY VARCHAR(64),
+CHANGED_AT TIMESTAMP
+);
@@ -0,0 +1,17 @@
+/* Adds the sales return tables (items returned by customers against done sales) */
+CREATE TABLE sales_returns (
+RETURN_ID VARCHAR(64) PRIMARY KEY,
+INVOICE_ID VARCHAR(64) REFERENCES sales(INVOICE_ID),
+RETURN_DATE TIMESTAMP,
+NOTE TEXT NULL
+);
+CREATE TABLE sales_return_items (
+ID BIGSERIAL PRIMARY KEY,
+RETURN_ID VARCHAR(64) REFERENCES sales_returns(RETURN_ID),
+SALE_ITEM_ID BIGINT REFERENCES sales_items(ID), /* returned line of sales_items */
+SKU VARCHAR(64) REFERENCES stock(SKU),
+QUANTITY BIGINT,
+BUY_PRICE BIGINT, /* cost of the item when it was sold */
+SELL_PRICE BIGINT, /* price of the item when it was sold (refunded amount per item) */
+UNIQUE(RETURN_ID, SKU)
+);
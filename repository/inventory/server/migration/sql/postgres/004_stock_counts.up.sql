@@ -0,0 +1,17 @@
+/* Adds the stock take (stock opname) tables */
+CREATE TABLE stock_counts (
+COUNT_ID VARCHAR(64) PRIMARY KEY,
+COUNT_DATE TIMESTAMP,
+STATUS VARCHAR(3), /* O = Open, P = Posted */
+NOTE TEXT NULL,
+POSTED_DATE TIMESTAMP NULL
+);
+CREATE TABLE stock_count_items (
+ID BIGSERIAL PRIMARY KEY,
+COUNT_ID VARCHAR(64) REFERENCES stock_counts(COUNT_ID),
+SKU VARCHAR(64) REFERENCES stock(SKU),
+COUNTED_QUANTITY BIGINT NULL, /* null until the item is counted */
+SYSTEM_QUANTITY BIGINT, /* stock quantity when the count was posted */
+BUY_PRICE BIGINT, /* buy price when the count was posted */
+UNIQUE(COUNT_ID, SKU)
+);
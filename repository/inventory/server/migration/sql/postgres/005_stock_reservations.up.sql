@@ -0,0 +1,4 @@
+/* Adds the reserved quantity of stock held by draft sales */
+/* quantities of existing draft sales are reserved right away */
+ALTER TABLE stock ADD COLUMN RESERVED_QUANTITY BIGINT NOT NULL DEFAULT 0; /* quantity held by draft sales */
+UPDATE stock SET RESERVED_QUANTITY = (SELECT COALESCE(SUM(sales_items.QUANTITY), 0) FROM sales_items INNER JOIN sales ON sales.INVOICE_ID = sales_items.INVOICE_ID WHERE sales.STATUS = 'D' AND sales_items.SKU = stock.SKU);
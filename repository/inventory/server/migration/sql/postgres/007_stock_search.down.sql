@@ -0,0 +1,2 @@
+/* Drops the full text search index of stock items */
+DROP INDEX stock_search;
@@ -0,0 +1,3 @@
+/* Adds the full text search index of the sku and name of stock items (see Stock datamapper Search) */
+/* the expression must match stockSearchVector of the datamapper, the index is kept in sync by the database */
+CREATE INDEX IF NOT EXISTS stock_search ON stock USING GIN (to_tsvector('simple', SKU || ' ' || COALESCE(NAME, '')));
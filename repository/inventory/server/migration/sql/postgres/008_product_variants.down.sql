@@ -0,0 +1,6 @@
+/* Drops products, stock items are no longer variants */
+DROP INDEX stock_product_id;
+ALTER TABLE stock DROP COLUMN COLOR;
+ALTER TABLE stock DROP COLUMN SIZE;
+ALTER TABLE stock DROP COLUMN PRODUCT_ID;
+DROP TABLE products;
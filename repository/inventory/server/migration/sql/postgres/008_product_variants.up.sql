@@ -0,0 +1,10 @@
+/* Adds products, the parent of stock items which are variants of the same product in other sizes and colors */
+/* existing items are assigned to their products by parsing their skus when the http server starts (see Inventory BackfillVariants), items whose sku doesn't follow the sku pattern are left without product */
+CREATE TABLE IF NOT EXISTS products (
+PRODUCT_ID VARCHAR(64) PRIMARY KEY,
+NAME VARCHAR(64)
+);
+ALTER TABLE stock ADD COLUMN PRODUCT_ID VARCHAR(64) NULL; /* null unless the item is a variant of a product */
+ALTER TABLE stock ADD COLUMN SIZE VARCHAR(16) NULL;
+ALTER TABLE stock ADD COLUMN COLOR VARCHAR(16) NULL;
+CREATE INDEX stock_product_id ON stock(PRODUCT_ID);
@@ -0,0 +1,7 @@
+/* Drops categories and attributes of stock items */
+DROP INDEX stock_category_id;
+ALTER TABLE stock DROP COLUMN CATEGORY_ID;
+DROP TABLE stock_attributes;
+DROP TABLE attributes;
+DROP INDEX categories_parent_id;
+DROP TABLE categories;
@@ -0,0 +1,19 @@
+/* Adds the category tree (a category without parent is a root category) and typed attributes of stock items (e.g. material, brand and season) */
+CREATE TABLE IF NOT EXISTS categories (
+CATEGORY_ID VARCHAR(64) PRIMARY KEY,
+NAME VARCHAR(64),
+PARENT_ID VARCHAR(64) NULL
+);
+CREATE INDEX categories_parent_id ON categories(PARENT_ID);
+CREATE TABLE IF NOT EXISTS attributes (
+NAME VARCHAR(64) PRIMARY KEY,
+TYPE VARCHAR(16) /* text, number, boolean or date (see model.AttributeType consts) */
+);
+CREATE TABLE IF NOT EXISTS stock_attributes (
+SKU VARCHAR(64),
+NAME VARCHAR(64),
+VALUE VARCHAR(255),
+PRIMARY KEY(SKU, NAME)
+);
+ALTER TABLE stock ADD COLUMN CATEGORY_ID VARCHAR(64) NULL; /* null unless the item is categorized */
+CREATE INDEX stock_category_id ON stock(CATEGORY_ID);
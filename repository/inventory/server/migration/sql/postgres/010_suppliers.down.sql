@@ -0,0 +1,5 @@
+/* Drops suppliers and the supplier of purchases */
+DROP INDEX purchase_supplier_id;
+ALTER TABLE purchase DROP COLUMN SUPPLIER_ID;
+DROP TABLE supplier_items;
+DROP TABLE suppliers;
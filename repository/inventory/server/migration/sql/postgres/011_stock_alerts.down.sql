@@ -0,0 +1,6 @@
+/* Drops low stock alerts and reorder settings of stock items */
+DROP INDEX stock_alerts_sku;
+DROP TABLE stock_alerts;
+ALTER TABLE stock DROP COLUMN SAFETY_STOCK;
+ALTER TABLE stock DROP COLUMN REORDER_QUANTITY;
+ALTER TABLE stock DROP COLUMN REORDER_POINT;
@@ -0,0 +1,16 @@
+/* Adds reorder settings of stock items and the low stock alerts raised when stock drops to the reorder point */
+ALTER TABLE stock ADD COLUMN REORDER_POINT BIGINT DEFAULT 0; /* 0 when stock of the item isn't watched */
+ALTER TABLE stock ADD COLUMN REORDER_QUANTITY BIGINT DEFAULT 0;
+ALTER TABLE stock ADD COLUMN SAFETY_STOCK BIGINT DEFAULT 0;
+CREATE TABLE IF NOT EXISTS stock_alerts (
+ID BIGSERIAL PRIMARY KEY,
+SKU VARCHAR(64) REFERENCES stock(SKU),
+LEVEL VARCHAR(16), /* low or critical (see model.AlertLevel consts) */
+QUANTITY BIGINT, /* stock quantity when the alert was last checked */
+REORDER_POINT BIGINT,
+REORDER_QUANTITY BIGINT,
+SAFETY_STOCK BIGINT,
+RAISED_AT TIMESTAMP,
+RESOLVED_AT TIMESTAMP NULL /* null while the alert is open */
+);
+CREATE INDEX stock_alerts_sku ON stock_alerts(SKU);
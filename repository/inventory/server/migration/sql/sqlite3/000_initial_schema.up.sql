@@ -0,0 +1,81 @@
+/* Creates the initial schema (the schema before any versioned migration) */
+/* tables are only created when missing, so databases restored from an older ijahDump.sql are left untouched */
+CREATE TABLE IF NOT EXISTS `stock` (
+`SKU` VARCHAR(64) PRIMARY KEY,
+`NAME` VARCHAR(64),
+`QUANTITY` INTEGER,
+`BUY_PRICE` REAL,
+`SELL_PRICE` REAL
+);
+CREATE TABLE IF NOT EXISTS `sales` (
+`INVOICE_ID` VARCHAR(64) PRIMARY KEY,
+`SALE_DATE` DATETIME,
+`STATUS` VARCHAR(3), /* D = Draft, C = Canceled, S = Sold/Done */
+`NOTE` TEXT NULL
+);
+CREATE TABLE IF NOT EXISTS `sales_items` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`INVOICE_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`BUY_PRICE` REAL NULL,
+`SELL_PRICE` REAL NULL,
+UNIQUE(`INVOICE_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`INVOICE_ID`) REFERENCES sales(`INVOICE_ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+CREATE TABLE IF NOT EXISTS `purchase` (
+`PURCHASE_ID` VARCHAR(64),
+`PURCHASE_DATE` DATETIME,
+`STATUS` VARCHAR(3), /* D = Draft, C = Canceled, S = Sold/Done */
+`NOTE` TEXT
+);
+CREATE TABLE IF NOT EXISTS `purchase_items` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`PURCHASE_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`BUY_PRICE` REAL,
+`NOTE` TEXT NULL,
+`RECEIVED_QUANTITY` INTEGER DEFAULT 0,
+UNIQUE(`PURCHASE_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`PURCHASE_ID`) REFERENCES purchase(`PURCHASE_ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+CREATE TABLE IF NOT EXISTS `purchase_receipts` (
+`RECEIPT_ID` VARCHAR(64) PRIMARY KEY,
+`PURCHASE_ID` VARCHAR(64),
+`RECEIPT_DATE` DATETIME,
+`NOTE` TEXT NULL,
+FOREIGN KEY(`PURCHASE_ID`) REFERENCES purchase(`PURCHASE_ID`)
+);
+CREATE TABLE IF NOT EXISTS `purchase_receipt_items` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`RECEIPT_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+UNIQUE(`RECEIPT_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`RECEIPT_ID`) REFERENCES purchase_receipts(`RECEIPT_ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+CREATE TABLE IF NOT EXISTS `stock_movements` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER, /* signed, negative quantity decreases stock */
+`UNIT_COST` REAL, /* buy price of the item at the time of the movement */
+`REASON` VARCHAR(64),
+`REFERENCE` VARCHAR(64),
+`NOTE` TEXT NULL,
+`MOVEMENT_DATE` DATETIME,
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+CREATE TABLE IF NOT EXISTS `cost_layers` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`REMAINING_QUANTITY` INTEGER,
+`UNIT_COST` REAL,
+`REFERENCE` VARCHAR(64),
+`LAYER_DATE` DATETIME,
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
@@ -0,0 +1,67 @@
+/* Converts money columns (buy/sell prices and unit costs) back from INTEGER rupiah to REAL */
+CREATE TABLE `stock_new` (
+`SKU` VARCHAR(64) PRIMARY KEY,
+`NAME` VARCHAR(64),
+`QUANTITY` INTEGER,
+`BUY_PRICE` REAL,
+`SELL_PRICE` REAL
+);
+INSERT INTO stock_new SELECT SKU, NAME, QUANTITY, CAST(BUY_PRICE AS REAL), CAST(SELL_PRICE AS REAL) FROM stock;
+DROP TABLE stock;
+ALTER TABLE stock_new RENAME TO stock;
+CREATE TABLE `sales_items_new` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`INVOICE_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`BUY_PRICE` REAL NULL,
+`SELL_PRICE` REAL NULL,
+UNIQUE(`INVOICE_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`INVOICE_ID`) REFERENCES sales(`INVOICE_ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+INSERT INTO sales_items_new SELECT ID, INVOICE_ID, SKU, QUANTITY, CAST(BUY_PRICE AS REAL), CAST(SELL_PRICE AS REAL) FROM sales_items;
+DROP TABLE sales_items;
+ALTER TABLE sales_items_new RENAME TO sales_items;
+CREATE TABLE `purchase_items_new` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`PURCHASE_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`BUY_PRICE` REAL,
+`NOTE` TEXT NULL,
+`RECEIVED_QUANTITY` INTEGER DEFAULT 0,
+UNIQUE(`PURCHASE_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`PURCHASE_ID`) REFERENCES purchase(`PURCHASE_ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+INSERT INTO purchase_items_new SELECT ID, PURCHASE_ID, SKU, QUANTITY, CAST(BUY_PRICE AS REAL), NOTE, RECEIVED_QUANTITY FROM purchase_items;
+DROP TABLE purchase_items;
+ALTER TABLE purchase_items_new RENAME TO purchase_items;
+CREATE TABLE `stock_movements_new` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER, /* signed, negative quantity decreases stock */
+`UNIT_COST` REAL, /* buy price of the item at the time of the movement */
+`REASON` VARCHAR(64),
+`REFERENCE` VARCHAR(64),
+`NOTE` TEXT NULL,
+`MOVEMENT_DATE` DATETIME,
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+INSERT INTO stock_movements_new SELECT ID, SKU, QUANTITY, CAST(UNIT_COST AS REAL), REASON, REFERENCE, NOTE, MOVEMENT_DATE FROM stock_movements;
+DROP TABLE stock_movements;
+ALTER TABLE stock_movements_new RENAME TO stock_movements;
+CREATE TABLE `cost_layers_new` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`REMAINING_QUANTITY` INTEGER,
+`UNIT_COST` REAL,
+`REFERENCE` VARCHAR(64),
+`LAYER_DATE` DATETIME,
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+INSERT INTO cost_layers_new SELECT ID, SKU, QUANTITY, REMAINING_QUANTITY, CAST(UNIT_COST AS REAL), REFERENCE, LAYER_DATE FROM cost_layers;
+DROP TABLE cost_layers;
+ALTER TABLE cost_layers_new RENAME TO cost_layers;
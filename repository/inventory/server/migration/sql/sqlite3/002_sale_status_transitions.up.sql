@@ -0,0 +1,10 @@
+/* Adds the sale status history table (every sale status transition along with who made it and when) */
+CREATE TABLE `sale_status_transitions` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`INVOICE_ID` VARCHAR(64),
+`FROM_STATUS` VARCHAR(3),
+`TO_STATUS` VARCHAR(3),
+`CHANGED_BY` VARCHAR(64),
+`CHANGED_AT` DATETIME,
+FOREIGN KEY(`INVOICE_ID`) REFERENCES sales(`INVOICE_ID`)
+);
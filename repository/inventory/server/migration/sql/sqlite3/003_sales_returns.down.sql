@@ -0,0 +1,3 @@
+/* Drops the sales return tables */
+DROP TABLE `sales_return_items`;
+DROP TABLE `sales_returns`;
@@ -0,0 +1,21 @@
+/* Adds the sales return tables (items returned by customers against done sales) */
+CREATE TABLE `sales_returns` (
+`RETURN_ID` VARCHAR(64) PRIMARY KEY,
+`INVOICE_ID` VARCHAR(64),
+`RETURN_DATE` DATETIME,
+`NOTE` TEXT NULL,
+FOREIGN KEY(`INVOICE_ID`) REFERENCES sales(`INVOICE_ID`)
+);
+CREATE TABLE `sales_return_items` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`RETURN_ID` VARCHAR(64),
+`SALE_ITEM_ID` INTEGER, /* returned line of sales_items */
+`SKU` VARCHAR(64),
+`QUANTITY` INTEGER,
+`BUY_PRICE` INTEGER, /* cost of the item when it was sold */
+`SELL_PRICE` INTEGER, /* price of the item when it was sold (refunded amount per item) */
+UNIQUE(`RETURN_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`RETURN_ID`) REFERENCES sales_returns(`RETURN_ID`),
+FOREIGN KEY(`SALE_ITEM_ID`) REFERENCES sales_items(`ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
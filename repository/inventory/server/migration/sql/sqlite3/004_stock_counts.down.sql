@@ -0,0 +1,3 @@
+/* Drops the stock take (stock opname) tables */
+DROP TABLE `stock_count_items`;
+DROP TABLE `stock_counts`;
@@ -0,0 +1,19 @@
+/* Adds the stock take (stock opname) tables */
+CREATE TABLE `stock_counts` (
+`COUNT_ID` VARCHAR(64) PRIMARY KEY,
+`COUNT_DATE` DATETIME,
+`STATUS` VARCHAR(3), /* O = Open, P = Posted */
+`NOTE` TEXT NULL,
+`POSTED_DATE` DATETIME NULL
+);
+CREATE TABLE `stock_count_items` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`COUNT_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`COUNTED_QUANTITY` INTEGER NULL, /* null until the item is counted */
+`SYSTEM_QUANTITY` INTEGER, /* stock quantity when the count was posted */
+`BUY_PRICE` INTEGER, /* buy price when the count was posted */
+UNIQUE(`COUNT_ID`,`SKU`) ON CONFLICT ROLLBACK,
+FOREIGN KEY(`COUNT_ID`) REFERENCES stock_counts(`COUNT_ID`),
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
@@ -0,0 +1,2 @@
+/* Drops the reserved quantity of stock, draft sales no longer hold stock */
+ALTER TABLE `stock` DROP COLUMN `RESERVED_QUANTITY`;
@@ -0,0 +1,4 @@
+/* Drops the indexes of sale and purchase items */
+DROP INDEX `purchase_items_purchase_id`;
+DROP INDEX `sales_status_sale_date`;
+DROP INDEX `sales_items_invoice_id`;
@@ -0,0 +1,5 @@
+/* Adds indexes for loading sales and purchases along with their items in bulk (see Sale and Purchase datamappers) */
+/* indexes are only created when missing, so databases restored from ijahDump.sql (which has them) are left untouched */
+CREATE INDEX IF NOT EXISTS `sales_items_invoice_id` ON `sales_items`(`INVOICE_ID`);
+CREATE INDEX IF NOT EXISTS `sales_status_sale_date` ON `sales`(`STATUS`, `SALE_DATE`);
+CREATE INDEX IF NOT EXISTS `purchase_items_purchase_id` ON `purchase_items`(`PURCHASE_ID`);
@@ -0,0 +1,7 @@
+/* Adds the full text search index of the sku and name of stock items (see Stock datamapper Search) */
+/* fts4 is used since it's built into go-sqlite3 by default (fts5 needs the sqlite_fts5 build tag), the index is kept in sync by the Stock datamapper */
+/* ijahDump.sql doesn't hold the index (sqlite3 shells aren't always built with fts4), it's created along with the other pending migrations when the http server starts */
+/* the index is only created when missing and is rebuilt from stock, so applying the migration again leaves it consistent */
+CREATE VIRTUAL TABLE IF NOT EXISTS `stock_search` USING fts4(`SKU`, `NAME`);
+DELETE FROM `stock_search`;
+INSERT INTO `stock_search`(`SKU`, `NAME`) SELECT `SKU`, COALESCE(`NAME`, '') FROM `stock`;
@@ -0,0 +1,21 @@
+/* Adds suppliers along with the items they supply (supplier sku codes and last prices) and the supplier of purchases */
+CREATE TABLE IF NOT EXISTS `suppliers` (
+`SUPPLIER_ID` VARCHAR(64) PRIMARY KEY,
+`NAME` VARCHAR(255),
+`CONTACT_NAME` VARCHAR(255) NULL,
+`PHONE` VARCHAR(64) NULL,
+`EMAIL` VARCHAR(255) NULL,
+`ADDRESS` TEXT NULL,
+`PAYMENT_TERM_DAYS` INTEGER DEFAULT 0 /* days the payment is due after the purchase date, 0 for cash on delivery */
+);
+CREATE TABLE IF NOT EXISTS `supplier_items` (
+`SUPPLIER_ID` VARCHAR(64),
+`SKU` VARCHAR(64),
+`SUPPLIER_SKU` VARCHAR(64) NULL, /* code of the item in the catalog of the supplier */
+`LAST_PRICE` INTEGER DEFAULT 0,
+`LAST_PURCHASE_DATE` DATETIME NULL, /* null when the item was never purchased from the supplier */
+PRIMARY KEY(`SUPPLIER_ID`, `SKU`),
+FOREIGN KEY(`SUPPLIER_ID`) REFERENCES suppliers(`SUPPLIER_ID`)
+);
+ALTER TABLE `purchase` ADD COLUMN `SUPPLIER_ID` VARCHAR(64) NULL; /* null when the supplier is unknown */
+CREATE INDEX `purchase_supplier_id` ON `purchase`(`SUPPLIER_ID`);
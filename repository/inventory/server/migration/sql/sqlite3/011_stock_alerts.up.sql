@@ -0,0 +1,17 @@
+/* Adds reorder settings of stock items and the low stock alerts raised when stock drops to the reorder point */
+ALTER TABLE `stock` ADD COLUMN `REORDER_POINT` INTEGER DEFAULT 0; /* 0 when stock of the item isn't watched */
+ALTER TABLE `stock` ADD COLUMN `REORDER_QUANTITY` INTEGER DEFAULT 0;
+ALTER TABLE `stock` ADD COLUMN `SAFETY_STOCK` INTEGER DEFAULT 0;
+CREATE TABLE IF NOT EXISTS `stock_alerts` (
+`ID` INTEGER PRIMARY KEY AUTOINCREMENT,
+`SKU` VARCHAR(64),
+`LEVEL` VARCHAR(16), /* low or critical (see model.AlertLevel consts) */
+`QUANTITY` INTEGER, /* stock quantity when the alert was last checked */
+`REORDER_POINT` INTEGER,
+`REORDER_QUANTITY` INTEGER,
+`SAFETY_STOCK` INTEGER,
+`RAISED_AT` DATETIME,
+`RESOLVED_AT` DATETIME NULL, /* null while the alert is open */
+FOREIGN KEY(`SKU`) REFERENCES stock(`SKU`)
+);
+CREATE INDEX `stock_alerts_sku` ON `stock_alerts`(`SKU`);
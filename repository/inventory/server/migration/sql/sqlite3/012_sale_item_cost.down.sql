@@ -0,0 +1,2 @@
+/* Drops the exact cost of the items sold by completed sales */
+ALTER TABLE `sales_items` DROP COLUMN `COST`;
@@ -0,0 +1,2 @@
+/* Adds the exact cost of the items sold by completed sales (taken from the consumed cost layers) */
+ALTER TABLE `sales_items` ADD COLUMN `COST` INTEGER DEFAULT 0; /* 0 for sales completed before the cost was recorded, costed at BUY_PRICE * QUANTITY */